@@ -0,0 +1,58 @@
+package main
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"anysync-backend/shared/handlers"
+	syncspacepb "anysync-backend/shared/proto/syncspace/v1"
+)
+
+// toStatusError converts a dispatcher error into a gRPC status error.
+// The status code follows the error's ErrorCode, and the structured
+// syncspace.v1.CommandError is attached as a status detail so clients can
+// decode it instead of matching on the message.
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	pbErr := handlers.ToProtoError(err)
+	st := status.New(grpcCode(pbErr.Code), pbErr.Message)
+	if withDetails, detailErr := st.WithDetails(pbErr); detailErr == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
+
+// grpcCode maps an ErrorCode to the closest gRPC status code.
+func grpcCode(code syncspacepb.ErrorCode) codes.Code {
+	switch code {
+	case syncspacepb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT:
+		return codes.InvalidArgument
+	case syncspacepb.ErrorCode_ERROR_CODE_NOT_FOUND:
+		return codes.NotFound
+	case syncspacepb.ErrorCode_ERROR_CODE_ALREADY_EXISTS:
+		return codes.AlreadyExists
+	case syncspacepb.ErrorCode_ERROR_CODE_NOT_INITIALIZED,
+		syncspacepb.ErrorCode_ERROR_CODE_ALREADY_INITIALIZED:
+		return codes.FailedPrecondition
+	case syncspacepb.ErrorCode_ERROR_CODE_VERSION_CONFLICT:
+		return codes.Aborted
+	case syncspacepb.ErrorCode_ERROR_CODE_UNIMPLEMENTED:
+		return codes.Unimplemented
+	case syncspacepb.ErrorCode_ERROR_CODE_DEADLINE_EXCEEDED:
+		return codes.DeadlineExceeded
+	case syncspacepb.ErrorCode_ERROR_CODE_CANCELLED:
+		return codes.Canceled
+	case syncspacepb.ErrorCode_ERROR_CODE_UNAVAILABLE:
+		return codes.Unavailable
+	case syncspacepb.ErrorCode_ERROR_CODE_LOCKED:
+		return codes.Unauthenticated
+	case syncspacepb.ErrorCode_ERROR_CODE_PERMISSION_DENIED:
+		return codes.PermissionDenied
+	default:
+		return codes.Internal
+	}
+}
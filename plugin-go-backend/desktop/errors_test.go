@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	transportpb "anysync-backend/desktop/proto/transport/v1"
+	syncspacepb "anysync-backend/shared/proto/syncspace/v1"
+)
+
+// TestInit_WrongPassphrase tests that a wrong passphrase reaches gRPC
+// clients as Unauthenticated, with the CommandError attached.
+func TestInit_WrongPassphrase(t *testing.T) {
+	ctx := context.Background()
+	dataDir := t.TempDir()
+
+	s := NewServer()
+	if _, err := s.Init(ctx, &transportpb.InitRequest{StoragePath: dataDir, Passphrase: "correct horse"}); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if _, err := s.Shutdown(ctx, &transportpb.ShutdownRequest{}); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	_, err := NewServer().Init(ctx, &transportpb.InitRequest{StoragePath: dataDir, Passphrase: "wrong horse"})
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected a status error, got %v", err)
+	}
+	if st.Code() != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated, got %v", st.Code())
+	}
+	details := st.Details()
+	if len(details) != 1 {
+		t.Fatalf("Expected the CommandError detail, got %v", details)
+	}
+	if code := details[0].(*syncspacepb.CommandError).Code; code != syncspacepb.ErrorCode_ERROR_CODE_LOCKED {
+		t.Errorf("Expected ERROR_CODE_LOCKED, got %v", code)
+	}
+}
+
+// TestGrpcCode tests the gRPC codes of the error codes clients act on.
+func TestGrpcCode(t *testing.T) {
+	tests := []struct {
+		code syncspacepb.ErrorCode
+		want codes.Code
+	}{
+		{syncspacepb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, codes.InvalidArgument},
+		{syncspacepb.ErrorCode_ERROR_CODE_UNAVAILABLE, codes.Unavailable},
+		{syncspacepb.ErrorCode_ERROR_CODE_LOCKED, codes.Unauthenticated},
+		{syncspacepb.ErrorCode_ERROR_CODE_PERMISSION_DENIED, codes.PermissionDenied},
+		{syncspacepb.ErrorCode_ERROR_CODE_INTERNAL, codes.Internal},
+	}
+	for _, tt := range tests {
+		if got := grpcCode(tt.code); got != tt.want {
+			t.Errorf("grpcCode(%v) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
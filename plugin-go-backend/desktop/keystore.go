@@ -0,0 +1,202 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	transportpb "anysync-backend/desktop/proto/transport/v1"
+	"anysync-backend/shared/anysync"
+)
+
+// hostKeyStore keeps the account secrets in the key store of the host, such
+// as the macOS Keychain. The host serves it over the KeyStore stream: each
+// operation is sent as a request and waits for the response with its id.
+type hostKeyStore struct {
+	mu      sync.Mutex
+	stream  transportpb.TransportService_KeyStoreServer // nil while the host serves none
+	nextID  uint64
+	pending map[uint64]chan *transportpb.KeyStoreResponse
+
+	sendMu   sync.Mutex    // Sends on stream one at a time
+	stopped  chan struct{} // Closed by stop
+	stopOnce sync.Once
+}
+
+func newHostKeyStore() *hostKeyStore {
+	return &hostKeyStore{
+		pending: make(map[uint64]chan *transportpb.KeyStoreResponse),
+		stopped: make(chan struct{}),
+	}
+}
+
+// open returns the key store of dataDir: the host key store while the host
+// serves one, the files of dataDir otherwise.
+func (k *hostKeyStore) open(dataDir string) anysync.KeyStore {
+	k.mu.Lock()
+	served := k.stream != nil
+	k.mu.Unlock()
+
+	if !served {
+		return anysync.NewFileKeyStore(dataDir)
+	}
+	return &anysync.CallbackKeyStore{
+		Scope:      dataDir,
+		LoadFunc:   k.load,
+		StoreFunc:  k.store,
+		DeleteFunc: k.delete,
+		ExistsFunc: k.exists,
+	}
+}
+
+// serve passes the responses the host sends on stream to the waiting
+// operations, until the host closes the stream or stop is called. Only one
+// stream is served at a time.
+func (k *hostKeyStore) serve(stream transportpb.TransportService_KeyStoreServer) error {
+	k.mu.Lock()
+	if k.stream != nil {
+		k.mu.Unlock()
+		return status.Error(codes.AlreadyExists, "a key store stream is already open")
+	}
+	k.stream = stream
+	k.mu.Unlock()
+
+	defer func() {
+		k.mu.Lock()
+		defer k.mu.Unlock()
+
+		// The operations still waiting fail
+		k.stream = nil
+		for id, ch := range k.pending {
+			close(ch)
+			delete(k.pending, id)
+		}
+	}()
+
+	// Receiving blocks, so it runs apart to let stop end the stream; it
+	// returns once the stream is done
+	received := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				received <- err
+				return
+			}
+			k.mu.Lock()
+			ch, ok := k.pending[resp.Id]
+			delete(k.pending, resp.Id)
+			k.mu.Unlock()
+			if ok {
+				ch <- resp
+			}
+		}
+	}()
+
+	select {
+	case err := <-received:
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+	case <-k.stopped:
+		return nil
+	}
+}
+
+// stop ends the stream being served, so that the server can stop.
+func (k *hostKeyStore) stop() {
+	k.stopOnce.Do(func() { close(k.stopped) })
+}
+
+// call sends req to the host and returns its response.
+func (k *hostKeyStore) call(req *transportpb.KeyStoreRequest) (*transportpb.KeyStoreResponse, error) {
+	ch := make(chan *transportpb.KeyStoreResponse, 1)
+	k.mu.Lock()
+	stream := k.stream
+	if stream == nil {
+		k.mu.Unlock()
+		return nil, errKeyStoreClosed()
+	}
+	k.nextID++
+	req.Id = k.nextID
+	k.pending[req.Id] = ch
+	k.mu.Unlock()
+
+	k.sendMu.Lock()
+	err := stream.Send(req)
+	k.sendMu.Unlock()
+	if err != nil {
+		k.mu.Lock()
+		delete(k.pending, req.Id)
+		k.mu.Unlock()
+		return nil, errKeyStoreClosed()
+	}
+
+	resp, ok := <-ch
+	if !ok {
+		return nil, errKeyStoreClosed()
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return resp, nil
+}
+
+func (k *hostKeyStore) load(scope, name string) ([]byte, error) {
+	resp, err := k.call(&transportpb.KeyStoreRequest{
+		Operation: transportpb.KeyStoreOperation_KEY_STORE_OPERATION_LOAD,
+		Scope:     scope,
+		Name:      name,
+	})
+	if err != nil || !resp.Found {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return []byte{}, nil
+	}
+	return resp.Data, nil
+}
+
+func (k *hostKeyStore) store(scope, name string, data []byte) error {
+	_, err := k.call(&transportpb.KeyStoreRequest{
+		Operation: transportpb.KeyStoreOperation_KEY_STORE_OPERATION_STORE,
+		Scope:     scope,
+		Name:      name,
+		Data:      data,
+	})
+	return err
+}
+
+func (k *hostKeyStore) delete(scope, name string) error {
+	_, err := k.call(&transportpb.KeyStoreRequest{
+		Operation: transportpb.KeyStoreOperation_KEY_STORE_OPERATION_DELETE,
+		Scope:     scope,
+		Name:      name,
+	})
+	return err
+}
+
+func (k *hostKeyStore) exists(scope, name string) (bool, error) {
+	resp, err := k.call(&transportpb.KeyStoreRequest{
+		Operation: transportpb.KeyStoreOperation_KEY_STORE_OPERATION_EXISTS,
+		Scope:     scope,
+		Name:      name,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Found, nil
+}
+
+// errKeyStoreClosed returns the ErrUnavailable error of an operation on a
+// key store whose stream the host closed.
+func errKeyStoreClosed() error {
+	return &anysync.Error{
+		Kind:    anysync.ErrUnavailable,
+		Message: "the key store stream of the host is closed",
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	transportpb "anysync-backend/desktop/proto/transport/v1"
+)
+
+// startServer serves a new Server in memory and returns a client of it.
+func startServer(t *testing.T) (*Server, transportpb.TransportServiceClient) {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	s := NewServer()
+	transportpb.RegisterTransportServiceServer(grpcServer, s)
+	go grpcServer.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		s.keys.stop()
+		grpcServer.Stop()
+	})
+	return s, transportpb.NewTransportServiceClient(conn)
+}
+
+// waitServed waits for the server to serve a KeyStore stream.
+func waitServed(s *Server) {
+	for {
+		s.keys.mu.Lock()
+		served := s.keys.stream != nil
+		s.keys.mu.Unlock()
+		if served {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// serveKeyStore answers the requests of stream from secrets, keyed by scope
+// and name, until the stream ends.
+func serveKeyStore(stream transportpb.TransportService_KeyStoreClient, mu *sync.Mutex, secrets map[string][]byte) {
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return
+		}
+		key := req.Scope + "/" + req.Name
+		resp := &transportpb.KeyStoreResponse{Id: req.Id}
+		mu.Lock()
+		switch req.Operation {
+		case transportpb.KeyStoreOperation_KEY_STORE_OPERATION_LOAD:
+			resp.Data, resp.Found = secrets[key]
+		case transportpb.KeyStoreOperation_KEY_STORE_OPERATION_STORE:
+			secrets[key] = req.Data
+		case transportpb.KeyStoreOperation_KEY_STORE_OPERATION_DELETE:
+			delete(secrets, key)
+		case transportpb.KeyStoreOperation_KEY_STORE_OPERATION_EXISTS:
+			_, resp.Found = secrets[key]
+		default:
+			resp.Error = "unknown operation"
+		}
+		mu.Unlock()
+		if err := stream.Send(resp); err != nil {
+			return
+		}
+	}
+}
+
+// TestKeyStore tests that the account secrets are kept by the host while it
+// serves the KeyStore stream.
+func TestKeyStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dataDir := t.TempDir()
+	s, client := startServer(t)
+
+	stream, err := client.KeyStore(ctx)
+	if err != nil {
+		t.Fatalf("KeyStore failed: %v", err)
+	}
+	var mu sync.Mutex
+	secrets := make(map[string][]byte)
+	go serveKeyStore(stream, &mu, secrets)
+	waitServed(s)
+
+	// A second stream is refused
+	second, err := client.KeyStore(ctx)
+	if err != nil {
+		t.Fatalf("KeyStore failed: %v", err)
+	}
+	if _, err := second.Recv(); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists for a second stream, got %v", err)
+	}
+
+	if _, err := client.Init(ctx, &transportpb.InitRequest{StoragePath: dataDir}); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	mu.Lock()
+	if len(secrets) == 0 {
+		t.Error("Expected the host to keep the account secrets")
+	}
+	for key := range secrets {
+		if filepath.Base(key) != "account.key" && filepath.Base(key) != "device.key" {
+			t.Errorf("Unexpected secret %s", key)
+		}
+	}
+	mu.Unlock()
+	err = filepath.WalkDir(dataDir, func(path string, entry os.DirEntry, err error) error {
+		if err == nil && (entry.Name() == "account.key" || entry.Name() == "device.key") {
+			t.Errorf("Expected no key file, found %s", path)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to walk the data directory: %v", err)
+	}
+
+	if _, err := client.Shutdown(ctx, &transportpb.ShutdownRequest{}); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+}
+
+// TestKeyStore_Closed tests that key store operations fail as Unavailable
+// once the host closes the stream.
+func TestKeyStore_Closed(t *testing.T) {
+	ctx := context.Background()
+	s, client := startServer(t)
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := client.KeyStore(streamCtx)
+	if err != nil {
+		t.Fatalf("KeyStore failed: %v", err)
+	}
+	// The request reaches the host, which closes the stream instead of
+	// answering
+	go func() {
+		stream.Recv()
+		cancel()
+	}()
+
+	waitServed(s)
+
+	store := s.keys.open(t.TempDir())
+	if _, err := store.Exists("account.key"); status.Code(toStatusError(err)) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+}
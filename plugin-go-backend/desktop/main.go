@@ -8,6 +8,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
 	"google.golang.org/grpc"
@@ -29,11 +30,24 @@ var (
 type Server struct {
 	transportpb.UnimplementedTransportServiceServer
 	dispatcher *dispatcher.Dispatcher
+	// keys serves the account secrets from the host over KeyStore
+	keys *hostKeyStore
+	// exit is closed when a Shutdown request asks the process to stop
+	exit     chan struct{}
+	exitOnce sync.Once
 }
 
 func NewServer() *Server {
+	// The profiles of the app reach each other in process, for invites and
+	// joins; there is no network transport yet
+	keys := newHostKeyStore()
+	profiles := handlers.NewProfilesWithKeyStore(keys.open)
+	profiles.SetTransport(anysync.NewMemoryTransport())
+
 	return &Server{
-		dispatcher: handlers.GetDispatcher(),
+		dispatcher: profiles.NewDispatcher(),
+		keys:       keys,
+		exit:       make(chan struct{}),
 	}
 }
 
@@ -41,8 +55,11 @@ func NewServer() *Server {
 func (s *Server) Init(ctx context.Context, req *transportpb.InitRequest) (*transportpb.InitResponse, error) {
 	// Convert transport.InitRequest to syncspace.InitRequest
 	syncspaceReq := &syncspacepb.InitRequest{
-		DataDir:   req.StoragePath,
-		NetworkId: req.NetworkId,
+		DataDir:    req.StoragePath,
+		NetworkId:  req.NetworkId,
+		ConfigJson: req.ConfigJson,
+		Passphrase: req.Passphrase,
+		Mnemonic:   req.Mnemonic,
 	}
 
 	// Marshal to bytes
@@ -54,7 +71,7 @@ func (s *Server) Init(ctx context.Context, req *transportpb.InitRequest) (*trans
 	// Call dispatcher with PascalCase command name
 	respBytes, err := s.dispatcher.Dispatch(ctx, "Init", reqBytes)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	// Unmarshal response
@@ -73,13 +90,14 @@ func (s *Server) Init(ctx context.Context, req *transportpb.InitRequest) (*trans
 	}, nil
 }
 
-// Command executes a command through the dispatcher
+// Command executes a command through the dispatcher.
+// Errors are returned as gRPC status errors carrying a syncspace.v1.CommandError detail.
 func (s *Server) Command(ctx context.Context, req *transportpb.CommandRequest) (*transportpb.CommandResponse, error) {
 	// req.Data contains protobuf bytes from TypeScript via Rust
 	// Dispatch directly - handlers expect and return protobuf bytes
 	respBytes, err := s.dispatcher.Dispatch(ctx, req.Cmd, req.Data)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return &transportpb.CommandResponse{
@@ -87,77 +105,60 @@ func (s *Server) Command(ctx context.Context, req *transportpb.CommandRequest) (
 	}, nil
 }
 
-// Subscribe streams events to the client
+// Subscribe streams events to the client.
+// It runs the streaming Subscribe command and converts each event to the
+// transport event format.
 func (s *Server) Subscribe(req *transportpb.SubscribeRequest, stream transportpb.TransportService_SubscribeServer) error {
-	ctx := stream.Context()
-
 	// Convert transport event types to syncspace event types
 	syncspaceReq := &syncspacepb.SubscribeRequest{
 		EventTypes: req.EventTypes,
 		SpaceIds:   []string{}, // Empty means all spaces
 	}
 
-	// Get subscription from handlers (special handling for streaming)
-	subscriberID, eventChan, err := handlers.Subscribe(ctx, syncspaceReq)
+	reqBytes, err := proto.Marshal(syncspaceReq)
 	if err != nil {
-		return fmt.Errorf("failed to subscribe: %w", err)
-	}
-	defer handlers.Unsubscribe(subscriberID)
-
-	// Stream events to client until context is cancelled or error occurs
-	for {
-		select {
-		case <-ctx.Done():
-			// Client disconnected
-			return ctx.Err()
-		case event, ok := <-eventChan:
-			if !ok {
-				// Channel closed (unsubscribed)
-				return nil
-			}
-
-			// Convert anysync.Event to syncspace.SubscribeResponse
-			syncspaceEvent := &syncspacepb.SubscribeResponse{
-				EventId:   event.ID,
-				EventType: string(event.Type),
-				SpaceId:   event.SpaceID,
-				Timestamp: event.Timestamp,
-				Payload:   marshalEventPayload(event),
-			}
-
-			// Marshal to bytes
-			eventBytes, err := proto.Marshal(syncspaceEvent)
-			if err != nil {
-				log.Printf("Failed to marshal event: %v", err)
-				continue
-			}
-
-			// Send to transport stream
-			transportEvent := &transportpb.SubscribeResponse{
-				Type:      string(event.Type),
-				Data:      eventBytes,
-				Timestamp: event.Timestamp * 1000, // Convert to milliseconds
-			}
-
-			if err := stream.Send(transportEvent); err != nil {
-				return fmt.Errorf("failed to send event: %w", err)
-			}
+		return fmt.Errorf("failed to marshal subscribe request: %w", err)
+	}
+
+	// Stream events to client until it disconnects or the backend shuts down
+	err = s.dispatcher.DispatchStream(stream.Context(), "Subscribe", reqBytes, func(eventBytes []byte) error {
+		var event syncspacepb.SubscribeResponse
+		if err := proto.Unmarshal(eventBytes, &event); err != nil {
+			return fmt.Errorf("failed to unmarshal event: %w", err)
 		}
+
+		return stream.Send(&transportpb.SubscribeResponse{
+			Type:      event.EventType,
+			Data:      eventBytes,
+			Timestamp: event.Timestamp * 1000, // Convert to milliseconds
+		})
+	})
+	if err != nil {
+		return toStatusError(err)
 	}
+	return nil
 }
 
-// marshalEventPayload converts event payload map to protobuf bytes
-// For now, this returns empty bytes. In the future, this could marshal
-// specific event types (DocumentCreatedEvent, DocumentUpdatedEvent, etc.)
-func marshalEventPayload(event *anysync.Event) []byte {
-	// TODO: Marshal specific event payload types based on event.Type
-	return []byte{}
+// Stream executes a streaming command through the dispatcher, sending each
+// streamed message as one response. The stream ends when the command
+// completes or the client cancels.
+func (s *Server) Stream(req *transportpb.CommandRequest, stream transportpb.TransportService_StreamServer) error {
+	err := s.dispatcher.DispatchStream(stream.Context(), req.Cmd, req.Data, func(msgBytes []byte) error {
+		return stream.Send(&transportpb.CommandResponse{Data: msgBytes})
+	})
+	if err != nil {
+		return toStatusError(err)
+	}
+	return nil
 }
 
-// Shutdown shuts down the backend
+// Shutdown shuts down the backend, waiting up to timeout_ms for in-flight
+// commands and streams. With exit set, the server process stops afterwards.
 func (s *Server) Shutdown(ctx context.Context, req *transportpb.ShutdownRequest) (*transportpb.ShutdownResponse, error) {
 	// Convert transport.ShutdownRequest to syncspace.ShutdownRequest
-	syncspaceReq := &syncspacepb.ShutdownRequest{}
+	syncspaceReq := &syncspacepb.ShutdownRequest{
+		TimeoutMs: req.TimeoutMs,
+	}
 
 	// Marshal to bytes
 	reqBytes, err := proto.Marshal(syncspaceReq)
@@ -168,7 +169,7 @@ func (s *Server) Shutdown(ctx context.Context, req *transportpb.ShutdownRequest)
 	// Call dispatcher with PascalCase command name
 	respBytes, err := s.dispatcher.Dispatch(ctx, "Shutdown", reqBytes)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	// Unmarshal response
@@ -180,6 +181,14 @@ func (s *Server) Shutdown(ctx context.Context, req *transportpb.ShutdownRequest)
 	msg := "shutdown successfully"
 	if !syncspaceResp.Success {
 		msg = "shutdown failed"
+	} else if syncspaceResp.Forced {
+		msg = "shutdown after cancelling in-flight work"
+	}
+
+	// Stop the process once this response has been sent; GracefulStop waits
+	// for this RPC to complete
+	if req.Exit {
+		s.exitOnce.Do(func() { close(s.exit) })
 	}
 
 	return &transportpb.ShutdownResponse{
@@ -187,6 +196,13 @@ func (s *Server) Shutdown(ctx context.Context, req *transportpb.ShutdownRequest)
 	}, nil
 }
 
+// KeyStore serves the account secrets from the key store of the host for as
+// long as the host keeps the stream open. Profiles opened meanwhile keep
+// their secrets there instead of in files.
+func (s *Server) KeyStore(stream transportpb.TransportService_KeyStoreServer) error {
+	return s.keys.serve(stream)
+}
+
 func main() {
 	flag.Parse()
 
@@ -232,11 +248,24 @@ func main() {
 		}
 	}()
 
-	// Wait for shutdown signal
-	<-sigCh
-	fmt.Println("\nShutting down server...")
+	// Wait for a shutdown signal or a Shutdown request with exit set
+	select {
+	case <-sigCh:
+		fmt.Println("\nShutting down server...")
+		// Drain the backend first; streams end once it has shut down, so
+		// GracefulStop does not wait on them. NOT_INITIALIZED means there
+		// was nothing to shut down.
+		reqBytes, _ := proto.Marshal(&syncspacepb.ShutdownRequest{})
+		if _, err := transportServer.dispatcher.Dispatch(context.Background(), "Shutdown", reqBytes); err != nil &&
+			handlers.ErrorCodeOf(err) != syncspacepb.ErrorCode_ERROR_CODE_NOT_INITIALIZED {
+			log.Printf("Warning: backend shutdown failed: %v", err)
+		}
+	case <-transportServer.exit:
+		fmt.Println("Shutting down server...")
+	}
 
-	// Graceful stop
+	// Graceful stop, which would wait for the host to close its key store
+	transportServer.keys.stop()
 	grpcServer.GracefulStop()
 
 	fmt.Println("Server stopped")
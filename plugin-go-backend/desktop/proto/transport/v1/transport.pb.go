@@ -21,6 +21,62 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Operation of the host key store
+type KeyStoreOperation int32
+
+const (
+	KeyStoreOperation_KEY_STORE_OPERATION_UNSPECIFIED KeyStoreOperation = 0
+	KeyStoreOperation_KEY_STORE_OPERATION_LOAD        KeyStoreOperation = 1 // Return the secret, or found = false
+	KeyStoreOperation_KEY_STORE_OPERATION_STORE       KeyStoreOperation = 2 // Create or replace the secret with data
+	KeyStoreOperation_KEY_STORE_OPERATION_DELETE      KeyStoreOperation = 3 // Remove the secret; a missing one is no error
+	KeyStoreOperation_KEY_STORE_OPERATION_EXISTS      KeyStoreOperation = 4 // Report whether the secret is stored in found
+)
+
+// Enum value maps for KeyStoreOperation.
+var (
+	KeyStoreOperation_name = map[int32]string{
+		0: "KEY_STORE_OPERATION_UNSPECIFIED",
+		1: "KEY_STORE_OPERATION_LOAD",
+		2: "KEY_STORE_OPERATION_STORE",
+		3: "KEY_STORE_OPERATION_DELETE",
+		4: "KEY_STORE_OPERATION_EXISTS",
+	}
+	KeyStoreOperation_value = map[string]int32{
+		"KEY_STORE_OPERATION_UNSPECIFIED": 0,
+		"KEY_STORE_OPERATION_LOAD":        1,
+		"KEY_STORE_OPERATION_STORE":       2,
+		"KEY_STORE_OPERATION_DELETE":      3,
+		"KEY_STORE_OPERATION_EXISTS":      4,
+	}
+)
+
+func (x KeyStoreOperation) Enum() *KeyStoreOperation {
+	p := new(KeyStoreOperation)
+	*p = x
+	return p
+}
+
+func (x KeyStoreOperation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KeyStoreOperation) Descriptor() protoreflect.EnumDescriptor {
+	return file_transport_v1_transport_proto_enumTypes[0].Descriptor()
+}
+
+func (KeyStoreOperation) Type() protoreflect.EnumType {
+	return &file_transport_v1_transport_proto_enumTypes[0]
+}
+
+func (x KeyStoreOperation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KeyStoreOperation.Descriptor instead.
+func (KeyStoreOperation) EnumDescriptor() ([]byte, []int) {
+	return file_transport_v1_transport_proto_rawDescGZIP(), []int{0}
+}
+
 // Init request with configuration
 type InitRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -29,7 +85,11 @@ type InitRequest struct {
 	// Network ID for Any-Sync
 	NetworkId string `protobuf:"bytes,2,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
 	// Optional configuration JSON
-	ConfigJson    string `protobuf:"bytes,3,opt,name=config_json,json=configJson,proto3" json:"config_json,omitempty"`
+	ConfigJson string `protobuf:"bytes,3,opt,name=config_json,json=configJson,proto3" json:"config_json,omitempty"`
+	// Passphrase of a passphrase-protected account key
+	Passphrase string `protobuf:"bytes,4,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	// Recovery phrase to restore the account from on a new device
+	Mnemonic      string `protobuf:"bytes,5,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -85,6 +145,20 @@ func (x *InitRequest) GetConfigJson() string {
 	return ""
 }
 
+func (x *InitRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+func (x *InitRequest) GetMnemonic() string {
+	if x != nil {
+		return x.Mnemonic
+	}
+	return ""
+}
+
 type InitResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Success message or empty
@@ -344,7 +418,9 @@ func (x *SubscribeResponse) GetTimestamp() int64 {
 type ShutdownRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Graceful shutdown timeout in milliseconds
-	TimeoutMs     int64 `protobuf:"varint,1,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	TimeoutMs int64 `protobuf:"varint,1,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	// Stop the server process once the backend has shut down
+	Exit          bool `protobuf:"varint,2,opt,name=exit,proto3" json:"exit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -386,6 +462,13 @@ func (x *ShutdownRequest) GetTimeoutMs() int64 {
 	return 0
 }
 
+func (x *ShutdownRequest) GetExit() bool {
+	if x != nil {
+		return x.Exit
+	}
+	return false
+}
+
 type ShutdownResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Success message
@@ -431,17 +514,175 @@ func (x *ShutdownResponse) GetMessage() string {
 	return ""
 }
 
+// Key store request (streamed to the host)
+type KeyStoreRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifies the request, echoed in its response
+	Id        uint64            `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Operation KeyStoreOperation `protobuf:"varint,2,opt,name=operation,proto3,enum=transport.v1.KeyStoreOperation" json:"operation,omitempty"`
+	// The account, as the data directory of its profile
+	Scope string `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	// The secret, "account.key" or "device.key"
+	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// The secret to store
+	Data          []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyStoreRequest) Reset() {
+	*x = KeyStoreRequest{}
+	mi := &file_transport_v1_transport_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyStoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyStoreRequest) ProtoMessage() {}
+
+func (x *KeyStoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_v1_transport_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyStoreRequest.ProtoReflect.Descriptor instead.
+func (*KeyStoreRequest) Descriptor() ([]byte, []int) {
+	return file_transport_v1_transport_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *KeyStoreRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *KeyStoreRequest) GetOperation() KeyStoreOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return KeyStoreOperation_KEY_STORE_OPERATION_UNSPECIFIED
+}
+
+func (x *KeyStoreRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *KeyStoreRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *KeyStoreRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Key store response (streamed by the host)
+type KeyStoreResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The id of the request
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The loaded secret
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Whether the secret is stored, for load and exists
+	Found bool `protobuf:"varint,3,opt,name=found,proto3" json:"found,omitempty"`
+	// Why the operation failed, empty on success
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyStoreResponse) Reset() {
+	*x = KeyStoreResponse{}
+	mi := &file_transport_v1_transport_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyStoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyStoreResponse) ProtoMessage() {}
+
+func (x *KeyStoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_v1_transport_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyStoreResponse.ProtoReflect.Descriptor instead.
+func (*KeyStoreResponse) Descriptor() ([]byte, []int) {
+	return file_transport_v1_transport_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *KeyStoreResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *KeyStoreResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *KeyStoreResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *KeyStoreResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 var File_transport_v1_transport_proto protoreflect.FileDescriptor
 
 const file_transport_v1_transport_proto_rawDesc = "" +
 	"\n" +
-	"\x1ctransport/v1/transport.proto\x12\ftransport.v1\"p\n" +
+	"\x1ctransport/v1/transport.proto\x12\ftransport.v1\"\xac\x01\n" +
 	"\vInitRequest\x12!\n" +
 	"\fstorage_path\x18\x01 \x01(\tR\vstoragePath\x12\x1d\n" +
 	"\n" +
 	"network_id\x18\x02 \x01(\tR\tnetworkId\x12\x1f\n" +
 	"\vconfig_json\x18\x03 \x01(\tR\n" +
-	"configJson\"(\n" +
+	"configJson\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x04 \x01(\tR\n" +
+	"passphrase\x12\x1a\n" +
+	"\bmnemonic\x18\x05 \x01(\tR\bmnemonic\"(\n" +
 	"\fInitResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\"6\n" +
 	"\x0eCommandRequest\x12\x10\n" +
@@ -455,17 +696,37 @@ const file_transport_v1_transport_proto_rawDesc = "" +
 	"\x11SubscribeResponse\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
 	"\x04data\x18\x02 \x01(\fR\x04data\x12\x1c\n" +
-	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\"0\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\"D\n" +
 	"\x0fShutdownRequest\x12\x1d\n" +
 	"\n" +
-	"timeout_ms\x18\x01 \x01(\x03R\ttimeoutMs\",\n" +
+	"timeout_ms\x18\x01 \x01(\x03R\ttimeoutMs\x12\x12\n" +
+	"\x04exit\x18\x02 \x01(\bR\x04exit\",\n" +
 	"\x10ShutdownResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2\xb4\x02\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\x9e\x01\n" +
+	"\x0fKeyStoreRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12=\n" +
+	"\toperation\x18\x02 \x01(\x0e2\x1f.transport.v1.KeyStoreOperationR\toperation\x12\x14\n" +
+	"\x05scope\x18\x03 \x01(\tR\x05scope\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\"b\n" +
+	"\x10KeyStoreResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x14\n" +
+	"\x05found\x18\x03 \x01(\bR\x05found\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error*\xb5\x01\n" +
+	"\x11KeyStoreOperation\x12#\n" +
+	"\x1fKEY_STORE_OPERATION_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18KEY_STORE_OPERATION_LOAD\x10\x01\x12\x1d\n" +
+	"\x19KEY_STORE_OPERATION_STORE\x10\x02\x12\x1e\n" +
+	"\x1aKEY_STORE_OPERATION_DELETE\x10\x03\x12\x1e\n" +
+	"\x1aKEY_STORE_OPERATION_EXISTS\x10\x042\xcc\x03\n" +
 	"\x10TransportService\x12=\n" +
 	"\x04Init\x12\x19.transport.v1.InitRequest\x1a\x1a.transport.v1.InitResponse\x12F\n" +
 	"\aCommand\x12\x1c.transport.v1.CommandRequest\x1a\x1d.transport.v1.CommandResponse\x12N\n" +
-	"\tSubscribe\x12\x1e.transport.v1.SubscribeRequest\x1a\x1f.transport.v1.SubscribeResponse0\x01\x12I\n" +
-	"\bShutdown\x12\x1d.transport.v1.ShutdownRequest\x1a\x1e.transport.v1.ShutdownResponseB\x9f\x01\n" +
+	"\tSubscribe\x12\x1e.transport.v1.SubscribeRequest\x1a\x1f.transport.v1.SubscribeResponse0\x01\x12G\n" +
+	"\x06Stream\x12\x1c.transport.v1.CommandRequest\x1a\x1d.transport.v1.CommandResponse0\x01\x12I\n" +
+	"\bShutdown\x12\x1d.transport.v1.ShutdownRequest\x1a\x1e.transport.v1.ShutdownResponse\x12M\n" +
+	"\bKeyStore\x12\x1e.transport.v1.KeyStoreResponse\x1a\x1d.transport.v1.KeyStoreRequest(\x010\x01B\x9f\x01\n" +
 	"\x10com.transport.v1B\x0eTransportProtoP\x01Z*anysync-backend/desktop/proto/v1;transport\xa2\x02\x03TXX\xaa\x02\fTransport.V1\xca\x02\fTransport\\V1\xe2\x02\x18Transport\\V1\\GPBMetadata\xea\x02\rTransport::V1b\x06proto3"
 
 var (
@@ -480,31 +741,40 @@ func file_transport_v1_transport_proto_rawDescGZIP() []byte {
 	return file_transport_v1_transport_proto_rawDescData
 }
 
-var file_transport_v1_transport_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_transport_v1_transport_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_transport_v1_transport_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_transport_v1_transport_proto_goTypes = []any{
-	(*InitRequest)(nil),       // 0: transport.v1.InitRequest
-	(*InitResponse)(nil),      // 1: transport.v1.InitResponse
-	(*CommandRequest)(nil),    // 2: transport.v1.CommandRequest
-	(*CommandResponse)(nil),   // 3: transport.v1.CommandResponse
-	(*SubscribeRequest)(nil),  // 4: transport.v1.SubscribeRequest
-	(*SubscribeResponse)(nil), // 5: transport.v1.SubscribeResponse
-	(*ShutdownRequest)(nil),   // 6: transport.v1.ShutdownRequest
-	(*ShutdownResponse)(nil),  // 7: transport.v1.ShutdownResponse
+	(KeyStoreOperation)(0),    // 0: transport.v1.KeyStoreOperation
+	(*InitRequest)(nil),       // 1: transport.v1.InitRequest
+	(*InitResponse)(nil),      // 2: transport.v1.InitResponse
+	(*CommandRequest)(nil),    // 3: transport.v1.CommandRequest
+	(*CommandResponse)(nil),   // 4: transport.v1.CommandResponse
+	(*SubscribeRequest)(nil),  // 5: transport.v1.SubscribeRequest
+	(*SubscribeResponse)(nil), // 6: transport.v1.SubscribeResponse
+	(*ShutdownRequest)(nil),   // 7: transport.v1.ShutdownRequest
+	(*ShutdownResponse)(nil),  // 8: transport.v1.ShutdownResponse
+	(*KeyStoreRequest)(nil),   // 9: transport.v1.KeyStoreRequest
+	(*KeyStoreResponse)(nil),  // 10: transport.v1.KeyStoreResponse
 }
 var file_transport_v1_transport_proto_depIdxs = []int32{
-	0, // 0: transport.v1.TransportService.Init:input_type -> transport.v1.InitRequest
-	2, // 1: transport.v1.TransportService.Command:input_type -> transport.v1.CommandRequest
-	4, // 2: transport.v1.TransportService.Subscribe:input_type -> transport.v1.SubscribeRequest
-	6, // 3: transport.v1.TransportService.Shutdown:input_type -> transport.v1.ShutdownRequest
-	1, // 4: transport.v1.TransportService.Init:output_type -> transport.v1.InitResponse
-	3, // 5: transport.v1.TransportService.Command:output_type -> transport.v1.CommandResponse
-	5, // 6: transport.v1.TransportService.Subscribe:output_type -> transport.v1.SubscribeResponse
-	7, // 7: transport.v1.TransportService.Shutdown:output_type -> transport.v1.ShutdownResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: transport.v1.KeyStoreRequest.operation:type_name -> transport.v1.KeyStoreOperation
+	1,  // 1: transport.v1.TransportService.Init:input_type -> transport.v1.InitRequest
+	3,  // 2: transport.v1.TransportService.Command:input_type -> transport.v1.CommandRequest
+	5,  // 3: transport.v1.TransportService.Subscribe:input_type -> transport.v1.SubscribeRequest
+	3,  // 4: transport.v1.TransportService.Stream:input_type -> transport.v1.CommandRequest
+	7,  // 5: transport.v1.TransportService.Shutdown:input_type -> transport.v1.ShutdownRequest
+	10, // 6: transport.v1.TransportService.KeyStore:input_type -> transport.v1.KeyStoreResponse
+	2,  // 7: transport.v1.TransportService.Init:output_type -> transport.v1.InitResponse
+	4,  // 8: transport.v1.TransportService.Command:output_type -> transport.v1.CommandResponse
+	6,  // 9: transport.v1.TransportService.Subscribe:output_type -> transport.v1.SubscribeResponse
+	4,  // 10: transport.v1.TransportService.Stream:output_type -> transport.v1.CommandResponse
+	8,  // 11: transport.v1.TransportService.Shutdown:output_type -> transport.v1.ShutdownResponse
+	9,  // 12: transport.v1.TransportService.KeyStore:output_type -> transport.v1.KeyStoreRequest
+	7,  // [7:13] is the sub-list for method output_type
+	1,  // [1:7] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_transport_v1_transport_proto_init() }
@@ -517,13 +787,14 @@ func file_transport_v1_transport_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_transport_v1_transport_proto_rawDesc), len(file_transport_v1_transport_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      1,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_transport_v1_transport_proto_goTypes,
 		DependencyIndexes: file_transport_v1_transport_proto_depIdxs,
+		EnumInfos:         file_transport_v1_transport_proto_enumTypes,
 		MessageInfos:      file_transport_v1_transport_proto_msgTypes,
 	}.Build()
 	File_transport_v1_transport_proto = out.File
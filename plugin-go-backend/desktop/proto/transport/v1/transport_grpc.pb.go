@@ -22,14 +22,16 @@ const (
 	TransportService_Init_FullMethodName      = "/transport.v1.TransportService/Init"
 	TransportService_Command_FullMethodName   = "/transport.v1.TransportService/Command"
 	TransportService_Subscribe_FullMethodName = "/transport.v1.TransportService/Subscribe"
+	TransportService_Stream_FullMethodName    = "/transport.v1.TransportService/Stream"
 	TransportService_Shutdown_FullMethodName  = "/transport.v1.TransportService/Shutdown"
+	TransportService_KeyStore_FullMethodName  = "/transport.v1.TransportService/KeyStore"
 )
 
 // TransportServiceClient is the client API for TransportService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
-// TransportService provides a simple 4-method interface for desktop gRPC
+// TransportService provides a small generic interface for desktop gRPC
 // and mobile FFI. Both platforms call the same Go dispatcher underneath.
 type TransportServiceClient interface {
 	// Initialize the backend
@@ -38,8 +40,16 @@ type TransportServiceClient interface {
 	Command(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (*CommandResponse, error)
 	// Subscribe to events (server streaming)
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeResponse], error)
+	// Execute a streaming command by name; each response carries one
+	// serialized message of the stream
+	Stream(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandResponse], error)
 	// Shutdown the backend
 	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
+	// Serve the account secrets from a host key store, such as the macOS
+	// Keychain, instead of files in the data directory. The host opens the
+	// stream before Init and answers each request sent on it; data
+	// directories opened while no stream is open keep their secrets in files
+	KeyStore(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[KeyStoreResponse, KeyStoreRequest], error)
 }
 
 type transportServiceClient struct {
@@ -89,6 +99,25 @@ func (c *transportServiceClient) Subscribe(ctx context.Context, in *SubscribeReq
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type TransportService_SubscribeClient = grpc.ServerStreamingClient[SubscribeResponse]
 
+func (c *transportServiceClient) Stream(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TransportService_ServiceDesc.Streams[1], TransportService_Stream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CommandRequest, CommandResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TransportService_StreamClient = grpc.ServerStreamingClient[CommandResponse]
+
 func (c *transportServiceClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ShutdownResponse)
@@ -99,11 +128,24 @@ func (c *transportServiceClient) Shutdown(ctx context.Context, in *ShutdownReque
 	return out, nil
 }
 
+func (c *transportServiceClient) KeyStore(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[KeyStoreResponse, KeyStoreRequest], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TransportService_ServiceDesc.Streams[2], TransportService_KeyStore_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[KeyStoreResponse, KeyStoreRequest]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TransportService_KeyStoreClient = grpc.BidiStreamingClient[KeyStoreResponse, KeyStoreRequest]
+
 // TransportServiceServer is the server API for TransportService service.
 // All implementations must embed UnimplementedTransportServiceServer
 // for forward compatibility.
 //
-// TransportService provides a simple 4-method interface for desktop gRPC
+// TransportService provides a small generic interface for desktop gRPC
 // and mobile FFI. Both platforms call the same Go dispatcher underneath.
 type TransportServiceServer interface {
 	// Initialize the backend
@@ -112,8 +154,16 @@ type TransportServiceServer interface {
 	Command(context.Context, *CommandRequest) (*CommandResponse, error)
 	// Subscribe to events (server streaming)
 	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[SubscribeResponse]) error
+	// Execute a streaming command by name; each response carries one
+	// serialized message of the stream
+	Stream(*CommandRequest, grpc.ServerStreamingServer[CommandResponse]) error
 	// Shutdown the backend
 	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
+	// Serve the account secrets from a host key store, such as the macOS
+	// Keychain, instead of files in the data directory. The host opens the
+	// stream before Init and answers each request sent on it; data
+	// directories opened while no stream is open keep their secrets in files
+	KeyStore(grpc.BidiStreamingServer[KeyStoreResponse, KeyStoreRequest]) error
 	mustEmbedUnimplementedTransportServiceServer()
 }
 
@@ -133,9 +183,15 @@ func (UnimplementedTransportServiceServer) Command(context.Context, *CommandRequ
 func (UnimplementedTransportServiceServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[SubscribeResponse]) error {
 	return status.Error(codes.Unimplemented, "method Subscribe not implemented")
 }
+func (UnimplementedTransportServiceServer) Stream(*CommandRequest, grpc.ServerStreamingServer[CommandResponse]) error {
+	return status.Error(codes.Unimplemented, "method Stream not implemented")
+}
 func (UnimplementedTransportServiceServer) Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Shutdown not implemented")
 }
+func (UnimplementedTransportServiceServer) KeyStore(grpc.BidiStreamingServer[KeyStoreResponse, KeyStoreRequest]) error {
+	return status.Error(codes.Unimplemented, "method KeyStore not implemented")
+}
 func (UnimplementedTransportServiceServer) mustEmbedUnimplementedTransportServiceServer() {}
 func (UnimplementedTransportServiceServer) testEmbeddedByValue()                          {}
 
@@ -204,6 +260,17 @@ func _TransportService_Subscribe_Handler(srv interface{}, stream grpc.ServerStre
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type TransportService_SubscribeServer = grpc.ServerStreamingServer[SubscribeResponse]
 
+func _TransportService_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CommandRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TransportServiceServer).Stream(m, &grpc.GenericServerStream[CommandRequest, CommandResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TransportService_StreamServer = grpc.ServerStreamingServer[CommandResponse]
+
 func _TransportService_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ShutdownRequest)
 	if err := dec(in); err != nil {
@@ -222,6 +289,13 @@ func _TransportService_Shutdown_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TransportService_KeyStore_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TransportServiceServer).KeyStore(&grpc.GenericServerStream[KeyStoreResponse, KeyStoreRequest]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TransportService_KeyStoreServer = grpc.BidiStreamingServer[KeyStoreResponse, KeyStoreRequest]
+
 // TransportService_ServiceDesc is the grpc.ServiceDesc for TransportService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -248,6 +322,17 @@ var TransportService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TransportService_Subscribe_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Stream",
+			Handler:       _TransportService_Stream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "KeyStore",
+			Handler:       _TransportService_KeyStore_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "transport/v1/transport.proto",
 }
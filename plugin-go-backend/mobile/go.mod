@@ -5,6 +5,7 @@ go 1.25.0
 require (
 	anysync-backend/shared v0.0.0
 	golang.org/x/mobile v0.0.0-20251126181937-5c265dc024c4
+	google.golang.org/protobuf v1.36.10
 )
 
 replace anysync-backend/shared => ../shared
@@ -82,7 +83,6 @@ require (
 	golang.org/x/sys v0.38.0 // indirect
 	golang.org/x/text v0.31.0 // indirect
 	golang.org/x/tools v0.39.0 // indirect
-	google.golang.org/protobuf v1.36.10 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	lukechampine.com/blake3 v1.4.1 // indirect
 	modernc.org/libc v1.66.8 // indirect
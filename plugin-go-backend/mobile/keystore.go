@@ -0,0 +1,53 @@
+package mobile
+
+import (
+	"sync"
+
+	"anysync-backend/shared/anysync"
+	"anysync-backend/shared/handlers"
+)
+
+var (
+	hostKeyStore   KeyStore
+	hostKeyStoreMu sync.RWMutex
+)
+
+// KeyStore stores the account secrets in a platform keystore, such as the
+// Android Keystore or the iOS Keychain, instead of files of the data
+// directory. scope identifies the account (the data directory of its
+// profile) and name the secret ("account.key" or "device.key"). Load returns
+// nil for a missing secret; Delete of a missing secret must succeed.
+type KeyStore interface {
+	Load(scope, name string) ([]byte, error)
+	Store(scope, name string, data []byte) error
+	Delete(scope, name string) error
+	Exists(scope, name string) (bool, error)
+}
+
+// SetKeyStore makes the backend keep the account secrets in store. It must be
+// called before Init; nil restores the default files in the data directory.
+func SetKeyStore(store KeyStore) {
+	hostKeyStoreMu.Lock()
+	defer hostKeyStoreMu.Unlock()
+	hostKeyStore = store
+}
+
+// keyStoreFunc adapts the host key store, if one is set, for the profile host.
+func keyStoreFunc() handlers.KeyStoreFunc {
+	hostKeyStoreMu.RLock()
+	store := hostKeyStore
+	hostKeyStoreMu.RUnlock()
+
+	if store == nil {
+		return nil
+	}
+	return func(dataDir string) anysync.KeyStore {
+		return &anysync.CallbackKeyStore{
+			Scope:      dataDir,
+			LoadFunc:   store.Load,
+			StoreFunc:  store.Store,
+			DeleteFunc: store.Delete,
+			ExistsFunc: store.Exists,
+		}
+	}
+}
@@ -1,29 +1,48 @@
 // Package mobile provides gomobile-compatible bindings for the SyncSpace API.
-// This package exports the minimal 4-function API for Android/iOS via gomobile.
+// This package exports a minimal API for Android/iOS via gomobile: Init,
+// Command, Stream/CancelStream, SetEventHandler, SetKeyStore and Shutdown.
 package mobile
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"anysync-backend/shared/anysync"
 	"anysync-backend/shared/dispatcher"
 	"anysync-backend/shared/handlers"
+	pb "anysync-backend/shared/proto/syncspace/v1"
 )
 
 var (
 	globalDispatcher *dispatcher.Dispatcher
-	eventHandler     func([]byte) error
+	eventHandler     EventHandler
 	eventHandlerMu   sync.RWMutex
 	dispatcherOnce   sync.Once
 )
 
-// Init initializes the global dispatcher.
-// Must be called before any Command calls.
+// EventHandler receives messages pushed by the backend.
+// It is an interface rather than a func so that gomobile can bind it; Kotlin
+// and Swift implement it with a lambda or closure-backed object.
+type EventHandler interface {
+	HandleEvent(data []byte) error
+}
+
+// Init creates the profile host of the app and its dispatcher, using the key
+// store set with SetKeyStore. Must be called before any Command calls. The
+// profiles reach each other in process, for invites and joins; there is no
+// network transport yet.
 func Init() error {
 	dispatcherOnce.Do(func() {
-		globalDispatcher = handlers.GetDispatcher()
+		profiles := handlers.NewProfilesWithKeyStore(keyStoreFunc())
+		profiles.SetTransport(anysync.NewMemoryTransport())
+		globalDispatcher = profiles.NewDispatcher()
 	})
 	return nil
 }
@@ -32,20 +51,29 @@ func Init() error {
 // cmd is the command name (e.g., "init", "createSpace", "getDocument").
 // data is the serialized protobuf request payload.
 // Returns the serialized protobuf response payload or an error.
-func Command(cmd string, data []byte) ([]byte, error) {
-	log.Printf("[Mobile.Command] cmd=%s, data.len=%d", cmd, len(data))
+// The error message is a JSON-encoded syncspace.v1.CommandError, so the host
+// can decode the error code and details instead of matching on text.
+// timeoutMs bounds the command in milliseconds; 0 or less uses the timeout
+// configured at Init.
+func Command(cmd string, data []byte, timeoutMs int64) ([]byte, error) {
+	log.Printf("[Mobile.Command] cmd=%s, data.len=%d, timeoutMs=%d", cmd, len(data), timeoutMs)
 
 	if globalDispatcher == nil {
-		err := fmt.Errorf("dispatcher not initialized")
+		err := fmt.Errorf("dispatcher %w", handlers.ErrNotInitialized)
 		log.Printf("[Mobile.Command] ERROR: %v", err)
-		return nil, err
+		return nil, toCommandError(err)
 	}
 
 	ctx := context.Background()
+	if timeoutMs > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
+		defer cancel()
+	}
 	result, err := globalDispatcher.Dispatch(ctx, cmd, data)
 	if err != nil {
 		log.Printf("[Mobile.Command] Dispatch failed for cmd=%s: %v", cmd, err)
-		return nil, fmt.Errorf("dispatch failed: %w", err)
+		return nil, toCommandError(err)
 	}
 
 	if result == nil {
@@ -58,16 +86,42 @@ func Command(cmd string, data []byte) ([]byte, error) {
 	return result, nil
 }
 
+// toCommandError converts a dispatcher error into an error whose message is
+// the protojson encoding of the structured CommandError.
+func toCommandError(err error) error {
+	payload, marshalErr := protojson.Marshal(handlers.ToProtoError(err))
+	if marshalErr != nil {
+		return fmt.Errorf("dispatch failed: %w", err)
+	}
+	return errors.New(string(payload))
+}
+
 // SetEventHandler sets the event handler callback.
-// The handler will be called with serialized event payloads.
-func SetEventHandler(handler func([]byte) error) {
+// The handler is called with a serialized syncspace.v1.StreamMessage for each
+// message of the streams started with Stream.
+func SetEventHandler(handler EventHandler) {
 	eventHandlerMu.Lock()
 	defer eventHandlerMu.Unlock()
 	eventHandler = handler
 }
 
 // Shutdown shuts down the service and cleans up resources.
+// The backend is shut down first, draining in-flight commands and closing
+// open spaces; if that fails, the dispatcher is kept so Shutdown can be
+// retried.
 func Shutdown() error {
+	if globalDispatcher != nil {
+		// NOT_INITIALIZED means there was nothing to shut down
+		reqBytes, _ := proto.Marshal(&pb.ShutdownRequest{})
+		if _, err := globalDispatcher.Dispatch(context.Background(), "Shutdown", reqBytes); err != nil &&
+			handlers.ErrorCodeOf(err) != pb.ErrorCode_ERROR_CODE_NOT_INITIALIZED {
+			log.Printf("[Mobile.Shutdown] backend shutdown failed: %v", err)
+			return toCommandError(err)
+		}
+	}
+
+	cancelAllStreams()
+
 	eventHandlerMu.Lock()
 	eventHandler = nil
 	eventHandlerMu.Unlock()
@@ -0,0 +1,121 @@
+package mobile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"anysync-backend/shared/handlers"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+)
+
+var (
+	streams      = make(map[string]context.CancelFunc)
+	streamsMu    sync.Mutex
+	streamNextID int64
+)
+
+// Stream starts a streaming command (e.g. "Subscribe") and returns its stream ID.
+// data is the serialized protobuf request payload.
+// Messages are delivered to the handler set with SetEventHandler as serialized
+// syncspace.v1.StreamMessage values carrying the stream ID. The last message
+// has done set, and error set if the stream failed. Call CancelStream to stop
+// the stream early.
+func Stream(cmd string, data []byte) (string, error) {
+	log.Printf("[Mobile.Stream] cmd=%s, data.len=%d", cmd, len(data))
+
+	if globalDispatcher == nil {
+		return "", toCommandError(fmt.Errorf("dispatcher %w", handlers.ErrNotInitialized))
+	}
+
+	eventHandlerMu.RLock()
+	hasHandler := eventHandler != nil
+	eventHandlerMu.RUnlock()
+	if !hasHandler {
+		return "", toCommandError(fmt.Errorf("event handler %w: call SetEventHandler first", handlers.ErrNotInitialized))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	streamsMu.Lock()
+	streamNextID++
+	streamID := fmt.Sprintf("stream-%d", streamNextID)
+	streams[streamID] = cancel
+	streamsMu.Unlock()
+
+	d := globalDispatcher
+	go func() {
+		defer removeStream(streamID)
+
+		err := d.DispatchStream(ctx, cmd, data, func(payload []byte) error {
+			return deliver(&pb.StreamMessage{StreamId: streamID, Command: cmd, Payload: payload})
+		})
+
+		done := &pb.StreamMessage{StreamId: streamID, Command: cmd, Done: true}
+		// Cancellation through CancelStream or Shutdown is a normal end
+		if err != nil && !(errors.Is(err, context.Canceled) && ctx.Err() != nil) {
+			log.Printf("[Mobile.Stream] stream %s (%s) failed: %v", streamID, cmd, err)
+			done.Error = handlers.ToProtoError(err)
+		}
+		if err := deliver(done); err != nil {
+			log.Printf("[Mobile.Stream] failed to deliver end of stream %s: %v", streamID, err)
+		}
+	}()
+
+	return streamID, nil
+}
+
+// CancelStream stops a stream started with Stream. The stream ends with a
+// final done message.
+func CancelStream(streamID string) error {
+	streamsMu.Lock()
+	cancel, ok := streams[streamID]
+	streamsMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("stream not found: %s", streamID)
+	}
+	cancel()
+	return nil
+}
+
+// deliver passes a stream message to the event handler.
+func deliver(msg *pb.StreamMessage) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stream message: %w", err)
+	}
+
+	eventHandlerMu.RLock()
+	handler := eventHandler
+	eventHandlerMu.RUnlock()
+
+	if handler == nil {
+		return fmt.Errorf("no event handler set")
+	}
+	return handler.HandleEvent(data)
+}
+
+func removeStream(streamID string) {
+	streamsMu.Lock()
+	defer streamsMu.Unlock()
+
+	if cancel, ok := streams[streamID]; ok {
+		cancel()
+		delete(streams, streamID)
+	}
+}
+
+// cancelAllStreams stops every running stream.
+func cancelAllStreams() {
+	streamsMu.Lock()
+	defer streamsMu.Unlock()
+
+	for _, cancel := range streams {
+		cancel()
+	}
+}
@@ -2,9 +2,9 @@
 package anysync
 
 import (
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"sync"
 
 	"github.com/anyproto/any-sync/commonspace/object/accountdata"
 	"github.com/anyproto/any-sync/util/crypto"
@@ -12,22 +12,33 @@ import (
 
 // AccountManager manages cryptographic keys for the account.
 // It handles generation, secure storage, and loading of account keys.
+// It is safe for concurrent use: mu guards keys, which RotateDeviceKey
+// replaces while the account is in use.
 type AccountManager struct {
-	keys    *accountdata.AccountKeys
-	dataDir string
+	mu    sync.RWMutex
+	keys  *accountdata.AccountKeys
+	store KeyStore
 }
 
 const (
-	// accountKeyFile stores the encrypted account private key
+	// accountKeyFile stores the account private key, sealed with a
+	// passphrase when one is set
 	accountKeyFile = "account.key"
 	// deviceKeyFile stores the device key encrypted with the account key
 	deviceKeyFile = "device.key"
 )
 
-// NewAccountManager creates a new AccountManager.
+// NewAccountManager creates a new AccountManager storing its keys in files
+// of dataDir.
 func NewAccountManager(dataDir string) *AccountManager {
+	return NewAccountManagerWithKeyStore(NewFileKeyStore(dataDir))
+}
+
+// NewAccountManagerWithKeyStore creates a new AccountManager storing its keys
+// in store.
+func NewAccountManagerWithKeyStore(store KeyStore) *AccountManager {
 	return &AccountManager{
-		dataDir: dataDir,
+		store: store,
 	}
 }
 
@@ -39,35 +50,137 @@ func (am *AccountManager) GenerateKeys() error {
 		return fmt.Errorf("failed to generate random keys: %w", err)
 	}
 
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
 	am.keys = keys
 	return nil
 }
 
-// StoreKeys securely persists the keys to disk.
+// RestoreKeys sets the keys of the account whose recovery phrase is mnemonic
+// (see ExportMnemonic): the account key is derived from it, and a new device
+// key is generated, so the account is used from a new device. Fails with
+// ErrInvalidArgument if the phrase is not valid.
+func (am *AccountManager) RestoreKeys(mnemonic string) error {
+	signKey, err := keyFromMnemonic(mnemonic)
+	if err != nil {
+		return err
+	}
+	return am.restoreKeys(signKey)
+}
+
+// ImportKeys sets the keys of the account whose marshalled account key is
+// accountKey (see ExportAccountKey), with a new device key.
+func (am *AccountManager) ImportKeys(accountKey []byte) error {
+	signKey, err := crypto.UnmarshalEd25519PrivateKeyProto(accountKey)
+	if err != nil {
+		return fmt.Errorf("%w: invalid account key: %v", ErrInvalidArgument, err)
+	}
+	return am.restoreKeys(signKey)
+}
+
+// restoreKeys sets the keys of the account of signKey on a new device.
+func (am *AccountManager) restoreKeys(signKey crypto.PrivKey) error {
+	peerKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	if err != nil {
+		return fmt.Errorf("failed to generate device key: %w", err)
+	}
+
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	am.keys = accountdata.New(peerKey, signKey)
+	return nil
+}
+
+// ExportMnemonic returns the recovery phrase of the stored account key,
+// opening it with passphrase if it is protected. Fails with ErrLocked if the
+// passphrase is missing or wrong.
+func (am *AccountManager) ExportMnemonic(passphrase string) (string, error) {
+	accountKeyBytes, err := am.ExportAccountKey(passphrase)
+	if err != nil {
+		return "", err
+	}
+	accountKey, err := crypto.UnmarshalEd25519PrivateKeyProto(accountKeyBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal account key: %w", err)
+	}
+	return mnemonicFromKey(accountKey)
+}
+
+// ExportAccountKey returns the stored account key, marshalled and opened
+// with passphrase if it is protected. Fails with ErrLocked if the passphrase
+// is missing or wrong.
+func (am *AccountManager) ExportAccountKey(passphrase string) ([]byte, error) {
+	return am.readAccountKey(passphrase)
+}
+
+// MatchesMnemonic reports whether the loaded account key is the one of the
+// recovery phrase mnemonic.
+func (am *AccountManager) MatchesMnemonic(mnemonic string) (bool, error) {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
+	if am.keys == nil {
+		return false, fmt.Errorf("no keys loaded")
+	}
+	signKey, err := keyFromMnemonic(mnemonic)
+	if err != nil {
+		return false, err
+	}
+	return am.keys.SignKey.Equals(signKey), nil
+}
+
+// StoreKeys persists the keys without a passphrase.
 // The device key is encrypted with the account key before storage.
 func (am *AccountManager) StoreKeys() error {
+	return am.StoreKeysWithPassphrase("")
+}
+
+// StoreKeysWithPassphrase persists the keys in the key store. With a non-empty
+// passphrase the account key is sealed with it (see ChangePassphrase);
+// the device key is always encrypted with the account key.
+func (am *AccountManager) StoreKeysWithPassphrase(passphrase string) error {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
 	if am.keys == nil {
 		return fmt.Errorf("no keys to store")
 	}
 
-	// Ensure data directory exists
-	if err := os.MkdirAll(am.dataDir, 0700); err != nil {
-		return fmt.Errorf("failed to create data directory: %w", err)
+	if err := am.writeAccountKey(passphrase); err != nil {
+		return err
 	}
+	return am.writeDeviceKey()
+}
 
-	// Marshal account key (SignKey is the account key)
-	accountKeyBytes, err := am.keys.SignKey.Marshall()
+// RotateDeviceKey replaces the device key with a new random one and stores
+// it. The account key, and so the passphrase, stay the same.
+func (am *AccountManager) RotateDeviceKey() error {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	if am.keys == nil {
+		return fmt.Errorf("no keys loaded")
+	}
+
+	peerKey, _, err := crypto.GenerateRandomEd25519KeyPair()
 	if err != nil {
-		return fmt.Errorf("failed to marshal account key: %w", err)
+		return fmt.Errorf("failed to generate device key: %w", err)
 	}
 
-	// Write account key to disk (unencrypted for now, but with restricted permissions)
-	// TODO: Consider encrypting with user-provided password or platform keychain
-	accountKeyPath := filepath.Join(am.dataDir, accountKeyFile)
-	if err := os.WriteFile(accountKeyPath, accountKeyBytes, 0600); err != nil {
-		return fmt.Errorf("failed to write account key: %w", err)
+	previous := am.keys
+	am.keys = accountdata.New(peerKey, previous.SignKey)
+	if err := am.writeDeviceKey(); err != nil {
+		am.keys = previous
+		return err
 	}
+	return nil
+}
 
+// writeDeviceKey stores the device key, encrypted with the account key.
+// The caller must hold am.mu.
+func (am *AccountManager) writeDeviceKey() error {
 	// Marshal device key (PeerKey is the device key)
 	deviceKeyBytes, err := am.keys.PeerKey.Marshall()
 	if err != nil {
@@ -81,33 +194,30 @@ func (am *AccountManager) StoreKeys() error {
 		return fmt.Errorf("failed to encrypt device key: %w", err)
 	}
 
-	// Write encrypted device key to disk
-	deviceKeyPath := filepath.Join(am.dataDir, deviceKeyFile)
-	if err := os.WriteFile(deviceKeyPath, encryptedDeviceKey, 0600); err != nil {
+	// Store encrypted device key
+	if err := am.store.Store(deviceKeyFile, encryptedDeviceKey); err != nil {
 		return fmt.Errorf("failed to write device key: %w", err)
 	}
 
 	return nil
 }
 
-// LoadKeys loads existing keys from disk.
-// Returns an error if keys are missing or corrupted.
+// LoadKeys loads existing keys from the key store.
+// Returns an error if keys are missing or corrupted, and ErrLocked if the
+// account key is passphrase-protected.
 func (am *AccountManager) LoadKeys() error {
-	accountKeyPath := filepath.Join(am.dataDir, accountKeyFile)
-	deviceKeyPath := filepath.Join(am.dataDir, deviceKeyFile)
-
-	// Check if key files exist
-	if _, err := os.Stat(accountKeyPath); os.IsNotExist(err) {
-		return fmt.Errorf("account key file not found: %s", accountKeyPath)
-	}
-	if _, err := os.Stat(deviceKeyPath); os.IsNotExist(err) {
-		return fmt.Errorf("device key file not found: %s", deviceKeyPath)
-	}
+	return am.LoadKeysWithPassphrase("")
+}
 
+// LoadKeysWithPassphrase loads existing keys from the key store, opening a
+// passphrase-protected account key with passphrase. It fails with ErrLocked
+// if the passphrase is missing or wrong. The passphrase is ignored when the
+// account key is not protected.
+func (am *AccountManager) LoadKeysWithPassphrase(passphrase string) error {
 	// Read account key
-	accountKeyBytes, err := os.ReadFile(accountKeyPath)
+	accountKeyBytes, err := am.readAccountKey(passphrase)
 	if err != nil {
-		return fmt.Errorf("failed to read account key: %w", err)
+		return err
 	}
 
 	// Unmarshal account key
@@ -117,7 +227,10 @@ func (am *AccountManager) LoadKeys() error {
 	}
 
 	// Read encrypted device key
-	encryptedDeviceKey, err := os.ReadFile(deviceKeyPath)
+	encryptedDeviceKey, err := am.store.Load(deviceKeyFile)
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("device key file not found: %w", err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to read device key: %w", err)
 	}
@@ -143,7 +256,9 @@ func (am *AccountManager) LoadKeys() error {
 	}
 
 	// Create AccountKeys from loaded keys
+	am.mu.Lock()
 	am.keys = accountdata.New(deviceKey, accountKey)
+	am.mu.Unlock()
 
 	return nil
 }
@@ -151,27 +266,121 @@ func (am *AccountManager) LoadKeys() error {
 // GetKeys returns the current AccountKeys.
 // Returns nil if keys haven't been generated or loaded yet.
 func (am *AccountManager) GetKeys() *accountdata.AccountKeys {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
 	return am.keys
 }
 
 // ClearKeys securely clears keys from memory.
 // This should be called during shutdown to prevent key leakage.
 func (am *AccountManager) ClearKeys() {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
 	am.keys = nil
 }
 
 // HasKeys returns true if keys are currently loaded in memory.
 func (am *AccountManager) HasKeys() bool {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
 	return am.keys != nil
 }
 
-// KeysExist checks if key files exist on disk.
+// KeysExist checks if both keys are in the key store. A store that cannot be
+// read counts as empty.
 func (am *AccountManager) KeysExist() bool {
-	accountKeyPath := filepath.Join(am.dataDir, accountKeyFile)
-	deviceKeyPath := filepath.Join(am.dataDir, deviceKeyFile)
+	accountExists, accountErr := am.store.Exists(accountKeyFile)
+	deviceExists, deviceErr := am.store.Exists(deviceKeyFile)
+
+	return accountErr == nil && deviceErr == nil && accountExists && deviceExists
+}
 
-	_, accountErr := os.Stat(accountKeyPath)
-	_, deviceErr := os.Stat(deviceKeyPath)
+// DeleteKeys removes both keys from the key store. The keys loaded in memory
+// are left as is.
+func (am *AccountManager) DeleteKeys() error {
+	if err := am.store.Delete(accountKeyFile); err != nil {
+		return fmt.Errorf("failed to delete account key: %w", err)
+	}
+	if err := am.store.Delete(deviceKeyFile); err != nil {
+		return fmt.Errorf("failed to delete device key: %w", err)
+	}
+	return nil
+}
+
+// PassphraseProtected reports whether the stored account key is sealed with
+// a passphrase.
+func (am *AccountManager) PassphraseProtected() (bool, error) {
+	data, err := am.store.Load(accountKeyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to read account key: %w", err)
+	}
+	_, sealed := parseSealedKey(data)
+	return sealed, nil
+}
+
+// ChangePassphrase re-encrypts the account key file of the loaded keys.
+// current must match the passphrase the file is sealed with (and is ignored
+// if it is not protected); an empty next removes the protection. Fails with
+// ErrLocked if current is wrong. The device key file is encrypted with the
+// account key, which does not change, so it is left as is.
+func (am *AccountManager) ChangePassphrase(current, next string) error {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
+	if am.keys == nil {
+		return fmt.Errorf("no keys loaded")
+	}
 
-	return accountErr == nil && deviceErr == nil
+	// Verify current against the file, not just the loaded keys
+	if _, err := am.readAccountKey(current); err != nil {
+		return err
+	}
+
+	return am.writeAccountKey(next)
+}
+
+// readAccountKey reads the marshalled account key, opening it with
+// passphrase if it is sealed.
+func (am *AccountManager) readAccountKey(passphrase string) ([]byte, error) {
+	data, err := am.store.Load(accountKeyFile)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("account key file not found: %w", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read account key: %w", err)
+	}
+
+	sealed, ok := parseSealedKey(data)
+	if !ok {
+		return data, nil
+	}
+	if passphrase == "" {
+		return nil, errPassphraseRequired()
+	}
+	return sealed.open(passphrase)
+}
+
+// writeAccountKey writes the account key, sealed with passphrase if it is
+// not empty. The caller must hold am.mu.
+func (am *AccountManager) writeAccountKey(passphrase string) error {
+	// Marshal account key (SignKey is the account key)
+	accountKeyBytes, err := am.keys.SignKey.Marshall()
+	if err != nil {
+		return fmt.Errorf("failed to marshal account key: %w", err)
+	}
+
+	if passphrase != "" {
+		accountKeyBytes, err = sealKey(accountKeyBytes, passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to seal account key: %w", err)
+		}
+	}
+
+	if err := am.store.Store(accountKeyFile, accountKeyBytes); err != nil {
+		return fmt.Errorf("failed to write account key: %w", err)
+	}
+	return nil
 }
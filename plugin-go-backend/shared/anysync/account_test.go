@@ -1,8 +1,11 @@
 package anysync
 
 import (
+	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -236,3 +239,238 @@ func TestStoreKeysWithoutGeneration(t *testing.T) {
 		t.Fatal("StoreKeys should fail when no keys are generated")
 	}
 }
+
+// lockedReason returns the reason detail of an ErrLocked error, or "" if err is not one.
+func lockedReason(err error) string {
+	var lockedErr *Error
+	if !errors.As(err, &lockedErr) || !errors.Is(err, ErrLocked) {
+		return ""
+	}
+	return lockedErr.Details["reason"]
+}
+
+// TestStoreAndLoadKeysWithPassphrase verifies that a sealed account key needs its passphrase.
+func TestStoreAndLoadKeysWithPassphrase(t *testing.T) {
+	tmpDir := t.TempDir()
+	am := NewAccountManager(tmpDir)
+
+	if err := am.GenerateKeys(); err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	originalPeerId := am.GetKeys().PeerId
+	plainKey, err := am.GetKeys().SignKey.Marshall()
+	if err != nil {
+		t.Fatalf("Marshall failed: %v", err)
+	}
+
+	if err := am.StoreKeysWithPassphrase("correct horse"); err != nil {
+		t.Fatalf("StoreKeysWithPassphrase failed: %v", err)
+	}
+
+	// The key file does not contain the plain key
+	data, err := os.ReadFile(filepath.Join(tmpDir, accountKeyFile))
+	if err != nil {
+		t.Fatalf("Failed to read account key: %v", err)
+	}
+	if bytes.Contains(data, plainKey) {
+		t.Fatal("Account key file contains the plain key")
+	}
+	if protected, err := am.PassphraseProtected(); err != nil || !protected {
+		t.Fatalf("Expected the key to be protected, got %v (err: %v)", protected, err)
+	}
+
+	am = NewAccountManager(tmpDir)
+	if err := am.LoadKeys(); lockedReason(err) != "passphrase_required" {
+		t.Fatalf("Expected passphrase_required, got %v", err)
+	}
+	if err := am.LoadKeysWithPassphrase("wrong horse"); lockedReason(err) != "wrong_passphrase" {
+		t.Fatalf("Expected wrong_passphrase, got %v", err)
+	}
+	if am.HasKeys() {
+		t.Fatal("Keys should not be loaded with a wrong passphrase")
+	}
+
+	if err := am.LoadKeysWithPassphrase("correct horse"); err != nil {
+		t.Fatalf("LoadKeysWithPassphrase failed: %v", err)
+	}
+	if am.GetKeys().PeerId != originalPeerId {
+		t.Fatalf("PeerId mismatch: got %s, want %s", am.GetKeys().PeerId, originalPeerId)
+	}
+}
+
+// TestChangePassphrase verifies setting, changing and removing the passphrase.
+func TestChangePassphrase(t *testing.T) {
+	tmpDir := t.TempDir()
+	am := NewAccountManager(tmpDir)
+
+	if err := am.GenerateKeys(); err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	if err := am.StoreKeys(); err != nil {
+		t.Fatalf("StoreKeys failed: %v", err)
+	}
+	originalPeerId := am.GetKeys().PeerId
+
+	// Set
+	if err := am.ChangePassphrase("", "first"); err != nil {
+		t.Fatalf("Setting the passphrase failed: %v", err)
+	}
+
+	// Change, which needs the current passphrase
+	if err := am.ChangePassphrase("wrong", "second"); lockedReason(err) != "wrong_passphrase" {
+		t.Fatalf("Expected wrong_passphrase, got %v", err)
+	}
+	if err := am.ChangePassphrase("first", "second"); err != nil {
+		t.Fatalf("Changing the passphrase failed: %v", err)
+	}
+	reloaded := NewAccountManager(tmpDir)
+	if err := reloaded.LoadKeysWithPassphrase("first"); lockedReason(err) != "wrong_passphrase" {
+		t.Fatalf("Expected the old passphrase to be rejected, got %v", err)
+	}
+	if err := reloaded.LoadKeysWithPassphrase("second"); err != nil {
+		t.Fatalf("Loading with the new passphrase failed: %v", err)
+	}
+	if reloaded.GetKeys().PeerId != originalPeerId {
+		t.Fatal("The device key changed with the passphrase")
+	}
+
+	// Remove
+	if err := am.ChangePassphrase("second", ""); err != nil {
+		t.Fatalf("Removing the passphrase failed: %v", err)
+	}
+	if protected, _ := am.PassphraseProtected(); protected {
+		t.Fatal("Expected the key to be unprotected")
+	}
+	if err := NewAccountManager(tmpDir).LoadKeys(); err != nil {
+		t.Fatalf("LoadKeys failed after removing the passphrase: %v", err)
+	}
+}
+
+// TestAccountManagerWithKeyStore verifies that keys go through the key store
+// and nothing is written to disk.
+func TestAccountManagerWithKeyStore(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := NewMemoryKeyStore()
+	am := NewAccountManagerWithKeyStore(store)
+
+	if am.KeysExist() {
+		t.Fatal("KeysExist should be false for an empty store")
+	}
+	if err := am.GenerateKeys(); err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	if err := am.StoreKeysWithPassphrase("secret"); err != nil {
+		t.Fatalf("StoreKeysWithPassphrase failed: %v", err)
+	}
+	originalPeerId := am.GetKeys().PeerId
+
+	for _, name := range []string{accountKeyFile, deviceKeyFile} {
+		if exists, _ := store.Exists(name); !exists {
+			t.Fatalf("%s was not stored", name)
+		}
+	}
+	if entries, _ := os.ReadDir(tmpDir); len(entries) != 0 {
+		t.Fatalf("Expected no files on disk, got %d", len(entries))
+	}
+
+	reloaded := NewAccountManagerWithKeyStore(store)
+	if err := reloaded.LoadKeysWithPassphrase("secret"); err != nil {
+		t.Fatalf("LoadKeysWithPassphrase failed: %v", err)
+	}
+	if reloaded.GetKeys().PeerId != originalPeerId {
+		t.Fatalf("PeerId mismatch: got %s, want %s", reloaded.GetKeys().PeerId, originalPeerId)
+	}
+
+	// Delete
+	if err := am.DeleteKeys(); err != nil {
+		t.Fatalf("DeleteKeys failed: %v", err)
+	}
+	if am.KeysExist() {
+		t.Fatal("KeysExist should be false after DeleteKeys")
+	}
+	if err := reloaded.LoadKeys(); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound after DeleteKeys, got %v", err)
+	}
+}
+
+// TestMnemonicExportAndRestore verifies that the recovery phrase restores the
+// same account key with a new device key.
+func TestMnemonicExportAndRestore(t *testing.T) {
+	am := NewAccountManagerWithKeyStore(NewMemoryKeyStore())
+	if err := am.GenerateKeys(); err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	if err := am.StoreKeysWithPassphrase("secret"); err != nil {
+		t.Fatalf("StoreKeysWithPassphrase failed: %v", err)
+	}
+
+	if _, err := am.ExportMnemonic(""); lockedReason(err) != "passphrase_required" {
+		t.Fatalf("Expected passphrase_required, got %v", err)
+	}
+	mnemonic, err := am.ExportMnemonic("secret")
+	if err != nil {
+		t.Fatalf("ExportMnemonic failed: %v", err)
+	}
+	if words := strings.Fields(mnemonic); len(words) != mnemonicWords {
+		t.Fatalf("Expected %d words, got %d", mnemonicWords, len(words))
+	}
+
+	restored := NewAccountManagerWithKeyStore(NewMemoryKeyStore())
+	// Case and whitespace do not matter
+	if err := restored.RestoreKeys("  " + strings.ToUpper(mnemonic) + "\n"); err != nil {
+		t.Fatalf("RestoreKeys failed: %v", err)
+	}
+	if !restored.GetKeys().SignKey.Equals(am.GetKeys().SignKey) {
+		t.Fatal("The restored account key differs")
+	}
+	if restored.GetKeys().PeerId == am.GetKeys().PeerId {
+		t.Fatal("The restored account should have a new device key")
+	}
+
+	matches, err := am.MatchesMnemonic(mnemonic)
+	if err != nil || !matches {
+		t.Fatalf("Expected the phrase to match its account, got %v, %v", matches, err)
+	}
+	other := NewAccountManagerWithKeyStore(NewMemoryKeyStore())
+	if err := other.GenerateKeys(); err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	if matches, _ := other.MatchesMnemonic(mnemonic); matches {
+		t.Fatal("The phrase should not match another account")
+	}
+}
+
+// TestRestoreKeysInvalidMnemonic verifies that invalid recovery phrases are rejected.
+func TestRestoreKeysInvalidMnemonic(t *testing.T) {
+	am := NewAccountManagerWithKeyStore(NewMemoryKeyStore())
+	if err := am.GenerateKeys(); err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	if err := am.StoreKeys(); err != nil {
+		t.Fatalf("StoreKeys failed: %v", err)
+	}
+	mnemonic, err := am.ExportMnemonic("")
+	if err != nil {
+		t.Fatalf("ExportMnemonic failed: %v", err)
+	}
+	words := strings.Fields(mnemonic)
+
+	// "abandon" x 23 + "art" is the BIP39 phrase of zero entropy; the last
+	// word carries the checksum
+	badChecksum := strings.Repeat("abandon ", mnemonicWords-1) + "abandon"
+
+	for name, phrase := range map[string]string{
+		"Empty":       "",
+		"TooShort":    strings.Join(words[:12], " "),
+		"UnknownWord": strings.Join(append([]string{"notaword"}, words[1:]...), " "),
+		"Checksum":    badChecksum,
+	} {
+		restored := NewAccountManagerWithKeyStore(NewMemoryKeyStore())
+		if err := restored.RestoreKeys(phrase); !errors.Is(err, ErrInvalidArgument) {
+			t.Fatalf("%s: expected ErrInvalidArgument, got %v", name, err)
+		}
+		if restored.HasKeys() {
+			t.Fatalf("%s: keys should not be set", name)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+// Package anysync provides Any-Sync integration components.
+package anysync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
+	"github.com/anyproto/any-sync/commonspace/spacepayloads"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	"github.com/anyproto/any-sync/consensus/consensusproto"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+const (
+	// bundleFormat identifies an account bundle.
+	bundleFormat = "anysync-account"
+	// bundleVersion is the format version of the account bundles written.
+	bundleVersion = 1
+)
+
+// AccountBundle is an account moved between machines: the account key, the
+// account-level settings and the key material of its spaces. Documents are
+// not included. A bundle is sealed with a passphrase (see SealAccountBundle).
+type AccountBundle struct {
+	CreatedAt  int64        `json:"created_at"`
+	AccountKey []byte       `json:"account_key"` // Marshalled account key
+	Devices    []*Device    `json:"devices"`     // The device registry
+	Spaces     []*SpaceKeys `json:"spaces"`
+}
+
+// SpaceKeys is the key material of a space: its header, its ACL log, which
+// holds the read and metadata keys encrypted for the members, and the root
+// of its settings tree. It recreates the space without its documents.
+type SpaceKeys struct {
+	Metadata   *SpaceMetadata   `json:"metadata"`
+	Header     []byte           `json:"header"`      // Raw space header
+	AclRecords []SpaceAclRecord `json:"acl_records"` // The ACL log, root first
+	SettingsID string           `json:"settings_id"`
+	Settings   []byte           `json:"settings"` // Raw root change of the settings tree
+}
+
+// SpaceAclRecord is one record of the ACL log of a space.
+type SpaceAclRecord struct {
+	ID         string `json:"id"`
+	PrevID     string `json:"prev_id"`
+	Order      int    `json:"order"`
+	ChangeSize int    `json:"change_size"`
+	Payload    []byte `json:"payload"`
+}
+
+// bundleEnvelope is the outer, unencrypted form of a bundle.
+type bundleEnvelope struct {
+	Format  string          `json:"format"`
+	Version int             `json:"version"`
+	Sealed  json.RawMessage `json:"sealed"` // A sealedKey holding the AccountBundle JSON
+}
+
+// SealAccountBundle encrypts bundle with a key derived from passphrase.
+func SealAccountBundle(bundle *AccountBundle, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("%w: a passphrase is required to seal the bundle", ErrInvalidArgument)
+	}
+	if bundle.CreatedAt == 0 {
+		bundle.CreatedAt = time.Now().Unix()
+	}
+
+	plaintext, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+	sealed, err := sealKey(plaintext, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal bundle: %w", err)
+	}
+
+	return json.Marshal(bundleEnvelope{Format: bundleFormat, Version: bundleVersion, Sealed: sealed})
+}
+
+// OpenAccountBundle decrypts and validates a bundle. It fails with ErrLocked
+// if the passphrase is wrong, and with ErrInvalidArgument if data is not a
+// valid bundle of a supported version.
+func OpenAccountBundle(data []byte, passphrase string) (*AccountBundle, error) {
+	var envelope bundleEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Format != bundleFormat {
+		return nil, fmt.Errorf("%w: not an account bundle", ErrInvalidArgument)
+	}
+	if envelope.Version != bundleVersion {
+		return nil, fmt.Errorf("%w: unsupported account bundle version %d", ErrInvalidArgument, envelope.Version)
+	}
+	sealed, ok := parseSealedKey(envelope.Sealed)
+	if !ok {
+		return nil, fmt.Errorf("%w: account bundle is corrupted", ErrInvalidArgument)
+	}
+
+	if passphrase == "" {
+		return nil, fmt.Errorf("%w: the bundle passphrase is required", ErrInvalidArgument)
+	}
+	plaintext, err := sealed.open(passphrase)
+	if errors.Is(err, ErrLocked) {
+		return nil, &Error{
+			Kind:    ErrLocked,
+			Message: "wrong bundle passphrase",
+			Details: map[string]string{"reason": "wrong_passphrase"},
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var bundle AccountBundle
+	if err := json.Unmarshal(plaintext, &bundle); err != nil {
+		return nil, fmt.Errorf("%w: account bundle is corrupted: %v", ErrInvalidArgument, err)
+	}
+	if err := bundle.validate(); err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+// validate checks the account key and the key material of every space.
+func (b *AccountBundle) validate() error {
+	if _, err := crypto.UnmarshalEd25519PrivateKeyProto(b.AccountKey); err != nil {
+		return fmt.Errorf("%w: account bundle has an invalid account key: %v", ErrInvalidArgument, err)
+	}
+	for _, space := range b.Spaces {
+		if err := space.validate(); err != nil {
+			return fmt.Errorf("%w: account bundle has %v", ErrInvalidArgument, err)
+		}
+	}
+	return nil
+}
+
+// validate checks that the key material is complete and consistent.
+func (s *SpaceKeys) validate() error {
+	if s.Metadata == nil || len(s.AclRecords) == 0 {
+		return errors.New("an incomplete space")
+	}
+	if err := spacepayloads.ValidateSpaceStorageCreatePayload(s.createPayload()); err != nil {
+		return fmt.Errorf("invalid keys for space %s: %v", s.Metadata.SpaceID, err)
+	}
+	return nil
+}
+
+// createPayload returns the payload that creates the storage of the space.
+func (s *SpaceKeys) createPayload() spacestorage.SpaceStorageCreatePayload {
+	root := s.AclRecords[0]
+	return spacestorage.SpaceStorageCreatePayload{
+		AclWithId: &consensusproto.RawRecordWithId{
+			Payload: root.Payload,
+			Id:      root.ID,
+		},
+		SpaceHeaderWithId: &spacesyncproto.RawSpaceHeaderWithId{
+			RawHeader: s.Header,
+			Id:        s.Metadata.SpaceID,
+		},
+		SpaceSettingsWithId: &treechangeproto.RawTreeChangeWithId{
+			RawChange: s.Settings,
+			Id:        s.SettingsID,
+		},
+	}
+}
+
+// laterAclRecords returns the ACL records after the root, in storage form.
+func (s *SpaceKeys) laterAclRecords() []list.StorageRecord {
+	records := make([]list.StorageRecord, 0, len(s.AclRecords)-1)
+	for _, record := range s.AclRecords[1:] {
+		records = append(records, list.StorageRecord{
+			RawRecord:  record.Payload,
+			PrevId:     record.PrevID,
+			Id:         record.ID,
+			Order:      record.Order,
+			ChangeSize: record.ChangeSize,
+		})
+	}
+	return records
+}
+
+// ExportSpaceKeys returns the key material of every space, sorted by space
+// ID. Spaces are opened as needed.
+func (sm *SpaceManager) ExportSpaceKeys(ctx context.Context) ([]*SpaceKeys, error) {
+	spaces := sm.ListSpaces()
+	sort.Slice(spaces, func(i, j int) bool { return spaces[i].SpaceID < spaces[j].SpaceID })
+
+	exported := make([]*SpaceKeys, 0, len(spaces))
+	for _, metadata := range spaces {
+		space, release, err := sm.acquireSpaceObject(ctx, metadata.SpaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open space %s: %w", metadata.SpaceID, err)
+		}
+		keys, err := exportSpaceKeys(ctx, space.Storage(), metadata)
+		release()
+		if err != nil {
+			return nil, fmt.Errorf("failed to export keys of space %s: %w", metadata.SpaceID, err)
+		}
+		exported = append(exported, keys)
+	}
+	return exported, nil
+}
+
+// exportSpaceKeys reads the key material of a space from its storage.
+func exportSpaceKeys(ctx context.Context, storage spacestorage.SpaceStorage, metadata *SpaceMetadata) (*SpaceKeys, error) {
+	state, err := storage.StateStorage().GetState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read space state: %w", err)
+	}
+
+	aclStorage, err := storage.AclStorage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ACL: %w", err)
+	}
+	var records []SpaceAclRecord
+	err = aclStorage.GetAfterOrder(ctx, 0, func(ctx context.Context, record list.StorageRecord) (bool, error) {
+		// The storage reuses the buffer of RawRecord for the next record
+		records = append(records, SpaceAclRecord{
+			ID:         record.Id,
+			PrevID:     record.PrevId,
+			Order:      record.Order,
+			ChangeSize: record.ChangeSize,
+			Payload:    bytes.Clone(record.RawRecord),
+		})
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ACL: %w", err)
+	}
+
+	settingsStorage, err := storage.TreeStorage(ctx, state.SettingsId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open settings: %w", err)
+	}
+	settingsRoot, err := settingsStorage.Root(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	return &SpaceKeys{
+		Metadata:   metadata,
+		Header:     state.SpaceHeader,
+		AclRecords: records,
+		SettingsID: settingsRoot.Id,
+		Settings:   settingsRoot.RawChange,
+	}, nil
+}
+
+// ImportSpaceKeys recreates spaces, without their documents, from their key
+// material. A space that already exists fails with ErrAlreadyExists; spaces
+// imported before it are kept.
+func (sm *SpaceManager) ImportSpaceKeys(ctx context.Context, spaces []*SpaceKeys) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	for _, keys := range spaces {
+		if err := sm.importSpace(ctx, keys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importSpace recreates a space from its key material. The caller must hold
+// sm.mu.
+func (sm *SpaceManager) importSpace(ctx context.Context, keys *SpaceKeys) error {
+	spaceID := keys.Metadata.SpaceID
+	if _, exists := sm.spaces[spaceID]; exists {
+		return &Error{
+			Kind:    ErrAlreadyExists,
+			Message: "space already exists: " + spaceID,
+			Details: map[string]string{"space_id": spaceID},
+		}
+	}
+
+	storage, err := sm.storageProvider.CreateSpaceStorage(ctx, keys.createPayload())
+	if err != nil {
+		return fmt.Errorf("failed to create storage of space %s: %w", spaceID, err)
+	}
+	if len(keys.AclRecords) > 1 {
+		aclStorage, err := storage.AclStorage()
+		if err != nil {
+			return fmt.Errorf("failed to open ACL of space %s: %w", spaceID, err)
+		}
+		if err := aclStorage.AddAll(ctx, keys.laterAclRecords()); err != nil {
+			return fmt.Errorf("failed to import ACL of space %s: %w", spaceID, err)
+		}
+	}
+
+	metadata := *keys.Metadata
+	sm.spaces[spaceID] = &metadata
+	if err := sm.saveMetadata(); err != nil {
+		delete(sm.spaces, spaceID)
+		return fmt.Errorf("failed to save metadata: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package anysync
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAccountBundleRoundTrip tests exporting spaces into a sealed bundle and importing them into another data directory.
+func TestAccountBundleRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+
+	sm, err := NewSpaceManager(t.TempDir(), keys, NewEventManager())
+	require.NoError(t, err)
+	defer sm.Close()
+	spaceID, err := sm.CreateSpace(ctx, "ref-1", "Notes", map[string]string{"color": "blue"})
+	require.NoError(t, err)
+
+	spaces, err := sm.ExportSpaceKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, spaces, 1)
+	accountKey, err := keys.SignKey.Marshall()
+	require.NoError(t, err)
+
+	sealed, err := SealAccountBundle(&AccountBundle{AccountKey: accountKey, Spaces: spaces}, "bundle secret")
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), spaceID, "the bundle must be encrypted")
+
+	bundle, err := OpenAccountBundle(sealed, "bundle secret")
+	require.NoError(t, err)
+	assert.NotZero(t, bundle.CreatedAt)
+	assert.Equal(t, accountKey, bundle.AccountKey)
+
+	// Import on another device of the account
+	imported, err := NewSpaceManager(t.TempDir(), accountdata.New(keys.PeerKey, keys.SignKey), NewEventManager())
+	require.NoError(t, err)
+	defer imported.Close()
+	require.NoError(t, imported.ImportSpaceKeys(ctx, bundle.Spaces))
+
+	metadata, err := imported.GetSpace(spaceID)
+	require.NoError(t, err)
+	assert.Equal(t, "Notes", metadata.Name)
+	assert.Equal(t, "blue", metadata.Metadata["color"])
+	space, err := imported.GetSpaceObject(ctx, spaceID)
+	require.NoError(t, err)
+	assert.Equal(t, spaceID, space.Id())
+
+	// Importing the same space again fails
+	err = imported.ImportSpaceKeys(ctx, bundle.Spaces)
+	assert.ErrorIs(t, err, ErrAlreadyExists)
+}
+
+// TestAccountBundleRoundTrip_AclRecords tests that a bundle carries every
+// ACL record of a space, here an invite and a join, in order.
+func TestAccountBundleRoundTrip_AclRecords(t *testing.T) {
+	ctx := context.Background()
+	transport := NewMemoryTransport()
+	owner := newPeerSpaceManager(t, transport)
+	guest := newPeerSpaceManager(t, transport)
+
+	spaceID, err := owner.CreateSpace(ctx, "team", "Team", nil)
+	require.NoError(t, err)
+	invite, err := owner.CreateInvite(ctx, "team", PermissionWriter, 0)
+	require.NoError(t, err)
+	_, err = guest.JoinSpace(ctx, "shared", invite.Token)
+	require.NoError(t, err)
+
+	spaces, err := owner.ExportSpaceKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, spaces, 1)
+	require.GreaterOrEqual(t, len(spaces[0].AclRecords), 3, "root, invite and join")
+	accountKey, err := owner.keys.SignKey.Marshall()
+	require.NoError(t, err)
+
+	sealed, err := SealAccountBundle(&AccountBundle{AccountKey: accountKey, Spaces: spaces}, "bundle secret")
+	require.NoError(t, err)
+	bundle, err := OpenAccountBundle(sealed, "bundle secret")
+	require.NoError(t, err)
+	assert.Equal(t, spaces, bundle.Spaces)
+
+	imported, err := NewSpaceManager(t.TempDir(), accountdata.New(owner.keys.PeerKey, owner.keys.SignKey), NewEventManager())
+	require.NoError(t, err)
+	defer imported.Close()
+	require.NoError(t, imported.ImportSpaceKeys(ctx, bundle.Spaces))
+
+	source, err := owner.GetSpaceObject(ctx, spaceID)
+	require.NoError(t, err)
+	space, err := imported.GetSpaceObject(ctx, spaceID)
+	require.NoError(t, err)
+	source.Acl().RLock()
+	head := source.Acl().Head().Id
+	source.Acl().RUnlock()
+
+	acl := space.Acl()
+	acl.RLock()
+	defer acl.RUnlock()
+	assert.Equal(t, head, acl.Head().Id)
+	assert.Len(t, acl.Records(), len(spaces[0].AclRecords))
+	assert.True(t, acl.AclState().Permissions(owner.keys.SignKey.GetPublic()).IsOwner())
+	assert.True(t, acl.AclState().Permissions(guest.keys.SignKey.GetPublic()).CanWrite())
+	readKey, err := acl.AclState().CurrentReadKey()
+	require.NoError(t, err)
+	assert.NotNil(t, readKey)
+}
+
+// TestOpenAccountBundleErrors tests that wrong passphrases and invalid bundles are rejected.
+func TestOpenAccountBundleErrors(t *testing.T) {
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	accountKey, err := keys.SignKey.Marshall()
+	require.NoError(t, err)
+	sealed, err := SealAccountBundle(&AccountBundle{AccountKey: accountKey}, "bundle secret")
+	require.NoError(t, err)
+
+	_, err = SealAccountBundle(&AccountBundle{AccountKey: accountKey}, "")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	_, err = OpenAccountBundle(sealed, "wrong")
+	assert.ErrorIs(t, err, ErrLocked)
+	assert.Equal(t, "wrong_passphrase", lockedReason(err))
+
+	_, err = OpenAccountBundle(sealed, "")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	_, err = OpenAccountBundle([]byte("not a bundle"), "bundle secret")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	// A newer format version
+	var envelope map[string]any
+	require.NoError(t, json.Unmarshal(sealed, &envelope))
+	envelope["version"] = bundleVersion + 1
+	newer, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	_, err = OpenAccountBundle(newer, "bundle secret")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	assert.Contains(t, err.Error(), "unsupported account bundle version")
+
+	// An invalid account key
+	sealed, err = SealAccountBundle(&AccountBundle{AccountKey: []byte("garbage")}, "bundle secret")
+	require.NoError(t, err)
+	_, err = OpenAccountBundle(sealed, "bundle secret")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
@@ -0,0 +1,384 @@
+// Package anysync provides Any-Sync integration components.
+package anysync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anyproto/any-sync/commonspace"
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// devicesFile stores the device registry of the account.
+const devicesFile = "devices.json"
+
+// Device is one device the account is used on, identified by a stable ID
+// while its key can be rotated.
+type Device struct {
+	DeviceID   string `json:"device_id"`
+	Name       string `json:"name"`
+	PeerID     string `json:"peer_id"`  // Peer ID of the current device key
+	PeerKey    []byte `json:"peer_key"` // Marshalled public device key
+	AddedAt    int64  `json:"added_at"`
+	LastSeenAt int64  `json:"last_seen_at"`
+	RevokedAt  int64  `json:"revoked_at,omitempty"` // 0 while the device is active
+}
+
+// Revoked reports whether the device was revoked.
+func (d *Device) Revoked() bool {
+	return d.RevokedAt != 0
+}
+
+// PublicKey returns the device key the device runs with.
+func (d *Device) PublicKey() (crypto.PubKey, error) {
+	key, err := crypto.UnmarshalEd25519PublicKeyProto(d.PeerKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key of device %s: %w", d.DeviceID, err)
+	}
+	return key, nil
+}
+
+// DeviceRegistry tracks the devices of the account and which one is this
+// device. It is persisted in the data directory.
+type DeviceRegistry struct {
+	mu      sync.RWMutex
+	path    string
+	self    string
+	devices map[string]*Device
+}
+
+// deviceRegistryFile is the on-disk form of a DeviceRegistry.
+type deviceRegistryFile struct {
+	Self    string    `json:"self"`
+	Devices []*Device `json:"devices"`
+}
+
+// NewDeviceRegistry loads the device registry of dataDir, which is empty if
+// none was saved yet.
+func NewDeviceRegistry(dataDir string) (*DeviceRegistry, error) {
+	dr := &DeviceRegistry{
+		path:    filepath.Join(dataDir, devicesFile),
+		devices: make(map[string]*Device),
+	}
+
+	data, err := os.ReadFile(dr.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return dr, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read device registry: %w", err)
+	}
+
+	var file deviceRegistryFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal device registry: %w", err)
+	}
+	dr.self = file.Self
+	for _, device := range file.Devices {
+		dr.devices[device.DeviceID] = device
+	}
+	return dr, nil
+}
+
+// RegisterSelf records that this device runs with peerKey and marks it as
+// seen now. deviceID defaults to the ID this device registered with before,
+// and to the peer ID of peerKey on first use; an empty name keeps the
+// current one. A revoked device fails with ErrPermissionDenied.
+func (dr *DeviceRegistry) RegisterSelf(deviceID, name string, peerKey crypto.PubKey) (*Device, error) {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+
+	if deviceID == "" {
+		deviceID = dr.self
+	}
+	if deviceID == "" {
+		deviceID = peerKey.PeerId()
+	}
+
+	peerKeyBytes, err := peerKey.Marshall()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal device key: %w", err)
+	}
+
+	now := time.Now().Unix()
+	device, ok := dr.devices[deviceID]
+	if !ok {
+		device = &Device{DeviceID: deviceID, Name: deviceID, AddedAt: now}
+	}
+	if device.Revoked() {
+		return nil, errDeviceRevoked(deviceID)
+	}
+	if name != "" {
+		device.Name = name
+	}
+	device.PeerID = peerKey.PeerId()
+	device.PeerKey = peerKeyBytes
+	device.LastSeenAt = now
+
+	dr.devices[deviceID] = device
+	dr.self = deviceID
+	if err := dr.save(); err != nil {
+		return nil, err
+	}
+
+	copied := *device
+	return &copied, nil
+}
+
+// Self returns the ID of this device, empty before RegisterSelf.
+func (dr *DeviceRegistry) Self() string {
+	dr.mu.RLock()
+	defer dr.mu.RUnlock()
+
+	return dr.self
+}
+
+// Get returns a device by ID.
+func (dr *DeviceRegistry) Get(deviceID string) (*Device, error) {
+	dr.mu.RLock()
+	defer dr.mu.RUnlock()
+
+	device, ok := dr.devices[deviceID]
+	if !ok {
+		return nil, errDeviceNotFound(deviceID)
+	}
+	copied := *device
+	return &copied, nil
+}
+
+// InUse reports whether an active device runs with the key of peerID.
+func (dr *DeviceRegistry) InUse(peerID string) bool {
+	dr.mu.RLock()
+	defer dr.mu.RUnlock()
+
+	for _, device := range dr.devices {
+		if device.PeerID == peerID && !device.Revoked() {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the devices, active and revoked, by date added.
+func (dr *DeviceRegistry) List() []*Device {
+	dr.mu.RLock()
+	defer dr.mu.RUnlock()
+
+	devices := make([]*Device, 0, len(dr.devices))
+	for _, device := range dr.devices {
+		copied := *device
+		devices = append(devices, &copied)
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		if devices[i].AddedAt != devices[j].AddedAt {
+			return devices[i].AddedAt < devices[j].AddedAt
+		}
+		return devices[i].DeviceID < devices[j].DeviceID
+	})
+	return devices
+}
+
+// Revoke revokes another device of the account: it can no longer register,
+// so its key cannot open the account. Revoking a revoked device is a no-op;
+// this device cannot be revoked.
+func (dr *DeviceRegistry) Revoke(deviceID string) (*Device, error) {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+
+	device, ok := dr.devices[deviceID]
+	if !ok {
+		return nil, errDeviceNotFound(deviceID)
+	}
+	if deviceID == dr.self {
+		return nil, fmt.Errorf("%w: this device cannot revoke itself, rotate its key instead", ErrInvalidArgument)
+	}
+
+	if !device.Revoked() {
+		device.RevokedAt = time.Now().Unix()
+		if err := dr.save(); err != nil {
+			device.RevokedAt = 0
+			return nil, err
+		}
+	}
+
+	copied := *device
+	return &copied, nil
+}
+
+// Import replaces the devices of an empty registry with those of another
+// data directory of the account, e.g. from an account bundle. This device is
+// registered by the next RegisterSelf.
+func (dr *DeviceRegistry) Import(devices []*Device) error {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+
+	if len(dr.devices) != 0 {
+		return fmt.Errorf("%w: the device registry is not empty", ErrAlreadyExists)
+	}
+	for _, device := range devices {
+		copied := *device
+		dr.devices[device.DeviceID] = &copied
+	}
+	if err := dr.save(); err != nil {
+		dr.devices = make(map[string]*Device)
+		return err
+	}
+	return nil
+}
+
+// save persists the registry. The caller must hold dr.mu for writing.
+func (dr *DeviceRegistry) save() error {
+	file := deviceRegistryFile{Self: dr.self, Devices: make([]*Device, 0, len(dr.devices))}
+	for _, device := range dr.devices {
+		file.Devices = append(file.Devices, device)
+	}
+	sort.Slice(file.Devices, func(i, j int) bool { return file.Devices[i].DeviceID < file.Devices[j].DeviceID })
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("failed to marshal device registry: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(dr.path), 0700); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	if err := writeFileAtomic(dr.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write device registry: %w", err)
+	}
+	return nil
+}
+
+// errDeviceNotFound returns an ErrNotFound error for a device not in the
+// registry.
+func errDeviceNotFound(deviceID string) error {
+	return &Error{
+		Kind:    ErrNotFound,
+		Message: "device not found: " + deviceID,
+		Details: map[string]string{"device_id": deviceID},
+	}
+}
+
+// errDeviceRevoked returns an ErrPermissionDenied error for a revoked device.
+func errDeviceRevoked(deviceID string) error {
+	return &Error{
+		Kind:    ErrPermissionDenied,
+		Message: "device was revoked: " + deviceID,
+		Details: map[string]string{"device_id": deviceID},
+	}
+}
+
+// Any-Sync ACLs grant access to accounts, and every device of an account
+// holds its key. So that one device can be cut off, each device key also
+// gets an entry of its own, as a writer, in the ACL of the spaces the account
+// owns. Device entries are added with AccountsAdd records, which members
+// never join with, and are not listed as members.
+
+// deviceEntryMetadata is the metadata of device entries.
+var deviceEntryMetadata = []byte("device")
+
+// addDeviceEntry adds the device key of this device to the ACL of space,
+// if the account owns it and the key has no entry yet. Copies of spaces the
+// account left are read-only and are not changed.
+func (sm *SpaceManager) addDeviceEntry(metadata *SpaceMetadata, space commonspace.Space) error {
+	if metadata.LeftAt != 0 {
+		return nil
+	}
+
+	acl := space.Acl()
+	acl.Lock()
+	defer acl.Unlock()
+
+	state := acl.AclState()
+	deviceKey := sm.keys.PeerKey.GetPublic()
+	if !state.Permissions(sm.keys.SignKey.GetPublic()).IsOwner() || !state.Permissions(deviceKey).NoPermissions() {
+		return nil
+	}
+
+	record, err := acl.RecordBuilder().BuildAccountsAdd(list.AccountsAddPayload{
+		Additions: []list.AccountAdd{{
+			Identity:    deviceKey,
+			Permissions: list.AclPermissionsWriter,
+			Metadata:    deviceEntryMetadata,
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add device to ACL: %w", err)
+	}
+	if err := sm.addAclRecord(acl, record); err != nil {
+		return fmt.Errorf("failed to add device to ACL: %w", err)
+	}
+	return nil
+}
+
+// RemoveDevice removes the entry of a device key from the ACL of every space
+// the account owns, and changes their read key so that the device key
+// cannot read what is written from then on. Spaces without an entry for the
+// key are left as they are, so a failed removal can be retried.
+func (sm *SpaceManager) RemoveDevice(ctx context.Context, deviceKey crypto.PubKey) error {
+	if deviceKey.Equals(sm.keys.PeerKey.GetPublic()) {
+		return fmt.Errorf("%w: the device key of this device cannot be removed", ErrInvalidArgument)
+	}
+
+	for _, metadata := range sm.ListSpaces() {
+		if metadata.LeftAt != 0 {
+			continue
+		}
+		space, release, err := sm.acquireSpaceObject(ctx, metadata.SpaceID)
+		if err != nil {
+			return err
+		}
+		err = sm.removeDeviceEntry(space.Acl(), deviceKey)
+		release()
+		if err != nil {
+			return fmt.Errorf("failed to remove device from space %s: %w", metadata.SpaceID, err)
+		}
+	}
+	return nil
+}
+
+// removeDeviceEntry removes the entry of deviceKey from acl, if the account
+// owns the space and the key has one.
+func (sm *SpaceManager) removeDeviceEntry(acl list.AclList, deviceKey crypto.PubKey) error {
+	acl.Lock()
+	defer acl.Unlock()
+
+	state := acl.AclState()
+	if !state.Permissions(sm.keys.SignKey.GetPublic()).IsOwner() || state.Permissions(deviceKey).NoPermissions() {
+		return nil
+	}
+
+	record, err := buildAccountRemove(acl, deviceKey)
+	if err != nil {
+		return err
+	}
+	return sm.addAclRecord(acl, record)
+}
+
+// isDeviceEntry reports whether account is the entry of a device key.
+func isDeviceEntry(acl list.AclList, account list.AccountState) bool {
+	if len(account.PermissionChanges) == 0 {
+		return false
+	}
+	record, err := acl.Get(account.PermissionChanges[0].RecordId)
+	if err != nil {
+		return false
+	}
+	data, ok := record.Model.(*aclrecordproto.AclData)
+	if !ok {
+		return false
+	}
+	for _, content := range data.GetAclContent() {
+		if content.GetAccountsAdd() != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package anysync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeviceRegistry tests registering, rotating and revoking devices, and that the registry persists.
+func TestDeviceRegistry(t *testing.T) {
+	dataDir := t.TempDir()
+	dr, err := NewDeviceRegistry(dataDir)
+	require.NoError(t, err)
+	assert.Empty(t, dr.List())
+
+	// The first key names the device when no ID is given
+	_, firstKey, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	device, err := dr.RegisterSelf("", "Laptop", firstKey)
+	require.NoError(t, err)
+	assert.Equal(t, firstKey.PeerId(), device.DeviceID)
+	assert.Equal(t, "Laptop", device.Name)
+	assert.Equal(t, device.DeviceID, dr.Self())
+
+	// A rotated key keeps the device ID and name
+	_, secondKey, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	rotated, err := dr.RegisterSelf("", "", secondKey)
+	require.NoError(t, err)
+	assert.Equal(t, device.DeviceID, rotated.DeviceID)
+	assert.Equal(t, "Laptop", rotated.Name)
+	assert.Equal(t, secondKey.PeerId(), rotated.PeerID)
+	assert.Equal(t, device.AddedAt, rotated.AddedAt)
+
+	// Another device, then revoke it
+	reloaded, err := NewDeviceRegistry(dataDir)
+	require.NoError(t, err)
+	_, phoneKey, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	_, err = reloaded.RegisterSelf("phone", "Phone", phoneKey)
+	require.NoError(t, err)
+	assert.Len(t, reloaded.List(), 2)
+
+	_, err = reloaded.Revoke("phone")
+	assert.ErrorIs(t, err, ErrInvalidArgument, "a device cannot revoke itself")
+	_, err = reloaded.RegisterSelf(device.DeviceID, "", secondKey)
+	require.NoError(t, err)
+	revoked, err := reloaded.Revoke("phone")
+	require.NoError(t, err)
+	assert.True(t, revoked.Revoked())
+	again, err := reloaded.Revoke("phone")
+	require.NoError(t, err)
+	assert.Equal(t, revoked.RevokedAt, again.RevokedAt)
+	_, err = reloaded.Revoke("tablet")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// A revoked device cannot register again
+	reloaded, err = NewDeviceRegistry(dataDir)
+	require.NoError(t, err)
+	_, err = reloaded.RegisterSelf("phone", "", phoneKey)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	assert.Equal(t, device.DeviceID, reloaded.Self())
+}
+
+// TestRotateDeviceKey verifies that rotation keeps the account key and stores the new device key.
+func TestRotateDeviceKey(t *testing.T) {
+	store := NewMemoryKeyStore()
+	am := NewAccountManagerWithKeyStore(store)
+	require.NoError(t, am.GenerateKeys())
+	require.NoError(t, am.StoreKeysWithPassphrase("secret"))
+	before := am.GetKeys()
+
+	require.NoError(t, am.RotateDeviceKey())
+	after := am.GetKeys()
+	assert.True(t, after.SignKey.Equals(before.SignKey))
+	assert.NotEqual(t, before.PeerId, after.PeerId)
+
+	reloaded := NewAccountManagerWithKeyStore(store)
+	require.NoError(t, reloaded.LoadKeysWithPassphrase("secret"))
+	assert.Equal(t, after.PeerId, reloaded.GetKeys().PeerId)
+}
+
+// TestRemoveDevice tests that a removed device key loses its ACL entry and
+// the read key of the spaces, while the other devices keep writing.
+func TestRemoveDevice(t *testing.T) {
+	ctx := context.Background()
+	laptopKeys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	laptop, err := NewSpaceManager(t.TempDir(), laptopKeys, NewEventManager())
+	require.NoError(t, err)
+	t.Cleanup(func() { laptop.Close() })
+
+	spaceID, err := laptop.CreateSpace(ctx, "team", "Team", nil)
+	require.NoError(t, err)
+	exported, err := laptop.ExportSpaceKeys(ctx)
+	require.NoError(t, err)
+
+	// The phone runs the same account with a device key of its own
+	phonePeerKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	phone, err := NewSpaceManager(t.TempDir(), accountdata.New(phonePeerKey, laptopKeys.SignKey), NewEventManager())
+	require.NoError(t, err)
+	t.Cleanup(func() { phone.Close() })
+	require.NoError(t, phone.ImportSpaceKeys(ctx, exported))
+	_, err = phone.GetSpaceObject(ctx, spaceID)
+	require.NoError(t, err)
+
+	// Both device keys are writers, and neither is listed as a member
+	laptopDevice := laptopKeys.PeerKey.GetPublic()
+	space, err := phone.GetSpaceObject(ctx, spaceID)
+	require.NoError(t, err)
+	space.Acl().RLock()
+	assert.True(t, space.Acl().AclState().Permissions(laptopDevice).CanWrite())
+	assert.True(t, space.Acl().AclState().Permissions(phonePeerKey.GetPublic()).CanWrite())
+	space.Acl().RUnlock()
+	members, err := phone.ListMembers(ctx, spaceID)
+	require.NoError(t, err)
+	assert.Len(t, members, 1)
+
+	asLaptopDevice := accountdata.New(laptopKeys.PeerKey, laptopKeys.PeerKey)
+	keys, err := phone.ExportSpaceKeys(ctx)
+	require.NoError(t, err)
+	acl, err := buildAcl(asLaptopDevice, keys[0])
+	require.NoError(t, err)
+	readKey, err := acl.AclState().CurrentReadKey()
+	require.NoError(t, err)
+	assert.NotNil(t, readKey, "the device key reads the space before removal")
+
+	// The phone removes the laptop's key
+	assert.ErrorIs(t, phone.RemoveDevice(ctx, phonePeerKey.GetPublic()), ErrInvalidArgument)
+	require.NoError(t, phone.RemoveDevice(ctx, laptopDevice))
+	require.NoError(t, phone.RemoveDevice(ctx, laptopDevice), "removing again is a no-op")
+
+	space.Acl().RLock()
+	assert.True(t, space.Acl().AclState().Permissions(laptopDevice).NoPermissions())
+	assert.True(t, space.Acl().AclState().Permissions(laptopKeys.SignKey.GetPublic()).IsOwner())
+	space.Acl().RUnlock()
+
+	keys, err = phone.ExportSpaceKeys(ctx)
+	require.NoError(t, err)
+	acl, err = buildAcl(asLaptopDevice, keys[0])
+	require.NoError(t, err)
+	readKey, _ = acl.AclState().CurrentReadKey()
+	assert.Nil(t, readKey, "the removed device key cannot read the new read key")
+
+	// The phone keeps writing, and the members are unchanged
+	documents, err := NewDocumentManager(phone, phone.keys, phone.eventManager)
+	require.NoError(t, err)
+	t.Cleanup(func() { documents.Close() })
+	_, err = documents.CreateDocument(ctx, spaceID, "Note", []byte("hello"), nil)
+	require.NoError(t, err)
+	members, err = phone.ListMembers(ctx, spaceID)
+	require.NoError(t, err)
+	assert.Len(t, members, 1)
+}
@@ -0,0 +1,221 @@
+// Package anysync provides Any-Sync integration components.
+package anysync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DocumentTx groups document writes so they can be committed or rolled back
+// as a unit. It holds the DocumentManager write lock from Begin until Commit
+// or Rollback, so other callers never observe a partially applied batch.
+//
+// ObjectTree changes are append-only, so rollback is done by compensation:
+// created documents are deleted, updated documents get their previous content
+// re-applied as a new change and their metadata restored. Deletes are
+// deferred until Commit. Events are buffered and only emitted on Commit.
+//
+// Operations honour their context, but Commit and Rollback do not take one:
+// once started they run to completion, so a cancelled batch is still undone.
+type DocumentTx struct {
+	dm      *DocumentManager
+	undo    []func() error  // Compensating actions, applied in reverse order on Rollback
+	deletes []pendingDelete // Tree deletions applied on Commit
+	events  []pendingEvent  // Events emitted on Commit
+	touched map[string]bool // Spaces whose metadata must be saved on Commit/Rollback
+	done    bool            // Set once Commit or Rollback has run
+}
+
+type pendingDelete struct {
+	spaceID    string
+	documentID string
+}
+
+type pendingEvent struct {
+	eventType EventType
+	spaceID   string
+	payload   map[string]string
+}
+
+// Begin starts a document transaction. The caller must call Commit or
+// Rollback to release the manager.
+func (dm *DocumentManager) Begin() *DocumentTx {
+	dm.mu.Lock()
+	return &DocumentTx{
+		dm:      dm,
+		touched: make(map[string]bool),
+	}
+}
+
+// CreateDocument creates a document within the transaction.
+func (tx *DocumentTx) CreateDocument(ctx context.Context, spaceID, title string, data []byte, metadata map[string]string) (string, error) {
+	spaceID = tx.dm.spaceManager.ResolveSpaceID(spaceID)
+
+	documentID, err := tx.dm.createDocument(ctx, spaceID, title, data, metadata, tx.emit)
+	if err != nil {
+		return "", err
+	}
+
+	tx.undo = append(tx.undo, func() error {
+		if err := tx.dm.deleteTree(context.Background(), spaceID, documentID); err != nil {
+			return err
+		}
+		delete(tx.dm.metadata[spaceID], documentID)
+		tx.touched[spaceID] = true
+		return nil
+	})
+
+	return documentID, nil
+}
+
+// GetDocument retrieves a document, including changes made in the transaction.
+func (tx *DocumentTx) GetDocument(ctx context.Context, spaceID, documentID string) ([]byte, *DocumentMetadata, error) {
+	spaceID = tx.dm.spaceManager.ResolveSpaceID(spaceID)
+
+	return tx.dm.getDocument(ctx, spaceID, documentID)
+}
+
+// UpdateDocument updates a document within the transaction.
+func (tx *DocumentTx) UpdateDocument(ctx context.Context, spaceID, documentID string, data []byte, metadata map[string]string, expectedVersion int64) (int64, error) {
+	spaceID = tx.dm.spaceManager.ResolveSpaceID(spaceID)
+
+	prevData, docMeta, err := tx.dm.getDocument(ctx, spaceID, documentID)
+	if err != nil {
+		return 0, err
+	}
+	prevMeta := *docMeta
+
+	version, err := tx.dm.updateDocument(ctx, spaceID, documentID, data, metadata, expectedVersion, tx.emit)
+	if err != nil {
+		return 0, err
+	}
+
+	tx.undo = append(tx.undo, func() error {
+		if err := tx.dm.addContent(context.Background(), spaceID, documentID, prevData); err != nil {
+			return err
+		}
+		*docMeta = prevMeta
+		tx.touched[spaceID] = true
+		return nil
+	})
+
+	return version, nil
+}
+
+// DeleteDocument removes a document within the transaction. The document
+// disappears from reads immediately; its ObjectTree is deleted on Commit.
+func (tx *DocumentTx) DeleteDocument(ctx context.Context, spaceID, documentID string) error {
+	spaceID = tx.dm.spaceManager.ResolveSpaceID(spaceID)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	docMeta, err := tx.dm.getMetadata(spaceID, documentID)
+	if err != nil {
+		return err
+	}
+
+	delete(tx.dm.metadata[spaceID], documentID)
+	tx.touched[spaceID] = true
+	tx.deletes = append(tx.deletes, pendingDelete{spaceID: spaceID, documentID: documentID})
+	tx.emit(EventDocumentDeleted, spaceID, map[string]string{
+		"document_id": documentID,
+	})
+
+	tx.undo = append(tx.undo, func() error {
+		tx.dm.metadata[spaceID][documentID] = docMeta
+		return nil
+	})
+
+	return nil
+}
+
+// ListDocuments returns all documents in a space, including changes made in the transaction.
+func (tx *DocumentTx) ListDocuments(ctx context.Context, spaceID string) ([]*DocumentMetadata, error) {
+	spaceID = tx.dm.spaceManager.ResolveSpaceID(spaceID)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return tx.dm.listDocuments(spaceID), nil
+}
+
+// QueryDocuments returns documents matching the given tags, including changes made in the transaction.
+func (tx *DocumentTx) QueryDocuments(ctx context.Context, spaceID string, tags []string) ([]*DocumentMetadata, error) {
+	spaceID = tx.dm.spaceManager.ResolveSpaceID(spaceID)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return tx.dm.queryDocuments(spaceID, tags), nil
+}
+
+// Commit applies deferred deletes, persists metadata and emits the buffered
+// events, then releases the manager.
+func (tx *DocumentTx) Commit() error {
+	if tx.done {
+		return fmt.Errorf("transaction already finished")
+	}
+	defer tx.finish()
+
+	var errs []error
+	for _, d := range tx.deletes {
+		if err := tx.dm.deleteTree(context.Background(), d.spaceID, d.documentID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	errs = append(errs, tx.saveTouched()...)
+
+	for _, e := range tx.events {
+		tx.dm.eventManager.EmitEvent(e.eventType, e.spaceID, e.payload)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Rollback undoes every change made in the transaction, drops the buffered
+// events and releases the manager. Calling Rollback after Commit is a no-op,
+// so it is safe to defer.
+func (tx *DocumentTx) Rollback() error {
+	if tx.done {
+		return nil
+	}
+	defer tx.finish()
+
+	var errs []error
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		if err := tx.undo[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	errs = append(errs, tx.saveTouched()...)
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("rollback incomplete: %w", err)
+	}
+	return nil
+}
+
+func (tx *DocumentTx) emit(eventType EventType, spaceID string, payload map[string]string) {
+	tx.events = append(tx.events, pendingEvent{eventType: eventType, spaceID: spaceID, payload: payload})
+}
+
+func (tx *DocumentTx) saveTouched() []error {
+	var errs []error
+	for spaceID := range tx.touched {
+		if err := tx.dm.saveMetadata(spaceID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save metadata: %w", err))
+		}
+	}
+	return errs
+}
+
+func (tx *DocumentTx) finish() {
+	tx.done = true
+	tx.undo = nil
+	tx.deletes = nil
+	tx.events = nil
+	tx.dm.mu.Unlock()
+}
@@ -0,0 +1,82 @@
+package anysync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDocumentTxTest(t *testing.T) (*DocumentManager, string) {
+	t.Helper()
+
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+
+	sm, err := NewSpaceManager(t.TempDir(), keys, NewEventManager())
+	require.NoError(t, err)
+	t.Cleanup(func() { sm.Close() })
+
+	_, err = sm.CreateSpace(context.Background(), "test-space", "Test Space", nil)
+	require.NoError(t, err)
+
+	dm, err := NewDocumentManager(sm, keys, NewEventManager())
+	require.NoError(t, err)
+
+	return dm, sm.ListSpaces()[0].SpaceID
+}
+
+func TestDocumentTx_Commit(t *testing.T) {
+	dm, spaceID := setupDocumentTxTest(t)
+
+	existingID, err := dm.CreateDocument(context.Background(), spaceID, "Existing", []byte("existing"), nil)
+	require.NoError(t, err)
+
+	tx := dm.Begin()
+	createdID, err := tx.CreateDocument(context.Background(), spaceID, "New", []byte("new"), nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.DeleteDocument(context.Background(), spaceID, existingID))
+
+	// The delete is visible inside the transaction
+	_, _, err = tx.GetDocument(context.Background(), spaceID, existingID)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, tx.Commit())
+	assert.NoError(t, tx.Rollback(), "Rollback after Commit is a no-op")
+
+	docs, err := dm.ListDocuments(context.Background(), spaceID)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, createdID, docs[0].DocumentID)
+}
+
+func TestDocumentTx_Rollback(t *testing.T) {
+	dm, spaceID := setupDocumentTxTest(t)
+
+	docID, err := dm.CreateDocument(context.Background(), spaceID, "Doc", []byte("Version 1"), nil)
+	require.NoError(t, err)
+
+	tx := dm.Begin()
+	_, err = tx.CreateDocument(context.Background(), spaceID, "New", []byte("new"), nil)
+	require.NoError(t, err)
+	_, err = tx.UpdateDocument(context.Background(), spaceID, docID, []byte("Version 2"), map[string]string{"title": "Changed"}, 1)
+	require.NoError(t, err)
+	require.NoError(t, tx.DeleteDocument(context.Background(), spaceID, docID))
+	require.NoError(t, tx.Rollback())
+
+	docs, err := dm.ListDocuments(context.Background(), spaceID)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+
+	data, meta, err := dm.GetDocument(context.Background(), spaceID, docID)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("Version 1"), data)
+	assert.Equal(t, "Doc", meta.Title)
+	assert.Equal(t, int64(1), meta.Version)
+
+	// The manager is usable again after the transaction
+	_, err = dm.UpdateDocument(context.Background(), spaceID, docID, []byte("Version 2"), nil, 1)
+	assert.NoError(t, err)
+}
@@ -4,9 +4,11 @@ package anysync
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"time"
 
@@ -23,12 +25,15 @@ type DocumentMetadata struct {
 	Title      string            `json:"title"`
 	Tags       []string          `json:"tags"`
 	Metadata   map[string]string `json:"metadata"`
+	Version    int64             `json:"version"` // Incremented on every update, starts at 1
 	CreatedAt  int64             `json:"created_at"`
 	UpdatedAt  int64             `json:"updated_at"`
 }
 
 // DocumentManager manages documents within spaces using ObjectTree.
 // Each document is an ObjectTree with changes stored as a DAG.
+// Every operation takes a context that bounds space opening and tree access;
+// when it is cancelled or its deadline passes the operation aborts.
 type DocumentManager struct {
 	mu           sync.RWMutex
 	spaceManager *SpaceManager
@@ -62,21 +67,130 @@ func NewDocumentManager(spaceManager *SpaceManager, keys *accountdata.AccountKey
 	if err := dm.loadAllMetadata(); err != nil {
 		return nil, fmt.Errorf("failed to load metadata: %w", err)
 	}
+	spaceManager.setOnDelete(dm.forgetSpace)
 
 	return dm, nil
 }
 
+// forgetSpace drops the cached document metadata of a deleted space. The
+// space manager removes its file.
+func (dm *DocumentManager) forgetSpace(spaceID string) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	delete(dm.metadata, spaceID)
+}
+
+// emitFunc publishes a document event. The manager emits directly to the
+// EventManager; a DocumentTx buffers events until Commit.
+type emitFunc func(eventType EventType, spaceID string, payload map[string]string)
+
 // CreateDocument creates a new document in a space.
 // The document data is stored as the root change in an ObjectTree.
-func (dm *DocumentManager) CreateDocument(spaceID, title string, data []byte, metadata map[string]string) (string, error) {
+func (dm *DocumentManager) CreateDocument(ctx context.Context, spaceID, title string, data []byte, metadata map[string]string) (string, error) {
+	spaceID = dm.spaceManager.ResolveSpaceID(spaceID)
+
 	dm.mu.Lock()
 	defer dm.mu.Unlock()
 
+	return dm.createDocument(ctx, spaceID, title, data, metadata, dm.eventManager.EmitEvent)
+}
+
+// GetDocument retrieves a document by ID from a space.
+func (dm *DocumentManager) GetDocument(ctx context.Context, spaceID, documentID string) ([]byte, *DocumentMetadata, error) {
+	spaceID = dm.spaceManager.ResolveSpaceID(spaceID)
+
+	dm.mu.RLock()
+	defer dm.mu.RUnlock()
+
+	return dm.getDocument(ctx, spaceID, documentID)
+}
+
+// UpdateDocument updates an existing document by adding a new change to its ObjectTree.
+// If expectedVersion is non-zero and does not match the current version, the
+// update is rejected with ErrVersionConflict. Returns the new version.
+func (dm *DocumentManager) UpdateDocument(ctx context.Context, spaceID, documentID string, data []byte, metadata map[string]string, expectedVersion int64) (int64, error) {
+	spaceID = dm.spaceManager.ResolveSpaceID(spaceID)
+
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	return dm.updateDocument(ctx, spaceID, documentID, data, metadata, expectedVersion, dm.eventManager.EmitEvent)
+}
+
+// DeleteDocument marks a document as deleted.
+func (dm *DocumentManager) DeleteDocument(ctx context.Context, spaceID, documentID string) error {
+	spaceID = dm.spaceManager.ResolveSpaceID(spaceID)
+
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	// Verify document exists
+	if _, err := dm.getMetadata(spaceID, documentID); err != nil {
+		return err
+	}
+
+	if err := dm.deleteTree(ctx, spaceID, documentID); err != nil {
+		return err
+	}
+
+	// Remove metadata
+	if dm.metadata[spaceID] != nil {
+		delete(dm.metadata[spaceID], documentID)
+		if err := dm.saveMetadata(spaceID); err != nil {
+			return fmt.Errorf("failed to save metadata: %w", err)
+		}
+	}
+
+	// Emit document.deleted event
+	dm.eventManager.EmitEvent(EventDocumentDeleted, spaceID, map[string]string{
+		"document_id": documentID,
+	})
+
+	return nil
+}
+
+// ListDocuments returns all documents in a space.
+func (dm *DocumentManager) ListDocuments(ctx context.Context, spaceID string) ([]*DocumentMetadata, error) {
+	spaceID = dm.spaceManager.ResolveSpaceID(spaceID)
+
+	dm.mu.RLock()
+	defer dm.mu.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dm.listDocuments(spaceID), nil
+}
+
+// QueryDocuments returns documents matching the given query.
+// For now, this is a simple tag-based filter, but can be extended.
+func (dm *DocumentManager) QueryDocuments(ctx context.Context, spaceID string, tags []string) ([]*DocumentMetadata, error) {
+	spaceID = dm.spaceManager.ResolveSpaceID(spaceID)
+
+	dm.mu.RLock()
+	defer dm.mu.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dm.queryDocuments(spaceID, tags), nil
+}
+
+// Helper functions
+//
+// The lowercase variants below do the actual work and expect dm.mu to be held
+// by the caller, so they can be shared between the manager and DocumentTx.
+// They take space IDs; the exported methods resolve aliases first (see
+// SpaceManager.ResolveSpaceID).
+
+func (dm *DocumentManager) createDocument(ctx context.Context, spaceID, title string, data []byte, metadata map[string]string, emit emitFunc) (string, error) {
 	// Get the space object
-	space, err := dm.spaceManager.GetSpaceObject(spaceID)
+	space, release, err := dm.spaceManager.writableSpaceObject(ctx, spaceID)
 	if err != nil {
 		return "", fmt.Errorf("failed to get space: %w", err)
 	}
+	defer release()
 
 	// Get TreeBuilder from space
 	treeBuilder := space.TreeBuilder()
@@ -85,7 +199,6 @@ func (dm *DocumentManager) CreateDocument(spaceID, title string, data []byte, me
 	}
 
 	// Create ObjectTree payload
-	ctx := context.Background()
 	createPayload := objecttree.ObjectTreeCreatePayload{
 		PrivKey:       dm.keys.SignKey,
 		ChangeType:    "document",
@@ -117,6 +230,7 @@ func (dm *DocumentManager) CreateDocument(spaceID, title string, data []byte, me
 		Title:      title,
 		Tags:       []string{},
 		Metadata:   metadata,
+		Version:    1,
 		CreatedAt:  now,
 		UpdatedAt:  now,
 	}
@@ -131,7 +245,7 @@ func (dm *DocumentManager) CreateDocument(spaceID, title string, data []byte, me
 	}
 
 	// Emit document.created event
-	dm.eventManager.EmitEvent(EventDocumentCreated, spaceID, map[string]string{
+	emit(EventDocumentCreated, spaceID, map[string]string{
 		"document_id": documentID,
 		"collection":  "", // TODO: Add collection support
 	})
@@ -139,11 +253,7 @@ func (dm *DocumentManager) CreateDocument(spaceID, title string, data []byte, me
 	return documentID, nil
 }
 
-// GetDocument retrieves a document by ID from a space.
-func (dm *DocumentManager) GetDocument(spaceID, documentID string) ([]byte, *DocumentMetadata, error) {
-	dm.mu.RLock()
-	defer dm.mu.RUnlock()
-
+func (dm *DocumentManager) getDocument(ctx context.Context, spaceID, documentID string) ([]byte, *DocumentMetadata, error) {
 	// Get metadata
 	docMeta, err := dm.getMetadata(spaceID, documentID)
 	if err != nil {
@@ -151,10 +261,11 @@ func (dm *DocumentManager) GetDocument(spaceID, documentID string) ([]byte, *Doc
 	}
 
 	// Get the space object
-	space, err := dm.spaceManager.GetSpaceObject(spaceID)
+	space, release, err := dm.spaceManager.acquireSpaceObject(ctx, spaceID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get space: %w", err)
 	}
+	defer release()
 
 	// Get TreeBuilder from space
 	treeBuilder := space.TreeBuilder()
@@ -163,7 +274,6 @@ func (dm *DocumentManager) GetDocument(spaceID, documentID string) ([]byte, *Doc
 	}
 
 	// Build the ObjectTree
-	ctx := context.Background()
 	tree, err := treeBuilder.BuildTree(ctx, documentID, objecttreebuilder.BuildTreeOpts{})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to build tree: %w", err)
@@ -203,21 +313,70 @@ func (dm *DocumentManager) GetDocument(spaceID, documentID string) ([]byte, *Doc
 	return extracted, docMeta, nil
 }
 
-// UpdateDocument updates an existing document by adding a new change to its ObjectTree.
-func (dm *DocumentManager) UpdateDocument(spaceID, documentID string, data []byte, metadata map[string]string) error {
-	dm.mu.Lock()
-	defer dm.mu.Unlock()
-
+func (dm *DocumentManager) updateDocument(ctx context.Context, spaceID, documentID string, data []byte, metadata map[string]string, expectedVersion int64, emit emitFunc) (int64, error) {
 	// Verify document exists
-	if _, err := dm.getMetadata(spaceID, documentID); err != nil {
-		return err
+	docMeta, err := dm.getMetadata(spaceID, documentID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Optimistic concurrency check
+	if expectedVersion != 0 && expectedVersion != docMeta.Version {
+		return 0, &Error{
+			Kind:    ErrVersionConflict,
+			Message: fmt.Sprintf("version conflict: document %s is at version %d, expected %d", documentID, docMeta.Version, expectedVersion),
+			Details: map[string]string{
+				"space_id":         spaceID,
+				"document_id":      documentID,
+				"current_version":  strconv.FormatInt(docMeta.Version, 10),
+				"expected_version": strconv.FormatInt(expectedVersion, 10),
+			},
+		}
+	}
+
+	if err := dm.addContent(ctx, spaceID, documentID, data); err != nil {
+		return 0, err
+	}
+
+	// Update metadata
+	oldVersion := docMeta.Version
+	docMeta.Version++
+	docMeta.UpdatedAt = time.Now().Unix()
+
+	// Replace metadata entirely with provided metadata
+	// Frontend should send complete metadata map to preserve fields
+	if metadata != nil {
+		// Update title if provided
+		if title, ok := metadata["title"]; ok {
+			docMeta.Title = title
+		}
+
+		// Full replacement, application controls what's kept
+		docMeta.Metadata = metadata
+	}
+
+	if err := dm.saveMetadata(spaceID); err != nil {
+		return 0, fmt.Errorf("failed to save metadata: %w", err)
 	}
 
+	// Emit document.updated event
+	emit(EventDocumentUpdated, spaceID, map[string]string{
+		"document_id": documentID,
+		"old_version": strconv.FormatInt(oldVersion, 10),
+		"new_version": strconv.FormatInt(docMeta.Version, 10),
+	})
+
+	return docMeta.Version, nil
+}
+
+// addContent appends a new change with the given data to a document's ObjectTree.
+func (dm *DocumentManager) addContent(ctx context.Context, spaceID, documentID string, data []byte) error {
 	// Get the space object
-	space, err := dm.spaceManager.GetSpaceObject(spaceID)
+	space, release, err := dm.spaceManager.writableSpaceObject(ctx, spaceID)
 	if err != nil {
 		return fmt.Errorf("failed to get space: %w", err)
 	}
+	defer release()
 
 	// Get TreeBuilder from space
 	treeBuilder := space.TreeBuilder()
@@ -226,7 +385,6 @@ func (dm *DocumentManager) UpdateDocument(spaceID, documentID string, data []byt
 	}
 
 	// Build the ObjectTree
-	ctx := context.Background()
 	tree, err := treeBuilder.BuildTree(ctx, documentID, objecttreebuilder.BuildTreeOpts{})
 	if err != nil {
 		return fmt.Errorf("failed to build tree: %w", err)
@@ -249,83 +407,44 @@ func (dm *DocumentManager) UpdateDocument(spaceID, documentID string, data []byt
 		return fmt.Errorf("failed to add content: %w", err)
 	}
 
-	// Update metadata
-	now := time.Now().Unix()
-	if dm.metadata[spaceID] != nil && dm.metadata[spaceID][documentID] != nil {
-		docMeta := dm.metadata[spaceID][documentID]
-		docMeta.UpdatedAt = now
-
-		// Replace metadata entirely with provided metadata
-		// Frontend should send complete metadata map to preserve fields
-		if metadata != nil {
-			// Update title if provided
-			if title, ok := metadata["title"]; ok {
-				docMeta.Title = title
-			}
-
-			// Full replacement, application controls what's kept
-			docMeta.Metadata = metadata
-		}
-
-		if err := dm.saveMetadata(spaceID); err != nil {
-			return fmt.Errorf("failed to save metadata: %w", err)
-		}
-	}
-
-	// Emit document.updated event
-	dm.eventManager.EmitEvent(EventDocumentUpdated, spaceID, map[string]string{
-		"document_id": documentID,
-	})
-
 	return nil
 }
 
-// DeleteDocument marks a document as deleted.
-func (dm *DocumentManager) DeleteDocument(spaceID, documentID string) error {
-	dm.mu.Lock()
-	defer dm.mu.Unlock()
-
-	// Verify document exists
-	if _, err := dm.getMetadata(spaceID, documentID); err != nil {
-		return err
-	}
-
+// deleteTree deletes a document's ObjectTree from its space.
+func (dm *DocumentManager) deleteTree(ctx context.Context, spaceID, documentID string) error {
 	// Get the space object
-	space, err := dm.spaceManager.GetSpaceObject(spaceID)
+	space, release, err := dm.spaceManager.writableSpaceObject(ctx, spaceID)
 	if err != nil {
 		return fmt.Errorf("failed to get space: %w", err)
 	}
+	defer release()
 
 	// Delete the tree via space
-	ctx := context.Background()
 	if err := space.DeleteTree(ctx, documentID); err != nil {
 		return fmt.Errorf("failed to delete tree: %w", err)
 	}
 
-	// Remove metadata
-	if dm.metadata[spaceID] != nil {
-		delete(dm.metadata[spaceID], documentID)
-		if err := dm.saveMetadata(spaceID); err != nil {
-			return fmt.Errorf("failed to save metadata: %w", err)
-		}
-	}
-
-	// Emit document.deleted event
-	dm.eventManager.EmitEvent(EventDocumentDeleted, spaceID, map[string]string{
-		"document_id": documentID,
-	})
-
 	return nil
 }
 
-// ListDocuments returns all documents in a space.
-func (dm *DocumentManager) ListDocuments(spaceID string) ([]*DocumentMetadata, error) {
+// DocumentCounts returns the number of documents in each space that has any.
+func (dm *DocumentManager) DocumentCounts() map[string]int {
 	dm.mu.RLock()
 	defer dm.mu.RUnlock()
 
+	counts := make(map[string]int, len(dm.metadata))
+	for spaceID, spaceMeta := range dm.metadata {
+		if len(spaceMeta) > 0 {
+			counts[spaceID] = len(spaceMeta)
+		}
+	}
+	return counts
+}
+
+func (dm *DocumentManager) listDocuments(spaceID string) []*DocumentMetadata {
 	spaceMeta, exists := dm.metadata[spaceID]
 	if !exists {
-		return []*DocumentMetadata{}, nil
+		return []*DocumentMetadata{}
 	}
 
 	documents := make([]*DocumentMetadata, 0, len(spaceMeta))
@@ -335,23 +454,18 @@ func (dm *DocumentManager) ListDocuments(spaceID string) ([]*DocumentMetadata, e
 		documents = append(documents, &docCopy)
 	}
 
-	return documents, nil
+	return documents
 }
 
-// QueryDocuments returns documents matching the given query.
-// For now, this is a simple tag-based filter, but can be extended.
-func (dm *DocumentManager) QueryDocuments(spaceID string, tags []string) ([]*DocumentMetadata, error) {
-	dm.mu.RLock()
-	defer dm.mu.RUnlock()
-
+func (dm *DocumentManager) queryDocuments(spaceID string, tags []string) []*DocumentMetadata {
 	spaceMeta, exists := dm.metadata[spaceID]
 	if !exists {
-		return []*DocumentMetadata{}, nil
+		return []*DocumentMetadata{}
 	}
 
 	if len(tags) == 0 {
 		// No filter, return all
-		return dm.ListDocuments(spaceID)
+		return dm.listDocuments(spaceID)
 	}
 
 	// Filter by tags
@@ -363,20 +477,18 @@ func (dm *DocumentManager) QueryDocuments(spaceID string, tags []string) ([]*Doc
 		}
 	}
 
-	return matched, nil
+	return matched
 }
 
-// Helper functions
-
 func (dm *DocumentManager) getMetadata(spaceID, documentID string) (*DocumentMetadata, error) {
 	spaceMeta, exists := dm.metadata[spaceID]
 	if !exists {
-		return nil, fmt.Errorf("space not found: %s", spaceID)
+		return nil, errSpaceNotFound(spaceID)
 	}
 
 	docMeta, exists := spaceMeta[documentID]
 	if !exists {
-		return nil, fmt.Errorf("document not found: %s", documentID)
+		return nil, errDocumentNotFound(spaceID, documentID)
 	}
 
 	return docMeta, nil
@@ -416,6 +528,13 @@ func (dm *DocumentManager) loadMetadata(spaceID string) error {
 		return fmt.Errorf("failed to unmarshal metadata: %w", err)
 	}
 
+	// Metadata written before version tracking has no version
+	for _, docMeta := range spaceMeta {
+		if docMeta.Version == 0 {
+			docMeta.Version = 1
+		}
+	}
+
 	dm.metadata[spaceID] = spaceMeta
 	return nil
 }
@@ -506,14 +625,24 @@ func extractProtobufField(data []byte, fieldNumber int) ([]byte, error) {
 	return nil, fmt.Errorf("field %d not found", fieldNumber)
 }
 
-// Close closes the DocumentManager and releases resources.
+// Close flushes the metadata of every space to disk and releases resources.
+// It waits for an open DocumentTx to finish.
 func (dm *DocumentManager) Close() error {
 	dm.mu.Lock()
 	defer dm.mu.Unlock()
 
+	// Flush metadata of the spaces that still exist. forgetSpace drops a
+	// deleted space from the cache only after the space manager removed it,
+	// so a space being deleted may still have an entry; it is not listed
+	var errs []error
+	for _, space := range dm.spaceManager.ListSpaces() {
+		if err := dm.saveMetadata(space.SpaceID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush metadata of space %s: %w", space.SpaceID, err))
+		}
+	}
+
 	// Clear metadata cache
 	dm.metadata = make(map[string]map[string]*DocumentMetadata)
 
-	// No other cleanup needed as we don't hold any persistent resources
-	return nil
+	return errors.Join(errs...)
 }
@@ -1,6 +1,7 @@
 package anysync
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -58,7 +59,7 @@ func TestCreateDocument_Success(t *testing.T) {
 	defer sm.Close()
 
 	// Create a space first
-	err = sm.CreateSpace("test-space", "Test Space", nil)
+	_, err = sm.CreateSpace(context.Background(), "test-space", "Test Space", nil)
 	require.NoError(t, err)
 
 	spaces := sm.ListSpaces()
@@ -71,7 +72,7 @@ func TestCreateDocument_Success(t *testing.T) {
 
 	// Create a document
 	docData := []byte("Hello, World!")
-	docID, err := dm.CreateDocument(spaceID, "Test Document", docData, map[string]string{
+	docID, err := dm.CreateDocument(context.Background(), spaceID, "Test Document", docData, map[string]string{
 		"author": "test",
 	})
 	require.NoError(t, err)
@@ -92,7 +93,7 @@ func TestCreateDocument_InvalidSpace(t *testing.T) {
 
 	// Try to create document in non-existent space
 	docData := []byte("Hello, World!")
-	docID, err := dm.CreateDocument("invalid-space-id", "Test Document", docData, nil)
+	docID, err := dm.CreateDocument(context.Background(), "invalid-space-id", "Test Document", docData, nil)
 	assert.Error(t, err)
 	assert.Empty(t, docID)
 }
@@ -107,7 +108,7 @@ func TestGetDocument_Success(t *testing.T) {
 	defer sm.Close()
 
 	// Create a space
-	err = sm.CreateSpace("test-space", "Test Space", nil)
+	_, err = sm.CreateSpace(context.Background(), "test-space", "Test Space", nil)
 	require.NoError(t, err)
 
 	spaces := sm.ListSpaces()
@@ -118,13 +119,13 @@ func TestGetDocument_Success(t *testing.T) {
 
 	// Create a document
 	docData := []byte("Hello, World!")
-	docID, err := dm.CreateDocument(spaceID, "Test Document", docData, map[string]string{
+	docID, err := dm.CreateDocument(context.Background(), spaceID, "Test Document", docData, map[string]string{
 		"author": "test",
 	})
 	require.NoError(t, err)
 
 	// Retrieve the document
-	retrievedData, meta, err := dm.GetDocument(spaceID, docID)
+	retrievedData, meta, err := dm.GetDocument(context.Background(), spaceID, docID)
 	require.NoError(t, err)
 	assert.Equal(t, docData, retrievedData)
 	assert.Equal(t, "Test Document", meta.Title)
@@ -143,7 +144,7 @@ func TestGetDocument_NotFound(t *testing.T) {
 	defer sm.Close()
 
 	// Create a space
-	err = sm.CreateSpace("test-space", "Test Space", nil)
+	_, err = sm.CreateSpace(context.Background(), "test-space", "Test Space", nil)
 	require.NoError(t, err)
 
 	spaces := sm.ListSpaces()
@@ -153,7 +154,7 @@ func TestGetDocument_NotFound(t *testing.T) {
 	require.NoError(t, err)
 
 	// Try to get non-existent document
-	data, meta, err := dm.GetDocument(spaceID, "non-existent-doc-id")
+	data, meta, err := dm.GetDocument(context.Background(), spaceID, "non-existent-doc-id")
 	assert.Error(t, err)
 	assert.Nil(t, data)
 	assert.Nil(t, meta)
@@ -169,7 +170,7 @@ func TestUpdateDocument_Success(t *testing.T) {
 	defer sm.Close()
 
 	// Create a space
-	err = sm.CreateSpace("test-space", "Test Space", nil)
+	_, err = sm.CreateSpace(context.Background(), "test-space", "Test Space", nil)
 	require.NoError(t, err)
 
 	spaces := sm.ListSpaces()
@@ -180,7 +181,7 @@ func TestUpdateDocument_Success(t *testing.T) {
 
 	// Create a document
 	docData := []byte("Version 1")
-	docID, err := dm.CreateDocument(spaceID, "Test Document", docData, nil)
+	docID, err := dm.CreateDocument(context.Background(), spaceID, "Test Document", docData, nil)
 	require.NoError(t, err)
 
 	// Add a small delay to ensure timestamp difference
@@ -193,13 +194,15 @@ func TestUpdateDocument_Success(t *testing.T) {
 		"updated": "2023-01-01T00:00:00Z",
 		"custom":  "value",
 	}
-	err = dm.UpdateDocument(spaceID, docID, newData, updateMetadata)
+	version, err := dm.UpdateDocument(context.Background(), spaceID, docID, newData, updateMetadata, 0)
 	require.NoError(t, err)
+	assert.Equal(t, int64(2), version)
 
 	// Retrieve and verify the updated document
-	retrievedData, meta, err := dm.GetDocument(spaceID, docID)
+	retrievedData, meta, err := dm.GetDocument(context.Background(), spaceID, docID)
 	require.NoError(t, err)
 	assert.Equal(t, newData, retrievedData)
+	assert.Equal(t, int64(2), meta.Version)
 	assert.Greater(t, meta.UpdatedAt, meta.CreatedAt)
 
 	// Verify metadata was updated
@@ -219,7 +222,7 @@ func TestUpdateDocument_NotFound(t *testing.T) {
 	defer sm.Close()
 
 	// Create a space
-	err = sm.CreateSpace("test-space", "Test Space", nil)
+	_, err = sm.CreateSpace(context.Background(), "test-space", "Test Space", nil)
 	require.NoError(t, err)
 
 	spaces := sm.ListSpaces()
@@ -230,8 +233,42 @@ func TestUpdateDocument_NotFound(t *testing.T) {
 
 	// Try to update non-existent document
 	newData := []byte("Version 2")
-	err = dm.UpdateDocument(spaceID, "non-existent-doc-id", newData, nil)
+	_, err = dm.UpdateDocument(context.Background(), spaceID, "non-existent-doc-id", newData, nil, 0)
 	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestUpdateDocument_VersionConflict(t *testing.T) {
+	tempDir := t.TempDir()
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+
+	sm, err := NewSpaceManager(tempDir, keys, NewEventManager())
+	require.NoError(t, err)
+	defer sm.Close()
+
+	_, err = sm.CreateSpace(context.Background(), "test-space", "Test Space", nil)
+	require.NoError(t, err)
+	spaceID := sm.ListSpaces()[0].SpaceID
+
+	dm, err := NewDocumentManager(sm, keys, NewEventManager())
+	require.NoError(t, err)
+
+	docID, err := dm.CreateDocument(context.Background(), spaceID, "Doc", []byte("Version 1"), nil)
+	require.NoError(t, err)
+
+	version, err := dm.UpdateDocument(context.Background(), spaceID, docID, []byte("Version 2"), nil, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+
+	// Updating against a stale version is rejected and leaves the document untouched
+	_, err = dm.UpdateDocument(context.Background(), spaceID, docID, []byte("Stale"), nil, 1)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	data, meta, err := dm.GetDocument(context.Background(), spaceID, docID)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("Version 2"), data)
+	assert.Equal(t, int64(2), meta.Version)
 }
 
 func TestDeleteDocument_Success(t *testing.T) {
@@ -244,7 +281,7 @@ func TestDeleteDocument_Success(t *testing.T) {
 	defer sm.Close()
 
 	// Create a space
-	err = sm.CreateSpace("test-space", "Test Space", nil)
+	_, err = sm.CreateSpace(context.Background(), "test-space", "Test Space", nil)
 	require.NoError(t, err)
 
 	spaces := sm.ListSpaces()
@@ -255,15 +292,15 @@ func TestDeleteDocument_Success(t *testing.T) {
 
 	// Create a document
 	docData := []byte("Hello, World!")
-	docID, err := dm.CreateDocument(spaceID, "Test Document", docData, nil)
+	docID, err := dm.CreateDocument(context.Background(), spaceID, "Test Document", docData, nil)
 	require.NoError(t, err)
 
 	// Delete the document
-	err = dm.DeleteDocument(spaceID, docID)
+	err = dm.DeleteDocument(context.Background(), spaceID, docID)
 	require.NoError(t, err)
 
 	// Verify document is gone
-	data, meta, err := dm.GetDocument(spaceID, docID)
+	data, meta, err := dm.GetDocument(context.Background(), spaceID, docID)
 	assert.Error(t, err)
 	assert.Nil(t, data)
 	assert.Nil(t, meta)
@@ -279,7 +316,7 @@ func TestDeleteDocument_NotFound(t *testing.T) {
 	defer sm.Close()
 
 	// Create a space
-	err = sm.CreateSpace("test-space", "Test Space", nil)
+	_, err = sm.CreateSpace(context.Background(), "test-space", "Test Space", nil)
 	require.NoError(t, err)
 
 	spaces := sm.ListSpaces()
@@ -289,7 +326,7 @@ func TestDeleteDocument_NotFound(t *testing.T) {
 	require.NoError(t, err)
 
 	// Try to delete non-existent document
-	err = dm.DeleteDocument(spaceID, "non-existent-doc-id")
+	err = dm.DeleteDocument(context.Background(), spaceID, "non-existent-doc-id")
 	assert.Error(t, err)
 }
 
@@ -303,7 +340,7 @@ func TestListDocuments_Empty(t *testing.T) {
 	defer sm.Close()
 
 	// Create a space
-	err = sm.CreateSpace("test-space", "Test Space", nil)
+	_, err = sm.CreateSpace(context.Background(), "test-space", "Test Space", nil)
 	require.NoError(t, err)
 
 	spaces := sm.ListSpaces()
@@ -313,7 +350,7 @@ func TestListDocuments_Empty(t *testing.T) {
 	require.NoError(t, err)
 
 	// List documents in empty space
-	docs, err := dm.ListDocuments(spaceID)
+	docs, err := dm.ListDocuments(context.Background(), spaceID)
 	require.NoError(t, err)
 	assert.Len(t, docs, 0)
 }
@@ -328,7 +365,7 @@ func TestListDocuments_Multiple(t *testing.T) {
 	defer sm.Close()
 
 	// Create a space
-	err = sm.CreateSpace("test-space", "Test Space", nil)
+	_, err = sm.CreateSpace(context.Background(), "test-space", "Test Space", nil)
 	require.NoError(t, err)
 
 	spaces := sm.ListSpaces()
@@ -341,13 +378,13 @@ func TestListDocuments_Multiple(t *testing.T) {
 	docIDs := make([]string, 3)
 	for i := 0; i < 3; i++ {
 		docData := []byte("Document " + string(rune('A'+i)))
-		docID, err := dm.CreateDocument(spaceID, "Doc "+string(rune('A'+i)), docData, nil)
+		docID, err := dm.CreateDocument(context.Background(), spaceID, "Doc "+string(rune('A'+i)), docData, nil)
 		require.NoError(t, err)
 		docIDs[i] = docID
 	}
 
 	// List all documents
-	docs, err := dm.ListDocuments(spaceID)
+	docs, err := dm.ListDocuments(context.Background(), spaceID)
 	require.NoError(t, err)
 	assert.Len(t, docs, 3)
 
@@ -371,7 +408,7 @@ func TestQueryDocuments_NoFilter(t *testing.T) {
 	defer sm.Close()
 
 	// Create a space
-	err = sm.CreateSpace("test-space", "Test Space", nil)
+	_, err = sm.CreateSpace(context.Background(), "test-space", "Test Space", nil)
 	require.NoError(t, err)
 
 	spaces := sm.ListSpaces()
@@ -381,13 +418,13 @@ func TestQueryDocuments_NoFilter(t *testing.T) {
 	require.NoError(t, err)
 
 	// Create documents
-	_, err = dm.CreateDocument(spaceID, "Doc A", []byte("A"), nil)
+	_, err = dm.CreateDocument(context.Background(), spaceID, "Doc A", []byte("A"), nil)
 	require.NoError(t, err)
-	_, err = dm.CreateDocument(spaceID, "Doc B", []byte("B"), nil)
+	_, err = dm.CreateDocument(context.Background(), spaceID, "Doc B", []byte("B"), nil)
 	require.NoError(t, err)
 
 	// Query with no filter
-	docs, err := dm.QueryDocuments(spaceID, nil)
+	docs, err := dm.QueryDocuments(context.Background(), spaceID, nil)
 	require.NoError(t, err)
 	assert.Len(t, docs, 2)
 }
@@ -402,9 +439,9 @@ func TestMultipleSpaces(t *testing.T) {
 	defer sm.Close()
 
 	// Create two spaces
-	err = sm.CreateSpace("space-1", "Space 1", nil)
+	_, err = sm.CreateSpace(context.Background(), "space-1", "Space 1", nil)
 	require.NoError(t, err)
-	err = sm.CreateSpace("space-2", "Space 2", nil)
+	_, err = sm.CreateSpace(context.Background(), "space-2", "Space 2", nil)
 	require.NoError(t, err)
 
 	spaces := sm.ListSpaces()
@@ -414,18 +451,18 @@ func TestMultipleSpaces(t *testing.T) {
 	require.NoError(t, err)
 
 	// Create documents in different spaces
-	_, err = dm.CreateDocument(spaces[0].SpaceID, "Doc in Space 1", []byte("Data 1"), nil)
+	_, err = dm.CreateDocument(context.Background(), spaces[0].SpaceID, "Doc in Space 1", []byte("Data 1"), nil)
 	require.NoError(t, err)
-	_, err = dm.CreateDocument(spaces[1].SpaceID, "Doc in Space 2", []byte("Data 2"), nil)
+	_, err = dm.CreateDocument(context.Background(), spaces[1].SpaceID, "Doc in Space 2", []byte("Data 2"), nil)
 	require.NoError(t, err)
 
 	// Verify documents are isolated by space
-	docs1, err := dm.ListDocuments(spaces[0].SpaceID)
+	docs1, err := dm.ListDocuments(context.Background(), spaces[0].SpaceID)
 	require.NoError(t, err)
 	assert.Len(t, docs1, 1)
 	assert.Equal(t, "Doc in Space 1", docs1[0].Title)
 
-	docs2, err := dm.ListDocuments(spaces[1].SpaceID)
+	docs2, err := dm.ListDocuments(context.Background(), spaces[1].SpaceID)
 	require.NoError(t, err)
 	assert.Len(t, docs2, 1)
 	assert.Equal(t, "Doc in Space 2", docs2[0].Title)
@@ -0,0 +1,72 @@
+// Package anysync provides Any-Sync integration components.
+package anysync
+
+import "errors"
+
+// Sentinel errors returned by the managers. Callers should match them with
+// errors.Is; the concrete error usually carries more context (see Error).
+var (
+	// ErrNotFound indicates that a space or document does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists indicates that an object with the same identity already exists.
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrInvalidArgument indicates that the caller supplied an invalid value.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrVersionConflict indicates that an optimistic concurrency check failed.
+	ErrVersionConflict = errors.New("version conflict")
+	// ErrLocked indicates that the account key is passphrase-protected and the
+	// passphrase is missing or wrong, or that the session is locked.
+	ErrLocked = errors.New("account is locked")
+	// ErrPermissionDenied indicates that the account or device may not
+	// perform the operation, e.g. a revoked device.
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrUnavailable indicates that a peer cannot be reached, or that no
+	// transport is configured to reach it.
+	ErrUnavailable = errors.New("unavailable")
+)
+
+// Error is a manager error that belongs to one of the sentinel kinds above
+// and carries structured details (e.g. "space_id", "document_id").
+type Error struct {
+	Kind    error             // One of the sentinel errors
+	Message string            // Human-readable description
+	Details map[string]string // Additional context for clients
+}
+
+// Error returns the human-readable description.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is the sentinel kind of this error.
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// errSpaceNotFound returns an ErrNotFound error for a missing space.
+func errSpaceNotFound(spaceID string) error {
+	return &Error{
+		Kind:    ErrNotFound,
+		Message: "space not found: " + spaceID,
+		Details: map[string]string{"space_id": spaceID},
+	}
+}
+
+// errSpaceLeft returns the ErrPermissionDenied error of a change to a space
+// the account left.
+func errSpaceLeft(spaceID string) error {
+	return &Error{
+		Kind:    ErrPermissionDenied,
+		Message: "the account left space " + spaceID + ", its copy is read-only",
+		Details: map[string]string{"space_id": spaceID, "reason": "space_left"},
+	}
+}
+
+// errDocumentNotFound returns an ErrNotFound error for a missing document.
+func errDocumentNotFound(spaceID, documentID string) error {
+	return &Error{
+		Kind:    ErrNotFound,
+		Message: "document not found: " + documentID,
+		Details: map[string]string{"space_id": spaceID, "document_id": documentID},
+	}
+}
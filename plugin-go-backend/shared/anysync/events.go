@@ -21,13 +21,28 @@ const (
 
 	// Space events
 	EventSpaceCreated EventType = "space.created"
+	EventSpaceUpdated EventType = "space.updated"
 	EventSpaceDeleted EventType = "space.deleted"
+	EventSpaceLeft    EventType = "space.left"
+
+	// Space member events, on the device that changes the ACL
+	EventSpaceMemberJoined            EventType = "space.member_joined"
+	EventSpaceMemberJoinRequested     EventType = "space.member_join_requested"
+	EventSpaceMemberRemoved           EventType = "space.member_removed"
+	EventSpaceMemberPermissionChanged EventType = "space.member_permission_changed"
 
 	// Sync events (for Phase 6)
 	EventSyncStarted   EventType = "sync.started"
 	EventSyncCompleted EventType = "sync.completed"
 	EventSyncError     EventType = "sync.error"
 	EventSyncConflict  EventType = "sync.conflict"
+
+	// Account events
+	EventAccountLocked   EventType = "account.locked"
+	EventAccountUnlocked EventType = "account.unlocked"
+
+	// Backend events
+	EventBackendPanic EventType = "backend.panic"
 )
 
 // Event represents a single event in the system.
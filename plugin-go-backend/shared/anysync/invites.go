@@ -0,0 +1,616 @@
+// Package anysync provides Any-Sync integration components.
+package anysync
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/acl/recordverifier"
+	"github.com/anyproto/any-sync/consensus/consensusproto"
+	"github.com/anyproto/any-sync/util/cidutil"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// SpacePermission is the access of an account to a space.
+type SpacePermission string
+
+const (
+	PermissionReader SpacePermission = "reader" // Reads documents
+	PermissionWriter SpacePermission = "writer" // Reads and writes documents
+	PermissionAdmin  SpacePermission = "admin"  // Also manages members and invites
+	PermissionOwner  SpacePermission = "owner"  // The creator of the space
+)
+
+// aclPermissions returns the Any-Sync ACL permissions of p.
+func (p SpacePermission) aclPermissions() (list.AclPermissions, error) {
+	switch p {
+	case PermissionReader:
+		return list.AclPermissions(aclrecordproto.AclUserPermissions_Reader), nil
+	case PermissionWriter:
+		return list.AclPermissions(aclrecordproto.AclUserPermissions_Writer), nil
+	case PermissionAdmin:
+		return list.AclPermissions(aclrecordproto.AclUserPermissions_Admin), nil
+	case PermissionOwner:
+		return list.AclPermissions(aclrecordproto.AclUserPermissions_Owner), nil
+	default:
+		return 0, fmt.Errorf("%w: unknown permission %q", ErrInvalidArgument, p)
+	}
+}
+
+// Invite token format.
+const (
+	inviteTokenFormat  = "syncspace-invite"
+	inviteTokenVersion = 1
+)
+
+// inviteToken is the content of an invite token. It holds everything a peer
+// needs to join the space: where to find it, and the private key of an ACL
+// invite that anyone holding it may join with, or request to join with.
+type inviteToken struct {
+	Format     string          `json:"format"`
+	Version    int             `json:"version"`
+	SpaceID    string          `json:"space_id"`
+	InviteKey  []byte          `json:"invite_key"`           // Marshalled private key of the ACL invite
+	Permission SpacePermission `json:"permission,omitempty"` // Empty for approval invites
+	Approval   bool            `json:"approval,omitempty"`   // Joins wait for ApproveJoinRequest
+	ExpiresAt  int64           `json:"expires_at,omitempty"` // Unix time, 0 = never
+	Peer       string          `json:"peer"`                 // Peer ID of the inviting device
+}
+
+// encode returns the token as a URL-safe string.
+func (t *inviteToken) encode() (string, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode invite token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// inviteProofMaxAge is how far the time of an InviteProof may be from the
+// clock of the peer that checks it.
+const inviteProofMaxAge = 5 * time.Minute
+
+// InviteProof shows that a peer holds the key of an invite to a space, for
+// the inviting peer to serve the space to it.
+type InviteProof struct {
+	InviteKey []byte // Marshalled public key of the invite
+	Timestamp int64  // Unix time the proof was made
+	Signature []byte // By the invite key, over the space ID and Timestamp
+}
+
+// newInviteProof signs a proof that the holder of inviteKey may fetch a
+// space.
+func newInviteProof(spaceID string, inviteKey crypto.PrivKey) (*InviteProof, error) {
+	publicKey, err := inviteKey.GetPublic().Marshall()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal invite key: %w", err)
+	}
+	timestamp := time.Now().Unix()
+	signature, err := inviteKey.Sign(inviteProofPayload(spaceID, timestamp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign invite proof: %w", err)
+	}
+	return &InviteProof{InviteKey: publicKey, Timestamp: timestamp, Signature: signature}, nil
+}
+
+// inviteProofPayload returns the bytes an InviteProof signs.
+func inviteProofPayload(spaceID string, timestamp int64) []byte {
+	return fmt.Appendf(nil, "syncspace-fetch:%s:%d", spaceID, timestamp)
+}
+
+// parseInviteToken decodes and checks an invite token, returning its private
+// invite key along with it.
+func parseInviteToken(token string) (*inviteToken, crypto.PrivKey, error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: invalid invite token: %s", ErrInvalidArgument, reason)
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, nil, invalid("not base64url")
+	}
+	var t inviteToken
+	if err := json.Unmarshal(data, &t); err != nil || t.Format != inviteTokenFormat {
+		return nil, nil, invalid("not an invite token")
+	}
+	if t.Version > inviteTokenVersion {
+		return nil, nil, fmt.Errorf("%w: unsupported invite token version %d", ErrInvalidArgument, t.Version)
+	}
+	if t.SpaceID == "" || t.Peer == "" {
+		return nil, nil, invalid("missing space or peer")
+	}
+	if _, err := t.Permission.aclPermissions(); err != nil && !t.Approval {
+		return nil, nil, invalid(err.Error())
+	}
+	inviteKey, err := crypto.UnmarshalEd25519PrivateKeyProto(t.InviteKey)
+	if err != nil {
+		return nil, nil, invalid("bad invite key")
+	}
+	return &t, inviteKey, nil
+}
+
+// Invite is an invite created by CreateInvite or CreateApprovalInvite.
+type Invite struct {
+	Token     string // Self-contained token to hand to the invitee
+	InviteID  string // ID of the invite record in the ACL
+	ExpiresAt int64  // Unix time, 0 = never
+}
+
+// CreateInvite adds an invite to the ACL of a space, by ID or alias, that
+// anyone holding its token may join with the given permission, reader or
+// writer. With a positive ttl, this device rejects joins once it has passed.
+// The account needs the permission to manage the members of the space.
+func (sm *SpaceManager) CreateInvite(ctx context.Context, spaceID string, permission SpacePermission, ttl time.Duration) (*Invite, error) {
+	if permission != PermissionReader && permission != PermissionWriter {
+		return nil, fmt.Errorf("%w: invites grant reader or writer permission, not %q", ErrInvalidArgument, permission)
+	}
+	aclPermission, err := permission.aclPermissions()
+	if err != nil {
+		return nil, err
+	}
+	return sm.createInvite(ctx, spaceID, permission, ttl, func(builder list.AclRecordBuilder) (list.InviteResult, error) {
+		return builder.BuildInviteAnyone(aclPermission)
+	})
+}
+
+// CreateApprovalInvite adds an invite to the ACL of a space, by ID or alias,
+// that anyone holding its token may request to join with. The requests wait
+// for ApproveJoinRequest, which sets the permission of the new member. ttl
+// is as with CreateInvite.
+func (sm *SpaceManager) CreateApprovalInvite(ctx context.Context, spaceID string, ttl time.Duration) (*Invite, error) {
+	return sm.createInvite(ctx, spaceID, "", ttl, func(builder list.AclRecordBuilder) (list.InviteResult, error) {
+		return builder.BuildInvite()
+	})
+}
+
+// createInvite adds the invite record build returns to the ACL of a space,
+// and returns its token. An empty permission makes an approval invite.
+func (sm *SpaceManager) createInvite(ctx context.Context, spaceID string, permission SpacePermission, ttl time.Duration, build func(list.AclRecordBuilder) (list.InviteResult, error)) (*Invite, error) {
+	if ttl < 0 {
+		return nil, fmt.Errorf("%w: the invite expiry must not be negative", ErrInvalidArgument)
+	}
+
+	space, release, err := sm.writableSpaceObject(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	spaceID = space.Id()
+
+	acl := space.Acl()
+	acl.Lock()
+	if !acl.AclState().Permissions(sm.keys.SignKey.GetPublic()).CanManageAccounts() {
+		acl.Unlock()
+		return nil, errNotManager(spaceID)
+	}
+	var inviteID string
+	result, err := build(acl.RecordBuilder())
+	if err == nil {
+		err = sm.addAclRecord(acl, result.InviteRec)
+		inviteID = acl.Head().Id
+	}
+	acl.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to add invite: %w", err)
+	}
+
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl).Unix()
+		if err := sm.setInviteExpiry(spaceID, inviteID, expiresAt); err != nil {
+			return nil, err
+		}
+	}
+
+	inviteKey, err := result.InviteKey.Marshall()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal invite key: %w", err)
+	}
+	token, err := (&inviteToken{
+		Format:     inviteTokenFormat,
+		Version:    inviteTokenVersion,
+		SpaceID:    spaceID,
+		InviteKey:  inviteKey,
+		Permission: permission,
+		Approval:   permission == "",
+		ExpiresAt:  expiresAt,
+		Peer:       sm.keys.PeerId,
+	}).encode()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Invite{Token: token, InviteID: inviteID, ExpiresAt: expiresAt}, nil
+}
+
+// setInviteExpiry records when an invite of a space expires.
+func (sm *SpaceManager) setInviteExpiry(spaceID, inviteID string, expiresAt int64) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	old, exists := sm.spaces[spaceID]
+	if !exists {
+		return errSpaceNotFound(spaceID)
+	}
+	// Copies returned by GetSpace and ListSpaces share the map
+	updated := *old
+	updated.InviteExpiry = make(map[string]int64, len(old.InviteExpiry)+1)
+	for id, expiry := range old.InviteExpiry {
+		updated.InviteExpiry[id] = expiry
+	}
+	updated.InviteExpiry[inviteID] = expiresAt
+
+	sm.spaces[spaceID] = &updated
+	if err := sm.saveMetadata(); err != nil {
+		sm.spaces[spaceID] = old
+		return fmt.Errorf("failed to save metadata: %w", err)
+	}
+	return nil
+}
+
+// JoinResult is the outcome of JoinSpace.
+type JoinResult struct {
+	SpaceID string // Any-Sync space ID
+	Pending bool   // The join request waits for approval, the space is not here yet
+}
+
+// JoinSpace joins a space with an invite token from CreateInvite, through
+// the transport: it fetches the space from the inviting peer, sends it a
+// record that joins the account to the ACL, and opens the space locally
+// with the ACL that includes it. A non-empty alias is stored with the space,
+// like with CreateSpace; joining with an alias that already names the space
+// returns it. Joining a space that is already here returns it too.
+//
+// With a token from CreateApprovalInvite, the record is a join request and
+// the result is pending. Calling JoinSpace again with the token once the
+// request is approved opens the space; until then the result stays pending.
+// An account that is a member already, e.g. after deleting its local copy,
+// opens the space without a new record.
+func (sm *SpaceManager) JoinSpace(ctx context.Context, alias, token string) (*JoinResult, error) {
+	t, inviteKey, err := parseInviteToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	sm.mu.RLock()
+	transport := sm.transport
+	spaceID, aliased := sm.lookup(alias)
+	local, joined := sm.spaces[t.SpaceID]
+	left := joined && local.LeftAt != 0
+	sm.mu.RUnlock()
+
+	if left {
+		return nil, fmt.Errorf("%w: the read-only copy of space %s is here, delete it to join again", ErrAlreadyExists, t.SpaceID)
+	}
+	if alias != "" && aliased {
+		if spaceID != t.SpaceID {
+			return nil, fmt.Errorf("%w: alias %q names another space", ErrAlreadyExists, alias)
+		}
+		return &JoinResult{SpaceID: spaceID}, nil
+	}
+	if joined {
+		return &JoinResult{SpaceID: t.SpaceID}, nil
+	}
+	if transport == nil {
+		return nil, fmt.Errorf("%w: no transport to reach peer %s", ErrUnavailable, t.Peer)
+	}
+
+	// The transport is called without holding sm.mu, so the inviting peer
+	// may be this process, or even this manager
+	proof, err := newInviteProof(t.SpaceID, inviteKey)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := transport.FetchSpace(ctx, t.Peer, t.SpaceID, proof)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch space %s: %w", t.SpaceID, err)
+	}
+	acl, err := buildAcl(sm.keys, keys)
+	if err != nil {
+		return nil, err
+	}
+	identity := sm.keys.SignKey.GetPublic()
+	if acl.AclState().Permissions(identity).NoPermissions() {
+		if _, err := acl.AclState().JoinRecord(identity, false); err == nil {
+			return &JoinResult{SpaceID: t.SpaceID, Pending: true}, nil
+		}
+		if t.ExpiresAt != 0 && time.Now().Unix() >= t.ExpiresAt {
+			return nil, errInviteExpired(t.SpaceID)
+		}
+		if keys, err = sm.sendJoin(ctx, transport, acl, t, inviteKey); err != nil {
+			return nil, err
+		}
+		if t.Approval {
+			return &JoinResult{SpaceID: t.SpaceID, Pending: true}, nil
+		}
+	}
+
+	now := time.Now().Unix()
+	keys.Metadata = &SpaceMetadata{
+		SpaceID:   t.SpaceID,
+		Alias:     alias,
+		Name:      keys.Metadata.Name,
+		Metadata:  keys.Metadata.Metadata,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Peer:      t.Peer,
+	}
+	if err := sm.importJoined(ctx, alias, keys); err != nil {
+		return nil, err
+	}
+
+	// Opening the space checks that the ACL gives this account the read key
+	if _, err := sm.GetSpaceObject(ctx, t.SpaceID); err != nil {
+		_ = sm.DeleteSpace(context.Background(), t.SpaceID)
+		return nil, fmt.Errorf("failed to open joined space: %w", err)
+	}
+	return &JoinResult{SpaceID: t.SpaceID}, nil
+}
+
+// sendJoin builds the record that joins the account to acl with the invite
+// of t, or requests to, and sends it to the inviting peer. It returns the
+// key material of the space with the record.
+func (sm *SpaceManager) sendJoin(ctx context.Context, transport Transport, acl list.AclList, t *inviteToken, inviteKey crypto.PrivKey) (*SpaceKeys, error) {
+	var record *consensusproto.RawRecord
+	var err error
+	if t.Approval {
+		record, err = acl.RecordBuilder().BuildRequestJoin(list.RequestJoinPayload{InviteKey: inviteKey})
+	} else {
+		aclPermission, _ := t.Permission.aclPermissions()
+		record, err = acl.RecordBuilder().BuildInviteJoinWithoutApprove(list.InviteJoinPayload{
+			InviteKey:   inviteKey,
+			Permissions: aclPermission,
+		})
+	}
+	switch {
+	case errors.Is(err, list.ErrNoSuchInvite), errors.Is(err, list.ErrIncorrectInviteKey):
+		return nil, errInviteInvalid(t.SpaceID)
+	case errors.Is(err, list.ErrInsufficientPermissions):
+		return nil, fmt.Errorf("%w: the account is already a member of space %s", ErrAlreadyExists, t.SpaceID)
+	case err != nil:
+		return nil, fmt.Errorf("failed to build join record: %w", err)
+	}
+	payload, err := record.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal join record: %w", err)
+	}
+	keys, err := transport.Join(ctx, t.Peer, t.SpaceID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join space %s: %w", t.SpaceID, err)
+	}
+	if err := keys.validate(); err != nil || keys.Metadata.SpaceID != t.SpaceID {
+		return nil, fmt.Errorf("%w: peer %s returned invalid keys: %v", ErrInvalidArgument, t.Peer, err)
+	}
+	return keys, nil
+}
+
+// importJoined stores a joined space, unless its alias was taken meanwhile.
+func (sm *SpaceManager) importJoined(ctx context.Context, alias string, keys *SpaceKeys) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if _, taken := sm.lookup(alias); alias != "" && taken {
+		return fmt.Errorf("%w: alias %q names another space", ErrAlreadyExists, alias)
+	}
+	return sm.importSpace(ctx, keys)
+}
+
+// HandleFetchSpace serves the key material of a space to a peer that is
+// about to join it. The peer must prove that it holds the key of an invite
+// to the space that has been neither revoked nor expired.
+func (sm *SpaceManager) HandleFetchSpace(ctx context.Context, spaceID string, proof *InviteProof) (*SpaceKeys, error) {
+	if err := sm.checkInviteProof(ctx, spaceID, proof); err != nil {
+		return nil, err
+	}
+	return sm.exportJoinKeys(ctx, spaceID)
+}
+
+// checkInviteProof checks that proof is a recent signature of spaceID by the
+// key of an invite in the ACL of the space, and that the invite has not
+// expired.
+func (sm *SpaceManager) checkInviteProof(ctx context.Context, spaceID string, proof *InviteProof) error {
+	sm.mu.RLock()
+	metadata, exists := sm.spaces[spaceID]
+	sm.mu.RUnlock()
+	if !exists {
+		return errSpaceNotFound(spaceID)
+	}
+
+	if proof == nil {
+		return errInviteInvalid(spaceID)
+	}
+	if age := time.Since(time.Unix(proof.Timestamp, 0)); age > inviteProofMaxAge || age < -inviteProofMaxAge {
+		return errInviteInvalid(spaceID)
+	}
+	inviteKey, err := crypto.UnmarshalEd25519PublicKeyProto(proof.InviteKey)
+	if err != nil {
+		return errInviteInvalid(spaceID)
+	}
+	if ok, err := inviteKey.Verify(inviteProofPayload(spaceID, proof.Timestamp), proof.Signature); err != nil || !ok {
+		return errInviteInvalid(spaceID)
+	}
+
+	space, release, err := sm.acquireSpaceObject(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+	defer release()
+	acl := space.Acl()
+	acl.RLock()
+	var inviteID string
+	for _, invite := range acl.AclState().Invites() {
+		if invite.Key.Equals(inviteKey) {
+			inviteID = invite.Id
+			break
+		}
+	}
+	acl.RUnlock()
+	if inviteID == "" {
+		return errInviteInvalid(spaceID)
+	}
+	if expiresAt, ok := metadata.InviteExpiry[inviteID]; ok && time.Now().Unix() >= expiresAt {
+		return errInviteExpired(spaceID)
+	}
+	return nil
+}
+
+// HandleJoin accepts a record of a peer that joins a space with an invite of
+// this device, or requests to: it checks that the invite exists and has not
+// expired and that the record is valid, and adds it to the ACL, acting as
+// the consensus node. It returns the key material of the space with the
+// record.
+func (sm *SpaceManager) HandleJoin(ctx context.Context, spaceID string, record []byte) (*SpaceKeys, error) {
+	sm.mu.RLock()
+	metadata, exists := sm.spaces[spaceID]
+	sm.mu.RUnlock()
+	if !exists {
+		return nil, errSpaceNotFound(spaceID)
+	}
+
+	rawRecord := &consensusproto.RawRecord{}
+	if err := rawRecord.UnmarshalVT(record); err != nil {
+		return nil, fmt.Errorf("%w: invalid join record: %v", ErrInvalidArgument, err)
+	}
+
+	space, release, err := sm.writableSpaceObject(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	acl := space.Acl()
+	acl.Lock()
+	var event EventType
+	var member *Member
+	err = func() error {
+		aclRecord, err := acl.RecordBuilder().Unmarshall(rawRecord)
+		if err != nil {
+			return errInviteInvalid(spaceID)
+		}
+		data, ok := aclRecord.Model.(*aclrecordproto.AclData)
+		if !ok || len(data.AclContent) != 1 {
+			return fmt.Errorf("%w: the record does not join space %s", ErrInvalidArgument, spaceID)
+		}
+		var inviteID string
+		switch content := data.AclContent[0]; {
+		case content.GetInviteJoin() != nil:
+			inviteID = content.GetInviteJoin().InviteRecordId
+			event = EventSpaceMemberJoined
+		case content.GetRequestJoin() != nil:
+			inviteID = content.GetRequestJoin().InviteRecordId
+			event = EventSpaceMemberJoinRequested
+		default:
+			return fmt.Errorf("%w: the record does not join space %s", ErrInvalidArgument, spaceID)
+		}
+		if expiresAt, ok := metadata.InviteExpiry[inviteID]; ok && time.Now().Unix() >= expiresAt {
+			return errInviteExpired(spaceID)
+		}
+		// The space ACL only checks signatures, the full validation checks
+		// the invite and the permissions
+		if err := acl.ValidateRawRecord(rawRecord, nil); err != nil {
+			return errInviteInvalid(spaceID)
+		}
+		if err := sm.addAclRecord(acl, rawRecord); err != nil {
+			return err
+		}
+		member, err = memberOf(acl, aclRecord.Identity)
+		return err
+	}()
+	acl.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	sm.emitMemberEvent(event, spaceID, member, "")
+	return sm.exportJoinKeys(ctx, spaceID)
+}
+
+// exportJoinKeys returns the key material of a space for another account,
+// without the data that only concerns this device.
+func (sm *SpaceManager) exportJoinKeys(ctx context.Context, spaceID string) (*SpaceKeys, error) {
+	metadata, err := sm.GetSpace(spaceID)
+	if err != nil {
+		return nil, err
+	}
+	space, release, err := sm.acquireSpaceObject(ctx, metadata.SpaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return exportSpaceKeys(ctx, space.Storage(), &SpaceMetadata{
+		SpaceID:   metadata.SpaceID,
+		Name:      metadata.Name,
+		Metadata:  metadata.Metadata,
+		CreatedAt: metadata.CreatedAt,
+		UpdatedAt: metadata.UpdatedAt,
+	})
+}
+
+// addAclRecord accepts a record, signing it as the acceptor like a consensus
+// node does, and adds it to acl. The caller must hold the lock of acl.
+func (sm *SpaceManager) addAclRecord(acl list.AclList, record *consensusproto.RawRecord) error {
+	identity, err := sm.keys.PeerKey.GetPublic().Marshall()
+	if err != nil {
+		return err
+	}
+	signature, err := sm.keys.PeerKey.Sign(record.Payload)
+	if err != nil {
+		return err
+	}
+	record.AcceptorIdentity = identity
+	record.AcceptorSignature = signature
+	record.AcceptorTimestamp = time.Now().Unix()
+
+	payload, err := record.MarshalVT()
+	if err != nil {
+		return err
+	}
+	id, err := cidutil.NewCidFromBytes(payload)
+	if err != nil {
+		return err
+	}
+	return acl.AddRawRecord(&consensusproto.RawRecordWithId{Payload: payload, Id: id})
+}
+
+// buildAcl builds the ACL of a space in memory from its key material, as
+// seen by the account of keys.
+func buildAcl(accountKeys *accountdata.AccountKeys, keys *SpaceKeys) (list.AclList, error) {
+	records := make([]*consensusproto.RawRecordWithId, len(keys.AclRecords))
+	for i, record := range keys.AclRecords {
+		records[i] = &consensusproto.RawRecordWithId{Payload: record.Payload, Id: record.ID}
+	}
+	storage, err := list.NewInMemoryStorage(records[0].Id, records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ACL: %w", err)
+	}
+	acl, err := list.BuildAclListWithIdentity(accountKeys, storage, recordverifier.New())
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid ACL of space %s: %v", ErrInvalidArgument, keys.Metadata.SpaceID, err)
+	}
+	return acl, nil
+}
+
+// errInviteExpired returns the ErrPermissionDenied error of an expired invite.
+func errInviteExpired(spaceID string) error {
+	return &Error{
+		Kind:    ErrPermissionDenied,
+		Message: "the invite to space " + spaceID + " has expired",
+		Details: map[string]string{"space_id": spaceID, "reason": "invite_expired"},
+	}
+}
+
+// errInviteInvalid returns the ErrPermissionDenied error of an invite that
+// the space does not have, or a join that does not match it.
+func errInviteInvalid(spaceID string) error {
+	return &Error{
+		Kind:    ErrPermissionDenied,
+		Message: "the invite to space " + spaceID + " is not valid",
+		Details: map[string]string{"space_id": spaceID, "reason": "invite_invalid"},
+	}
+}
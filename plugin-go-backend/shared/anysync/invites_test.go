@@ -0,0 +1,177 @@
+package anysync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPeerSpaceManager creates a space manager of a new account, connected to transport.
+func newPeerSpaceManager(t *testing.T, transport Transport) *SpaceManager {
+	t.Helper()
+
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	sm, err := NewSpaceManager(t.TempDir(), keys, NewEventManager())
+	require.NoError(t, err)
+	t.Cleanup(func() { sm.Close() })
+	sm.SetTransport(transport)
+	return sm
+}
+
+// TestJoinSpace tests joining the space of another account with an invite over a memory transport.
+func TestJoinSpace(t *testing.T) {
+	ctx := context.Background()
+	transport := NewMemoryTransport()
+	owner := newPeerSpaceManager(t, transport)
+	guest := newPeerSpaceManager(t, transport)
+
+	spaceID, err := owner.CreateSpace(ctx, "team", "Team", map[string]string{"color": "blue"})
+	require.NoError(t, err)
+	invite, err := owner.CreateInvite(ctx, "team", PermissionWriter, 0)
+	require.NoError(t, err)
+	assert.NotEmpty(t, invite.InviteID)
+	assert.Zero(t, invite.ExpiresAt)
+
+	joined, err := guest.JoinSpace(ctx, "shared", invite.Token)
+	require.NoError(t, err)
+	assert.Equal(t, &JoinResult{SpaceID: spaceID}, joined)
+
+	// The guest has the space under its own alias, with the owner's name
+	space, err := guest.GetSpace("shared")
+	require.NoError(t, err)
+	assert.Equal(t, spaceID, space.SpaceID)
+	assert.Equal(t, "Team", space.Name)
+	assert.Equal(t, "blue", space.Metadata["color"])
+
+	// Both ACLs hold the guest as a writer
+	guestIdentity := guest.keys.SignKey.GetPublic()
+	for _, sm := range []*SpaceManager{owner, guest} {
+		object, err := sm.GetSpaceObject(ctx, spaceID)
+		require.NoError(t, err)
+		object.Acl().RLock()
+		assert.True(t, object.Acl().AclState().Permissions(guestIdentity).CanWrite())
+		object.Acl().RUnlock()
+	}
+
+	// Joining again returns the space
+	joined, err = guest.JoinSpace(ctx, "shared", invite.Token)
+	require.NoError(t, err)
+	assert.Equal(t, spaceID, joined.SpaceID)
+	_, err = guest.JoinSpace(ctx, "other", invite.Token)
+	assert.NoError(t, err)
+	assert.Len(t, guest.ListSpaces(), 1)
+}
+
+// TestJoinSpace_Errors tests the invites and tokens that do not let a peer join.
+func TestJoinSpace_Errors(t *testing.T) {
+	ctx := context.Background()
+	transport := NewMemoryTransport()
+	owner := newPeerSpaceManager(t, transport)
+	guest := newPeerSpaceManager(t, transport)
+
+	spaceID, err := owner.CreateSpace(ctx, "", "Team", nil)
+	require.NoError(t, err)
+
+	_, err = owner.CreateInvite(ctx, spaceID, PermissionAdmin, 0)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = owner.CreateInvite(ctx, "missing", PermissionReader, 0)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = guest.JoinSpace(ctx, "", "not a token")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	// Expired, as checked by the token and by the owner
+	invite, err := owner.CreateInvite(ctx, spaceID, PermissionReader, time.Second)
+	require.NoError(t, err)
+	assert.NotZero(t, invite.ExpiresAt)
+	token, inviteKey, err := parseInviteToken(invite.Token)
+	require.NoError(t, err)
+	proof, err := newInviteProof(spaceID, inviteKey)
+	require.NoError(t, err)
+	_, err = owner.HandleFetchSpace(ctx, spaceID, proof)
+	require.NoError(t, err)
+	time.Sleep(time.Until(time.Unix(invite.ExpiresAt, 0)) + 10*time.Millisecond)
+	_, err = guest.JoinSpace(ctx, "", invite.Token)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	token.ExpiresAt = 0
+	forged, err := token.encode()
+	require.NoError(t, err)
+	_, err = guest.JoinSpace(ctx, "", forged)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	var anysyncErr *Error
+	require.ErrorAs(t, err, &anysyncErr)
+	assert.Equal(t, "invite_expired", anysyncErr.Details["reason"])
+	_, err = owner.HandleFetchSpace(ctx, spaceID, proof)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	// An unreachable peer
+	invite, err = owner.CreateInvite(ctx, spaceID, PermissionReader, 0)
+	require.NoError(t, err)
+	owner.SetTransport(nil)
+	_, err = guest.JoinSpace(ctx, "", invite.Token)
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	// Without a transport
+	guest.SetTransport(nil)
+	_, err = guest.JoinSpace(ctx, "", invite.Token)
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Empty(t, guest.ListSpaces())
+}
+
+// TestHandleFetchSpace_Proof tests that a space is served only with a proof
+// signed by the key of one of its invites.
+func TestHandleFetchSpace_Proof(t *testing.T) {
+	ctx := context.Background()
+	owner := newPeerSpaceManager(t, NewMemoryTransport())
+
+	spaceID, err := owner.CreateSpace(ctx, "", "Team", nil)
+	require.NoError(t, err)
+	otherID, err := owner.CreateSpace(ctx, "", "Other", nil)
+	require.NoError(t, err)
+	invite, err := owner.CreateInvite(ctx, spaceID, PermissionReader, 0)
+	require.NoError(t, err)
+	_, inviteKey, err := parseInviteToken(invite.Token)
+	require.NoError(t, err)
+
+	proof, err := newInviteProof(spaceID, inviteKey)
+	require.NoError(t, err)
+	keys, err := owner.HandleFetchSpace(ctx, spaceID, proof)
+	require.NoError(t, err)
+	assert.Equal(t, spaceID, keys.Metadata.SpaceID)
+
+	_, err = owner.HandleFetchSpace(ctx, spaceID, nil)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	// Signed for another space
+	_, err = owner.HandleFetchSpace(ctx, otherID, proof)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	otherProof, err := newInviteProof(otherID, inviteKey)
+	require.NoError(t, err)
+	_, err = owner.HandleFetchSpace(ctx, otherID, otherProof)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	// Too old
+	stale := *proof
+	stale.Timestamp -= int64(2 * inviteProofMaxAge / time.Second)
+	stale.Signature, err = inviteKey.Sign(inviteProofPayload(spaceID, stale.Timestamp))
+	require.NoError(t, err)
+	_, err = owner.HandleFetchSpace(ctx, spaceID, &stale)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	// By a key that is not an invite
+	strangerKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	strangerProof, err := newInviteProof(spaceID, strangerKey)
+	require.NoError(t, err)
+	_, err = owner.HandleFetchSpace(ctx, spaceID, strangerProof)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	_, err = owner.HandleFetchSpace(ctx, "missing", proof)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
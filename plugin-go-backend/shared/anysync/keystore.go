@@ -0,0 +1,208 @@
+// Package anysync provides Any-Sync integration components.
+package anysync
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// KeyStore persists the account secrets (the account and device key files)
+// under short names such as "account.key". AccountManager stores its keys
+// through it, so hosts can keep them in a platform keystore instead of the
+// data directory. Implementations must be safe for concurrent use.
+type KeyStore interface {
+	// Load returns the secret stored under name, or an ErrNotFound error.
+	Load(name string) ([]byte, error)
+	// Store creates or replaces the secret under name.
+	Store(name string, data []byte) error
+	// Delete removes the secret under name; deleting a missing one is not an error.
+	Delete(name string) error
+	// Exists reports whether a secret is stored under name.
+	Exists(name string) (bool, error)
+}
+
+// errSecretNotFound returns an ErrNotFound error for a missing secret.
+func errSecretNotFound(name string) error {
+	return &Error{
+		Kind:    ErrNotFound,
+		Message: "key not found: " + name,
+		Details: map[string]string{"key": name},
+	}
+}
+
+// FileKeyStore stores each secret in a file of a directory, readable only
+// by the owner. It is the default KeyStore.
+type FileKeyStore struct {
+	dir string
+}
+
+// NewFileKeyStore returns a KeyStore keeping its files in dir.
+func NewFileKeyStore(dir string) *FileKeyStore {
+	return &FileKeyStore{dir: dir}
+}
+
+// Load reads the file of name.
+func (s *FileKeyStore) Load(name string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errSecretNotFound(name)
+	}
+	return data, err
+}
+
+// Store replaces the file of name atomically, so a crash never leaves a
+// partially written key.
+func (s *FileKeyStore) Store(name string, data []byte) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create key directory: %w", err)
+	}
+	return writeFileAtomic(filepath.Join(s.dir, name), data, 0600)
+}
+
+// Delete overwrites the file of name with zeros, then removes it.
+func (s *FileKeyStore) Delete(name string) error {
+	_, err := shredFile(filepath.Join(s.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Exists reports whether the file of name exists.
+func (s *FileKeyStore) Exists(name string) (bool, error) {
+	_, err := os.Stat(filepath.Join(s.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// MemoryKeyStore keeps secrets in memory only, for tests and ephemeral
+// accounts.
+type MemoryKeyStore struct {
+	mu      sync.RWMutex
+	secrets map[string][]byte
+}
+
+// NewMemoryKeyStore returns an empty in-memory KeyStore.
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{secrets: make(map[string][]byte)}
+}
+
+// Load returns a copy of the secret under name.
+func (s *MemoryKeyStore) Load(name string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.secrets[name]
+	if !ok {
+		return nil, errSecretNotFound(name)
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// Store keeps a copy of data under name.
+func (s *MemoryKeyStore) Store(name string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.secrets[name] = append([]byte(nil), data...)
+	return nil
+}
+
+// Delete removes the secret under name.
+func (s *MemoryKeyStore) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.secrets, name)
+	return nil
+}
+
+// Exists reports whether a secret is stored under name.
+func (s *MemoryKeyStore) Exists(name string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.secrets[name]
+	return ok, nil
+}
+
+// CallbackKeyStore forwards every operation to functions supplied by the
+// host, such as a mobile app backing them with the Android Keystore or the
+// iOS Keychain. Scope is passed to each callback so one host store can serve
+// several accounts (e.g. profiles); Load reports a missing secret by
+// returning nil data and a nil error.
+type CallbackKeyStore struct {
+	Scope      string
+	LoadFunc   func(scope, name string) ([]byte, error)
+	StoreFunc  func(scope, name string, data []byte) error
+	DeleteFunc func(scope, name string) error
+	ExistsFunc func(scope, name string) (bool, error)
+}
+
+// Load calls LoadFunc.
+func (s *CallbackKeyStore) Load(name string) ([]byte, error) {
+	data, err := s.LoadFunc(s.Scope, name)
+	if err != nil {
+		return nil, fmt.Errorf("host key store: %w", err)
+	}
+	if data == nil {
+		return nil, errSecretNotFound(name)
+	}
+	return data, nil
+}
+
+// Store calls StoreFunc.
+func (s *CallbackKeyStore) Store(name string, data []byte) error {
+	if err := s.StoreFunc(s.Scope, name, data); err != nil {
+		return fmt.Errorf("host key store: %w", err)
+	}
+	return nil
+}
+
+// Delete calls DeleteFunc.
+func (s *CallbackKeyStore) Delete(name string) error {
+	if err := s.DeleteFunc(s.Scope, name); err != nil {
+		return fmt.Errorf("host key store: %w", err)
+	}
+	return nil
+}
+
+// Exists calls ExistsFunc.
+func (s *CallbackKeyStore) Exists(name string) (bool, error) {
+	exists, err := s.ExistsFunc(s.Scope, name)
+	if err != nil {
+		return false, fmt.Errorf("host key store: %w", err)
+	}
+	return exists, nil
+}
+
+// writeFileAtomic replaces path with data, so a crash never leaves a
+// partially written key file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
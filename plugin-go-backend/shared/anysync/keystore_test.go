@@ -0,0 +1,119 @@
+package anysync
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKeyStore runs the KeyStore contract against store.
+func testKeyStore(t *testing.T, store KeyStore) {
+	t.Helper()
+
+	exists, err := store.Exists("account.key")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = store.Load("account.key")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Store("account.key", []byte("first")))
+	require.NoError(t, store.Store("account.key", []byte("second")))
+
+	data, err := store.Load("account.key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), data)
+
+	exists, err = store.Exists("account.key")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, store.Delete("account.key"))
+	require.NoError(t, store.Delete("account.key"), "deleting a missing secret is not an error")
+
+	_, err = store.Load("account.key")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+// TestFileKeyStore tests the file store, including the file permissions.
+func TestFileKeyStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keys")
+	testKeyStore(t, NewFileKeyStore(dir))
+
+	store := NewFileKeyStore(dir)
+	require.NoError(t, store.Store("device.key", []byte("data")))
+
+	info, err := os.Stat(filepath.Join(dir, "device.key"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+// TestMemoryKeyStore tests the in-memory store and that it copies secrets.
+func TestMemoryKeyStore(t *testing.T) {
+	testKeyStore(t, NewMemoryKeyStore())
+
+	store := NewMemoryKeyStore()
+	secret := []byte("data")
+	require.NoError(t, store.Store("device.key", secret))
+	secret[0] = 'X'
+
+	data, err := store.Load("device.key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+}
+
+// TestCallbackKeyStore tests the callback store against a host map keyed by scope.
+func TestCallbackKeyStore(t *testing.T) {
+	var mu sync.Mutex
+	host := make(map[string][]byte)
+
+	newStore := func(scope string) *CallbackKeyStore {
+		return &CallbackKeyStore{
+			Scope: scope,
+			LoadFunc: func(scope, name string) ([]byte, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				return host[scope+"/"+name], nil
+			},
+			StoreFunc: func(scope, name string, data []byte) error {
+				mu.Lock()
+				defer mu.Unlock()
+				host[scope+"/"+name] = data
+				return nil
+			},
+			DeleteFunc: func(scope, name string) error {
+				mu.Lock()
+				defer mu.Unlock()
+				delete(host, scope+"/"+name)
+				return nil
+			},
+			ExistsFunc: func(scope, name string) (bool, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				_, ok := host[scope+"/"+name]
+				return ok, nil
+			},
+		}
+	}
+
+	testKeyStore(t, newStore("default"))
+
+	// Scopes are isolated from each other
+	require.NoError(t, newStore("work").Store("account.key", []byte("work")))
+	exists, err := newStore("personal").Exists("account.key")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Host errors are wrapped
+	hostErr := errors.New("keychain unavailable")
+	failing := newStore("default")
+	failing.LoadFunc = func(scope, name string) ([]byte, error) { return nil, hostErr }
+	_, err = failing.Load("account.key")
+	assert.ErrorIs(t, err, hostErr)
+	assert.Contains(t, err.Error(), "host key store")
+}
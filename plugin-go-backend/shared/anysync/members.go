@@ -0,0 +1,523 @@
+// Package anysync provides Any-Sync integration components.
+package anysync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/consensus/consensusproto"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// MemberStatus is the state of an account in the ACL of a space.
+type MemberStatus string
+
+const (
+	MemberActive        MemberStatus = "active"         // Has access to the space
+	MemberJoinRequested MemberStatus = "join_requested" // Waits for ApproveJoinRequest
+	MemberLeaving       MemberStatus = "leaving"        // Asked to be removed from the space
+)
+
+// Member is an account in the ACL of a space.
+type Member struct {
+	Identity   string          // Account address of the member
+	Permission SpacePermission // Empty while the join request waits
+	Status     MemberStatus
+	JoinedAt   int64 // Unix time the member joined, or requested to
+	UpdatedAt  int64 // Unix time of the last change of the membership
+
+	joinedIndex int // Index of the record the member joined with in the ACL
+}
+
+// ListMembers returns the members of a space, by ID or alias, and the
+// accounts that requested to join it, in the order they joined.
+func (sm *SpaceManager) ListMembers(ctx context.Context, spaceID string) ([]*Member, error) {
+	space, release, err := sm.acquireSpaceObject(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	acl := space.Acl()
+	acl.RLock()
+	defer acl.RUnlock()
+
+	var members []*Member
+	for _, account := range acl.AclState().CurrentAccounts() {
+		if member := toMember(acl, account); member != nil {
+			members = append(members, member)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].joinedIndex < members[j].joinedIndex
+	})
+	return members, nil
+}
+
+// ApproveJoinRequest accepts the request of an account to join a space, by
+// ID or alias, with a reader, writer or admin permission. The account opens
+// the space by calling JoinSpace again.
+func (sm *SpaceManager) ApproveJoinRequest(ctx context.Context, spaceID, identity string, permission SpacePermission) (*Member, error) {
+	aclPermission, err := memberPermission(permission)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := sm.changeMember(ctx, spaceID, identity, func(acl list.AclList, before *Member, pubKey crypto.PubKey) (*consensusproto.RawRecord, error) {
+		request, err := acl.AclState().JoinRecord(pubKey, false)
+		if err != nil || before.Status != MemberJoinRequested {
+			return nil, errJoinRequestNotFound(spaceID, identity)
+		}
+		return acl.RecordBuilder().BuildRequestAccept(list.RequestAcceptPayload{
+			RequestRecordId: request.RecordId,
+			Permissions:     aclPermission,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sm.emitMemberEvent(EventSpaceMemberJoined, change.spaceID, change.after, "")
+	return change.after, nil
+}
+
+// RemoveMember removes an account from a space, by ID or alias, and
+// changes the read key so it cannot read what is written from then on. For
+// an account that requested to join, it declines the request. The account
+// of this device leaves with LeaveSpace instead.
+func (sm *SpaceManager) RemoveMember(ctx context.Context, spaceID, identity string) error {
+	change, err := sm.changeMember(ctx, spaceID, identity, func(acl list.AclList, before *Member, pubKey crypto.PubKey) (*consensusproto.RawRecord, error) {
+		if pubKey.Equals(sm.keys.SignKey.GetPublic()) {
+			return nil, fmt.Errorf("%w: the account cannot remove itself, leave the space instead", ErrInvalidArgument)
+		}
+		if before.Status == MemberJoinRequested {
+			request, err := acl.AclState().JoinRecord(pubKey, false)
+			if err != nil {
+				return nil, errJoinRequestNotFound(spaceID, identity)
+			}
+			return acl.RecordBuilder().BuildRequestDecline(request.RecordId)
+		}
+
+		return buildAccountRemove(acl, pubKey)
+	})
+	if err != nil {
+		return err
+	}
+
+	sm.emitMemberEvent(EventSpaceMemberRemoved, change.spaceID, change.before, "")
+	return nil
+}
+
+// ChangeMemberPermission sets the permission of a member of a space, by ID
+// or alias, to reader, writer or admin. Setting the current permission
+// changes nothing.
+func (sm *SpaceManager) ChangeMemberPermission(ctx context.Context, spaceID, identity string, permission SpacePermission) (*Member, error) {
+	aclPermission, err := memberPermission(permission)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := sm.changeMember(ctx, spaceID, identity, func(acl list.AclList, before *Member, pubKey crypto.PubKey) (*consensusproto.RawRecord, error) {
+		if before.Status == MemberJoinRequested {
+			return nil, &Error{
+				Kind:    ErrNotFound,
+				Message: "the account has not joined space " + spaceID + " yet: " + identity,
+				Details: map[string]string{"space_id": spaceID, "identity": identity},
+			}
+		}
+		if before.Permission == PermissionOwner {
+			return nil, list.ErrIsOwner
+		}
+		if before.Permission == permission {
+			return nil, nil
+		}
+		return acl.RecordBuilder().BuildPermissionChange(list.PermissionChangePayload{
+			Identity:    pubKey,
+			Permissions: aclPermission,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if change.after.Permission != change.before.Permission {
+		sm.emitMemberEvent(EventSpaceMemberPermissionChanged, change.spaceID, change.after, change.before.Permission)
+	}
+	return change.after, nil
+}
+
+// LeaveResult is the outcome of LeaveSpace.
+type LeaveResult struct {
+	SpaceID  string // Any-Sync space ID
+	AclError string // Why the leave was not recorded in the ACL, empty if it was or there was nothing to record
+}
+
+// LeaveSpace stops the account from taking part in a space, by ID or alias.
+// For a space joined from another account, it first sends the inviting
+// peer a request to be removed from the ACL, which the peer accepts when it
+// manages the members. That request is best effort: when it fails, the
+// space is still left here and the result reports why. It then closes the
+// space. With deleteLocal, the copy here is deleted, with its documents;
+// otherwise it stays as a read-only copy, listed with the time it was left.
+// Spaces of this account are only left locally, as the owner cannot leave
+// the ACL. Leaving a space again is a no-op, unless it deletes the read-only
+// copy.
+func (sm *SpaceManager) LeaveSpace(ctx context.Context, spaceID string, deleteLocal bool) (*LeaveResult, error) {
+	metadata, err := sm.GetSpace(spaceID)
+	if err != nil {
+		return nil, err
+	}
+	spaceID = metadata.SpaceID
+	result := &LeaveResult{SpaceID: spaceID}
+
+	if metadata.LeftAt == 0 && metadata.Peer != "" {
+		if err := sm.requestRemove(ctx, metadata); err != nil {
+			result.AclError = err.Error()
+		}
+	}
+
+	switch {
+	case deleteLocal:
+		// The local leave finishes even when the ACL request used up ctx
+		if err := sm.DeleteSpace(context.WithoutCancel(ctx), spaceID); err != nil {
+			return nil, err
+		}
+	case metadata.LeftAt != 0:
+		return result, nil
+	default:
+		if err := sm.markLeft(spaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	payload := map[string]string{"delete_local": strconv.FormatBool(deleteLocal)}
+	if result.AclError != "" {
+		payload["acl_error"] = result.AclError
+	}
+	sm.eventManager.EmitEvent(EventSpaceLeft, spaceID, payload)
+	return result, nil
+}
+
+// requestRemove sends the peer a space was joined through a record that
+// requests to remove the account from the ACL. An account without
+// permissions, such as one that was removed already, sends nothing.
+func (sm *SpaceManager) requestRemove(ctx context.Context, metadata *SpaceMetadata) error {
+	space, release, err := sm.acquireSpaceObject(ctx, metadata.SpaceID)
+	if err != nil {
+		return err
+	}
+	defer release()
+	acl := space.Acl()
+	acl.Lock()
+	record, err := acl.RecordBuilder().BuildRequestRemove()
+	acl.Unlock()
+	switch {
+	case errors.Is(err, list.ErrNoSuchAccount), errors.Is(err, list.ErrIsOwner):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to build leave record: %w", err)
+	}
+	payload, err := record.MarshalVT()
+	if err != nil {
+		return fmt.Errorf("failed to marshal leave record: %w", err)
+	}
+
+	sm.mu.RLock()
+	transport := sm.transport
+	sm.mu.RUnlock()
+	if transport == nil {
+		return fmt.Errorf("%w: no transport to reach peer %s", ErrUnavailable, metadata.Peer)
+	}
+	if err := transport.Leave(ctx, metadata.Peer, metadata.SpaceID, payload); err != nil {
+		return fmt.Errorf("failed to leave space %s: %w", metadata.SpaceID, err)
+	}
+	return nil
+}
+
+// markLeft closes a space and keeps it as a read-only copy.
+func (sm *SpaceManager) markLeft(spaceID string) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	old, exists := sm.spaces[spaceID]
+	if !exists {
+		return errSpaceNotFound(spaceID)
+	}
+	sm.closeSpaceObject(spaceID)
+
+	updated := *old
+	updated.LeftAt = time.Now().Unix()
+	sm.spaces[spaceID] = &updated
+	if err := sm.saveMetadata(); err != nil {
+		sm.spaces[spaceID] = old
+		return fmt.Errorf("failed to save metadata: %w", err)
+	}
+	return nil
+}
+
+// HandleLeave accepts a record of a peer that requests to leave a space of
+// this device. A manager of the space removes the account of the peer right
+// away; otherwise the request waits in the ACL for RemoveMember.
+func (sm *SpaceManager) HandleLeave(ctx context.Context, spaceID string, record []byte) error {
+	rawRecord := &consensusproto.RawRecord{}
+	if err := rawRecord.UnmarshalVT(record); err != nil {
+		return fmt.Errorf("%w: invalid leave record: %v", ErrInvalidArgument, err)
+	}
+
+	space, release, err := sm.writableSpaceObject(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+	defer release()
+	acl := space.Acl()
+	acl.Lock()
+	identity, err := func() (crypto.PubKey, error) {
+		aclRecord, err := acl.RecordBuilder().Unmarshall(rawRecord)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid leave record: %v", ErrInvalidArgument, err)
+		}
+		data, ok := aclRecord.Model.(*aclrecordproto.AclData)
+		if !ok || len(data.AclContent) != 1 || data.AclContent[0].GetAccountRequestRemove() == nil {
+			return nil, fmt.Errorf("%w: the record does not leave space %s", ErrInvalidArgument, spaceID)
+		}
+		// A retry finds the request of the first attempt
+		if request, err := acl.AclState().Record(aclRecord.Identity); err == nil && request.Type == list.RequestTypeRemove {
+			return aclRecord.Identity, nil
+		}
+		if err := acl.ValidateRawRecord(rawRecord, nil); err != nil {
+			return nil, &Error{
+				Kind:    ErrPermissionDenied,
+				Message: "the account may not leave space " + spaceID + ": " + err.Error(),
+				Details: map[string]string{"space_id": spaceID},
+			}
+		}
+		return aclRecord.Identity, sm.addAclRecord(acl, rawRecord)
+	}()
+	acl.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = sm.RemoveMember(ctx, spaceID, identity.Account())
+	if err != nil && !errors.Is(err, ErrPermissionDenied) {
+		return err
+	}
+	return nil
+}
+
+// memberChange is a change of one member, see changeMember.
+type memberChange struct {
+	spaceID       string
+	before, after *Member // after is nil once the member is gone
+}
+
+// changeMember adds the record build returns for the member with identity
+// to the ACL of a space, by ID or alias, acting as the consensus node. A nil
+// record changes nothing. The account needs the permission to manage the
+// members of the space.
+func (sm *SpaceManager) changeMember(ctx context.Context, spaceID, identity string, build func(acl list.AclList, before *Member, pubKey crypto.PubKey) (*consensusproto.RawRecord, error)) (*memberChange, error) {
+	pubKey, err := crypto.DecodeAccountAddress(identity)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid member identity %q", ErrInvalidArgument, identity)
+	}
+	space, release, err := sm.writableSpaceObject(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	change := &memberChange{spaceID: space.Id()}
+
+	acl := space.Acl()
+	acl.Lock()
+	defer acl.Unlock()
+
+	if !acl.AclState().Permissions(sm.keys.SignKey.GetPublic()).CanManageAccounts() {
+		return nil, errNotManager(change.spaceID)
+	}
+	change.before, err = memberOf(acl, pubKey)
+	if err != nil {
+		return nil, errMemberNotFound(change.spaceID, identity)
+	}
+
+	record, err := build(acl, change.before, pubKey)
+	switch {
+	case errors.Is(err, list.ErrInsufficientPermissions), errors.Is(err, list.ErrIsOwner):
+		return nil, &Error{
+			Kind:    ErrPermissionDenied,
+			Message: "the account may not change member " + identity + " of space " + change.spaceID,
+			Details: map[string]string{"space_id": change.spaceID, "identity": identity},
+		}
+	case errors.Is(err, list.ErrNoSuchAccount):
+		return nil, errMemberNotFound(change.spaceID, identity)
+	case err != nil:
+		return nil, err
+	case record == nil:
+		change.after = change.before
+		return change, nil
+	}
+	if err := sm.addAclRecord(acl, record); err != nil {
+		return nil, fmt.Errorf("failed to change member: %w", err)
+	}
+
+	change.after, _ = memberOf(acl, pubKey)
+	return change, nil
+}
+
+// emitMemberEvent emits a space.member_* event for member, with the
+// permission it had before if it changed.
+func (sm *SpaceManager) emitMemberEvent(eventType EventType, spaceID string, member *Member, oldPermission SpacePermission) {
+	payload := map[string]string{
+		"identity":   member.Identity,
+		"permission": string(member.Permission),
+		"status":     string(member.Status),
+	}
+	if oldPermission != "" {
+		payload["old_permission"] = string(oldPermission)
+	}
+	sm.eventManager.EmitEvent(eventType, spaceID, payload)
+}
+
+// memberOf returns the member of acl with identity. The caller must hold
+// the lock of acl.
+func memberOf(acl list.AclList, identity crypto.PubKey) (*Member, error) {
+	for _, account := range acl.AclState().CurrentAccounts() {
+		if !account.PubKey.Equals(identity) {
+			continue
+		}
+		if member := toMember(acl, account); member != nil {
+			return member, nil
+		}
+		break
+	}
+	return nil, list.ErrNoSuchAccount
+}
+
+// toMember converts the state of an account in acl, nil once the account
+// was removed or its request declined or cancelled, and for device entries.
+func toMember(acl list.AclList, account list.AccountState) *Member {
+	member := &Member{
+		Identity:   account.PubKey.Account(),
+		Permission: permissionOf(account.Permissions),
+	}
+	switch account.Status {
+	case list.StatusActive:
+		member.Status = MemberActive
+	case list.StatusJoining:
+		member.Status = MemberJoinRequested
+	case list.StatusRemoving:
+		member.Status = MemberLeaving
+	default:
+		return nil
+	}
+	if isDeviceEntry(acl, account) {
+		return nil
+	}
+
+	// The member joined with the last change that gave it a permission
+	var joinedID, updatedID string
+	for i, change := range account.PermissionChanges {
+		if i == 0 || account.PermissionChanges[i-1].Permission.NoPermissions() {
+			joinedID = change.RecordId
+		}
+		updatedID = change.RecordId
+	}
+	// A pending join or remove request is the latest change
+	if request, err := acl.AclState().Record(account.PubKey); err == nil {
+		updatedID = request.RecordId
+		if member.Status == MemberJoinRequested {
+			joinedID = request.RecordId
+		}
+	}
+	member.JoinedAt = recordTime(acl, joinedID)
+	member.UpdatedAt = recordTime(acl, updatedID)
+	member.joinedIndex = acl.GetRecordIndex(joinedID)
+	return member
+}
+
+// buildAccountRemove builds the record that removes identity from acl, with
+// a new read key that identity cannot decrypt.
+func buildAccountRemove(acl list.AclList, identity crypto.PubKey) (*consensusproto.RawRecord, error) {
+	metadataKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate metadata key: %w", err)
+	}
+	return acl.RecordBuilder().BuildAccountRemove(list.AccountRemovePayload{
+		Identities: []crypto.PubKey{identity},
+		Change: list.ReadKeyChangePayload{
+			MetadataKey: metadataKey,
+			ReadKey:     crypto.NewAES(),
+		},
+	})
+}
+
+// recordTime returns the time a record of acl was created, 0 if it is not
+// there.
+func recordTime(acl list.AclList, recordID string) int64 {
+	record, err := acl.Get(recordID)
+	if err != nil {
+		return 0
+	}
+	return record.Timestamp
+}
+
+// permissionOf returns the permission of Any-Sync ACL permissions, empty for
+// none.
+func permissionOf(permissions list.AclPermissions) SpacePermission {
+	switch aclrecordproto.AclUserPermissions(permissions) {
+	case aclrecordproto.AclUserPermissions_Owner:
+		return PermissionOwner
+	case aclrecordproto.AclUserPermissions_Admin:
+		return PermissionAdmin
+	case aclrecordproto.AclUserPermissions_Writer:
+		return PermissionWriter
+	case aclrecordproto.AclUserPermissions_Reader, aclrecordproto.AclUserPermissions_Guest:
+		return PermissionReader
+	default:
+		return ""
+	}
+}
+
+// memberPermission returns the ACL permissions of a permission that can be
+// given to a member: reader, writer or admin.
+func memberPermission(permission SpacePermission) (list.AclPermissions, error) {
+	if permission == PermissionOwner {
+		return 0, fmt.Errorf("%w: the owner permission cannot be given to a member", ErrInvalidArgument)
+	}
+	return permission.aclPermissions()
+}
+
+// errNotManager returns the ErrPermissionDenied error of an account that may
+// not manage the members of a space.
+func errNotManager(spaceID string) error {
+	return &Error{
+		Kind:    ErrPermissionDenied,
+		Message: "the account may not manage the members of space " + spaceID,
+		Details: map[string]string{"space_id": spaceID},
+	}
+}
+
+// errMemberNotFound returns an ErrNotFound error for an account that is not
+// a member of a space.
+func errMemberNotFound(spaceID, identity string) error {
+	return &Error{
+		Kind:    ErrNotFound,
+		Message: "member not found in space " + spaceID + ": " + identity,
+		Details: map[string]string{"space_id": spaceID, "identity": identity},
+	}
+}
+
+// errJoinRequestNotFound returns an ErrNotFound error for an account that
+// has no pending request to join a space.
+func errJoinRequestNotFound(spaceID, identity string) error {
+	return &Error{
+		Kind:    ErrNotFound,
+		Message: "no join request in space " + spaceID + " from " + identity,
+		Details: map[string]string{"space_id": spaceID, "identity": identity},
+	}
+}
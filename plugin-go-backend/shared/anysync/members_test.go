@@ -0,0 +1,282 @@
+package anysync
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nextMemberEvent returns the next event of events, failing after a second.
+func nextMemberEvent(t *testing.T, events <-chan *Event) *Event {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("expected a member event")
+		return nil
+	}
+}
+
+// TestMembers tests requesting to join, approving, changing and removing a
+// member of a space.
+func TestMembers(t *testing.T) {
+	ctx := context.Background()
+	transport := NewMemoryTransport()
+	owner := newPeerSpaceManager(t, transport)
+	guest := newPeerSpaceManager(t, transport)
+	ownerIdentity := owner.keys.SignKey.GetPublic().Account()
+	guestIdentity := guest.keys.SignKey.GetPublic().Account()
+
+	spaceID, err := owner.CreateSpace(ctx, "team", "Team", nil)
+	require.NoError(t, err)
+	_, events, err := owner.eventManager.Subscribe(ctx, EventFilter{SpaceIDs: []string{spaceID}})
+	require.NoError(t, err)
+
+	members, err := owner.ListMembers(ctx, "team")
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, ownerIdentity, members[0].Identity)
+	assert.Equal(t, PermissionOwner, members[0].Permission)
+	assert.Equal(t, MemberActive, members[0].Status)
+	assert.NotZero(t, members[0].JoinedAt)
+
+	// The join request waits for approval
+	invite, err := owner.CreateApprovalInvite(ctx, "team", 0)
+	require.NoError(t, err)
+	joined, err := guest.JoinSpace(ctx, "shared", invite.Token)
+	require.NoError(t, err)
+	assert.Equal(t, &JoinResult{SpaceID: spaceID, Pending: true}, joined)
+	assert.Empty(t, guest.ListSpaces())
+	joined, err = guest.JoinSpace(ctx, "shared", invite.Token)
+	require.NoError(t, err)
+	assert.True(t, joined.Pending)
+
+	event := nextMemberEvent(t, events)
+	assert.Equal(t, EventSpaceMemberJoinRequested, event.Type)
+	assert.Equal(t, guestIdentity, event.Payload["identity"])
+	members, err = owner.ListMembers(ctx, spaceID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, guestIdentity, members[1].Identity)
+	assert.Equal(t, MemberJoinRequested, members[1].Status)
+	assert.Empty(t, members[1].Permission)
+
+	member, err := owner.ApproveJoinRequest(ctx, "team", guestIdentity, PermissionWriter)
+	require.NoError(t, err)
+	assert.Equal(t, MemberActive, member.Status)
+	assert.Equal(t, PermissionWriter, member.Permission)
+	event = nextMemberEvent(t, events)
+	assert.Equal(t, EventSpaceMemberJoined, event.Type)
+	assert.Equal(t, "writer", event.Payload["permission"])
+
+	// Joining again once approved opens the space
+	joined, err = guest.JoinSpace(ctx, "shared", invite.Token)
+	require.NoError(t, err)
+	assert.Equal(t, &JoinResult{SpaceID: spaceID}, joined)
+	guestMembers, err := guest.ListMembers(ctx, "shared")
+	require.NoError(t, err)
+	assert.Len(t, guestMembers, 2)
+
+	member, err = owner.ChangeMemberPermission(ctx, spaceID, guestIdentity, PermissionReader)
+	require.NoError(t, err)
+	assert.Equal(t, PermissionReader, member.Permission)
+	assert.GreaterOrEqual(t, member.UpdatedAt, member.JoinedAt)
+	event = nextMemberEvent(t, events)
+	assert.Equal(t, EventSpaceMemberPermissionChanged, event.Type)
+	assert.Equal(t, "reader", event.Payload["permission"])
+	assert.Equal(t, "writer", event.Payload["old_permission"])
+
+	// The same permission changes nothing
+	_, err = owner.ChangeMemberPermission(ctx, spaceID, guestIdentity, PermissionReader)
+	require.NoError(t, err)
+
+	require.NoError(t, owner.RemoveMember(ctx, spaceID, guestIdentity))
+	event = nextMemberEvent(t, events)
+	assert.Equal(t, EventSpaceMemberRemoved, event.Type)
+	assert.Equal(t, guestIdentity, event.Payload["identity"])
+	assert.Equal(t, "reader", event.Payload["permission"])
+	members, err = owner.ListMembers(ctx, spaceID)
+	require.NoError(t, err)
+	assert.Len(t, members, 1)
+
+	// The owner keeps writing with the new read key
+	dm, err := NewDocumentManager(owner, owner.keys, NewEventManager())
+	require.NoError(t, err)
+	_, err = dm.CreateDocument(ctx, spaceID, "After", []byte("after"), nil)
+	require.NoError(t, err)
+}
+
+// TestMembers_Errors tests the member changes that are not allowed.
+func TestMembers_Errors(t *testing.T) {
+	ctx := context.Background()
+	transport := NewMemoryTransport()
+	owner := newPeerSpaceManager(t, transport)
+	guest := newPeerSpaceManager(t, transport)
+	requester := newPeerSpaceManager(t, transport)
+	ownerIdentity := owner.keys.SignKey.GetPublic().Account()
+	guestIdentity := guest.keys.SignKey.GetPublic().Account()
+	requesterIdentity := requester.keys.SignKey.GetPublic().Account()
+
+	spaceID, err := owner.CreateSpace(ctx, "", "Team", nil)
+	require.NoError(t, err)
+	invite, err := owner.CreateInvite(ctx, spaceID, PermissionWriter, 0)
+	require.NoError(t, err)
+	_, err = guest.JoinSpace(ctx, "", invite.Token)
+	require.NoError(t, err)
+
+	_, err = owner.ListMembers(ctx, "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	err = owner.RemoveMember(ctx, spaceID, "not an identity")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	err = owner.RemoveMember(ctx, spaceID, requesterIdentity)
+	assert.ErrorIs(t, err, ErrNotFound)
+	err = owner.RemoveMember(ctx, spaceID, ownerIdentity)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = owner.ChangeMemberPermission(ctx, spaceID, guestIdentity, PermissionOwner)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = owner.ApproveJoinRequest(ctx, spaceID, guestIdentity, PermissionReader)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Writers do not manage members
+	err = guest.RemoveMember(ctx, spaceID, ownerIdentity)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	_, err = guest.CreateApprovalInvite(ctx, spaceID, 0)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	// Admins do, but not of the owner
+	_, err = owner.ChangeMemberPermission(ctx, spaceID, guestIdentity, PermissionAdmin)
+	require.NoError(t, err)
+	_, err = owner.ChangeMemberPermission(ctx, spaceID, ownerIdentity, PermissionReader)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	// Removing a requester declines the request
+	approval, err := owner.CreateApprovalInvite(ctx, spaceID, 0)
+	require.NoError(t, err)
+	joined, err := requester.JoinSpace(ctx, "", approval.Token)
+	require.NoError(t, err)
+	assert.True(t, joined.Pending)
+	_, err = owner.ChangeMemberPermission(ctx, spaceID, requesterIdentity, PermissionReader)
+	assert.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, owner.RemoveMember(ctx, spaceID, requesterIdentity))
+	members, err := owner.ListMembers(ctx, spaceID)
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+}
+
+// TestLeaveSpace tests leaving a joined space, keeping a read-only copy and
+// then deleting it, and leaving when the inviting peer cannot be reached.
+func TestLeaveSpace(t *testing.T) {
+	ctx := context.Background()
+	transport := NewMemoryTransport()
+	owner := newPeerSpaceManager(t, transport)
+	guest := newPeerSpaceManager(t, transport)
+	guestIdentity := guest.keys.SignKey.GetPublic().Account()
+
+	spaceID, err := owner.CreateSpace(ctx, "team", "Team", nil)
+	require.NoError(t, err)
+	invite, err := owner.CreateInvite(ctx, spaceID, PermissionWriter, 0)
+	require.NoError(t, err)
+	_, err = guest.JoinSpace(ctx, "shared", invite.Token)
+	require.NoError(t, err)
+	dm, err := NewDocumentManager(guest, guest.keys, guest.eventManager)
+	require.NoError(t, err)
+	documentID, err := dm.CreateDocument(ctx, "shared", "Note", []byte("note"), nil)
+	require.NoError(t, err)
+	documentsPath := filepath.Join(guest.GetDataDir(), "documents", spaceID+".json")
+	require.FileExists(t, documentsPath)
+
+	_, ownerEvents, err := owner.eventManager.Subscribe(ctx, EventFilter{EventTypes: []EventType{EventSpaceMemberRemoved}})
+	require.NoError(t, err)
+	_, guestEvents, err := guest.eventManager.Subscribe(ctx, EventFilter{EventTypes: []EventType{EventSpaceLeft}})
+	require.NoError(t, err)
+
+	result, err := guest.LeaveSpace(ctx, "shared", false)
+	require.NoError(t, err)
+	assert.Equal(t, &LeaveResult{SpaceID: spaceID}, result)
+	event := nextMemberEvent(t, ownerEvents)
+	assert.Equal(t, guestIdentity, event.Payload["identity"])
+	assert.Equal(t, string(MemberLeaving), event.Payload["status"])
+	event = nextMemberEvent(t, guestEvents)
+	assert.Equal(t, spaceID, event.SpaceID)
+	assert.Equal(t, "false", event.Payload["delete_local"])
+	assert.NotContains(t, event.Payload, "acl_error")
+	members, err := owner.ListMembers(ctx, spaceID)
+	require.NoError(t, err)
+	assert.Len(t, members, 1)
+
+	// The copy stays, closed and read-only
+	space, err := guest.GetSpace("shared")
+	require.NoError(t, err)
+	assert.NotZero(t, space.LeftAt)
+	assert.Zero(t, guest.OpenSpaceCount())
+	data, _, err := dm.GetDocument(ctx, "shared", documentID)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("note"), data)
+	_, err = dm.CreateDocument(ctx, "shared", "Other", []byte("other"), nil)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	_, err = dm.UpdateDocument(ctx, "shared", documentID, []byte("changed"), nil, 0)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	_, err = guest.JoinSpace(ctx, "", invite.Token)
+	assert.ErrorIs(t, err, ErrAlreadyExists)
+
+	// Leaving again only deletes the copy, with its documents
+	_, err = guest.LeaveSpace(ctx, "shared", false)
+	require.NoError(t, err)
+	_, err = guest.LeaveSpace(ctx, "shared", true)
+	require.NoError(t, err)
+	assert.Empty(t, guest.ListSpaces())
+	event = nextMemberEvent(t, guestEvents)
+	assert.Equal(t, "true", event.Payload["delete_local"])
+	documents, err := dm.ListDocuments(ctx, spaceID)
+	require.NoError(t, err)
+	assert.Empty(t, documents)
+	assert.NoFileExists(t, documentsPath)
+
+	// A removed account may join again with a valid invite
+	joined, err := guest.JoinSpace(ctx, "", invite.Token)
+	require.NoError(t, err)
+	assert.Equal(t, spaceID, joined.SpaceID)
+	documents, err = dm.ListDocuments(ctx, spaceID)
+	require.NoError(t, err)
+	assert.Empty(t, documents)
+
+	// Without the inviting peer, the space is still left here, and the
+	// result reports why the ACL was not changed
+	owner.SetTransport(nil)
+	result, err = guest.LeaveSpace(ctx, spaceID, true)
+	require.NoError(t, err)
+	assert.Contains(t, result.AclError, "not reachable")
+	assert.Empty(t, guest.ListSpaces())
+	event = nextMemberEvent(t, guestEvents)
+	assert.Equal(t, result.AclError, event.Payload["acl_error"])
+	members, err = owner.ListMembers(ctx, spaceID)
+	require.NoError(t, err)
+	assert.Len(t, members, 2, "the owner still lists the guest")
+}
+
+// TestLeaveSpace_Owner tests that the owner leaves its own space locally.
+func TestLeaveSpace_Owner(t *testing.T) {
+	ctx := context.Background()
+	owner := newPeerSpaceManager(t, NewMemoryTransport())
+
+	spaceID, err := owner.CreateSpace(ctx, "", "Mine", nil)
+	require.NoError(t, err)
+	result, err := owner.LeaveSpace(ctx, spaceID, false)
+	require.NoError(t, err)
+	assert.Empty(t, result.AclError)
+
+	space, err := owner.GetSpace(spaceID)
+	require.NoError(t, err)
+	assert.NotZero(t, space.LeftAt)
+	_, err = owner.CreateInvite(ctx, spaceID, PermissionReader, 0)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	_, err = owner.LeaveSpace(ctx, "missing", true)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,55 @@
+// Package anysync provides Any-Sync integration components.
+package anysync
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"strings"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// The recovery phrase of an account is the BIP39 encoding of the seed of its
+// account (sign) key: 32 bytes of entropy, so 24 words. Unlike a phrase run
+// through a derivation path, this works for the accounts created from random
+// keys, and the phrase gives back exactly the same key.
+
+// mnemonicWords is the number of words of a recovery phrase.
+const mnemonicWords = 24
+
+// mnemonicFromKey returns the recovery phrase of an account key.
+func mnemonicFromKey(key crypto.PrivKey) (string, error) {
+	raw, err := key.Raw()
+	if err != nil {
+		return "", fmt.Errorf("failed to read account key: %w", err)
+	}
+	if len(raw) != ed25519.PrivateKeySize {
+		return "", fmt.Errorf("unexpected account key size %d", len(raw))
+	}
+
+	mnemonic, err := crypto.NewMnemonicGenerator().WithEntropy(raw[:ed25519.SeedSize])
+	if err != nil {
+		return "", fmt.Errorf("failed to encode recovery phrase: %w", err)
+	}
+	return string(mnemonic), nil
+}
+
+// keyFromMnemonic returns the account key of a recovery phrase. Case and
+// extra whitespace are ignored; anything else that is not a valid phrase
+// fails with ErrInvalidArgument.
+func keyFromMnemonic(mnemonic string) (crypto.PrivKey, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) != mnemonicWords {
+		return nil, fmt.Errorf("%w: recovery phrase must have %d words, got %d", ErrInvalidArgument, mnemonicWords, len(words))
+	}
+
+	seed, err := crypto.Mnemonic(strings.Join(words, " ")).Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid recovery phrase: %v", ErrInvalidArgument, err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%w: invalid recovery phrase", ErrInvalidArgument)
+	}
+
+	return crypto.NewEd25519PrivKey(ed25519.NewKeyFromSeed(seed)), nil
+}
@@ -135,6 +135,24 @@ func (p *localSpaceStorageProvider) WaitSpaceStorage(ctx context.Context, id str
 	return storage, nil
 }
 
+// DeleteSpaceStorage closes the storage of a space and removes its database,
+// so that the space can be created again.
+func (p *localSpaceStorageProvider) DeleteSpaceStorage(ctx context.Context, id string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if storage, exists := p.storages[id]; exists {
+		storage.Close(ctx)
+		delete(p.storages, id)
+	}
+	if db, exists := p.databases[id]; exists {
+		db.Close()
+		delete(p.databases, id)
+	}
+
+	return os.RemoveAll(filepath.Join(p.storageDir, id+".db"))
+}
+
 // localAccountService implements accountservice.Service for local-only operation.
 type localAccountService struct {
 	keys *accountdata.AccountKeys
@@ -268,22 +286,20 @@ func (p *noOpPool) Flush(ctx context.Context) error {
 }
 
 // noOpConfig is a minimal Config implementation that satisfies config.ConfigGetter.
-type noOpConfig struct{}
+type noOpConfig struct {
+	space config.Config
+}
 
-func newNoOpConfig() *noOpConfig { return &noOpConfig{} }
+func newNoOpConfig(space config.Config) *noOpConfig { return &noOpConfig{space: space} }
 
 func (c *noOpConfig) Name() string                    { return "config" }
 func (c *noOpConfig) Init(a *app.App) error           { return nil }
 func (c *noOpConfig) Run(ctx context.Context) error   { return nil }
 func (c *noOpConfig) Close(ctx context.Context) error { return nil }
 
-// GetSpace returns minimal space configuration for local-only operation.
+// GetSpace returns the space configuration the manager was created with.
 func (c *noOpConfig) GetSpace() config.Config {
-	return config.Config{
-		GCTTL:                60,
-		SyncPeriod:           5,
-		KeepTreeDataInMemory: true,
-	}
+	return c.space
 }
 
 // noOpTreeSyncer is a minimal TreeSyncer implementation for local-only operation.
@@ -7,17 +7,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/anyproto/any-sync/accountservice"
 	"github.com/anyproto/any-sync/app"
 	"github.com/anyproto/any-sync/commonspace"
+	"github.com/anyproto/any-sync/commonspace/config"
 	"github.com/anyproto/any-sync/commonspace/credentialprovider"
 	"github.com/anyproto/any-sync/commonspace/object/accountdata"
 	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage"
 	"github.com/anyproto/any-sync/commonspace/spacepayloads"
-	"github.com/anyproto/any-sync/commonspace/spacestorage"
 	"github.com/anyproto/any-sync/commonspace/syncstatus"
 	"github.com/anyproto/any-sync/node/nodeclient"
 	"github.com/anyproto/any-sync/util/crypto"
@@ -28,10 +29,25 @@ import (
 // This is stored separately from Any-Sync's internal space structure.
 type SpaceMetadata struct {
 	SpaceID   string            `json:"space_id"`
+	Alias     string            `json:"alias,omitempty"` // Unique client-chosen ID, see CreateSpace
 	Name      string            `json:"name"`
 	Metadata  map[string]string `json:"metadata"`
 	CreatedAt int64             `json:"created_at"`
 	UpdatedAt int64             `json:"updated_at"`
+	Peer      string            `json:"peer,omitempty"`    // Peer ID of the device the space was joined through
+	LeftAt    int64             `json:"left_at,omitempty"` // Unix time the account left the space, see LeaveSpace
+
+	// InviteExpiry holds the expiry (Unix time) of the invites created on
+	// this device that expire, by the ID of their ACL record
+	InviteExpiry map[string]int64 `json:"invite_expiry,omitempty"`
+}
+
+// SpaceUpdate is a partial update of a space, see UpdateSpace.
+type SpaceUpdate struct {
+	Name              *string           // New name, nil keeps the current one
+	Metadata          map[string]string // Entries to merge, or the new metadata with ReplaceMetadata
+	ReplaceMetadata   bool              // Replace the metadata instead of merging into it
+	ExpectedUpdatedAt int64             // For optimistic locking (0 = skip check)
 }
 
 // SpaceManager manages local spaces with full Any-Sync structure.
@@ -48,17 +64,42 @@ type SpaceManager struct {
 	keys         *accountdata.AccountKeys
 	spaces       map[string]*SpaceMetadata    // Application-level metadata
 	spaceObjects map[string]commonspace.Space // Any-Sync Space objects
+	loadErrors   map[string]string            // Why a space object last failed to load
 	storageDir   string                       // Directory for space storage databases
 	eventManager *EventManager                // Event system for broadcasting space events
+	spaceConfig  config.Config                // Settings handed to Any-Sync spaces
+	transport    Transport                    // Reaches other peers, nil when there is none
+	unregister   func()                       // Removes the manager from transport
+	cacheSize    int                          // Space objects kept open, 0 for no limit
+	lastUsed     map[string]uint64            // Order in which space objects were last used
+	useCount     uint64                       // Last value handed out in lastUsed
+	inUse        map[string]int               // Holders of each space object, see acquireSpaceObject
+	onDelete     func(spaceID string)         // Called once a space is deleted, see DocumentManager
 
 	// Any-Sync components
 	app             *app.App
 	spaceService    commonspace.SpaceService
-	storageProvider spacestorage.SpaceStorageProvider
+	storageProvider *localSpaceStorageProvider
+}
+
+// DefaultSpaceConfig returns the Any-Sync space settings used when the
+// backend configuration does not override them.
+func DefaultSpaceConfig() config.Config {
+	return config.Config{
+		GCTTL:                60,
+		SyncPeriod:           5,
+		KeepTreeDataInMemory: true,
+	}
 }
 
-// NewSpaceManager creates a new SpaceManager with full Any-Sync integration.
+// NewSpaceManager creates a new SpaceManager with full Any-Sync integration,
+// using DefaultSpaceConfig.
 func NewSpaceManager(dataDir string, keys *accountdata.AccountKeys, eventManager *EventManager) (*SpaceManager, error) {
+	return NewSpaceManagerWithConfig(dataDir, keys, eventManager, DefaultSpaceConfig())
+}
+
+// NewSpaceManagerWithConfig creates a new SpaceManager whose spaces use spaceConfig.
+func NewSpaceManagerWithConfig(dataDir string, keys *accountdata.AccountKeys, eventManager *EventManager, spaceConfig config.Config) (*SpaceManager, error) {
 	if keys == nil {
 		return nil, fmt.Errorf("account keys required")
 	}
@@ -76,8 +117,12 @@ func NewSpaceManager(dataDir string, keys *accountdata.AccountKeys, eventManager
 		keys:         keys,
 		spaces:       make(map[string]*SpaceMetadata),
 		spaceObjects: make(map[string]commonspace.Space),
+		loadErrors:   make(map[string]string),
+		lastUsed:     make(map[string]uint64),
+		inUse:        make(map[string]int),
 		storageDir:   storageDir,
 		eventManager: eventManager,
+		spaceConfig:  spaceConfig,
 	}
 
 	// Initialize Any-Sync components
@@ -111,7 +156,7 @@ func (sm *SpaceManager) initializeAnySync() error {
 	sm.app.Register(newNoOpNodeConf())
 	sm.app.Register(newNoOpPeerManagerProvider())
 	sm.app.Register(newNoOpPool())
-	sm.app.Register(newNoOpConfig())
+	sm.app.Register(newNoOpConfig(sm.spaceConfig))
 	sm.app.Register(newNoOpTreeSyncer())          // Required by headsync
 	sm.app.Register(syncqueues.New())             // Required by sync service
 	sm.app.Register(nodeclient.New())             // Required by ACL client
@@ -130,22 +175,35 @@ func (sm *SpaceManager) initializeAnySync() error {
 	return nil
 }
 
-// CreateSpace creates a new space with full Any-Sync structure using SpaceService.
-func (sm *SpaceManager) CreateSpace(referenceName, name string, metadata map[string]string) error {
+// CreateSpace creates a new space with full Any-Sync structure using SpaceService
+// and returns its ID. A non-empty alias is the client's own ID for the space:
+// it is stored with the space and accepted wherever a space ID is. Creating a
+// space with an alias that already resolves to a space returns that space, so
+// clients can retry safely; name and metadata are then ignored.
+// Storage creation and space initialization abort when ctx is done.
+func (sm *SpaceManager) CreateSpace(ctx context.Context, alias, name string, metadata map[string]string) (string, error) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
-	ctx := context.Background()
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	if alias != "" {
+		if spaceID, ok := sm.lookup(alias); ok {
+			return spaceID, nil
+		}
+	}
 
 	// Generate cryptographic keys for the space
 	masterKey, _, err := crypto.GenerateRandomEd25519KeyPair()
 	if err != nil {
-		return fmt.Errorf("failed to generate master key: %w", err)
+		return "", fmt.Errorf("failed to generate master key: %w", err)
 	}
 
 	metadataKey, _, err := crypto.GenerateRandomEd25519KeyPair()
 	if err != nil {
-		return fmt.Errorf("failed to generate metadata key: %w", err)
+		return "", fmt.Errorf("failed to generate metadata key: %w", err)
 	}
 
 	readKey := crypto.NewAES()
@@ -165,13 +223,13 @@ func (sm *SpaceManager) CreateSpace(referenceName, name string, metadata map[str
 	// Convert to storage payload
 	storagePayload, err := spacepayloads.StoragePayloadForSpaceCreate(createPayload)
 	if err != nil {
-		return fmt.Errorf("failed to create storage payload: %w", err)
+		return "", fmt.Errorf("failed to create storage payload: %w", err)
 	}
 
 	// Create space storage via provider
 	storage, err := sm.storageProvider.CreateSpaceStorage(ctx, storagePayload)
 	if err != nil {
-		return fmt.Errorf("failed to create space storage: %w", err)
+		return "", fmt.Errorf("failed to create space storage: %w", err)
 	}
 
 	// Extract the space ID from the space header
@@ -182,7 +240,7 @@ func (sm *SpaceManager) CreateSpace(referenceName, name string, metadata map[str
 	space, err := sm.spaceService.NewSpace(ctx, actualSpaceID, spaceDeps)
 	if err != nil {
 		storage.Close(ctx)
-		return fmt.Errorf("failed to create space object: %w", err)
+		return "", fmt.Errorf("failed to create space object: %w", err)
 	}
 
 	// Initialize the space to set up TreeBuilder
@@ -190,18 +248,24 @@ func (sm *SpaceManager) CreateSpace(referenceName, name string, metadata map[str
 	if err := space.Init(ctx); err != nil {
 		space.Close()
 		storage.Close(ctx)
-		return fmt.Errorf("failed to initialize space: %w", err)
+		return "", fmt.Errorf("failed to initialize space: %w", err)
 	}
 
 	// Store application metadata
 	now := time.Now().Unix()
 	spaceMeta := &SpaceMetadata{
 		SpaceID:   actualSpaceID,
+		Alias:     alias,
 		Name:      name,
 		Metadata:  metadata,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
+	if err := sm.addDeviceEntry(spaceMeta, space); err != nil {
+		space.Close()
+		storage.Close(ctx)
+		return "", err
+	}
 
 	sm.spaces[actualSpaceID] = spaceMeta
 	sm.spaceObjects[actualSpaceID] = space
@@ -211,15 +275,48 @@ func (sm *SpaceManager) CreateSpace(referenceName, name string, metadata map[str
 		space.Close()
 		delete(sm.spaces, actualSpaceID)
 		delete(sm.spaceObjects, actualSpaceID)
-		return fmt.Errorf("failed to save metadata: %w", err)
+		return "", fmt.Errorf("failed to save metadata: %w", err)
 	}
+	sm.touch(actualSpaceID)
+	sm.evictSpaces()
 
 	// Emit space.created event
 	sm.eventManager.EmitEvent(EventSpaceCreated, actualSpaceID, map[string]string{
-		"name": name,
+		"name":  name,
+		"alias": alias,
 	})
 
-	return nil
+	return actualSpaceID, nil
+}
+
+// ResolveSpaceID returns the ID of the space that idOrAlias names, either by
+// its ID or by its alias. Unknown values are returned as is, so that the
+// operation using them reports the space as not found.
+func (sm *SpaceManager) ResolveSpaceID(idOrAlias string) string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if spaceID, ok := sm.lookup(idOrAlias); ok {
+		return spaceID
+	}
+	return idOrAlias
+}
+
+// lookup finds the space that idOrAlias names; IDs take precedence over
+// aliases. The caller must hold sm.mu.
+func (sm *SpaceManager) lookup(idOrAlias string) (string, bool) {
+	if _, exists := sm.spaces[idOrAlias]; exists {
+		return idOrAlias, true
+	}
+	if idOrAlias == "" {
+		return "", false
+	}
+	for spaceID, space := range sm.spaces {
+		if space.Alias == idOrAlias {
+			return spaceID, true
+		}
+	}
+	return "", false
 }
 
 // createSpaceDeps creates the dependencies needed for Space creation.
@@ -232,40 +329,187 @@ func (sm *SpaceManager) createSpaceDeps() commonspace.Deps {
 	}
 }
 
-// GetSpaceObject retrieves or initializes a Space object by ID.
+// GetSpaceObject retrieves or initializes a Space object by ID or alias.
 // This is the method that Phase 2D will use to access TreeBuilder.
-func (sm *SpaceManager) GetSpaceObject(spaceID string) (commonspace.Space, error) {
+// Opening a space that is not yet initialized aborts when ctx is done.
+// With a space cache size, the object may be closed once other spaces are
+// used; use acquireSpaceObject to keep it open.
+func (sm *SpaceManager) GetSpaceObject(ctx context.Context, spaceID string) (commonspace.Space, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	space, err := sm.openSpaceObject(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	sm.evictSpaces()
+	return space, nil
+}
+
+// acquireSpaceObject returns the Space object of a space, by ID or alias, as
+// GetSpaceObject does, and keeps it open until release is called, whatever
+// the space cache size. release must be called without holding sm.mu.
+func (sm *SpaceManager) acquireSpaceObject(ctx context.Context, spaceID string) (space commonspace.Space, release func(), err error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	space, err = sm.openSpaceObject(ctx, spaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	spaceID = space.Id()
+	sm.inUse[spaceID]++
+	sm.evictSpaces()
+
+	var once sync.Once
+	return space, func() { once.Do(func() { sm.releaseSpaceObject(spaceID) }) }, nil
+}
+
+// releaseSpaceObject ends a use of a space object from acquireSpaceObject,
+// and closes the spaces that were kept open beyond the cache size for it.
+func (sm *SpaceManager) releaseSpaceObject(spaceID string) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
+	if sm.inUse[spaceID]--; sm.inUse[spaceID] <= 0 {
+		delete(sm.inUse, spaceID)
+	}
+	sm.evictSpaces()
+}
+
+// openSpaceObject returns the Space object of a space, by ID or alias,
+// initializing it if needed, without closing others. The caller must hold
+// sm.mu for writing.
+func (sm *SpaceManager) openSpaceObject(ctx context.Context, spaceID string) (commonspace.Space, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Check if space metadata exists
-	if _, exists := sm.spaces[spaceID]; !exists {
-		return nil, fmt.Errorf("space not found: %s", spaceID)
+	resolved, exists := sm.lookup(spaceID)
+	if !exists {
+		return nil, errSpaceNotFound(spaceID)
 	}
+	spaceID = resolved
 
 	// Check if already initialized
 	if space, exists := sm.spaceObjects[spaceID]; exists {
+		sm.touch(spaceID)
 		return space, nil
 	}
 
 	// Initialize the Space object
-	ctx := context.Background()
 	spaceDeps := sm.createSpaceDeps()
 	space, err := sm.spaceService.NewSpace(ctx, spaceID, spaceDeps)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create space object: %w", err)
+		err = fmt.Errorf("failed to create space object: %w", err)
+		sm.recordLoadError(ctx, spaceID, err)
+		return nil, err
 	}
 
 	// Initialize the space to set up TreeBuilder
 	if err := space.Init(ctx); err != nil {
 		space.Close()
-		return nil, fmt.Errorf("failed to initialize space: %w", err)
+		err = fmt.Errorf("failed to initialize space: %w", err)
+		sm.recordLoadError(ctx, spaceID, err)
+		return nil, err
+	}
+	if err := sm.addDeviceEntry(sm.spaces[spaceID], space); err != nil {
+		space.Close()
+		sm.recordLoadError(ctx, spaceID, err)
+		return nil, err
 	}
 
+	delete(sm.loadErrors, spaceID)
 	sm.spaceObjects[spaceID] = space
+	sm.touch(spaceID)
 	return space, nil
 }
 
+// SetSpaceCacheSize limits the number of space objects kept open to size.
+// Once the limit is exceeded, the least recently used spaces are closed; they
+// are opened again on their next use. Zero keeps every space open.
+func (sm *SpaceManager) SetSpaceCacheSize(size int) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.cacheSize = size
+	sm.evictSpaces()
+}
+
+// touch marks a space object as the most recently used. The caller must hold
+// sm.mu for writing.
+func (sm *SpaceManager) touch(spaceID string) {
+	sm.useCount++
+	sm.lastUsed[spaceID] = sm.useCount
+}
+
+// evictSpaces closes the least recently used space objects beyond the cache
+// size. Objects in use stay open, and so does the one used last, which the
+// caller may be about to hand out, so the cache may exceed its size until
+// they are released. The caller must hold sm.mu for writing.
+func (sm *SpaceManager) evictSpaces() {
+	for sm.cacheSize > 0 && len(sm.spaceObjects) > sm.cacheSize {
+		oldest := ""
+		for spaceID := range sm.spaceObjects {
+			if sm.inUse[spaceID] > 0 || sm.lastUsed[spaceID] == sm.useCount {
+				continue
+			}
+			if oldest == "" || sm.lastUsed[spaceID] < sm.lastUsed[oldest] {
+				oldest = spaceID
+			}
+		}
+		if oldest == "" {
+			return
+		}
+		sm.closeSpaceObject(oldest)
+	}
+}
+
+// closeSpaceObject closes the space object of a space if it is open. The
+// caller must hold sm.mu for writing.
+func (sm *SpaceManager) closeSpaceObject(spaceID string) {
+	space, ok := sm.spaceObjects[spaceID]
+	if !ok {
+		return
+	}
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				// Ignore panics from closing partially initialized spaces
+			}
+		}()
+		space.Close()
+	}()
+	delete(sm.spaceObjects, spaceID)
+	delete(sm.lastUsed, spaceID)
+}
+
+// writableSpaceObject acquires the Space object of a space, by ID or alias,
+// to change its documents or ACL, see acquireSpaceObject. The copy of a
+// space the account left is read-only.
+func (sm *SpaceManager) writableSpaceObject(ctx context.Context, spaceID string) (commonspace.Space, func(), error) {
+	sm.mu.RLock()
+	resolved, exists := sm.lookup(spaceID)
+	left := exists && sm.spaces[resolved].LeftAt != 0
+	sm.mu.RUnlock()
+
+	if left {
+		return nil, nil, errSpaceLeft(resolved)
+	}
+	return sm.acquireSpaceObject(ctx, spaceID)
+}
+
+// recordLoadError remembers why a space failed to load, for SpaceStatuses.
+// Failures caused by the caller giving up are not the space's fault and are
+// not recorded. The caller must hold sm.mu for writing.
+func (sm *SpaceManager) recordLoadError(ctx context.Context, spaceID string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	sm.loadErrors[spaceID] = err.Error()
+}
+
 // ListSpaces returns all spaces.
 func (sm *SpaceManager) ListSpaces() []*SpaceMetadata {
 	sm.mu.RLock()
@@ -281,55 +525,115 @@ func (sm *SpaceManager) ListSpaces() []*SpaceMetadata {
 	return spaces
 }
 
-// GetSpace retrieves space metadata by ID.
+// GetSpace retrieves space metadata by ID or alias.
 func (sm *SpaceManager) GetSpace(spaceID string) (*SpaceMetadata, error) {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
-	space, exists := sm.spaces[spaceID]
+	resolved, exists := sm.lookup(spaceID)
 	if !exists {
-		return nil, fmt.Errorf("space not found: %s", spaceID)
+		return nil, errSpaceNotFound(spaceID)
 	}
+	space := sm.spaces[resolved]
 
 	// Return a copy
 	spaceCopy := *space
 	return &spaceCopy, nil
 }
 
-// DeleteSpace removes a space and its storage.
-func (sm *SpaceManager) DeleteSpace(spaceID string) error {
+// UpdateSpace applies update to a space, by ID or alias, and returns its new
+// metadata. Merged metadata entries with an empty value are removed. If
+// update.ExpectedUpdatedAt is non-zero and does not match the space, the
+// update is rejected with ErrVersionConflict. UpdatedAt grows with every
+// update, even within the same second, so clients can use it as a version.
+func (sm *SpaceManager) UpdateSpace(ctx context.Context, spaceID string, update SpaceUpdate) (*SpaceMetadata, error) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
-	// Check if space exists
-	if _, exists := sm.spaces[spaceID]; !exists {
-		return fmt.Errorf("space not found: %s", spaceID)
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	// Close Space object if open (catch panics from partially initialized spaces)
-	if space, ok := sm.spaceObjects[spaceID]; ok {
-		func() {
-			defer func() {
-				if r := recover(); r != nil {
-					// Ignore panics from closing partially initialized spaces
-				}
-			}()
-			space.Close()
-		}()
-		delete(sm.spaceObjects, spaceID)
+	resolved, exists := sm.lookup(spaceID)
+	if !exists {
+		return nil, errSpaceNotFound(spaceID)
+	}
+	old := sm.spaces[resolved]
+
+	// Optimistic concurrency check
+	if update.ExpectedUpdatedAt != 0 && update.ExpectedUpdatedAt != old.UpdatedAt {
+		return nil, &Error{
+			Kind:    ErrVersionConflict,
+			Message: fmt.Sprintf("version conflict: space %s was updated at %d, expected %d", resolved, old.UpdatedAt, update.ExpectedUpdatedAt),
+			Details: map[string]string{
+				"space_id":            resolved,
+				"current_updated_at":  strconv.FormatInt(old.UpdatedAt, 10),
+				"expected_updated_at": strconv.FormatInt(update.ExpectedUpdatedAt, 10),
+			},
+		}
 	}
 
-	// Remove storage database file
-	dbPath := filepath.Join(sm.storageDir, spaceID+".db")
-	if err := os.RemoveAll(dbPath); err != nil {
-		return fmt.Errorf("failed to remove space storage: %w", err)
+	// Copies returned by GetSpace and ListSpaces share the metadata map, so
+	// the update builds a new one
+	updated := *old
+	if update.Name != nil {
+		updated.Name = *update.Name
 	}
+	if update.ReplaceMetadata {
+		updated.Metadata = make(map[string]string, len(update.Metadata))
+	} else {
+		updated.Metadata = make(map[string]string, len(old.Metadata)+len(update.Metadata))
+		for key, value := range old.Metadata {
+			updated.Metadata[key] = value
+		}
+	}
+	for key, value := range update.Metadata {
+		if value == "" && !update.ReplaceMetadata {
+			delete(updated.Metadata, key)
+			continue
+		}
+		updated.Metadata[key] = value
+	}
+	updated.UpdatedAt = max(time.Now().Unix(), old.UpdatedAt+1)
 
-	// Remove from metadata
-	delete(sm.spaces, spaceID)
-
+	sm.spaces[resolved] = &updated
 	if err := sm.saveMetadata(); err != nil {
-		return fmt.Errorf("failed to save metadata: %w", err)
+		sm.spaces[resolved] = old
+		return nil, fmt.Errorf("failed to save metadata: %w", err)
+	}
+
+	// Emit space.updated event
+	oldMetadata, _ := json.Marshal(old.Metadata)
+	newMetadata, _ := json.Marshal(updated.Metadata)
+	sm.eventManager.EmitEvent(EventSpaceUpdated, resolved, map[string]string{
+		"old_name":       old.Name,
+		"new_name":       updated.Name,
+		"old_metadata":   string(oldMetadata),
+		"new_metadata":   string(newMetadata),
+		"old_updated_at": strconv.FormatInt(old.UpdatedAt, 10),
+		"new_updated_at": strconv.FormatInt(updated.UpdatedAt, 10),
+	})
+
+	spaceCopy := updated
+	return &spaceCopy, nil
+}
+
+// DeleteSpace removes a space, by ID or alias, its storage and the metadata
+// of its documents. The context is checked before anything is removed; once
+// deletion has started it runs to completion.
+func (sm *SpaceManager) DeleteSpace(ctx context.Context, spaceID string) error {
+	spaceID, err := sm.deleteSpace(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+
+	// Called without sm.mu, as the document manager calls the space manager
+	// with its own lock held
+	sm.mu.RLock()
+	onDelete := sm.onDelete
+	sm.mu.RUnlock()
+	if onDelete != nil {
+		onDelete(spaceID)
 	}
 
 	// Emit space.deleted event
@@ -338,6 +642,46 @@ func (sm *SpaceManager) DeleteSpace(spaceID string) error {
 	return nil
 }
 
+// deleteSpace removes the space and its files, and returns its ID.
+func (sm *SpaceManager) deleteSpace(ctx context.Context, spaceID string) (string, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	// Check if space exists
+	resolved, exists := sm.lookup(spaceID)
+	if !exists {
+		return "", errSpaceNotFound(spaceID)
+	}
+	spaceID = resolved
+
+	// Close Space object if open
+	sm.closeSpaceObject(spaceID)
+	delete(sm.loadErrors, spaceID)
+
+	// Remove storage database file
+	if err := sm.storageProvider.DeleteSpaceStorage(ctx, spaceID); err != nil {
+		return "", fmt.Errorf("failed to remove space storage: %w", err)
+	}
+
+	// Remove the document metadata, written by the document manager
+	documentsPath := filepath.Join(sm.dataDir, "documents", spaceID+".json")
+	if err := os.Remove(documentsPath); err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to remove document metadata: %w", err)
+	}
+
+	// Remove from metadata
+	delete(sm.spaces, spaceID)
+
+	if err := sm.saveMetadata(); err != nil {
+		return "", fmt.Errorf("failed to save metadata: %w", err)
+	}
+	return spaceID, nil
+}
+
 // loadMetadata loads space metadata from disk.
 func (sm *SpaceManager) loadMetadata() error {
 	metadataPath := filepath.Join(sm.dataDir, "spaces_metadata.json")
@@ -384,12 +728,90 @@ func (sm *SpaceManager) saveMetadata() error {
 	return nil
 }
 
-// Close closes all open spaces and shuts down the Any-Sync app.
+// SpaceStatus describes the local state of a space, for diagnostics.
+type SpaceStatus struct {
+	SpaceID      string
+	Name         string
+	Open         bool   // The Any-Sync space object is loaded
+	StorageBytes int64  // Size of the space storage on disk
+	LoadError    string // Why the space object last failed to load, if it did
+}
+
+// SpaceStatuses reports the local state of every space.
+func (sm *SpaceManager) SpaceStatuses() []*SpaceStatus {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	statuses := make([]*SpaceStatus, 0, len(sm.spaces))
+	for spaceID, space := range sm.spaces {
+		_, open := sm.spaceObjects[spaceID]
+		statuses = append(statuses, &SpaceStatus{
+			SpaceID:      spaceID,
+			Name:         space.Name,
+			Open:         open,
+			StorageBytes: diskUsage(filepath.Join(sm.storageDir, spaceID+".db")),
+			LoadError:    sm.loadErrors[spaceID],
+		})
+	}
+
+	return statuses
+}
+
+// OpenSpaceCount returns the number of loaded Any-Sync space objects.
+func (sm *SpaceManager) OpenSpaceCount() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return len(sm.spaceObjects)
+}
+
+// diskUsage returns the size of a file or directory tree, or 0 if it does not
+// exist. Entries that cannot be read are skipped.
+func diskUsage(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil && !entry.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// SetTransport connects the manager to other peers through transport, as the
+// peer ID of its device, so it can join spaces and serve the invites it
+// created. A nil transport disconnects it.
+func (sm *SpaceManager) SetTransport(transport Transport) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if sm.unregister != nil {
+		sm.unregister()
+		sm.unregister = nil
+	}
+	sm.transport = transport
+	if transport != nil {
+		sm.unregister = transport.Register(sm.keys.PeerId, sm)
+	}
+}
+
+// setOnDelete sets the func called with the ID of each deleted space.
+func (sm *SpaceManager) setOnDelete(onDelete func(spaceID string)) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.onDelete = onDelete
+}
+
 // GetDataDir returns the data directory path.
 func (sm *SpaceManager) GetDataDir() string {
 	return sm.dataDir
 }
 
+// Close closes all open spaces and shuts down the Any-Sync app.
 func (sm *SpaceManager) Close() error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -407,6 +829,13 @@ func (sm *SpaceManager) Close() error {
 		}()
 	}
 	sm.spaceObjects = make(map[string]commonspace.Space)
+	sm.lastUsed = make(map[string]uint64)
+	sm.inUse = make(map[string]int)
+
+	if sm.unregister != nil {
+		sm.unregister()
+		sm.unregister = nil
+	}
 
 	// Close the app (which closes all components)
 	if sm.app != nil {
@@ -1,10 +1,15 @@
 package anysync
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/anyproto/any-sync/commonspace/config"
 	"github.com/anyproto/any-sync/commonspace/object/accountdata"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,6 +36,151 @@ func TestNewSpaceManager(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestNewSpaceManagerWithConfig tests that spaces get the configured settings.
+func TestNewSpaceManagerWithConfig(t *testing.T) {
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+
+	spaceConfig := config.Config{GCTTL: 120, SyncPeriod: 30}
+	sm, err := NewSpaceManagerWithConfig(t.TempDir(), keys, NewEventManager(), spaceConfig)
+	require.NoError(t, err)
+	defer sm.Close()
+
+	got := sm.app.MustComponent("config").(config.ConfigGetter).GetSpace()
+	assert.Equal(t, spaceConfig, got)
+}
+
+// TestSpaceCacheSize tests that the least recently used spaces are closed
+// beyond the cache size and open again on use.
+func TestSpaceCacheSize(t *testing.T) {
+	ctx := context.Background()
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	sm, err := NewSpaceManager(t.TempDir(), keys, NewEventManager())
+	require.NoError(t, err)
+	defer sm.Close()
+	dm, err := NewDocumentManager(sm, keys, NewEventManager())
+	require.NoError(t, err)
+
+	for _, alias := range []string{"one", "two", "three"} {
+		_, err := sm.CreateSpace(ctx, alias, alias, nil)
+		require.NoError(t, err)
+	}
+	documentID, err := dm.CreateDocument(ctx, "one", "Note", []byte("note"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, sm.OpenSpaceCount())
+
+	// "one" was used last, so "two" and then "three" are closed
+	sm.SetSpaceCacheSize(1)
+	assert.Equal(t, 1, sm.OpenSpaceCount())
+	_, err = sm.GetSpaceObject(ctx, "one")
+	require.NoError(t, err)
+	assert.Equal(t, 1, sm.OpenSpaceCount())
+
+	sm.SetSpaceCacheSize(2)
+	_, err = sm.GetSpaceObject(ctx, "two")
+	require.NoError(t, err)
+	_, err = sm.GetSpaceObject(ctx, "three")
+	require.NoError(t, err)
+	assert.Equal(t, 2, sm.OpenSpaceCount())
+
+	// Reopening the closed space keeps its documents
+	data, _, err := dm.GetDocument(ctx, "one", documentID)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("note"), data)
+	assert.Equal(t, 2, sm.OpenSpaceCount())
+}
+
+// TestSpaceCacheSize_InUse tests that a space object in use is not closed to
+// make room for others, and is closed once released.
+func TestSpaceCacheSize_InUse(t *testing.T) {
+	ctx := context.Background()
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	sm, err := NewSpaceManager(t.TempDir(), keys, NewEventManager())
+	require.NoError(t, err)
+	defer sm.Close()
+
+	for _, alias := range []string{"one", "two"} {
+		_, err := sm.CreateSpace(ctx, alias, alias, nil)
+		require.NoError(t, err)
+	}
+	sm.SetSpaceCacheSize(1)
+
+	space, release, err := sm.acquireSpaceObject(ctx, "one")
+	require.NoError(t, err)
+	_, err = sm.GetSpaceObject(ctx, "two")
+	require.NoError(t, err)
+	assert.Equal(t, 2, sm.OpenSpaceCount())
+
+	// "two" is closed once "one" is used again, and then opened again
+	members, err := sm.ListMembers(ctx, "one")
+	require.NoError(t, err)
+	assert.Len(t, members, 1)
+	assert.Equal(t, 1, sm.OpenSpaceCount())
+	_, err = sm.GetSpaceObject(ctx, "two")
+	require.NoError(t, err)
+	assert.Equal(t, 2, sm.OpenSpaceCount())
+
+	release()
+	release()
+	assert.Equal(t, 1, sm.OpenSpaceCount())
+	reopened, err := sm.GetSpaceObject(ctx, "one")
+	require.NoError(t, err)
+	assert.NotSame(t, space, reopened)
+}
+
+// TestSpaceCacheSize_Concurrent tests that operations on different spaces
+// may run at once with a cache of a single space.
+func TestSpaceCacheSize_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	sm, err := NewSpaceManager(t.TempDir(), keys, NewEventManager())
+	require.NoError(t, err)
+	defer sm.Close()
+	dm, err := NewDocumentManager(sm, keys, NewEventManager())
+	require.NoError(t, err)
+
+	aliases := []string{"one", "two", "three", "four"}
+	for _, alias := range aliases {
+		_, err := sm.CreateSpace(ctx, alias, alias, nil)
+		require.NoError(t, err)
+	}
+	sm.SetSpaceCacheSize(1)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(aliases)*10)
+	for _, alias := range aliases {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 5 {
+				documentID, err := dm.CreateDocument(ctx, alias, "Note", []byte{byte(i)}, nil)
+				if err == nil {
+					_, _, err = dm.GetDocument(ctx, alias, documentID)
+				}
+				if err == nil {
+					_, err = sm.CreateInvite(ctx, alias, PermissionReader, 0)
+				}
+				if err == nil {
+					_, err = sm.ListMembers(ctx, alias)
+				}
+				if err != nil {
+					errs <- fmt.Errorf("space %s: %w", alias, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	assert.Equal(t, 1, sm.OpenSpaceCount())
+}
+
 // TestNewSpaceManager_NilKeys tests that creation fails without keys.
 func TestNewSpaceManager_NilKeys(t *testing.T) {
 	tempDir := t.TempDir()
@@ -52,7 +202,7 @@ func TestCreateSpace_Success(t *testing.T) {
 	defer sm.Close()
 
 	// Create a space
-	err = sm.CreateSpace("ref-1", "Test Space", map[string]string{
+	spaceID, err := sm.CreateSpace(context.Background(), "ref-1", "Test Space", map[string]string{
 		"description": "A test space",
 	})
 	require.NoError(t, err)
@@ -60,6 +210,8 @@ func TestCreateSpace_Success(t *testing.T) {
 	// Verify space exists in memory
 	spaces := sm.ListSpaces()
 	require.Len(t, spaces, 1)
+	assert.Equal(t, spaceID, spaces[0].SpaceID)
+	assert.Equal(t, "ref-1", spaces[0].Alias)
 	assert.Equal(t, "Test Space", spaces[0].Name)
 	assert.Equal(t, "A test space", spaces[0].Metadata["description"])
 	assert.Greater(t, spaces[0].CreatedAt, int64(0))
@@ -67,6 +219,53 @@ func TestCreateSpace_Success(t *testing.T) {
 	assert.NotEmpty(t, spaces[0].SpaceID) // ID is generated
 }
 
+// TestCreateSpace_Alias tests that an alias names its space everywhere and makes creation idempotent.
+func TestCreateSpace_Alias(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+
+	sm, err := NewSpaceManager(tempDir, keys, NewEventManager())
+	require.NoError(t, err)
+
+	// Spaces with the same name keep their own IDs
+	notesID, err := sm.CreateSpace(ctx, "notes", "Shared Name", nil)
+	require.NoError(t, err)
+	tasksID, err := sm.CreateSpace(ctx, "tasks", "Shared Name", nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, notesID, tasksID)
+
+	// A retry returns the existing space
+	retryID, err := sm.CreateSpace(ctx, "notes", "Other Name", nil)
+	require.NoError(t, err)
+	assert.Equal(t, notesID, retryID)
+	assert.Len(t, sm.ListSpaces(), 2)
+
+	assert.Equal(t, notesID, sm.ResolveSpaceID("notes"))
+	assert.Equal(t, notesID, sm.ResolveSpaceID(notesID))
+	assert.Equal(t, "unknown", sm.ResolveSpaceID("unknown"))
+	space, err := sm.GetSpace("notes")
+	require.NoError(t, err)
+	assert.Equal(t, notesID, space.SpaceID)
+	spaceObject, err := sm.GetSpaceObject(ctx, "notes")
+	require.NoError(t, err)
+	assert.Equal(t, notesID, spaceObject.Id())
+
+	// Aliases persist
+	require.NoError(t, sm.Close())
+	sm, err = NewSpaceManager(tempDir, keys, NewEventManager())
+	require.NoError(t, err)
+	defer sm.Close()
+	assert.Equal(t, tasksID, sm.ResolveSpaceID("tasks"))
+
+	require.NoError(t, sm.DeleteSpace(ctx, "tasks"))
+	_, err = sm.GetSpace(tasksID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = sm.GetSpace("tasks")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
 // TestCreateSpace_MultipleSpaces tests creating multiple spaces with unique IDs.
 func TestCreateSpace_MultipleSpaces(t *testing.T) {
 	tempDir := t.TempDir()
@@ -78,10 +277,10 @@ func TestCreateSpace_MultipleSpaces(t *testing.T) {
 	defer sm.Close()
 
 	// Create multiple spaces - each will get a unique generated ID
-	err = sm.CreateSpace("ref-1", "First Space", nil)
+	_, err = sm.CreateSpace(context.Background(), "ref-1", "First Space", nil)
 	require.NoError(t, err)
 
-	err = sm.CreateSpace("ref-2", "Second Space", nil)
+	_, err = sm.CreateSpace(context.Background(), "ref-2", "Second Space", nil)
 	require.NoError(t, err)
 
 	// Verify both spaces exist
@@ -121,13 +320,13 @@ func TestListSpaces_Multiple(t *testing.T) {
 	defer sm.Close()
 
 	// Create multiple spaces
-	err = sm.CreateSpace("ref-1", "Space 1", nil)
+	_, err = sm.CreateSpace(context.Background(), "ref-1", "Space 1", nil)
 	require.NoError(t, err)
 
-	err = sm.CreateSpace("ref-2", "Space 2", nil)
+	_, err = sm.CreateSpace(context.Background(), "ref-2", "Space 2", nil)
 	require.NoError(t, err)
 
-	err = sm.CreateSpace("ref-3", "Space 3", nil)
+	_, err = sm.CreateSpace(context.Background(), "ref-3", "Space 3", nil)
 	require.NoError(t, err)
 
 	// List and verify
@@ -155,7 +354,7 @@ func TestGetSpace_Success(t *testing.T) {
 	defer sm.Close()
 
 	// Create a space
-	err = sm.CreateSpace("ref-1", "Test Space", map[string]string{
+	_, err = sm.CreateSpace(context.Background(), "ref-1", "Test Space", map[string]string{
 		"key": "value",
 	})
 	require.NoError(t, err)
@@ -187,6 +386,77 @@ func TestGetSpace_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "space not found")
 }
 
+// TestUpdateSpace tests renaming a space and merging or replacing its metadata.
+func TestUpdateSpace(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+
+	em := NewEventManager()
+	defer em.Close()
+	sm, err := NewSpaceManager(tempDir, keys, em)
+	require.NoError(t, err)
+
+	spaceID, err := sm.CreateSpace(ctx, "notes", "Notes", map[string]string{"color": "blue", "icon": "book"})
+	require.NoError(t, err)
+	created, err := sm.GetSpace(spaceID)
+	require.NoError(t, err)
+
+	_, events, err := em.Subscribe(ctx, EventFilter{EventTypes: []EventType{EventSpaceUpdated}})
+	require.NoError(t, err)
+
+	// Rename and merge, by alias
+	name := "Journal"
+	updated, err := sm.UpdateSpace(ctx, "notes", SpaceUpdate{
+		Name:     &name,
+		Metadata: map[string]string{"color": "green", "icon": ""},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Journal", updated.Name)
+	assert.Equal(t, map[string]string{"color": "green"}, updated.Metadata)
+	assert.Greater(t, updated.UpdatedAt, created.UpdatedAt)
+	assert.Equal(t, created.CreatedAt, updated.CreatedAt)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, spaceID, event.SpaceID)
+		assert.Equal(t, "Notes", event.Payload["old_name"])
+		assert.Equal(t, "Journal", event.Payload["new_name"])
+		assert.JSONEq(t, `{"color":"blue","icon":"book"}`, event.Payload["old_metadata"])
+		assert.JSONEq(t, `{"color":"green"}`, event.Payload["new_metadata"])
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for space.updated")
+	}
+
+	// A stale updated_at is rejected
+	_, err = sm.UpdateSpace(ctx, spaceID, SpaceUpdate{ExpectedUpdatedAt: created.UpdatedAt})
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	// Replace, keeping the name
+	updated, err = sm.UpdateSpace(ctx, spaceID, SpaceUpdate{
+		Metadata:          map[string]string{"pinned": "true"},
+		ReplaceMetadata:   true,
+		ExpectedUpdatedAt: updated.UpdatedAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Journal", updated.Name)
+	assert.Equal(t, map[string]string{"pinned": "true"}, updated.Metadata)
+
+	_, err = sm.UpdateSpace(ctx, "missing", SpaceUpdate{Name: &name})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Updates persist
+	require.NoError(t, sm.Close())
+	sm, err = NewSpaceManager(tempDir, keys, em)
+	require.NoError(t, err)
+	defer sm.Close()
+	space, err := sm.GetSpace(spaceID)
+	require.NoError(t, err)
+	assert.Equal(t, "Journal", space.Name)
+	assert.Equal(t, updated.UpdatedAt, space.UpdatedAt)
+}
+
 // TestDeleteSpace_Success tests successful space deletion.
 func TestDeleteSpace_Success(t *testing.T) {
 	tempDir := t.TempDir()
@@ -198,7 +468,7 @@ func TestDeleteSpace_Success(t *testing.T) {
 	defer sm.Close()
 
 	// Create a space
-	err = sm.CreateSpace("ref-1", "Test Space", nil)
+	_, err = sm.CreateSpace(context.Background(), "ref-1", "Test Space", nil)
 	require.NoError(t, err)
 
 	// Get the actual space ID
@@ -206,8 +476,14 @@ func TestDeleteSpace_Success(t *testing.T) {
 	require.Len(t, spaces, 1)
 	actualSpaceID := spaces[0].SpaceID
 
+	// Add a document, so the space has document metadata
+	dm, err := NewDocumentManager(sm, keys, sm.eventManager)
+	require.NoError(t, err)
+	_, err = dm.CreateDocument(context.Background(), actualSpaceID, "Note", []byte("note"), nil)
+	require.NoError(t, err)
+
 	// Delete the space
-	err = sm.DeleteSpace(actualSpaceID)
+	err = sm.DeleteSpace(context.Background(), actualSpaceID)
 	require.NoError(t, err)
 
 	// Verify space is gone
@@ -218,6 +494,12 @@ func TestDeleteSpace_Success(t *testing.T) {
 	dbPath := filepath.Join(tempDir, "spaces", actualSpaceID+".db")
 	_, err = os.Stat(dbPath)
 	assert.True(t, os.IsNotExist(err))
+
+	// Verify the document metadata is gone
+	documents, err := dm.ListDocuments(context.Background(), actualSpaceID)
+	require.NoError(t, err)
+	assert.Empty(t, documents)
+	assert.NoFileExists(t, filepath.Join(tempDir, "documents", actualSpaceID+".json"))
 }
 
 // TestDeleteSpace_NotFound tests deleting a non-existent space.
@@ -230,7 +512,7 @@ func TestDeleteSpace_NotFound(t *testing.T) {
 	require.NoError(t, err)
 	defer sm.Close()
 
-	err = sm.DeleteSpace("non-existent-space")
+	err = sm.DeleteSpace(context.Background(), "non-existent-space")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "space not found")
 }
@@ -245,10 +527,10 @@ func TestSpaceManager_Persistence(t *testing.T) {
 	sm1, err := NewSpaceManager(tempDir, keys, NewEventManager())
 	require.NoError(t, err)
 
-	err = sm1.CreateSpace("ref-1", "Space 1", map[string]string{"key": "value1"})
+	_, err = sm1.CreateSpace(context.Background(), "ref-1", "Space 1", map[string]string{"key": "value1"})
 	require.NoError(t, err)
 
-	err = sm1.CreateSpace("ref-2", "Space 2", map[string]string{"key": "value2"})
+	_, err = sm1.CreateSpace(context.Background(), "ref-2", "Space 2", map[string]string{"key": "value2"})
 	require.NoError(t, err)
 
 	// Get actual space IDs
@@ -294,7 +576,7 @@ func TestSpaceManager_SpaceStorageCreated(t *testing.T) {
 	defer sm.Close()
 
 	// Create a space
-	err = sm.CreateSpace("ref-1", "Test Space", nil)
+	_, err = sm.CreateSpace(context.Background(), "ref-1", "Test Space", nil)
 	require.NoError(t, err)
 
 	// Get the actual space ID
@@ -321,7 +603,7 @@ func TestSpaceManager_ConcurrentAccess(t *testing.T) {
 
 	// Create some initial spaces
 	for i := 0; i < 5; i++ {
-		err = sm.CreateSpace("", "", nil)
+		_, err = sm.CreateSpace(context.Background(), "", "", nil)
 		if err != nil {
 			// Spaces should be created successfully
 			t.Logf("Warning: failed to create initial space: %v", err)
@@ -353,11 +635,11 @@ func TestSpaceManager_ConcurrentAccess(t *testing.T) {
 	// Goroutine 3: Create and delete spaces
 	go func() {
 		for i := 0; i < 10; i++ {
-			err := sm.CreateSpace("", "", nil)
+			_, err := sm.CreateSpace(context.Background(), "", "", nil)
 			if err == nil {
 				spaces := sm.ListSpaces()
 				if len(spaces) > 0 {
-					sm.DeleteSpace(spaces[len(spaces)-1].SpaceID)
+					sm.DeleteSpace(context.Background(), spaces[len(spaces)-1].SpaceID)
 				}
 			}
 		}
@@ -371,3 +653,41 @@ func TestSpaceManager_ConcurrentAccess(t *testing.T) {
 
 	// If we get here without race conditions, the test passes
 }
+
+// TestSpaceStatuses tests that statuses report loaded spaces, storage sizes and load failures.
+func TestSpaceStatuses(t *testing.T) {
+	tempDir := t.TempDir()
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+
+	sm, err := NewSpaceManager(tempDir, keys, NewEventManager())
+	require.NoError(t, err)
+	_, err = sm.CreateSpace(context.Background(), "ref-1", "Test Space", nil)
+	require.NoError(t, err)
+
+	statuses := sm.SpaceStatuses()
+	require.Len(t, statuses, 1)
+	spaceID := statuses[0].SpaceID
+	assert.Equal(t, "Test Space", statuses[0].Name)
+	assert.True(t, statuses[0].Open)
+	assert.Positive(t, statuses[0].StorageBytes)
+	assert.Empty(t, statuses[0].LoadError)
+	assert.Equal(t, 1, sm.OpenSpaceCount())
+	require.NoError(t, sm.Close())
+
+	// A space whose storage is gone fails to load, and the failure is kept
+	require.NoError(t, os.RemoveAll(filepath.Join(tempDir, "spaces", spaceID+".db")))
+	sm, err = NewSpaceManager(tempDir, keys, NewEventManager())
+	require.NoError(t, err)
+	defer sm.Close()
+
+	_, err = sm.GetSpaceObject(context.Background(), spaceID)
+	require.Error(t, err)
+
+	statuses = sm.SpaceStatuses()
+	require.Len(t, statuses, 1)
+	assert.False(t, statuses[0].Open)
+	assert.Zero(t, statuses[0].StorageBytes)
+	assert.Equal(t, err.Error(), statuses[0].LoadError)
+	assert.Zero(t, sm.OpenSpaceCount())
+}
@@ -0,0 +1,110 @@
+// Package anysync provides Any-Sync integration components.
+package anysync
+
+import (
+	"context"
+	"sync"
+)
+
+// Transport carries space traffic between the peers of different accounts,
+// such as joining a space with an invite. Peers are addressed by the peer ID
+// of their device.
+type Transport interface {
+	// Register makes handler reachable as peerID, until the returned func is
+	// called.
+	Register(peerID string, handler TransportHandler) (unregister func())
+	// FetchSpace returns the key material of a space from peerID, to a peer
+	// that proves it holds an invite to the space.
+	FetchSpace(ctx context.Context, peerID, spaceID string, proof *InviteProof) (*SpaceKeys, error)
+	// Join submits an ACL record that joins a space to peerID, which accepts
+	// it and returns the key material of the space with the record.
+	Join(ctx context.Context, peerID, spaceID string, record []byte) (*SpaceKeys, error)
+	// Leave submits an ACL record that requests to leave a space to peerID.
+	Leave(ctx context.Context, peerID, spaceID string, record []byte) error
+}
+
+// TransportHandler serves the requests a Transport delivers to a peer.
+// SpaceManager implements it.
+type TransportHandler interface {
+	HandleFetchSpace(ctx context.Context, spaceID string, proof *InviteProof) (*SpaceKeys, error)
+	HandleJoin(ctx context.Context, spaceID string, record []byte) (*SpaceKeys, error)
+	HandleLeave(ctx context.Context, spaceID string, record []byte) error
+}
+
+// MemoryTransport is a Transport between the space managers of one process,
+// for tests and for hosts that run several backends.
+type MemoryTransport struct {
+	mu    sync.RWMutex
+	peers map[string]TransportHandler
+}
+
+// NewMemoryTransport creates a transport with no peers.
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{peers: make(map[string]TransportHandler)}
+}
+
+// Register makes handler reachable as peerID, replacing a previous handler.
+func (t *MemoryTransport) Register(peerID string, handler TransportHandler) func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.peers[peerID] = handler
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		// A newer registration of the peer stays
+		if t.peers[peerID] == handler {
+			delete(t.peers, peerID)
+		}
+	}
+}
+
+// FetchSpace calls HandleFetchSpace on the handler of peerID.
+func (t *MemoryTransport) FetchSpace(ctx context.Context, peerID, spaceID string, proof *InviteProof) (*SpaceKeys, error) {
+	handler, err := t.peer(peerID)
+	if err != nil {
+		return nil, err
+	}
+	return handler.HandleFetchSpace(ctx, spaceID, proof)
+}
+
+// Join calls HandleJoin on the handler of peerID.
+func (t *MemoryTransport) Join(ctx context.Context, peerID, spaceID string, record []byte) (*SpaceKeys, error) {
+	handler, err := t.peer(peerID)
+	if err != nil {
+		return nil, err
+	}
+	return handler.HandleJoin(ctx, spaceID, record)
+}
+
+// Leave calls HandleLeave on the handler of peerID.
+func (t *MemoryTransport) Leave(ctx context.Context, peerID, spaceID string, record []byte) error {
+	handler, err := t.peer(peerID)
+	if err != nil {
+		return err
+	}
+	return handler.HandleLeave(ctx, spaceID, record)
+}
+
+// peer returns the handler of peerID, or an ErrUnavailable error.
+func (t *MemoryTransport) peer(peerID string) (TransportHandler, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	handler, ok := t.peers[peerID]
+	if !ok {
+		return nil, errPeerUnavailable(peerID)
+	}
+	return handler, nil
+}
+
+// errPeerUnavailable returns an ErrUnavailable error for a peer that cannot
+// be reached.
+func errPeerUnavailable(peerID string) error {
+	return &Error{
+		Kind:    ErrUnavailable,
+		Message: "peer is not reachable: " + peerID,
+		Details: map[string]string{"peer_id": peerID},
+	}
+}
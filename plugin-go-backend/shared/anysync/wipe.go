@@ -0,0 +1,122 @@
+// Package anysync provides Any-Sync integration components.
+package anysync
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// accountFiles are the files and directories of an account in its data
+// directory, besides the keys. Anything else, such as the profiles of a
+// profile host, is left alone.
+var accountFiles = []string{
+	"spaces",               // Space storages (SQLite databases)
+	"documents",            // Document metadata per space
+	"spaces_metadata.json", // Space names and metadata
+	devicesFile,
+}
+
+// WipeReport lists what WipeAccount removed.
+type WipeReport struct {
+	Files []string // Paths relative to the data directory
+	Keys  []string // Names removed from the key store
+	Bytes int64    // Total size of the removed files
+}
+
+// WipeAccount removes the account of dataDir: its keys from store, and the
+// storage of its spaces, the document and space metadata and the device
+// registry from dataDir. Files are overwritten with zeros before they are
+// removed, on a best-effort basis: a copy-on-write or journaling file system
+// may keep the old blocks. It removes as much as it can and reports what it
+// removed, along with the errors of what it could not.
+func WipeAccount(dataDir string, store KeyStore) (*WipeReport, error) {
+	report := &WipeReport{}
+	var errs []error
+
+	for _, name := range []string{accountKeyFile, deviceKeyFile} {
+		exists, err := store.Exists(name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to check key %s: %w", name, err))
+			continue
+		}
+		if !exists {
+			continue
+		}
+		if err := store.Delete(name); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete key %s: %w", name, err))
+			continue
+		}
+		report.Keys = append(report.Keys, name)
+	}
+
+	for _, name := range accountFiles {
+		root := filepath.Join(dataDir, name)
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil {
+				errs = append(errs, err)
+				return nil
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			size, err := shredFile(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to remove %s: %w", path, err))
+				return nil
+			}
+			rel, _ := filepath.Rel(dataDir, path)
+			report.Files = append(report.Files, filepath.ToSlash(rel))
+			report.Bytes += size
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+		// The directories are empty now, unless a file could not be removed
+		if err := os.RemoveAll(root); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove %s: %w", root, err))
+		}
+	}
+
+	sort.Strings(report.Files)
+	return report, errors.Join(errs...)
+}
+
+// shredFile overwrites the file at path with zeros, then removes it, and
+// returns its size. A failed overwrite does not prevent the removal.
+func shredFile(path string) (int64, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return 0, err
+	}
+
+	if info.Mode().IsRegular() {
+		if file, err := os.OpenFile(path, os.O_WRONLY, 0); err == nil {
+			if _, err := io.CopyN(file, zeroReader{}, info.Size()); err == nil {
+				_ = file.Sync()
+			}
+			file.Close()
+		}
+	}
+
+	if err := os.Remove(path); err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// zeroReader reads an endless stream of zeros.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
@@ -0,0 +1,61 @@
+package anysync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWipeAccount tests that the keys and account files are removed and everything else is kept.
+func TestWipeAccount(t *testing.T) {
+	dataDir := t.TempDir()
+	am := NewAccountManager(dataDir)
+	require.NoError(t, am.GenerateKeys())
+	require.NoError(t, am.StoreKeys())
+
+	files := map[string]string{
+		"spaces/space-1.db":         "database",
+		"spaces/space-1.db-wal":     "wal",
+		"documents/space-1.json":    "{}",
+		"spaces_metadata.json":      "{}",
+		"devices.json":              "{}",
+		"profile.json":              "{}",
+		"profiles/work/account.key": "other account",
+	}
+	for name, content := range files {
+		path := filepath.Join(dataDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+
+	report, err := WipeAccount(dataDir, NewFileKeyStore(dataDir))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"account.key", "device.key"}, report.Keys)
+	assert.Equal(t, []string{
+		"devices.json",
+		"documents/space-1.json",
+		"spaces/space-1.db",
+		"spaces/space-1.db-wal",
+		"spaces_metadata.json",
+	}, report.Files)
+	assert.Equal(t, int64(len("database")+len("wal")+3*len("{}")), report.Bytes)
+
+	assert.False(t, am.KeysExist())
+	for _, name := range []string{"spaces", "documents", "spaces_metadata.json", "devices.json"} {
+		_, err := os.Stat(filepath.Join(dataDir, name))
+		assert.True(t, os.IsNotExist(err), name)
+	}
+	for _, name := range []string{"profile.json", "profiles/work/account.key"} {
+		_, err := os.Stat(filepath.Join(dataDir, name))
+		assert.NoError(t, err, name)
+	}
+
+	// Nothing is left to remove
+	report, err = WipeAccount(dataDir, NewFileKeyStore(dataDir))
+	require.NoError(t, err)
+	assert.Empty(t, report.Keys)
+	assert.Empty(t, report.Files)
+}
@@ -3,24 +3,65 @@ package dispatcher
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"sync"
 
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Errors returned by Dispatch before a handler runs.
+var (
+	// ErrUnknownCommand is returned when no handler is registered for a command.
+	ErrUnknownCommand = errors.New("unknown command")
+	// ErrInvalidPayload is returned when the payload cannot be decoded into the request type.
+	ErrInvalidPayload = errors.New("invalid payload")
+	// ErrCommandExists is returned by RegisterNew when the command is already registered.
+	ErrCommandExists = errors.New("command already registered")
+	// ErrCommandKind is returned when a streaming command is passed to
+	// Dispatch, or a unary one to DispatchStream.
+	ErrCommandKind = errors.New("wrong command kind")
 )
 
 // Handler is a function that processes a command.
 // It receives the deserialized request message and returns a response message or error.
 type Handler func(ctx context.Context, req proto.Message) (proto.Message, error)
 
+// Middleware intercepts a command on its way to the handler.
+// It receives the command name and the decoded request, and must call next
+// to continue the chain. It can inspect or replace the response and error.
+type Middleware func(ctx context.Context, command string, req proto.Message, next Handler) (proto.Message, error)
+
 // Dispatcher routes commands to their handlers.
+// It is safe to register commands and middleware while commands are being
+// dispatched; a command registered during a Dispatch call is visible to the
+// next one.
 type Dispatcher struct {
-	handlers map[string]HandlerEntry
+	mu               sync.RWMutex
+	handlers         map[string]HandlerEntry
+	middleware       []Middleware
+	streamMiddleware []StreamMiddleware
 }
 
 // HandlerEntry contains the handler function and message types for a command.
+// Exactly one of Handler and StreamHandler is set.
 type HandlerEntry struct {
-	Handler     Handler
-	RequestType proto.Message // Used for creating new instances of the request type
+	Handler       Handler
+	StreamHandler StreamHandler
+	RequestType   proto.Message // Used for creating new instances of the request type
+	ResponseType  proto.Message // Describes the response message; never instantiated
+}
+
+// CommandInfo describes a registered command.
+type CommandInfo struct {
+	Name         string // Command name passed to Dispatch
+	RequestType  string // Fully-qualified request message name
+	ResponseType string // Fully-qualified response message name
+	Streaming    bool   // Whether the command streams responses (see DispatchStream)
 }
 
 // New creates a new dispatcher.
@@ -31,30 +72,67 @@ func New() *Dispatcher {
 }
 
 // Register registers a handler for a command.
-// requestType should be a zero-value instance of the request message type.
-func (d *Dispatcher) Register(command string, handler Handler, requestType proto.Message) {
+// requestType and responseType should be zero-value instances of the request
+// and response message types.
+func (d *Dispatcher) Register(command string, handler Handler, requestType, responseType proto.Message) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.handlers[command] = HandlerEntry{
+		Handler:      handler,
+		RequestType:  requestType,
+		ResponseType: responseType,
+	}
+}
+
+// RegisterNew is like Register but fails with ErrCommandExists instead of
+// replacing an existing handler.
+func (d *Dispatcher) RegisterNew(command string, handler Handler, requestType, responseType proto.Message) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, exists := d.handlers[command]; exists {
+		return fmt.Errorf("%w: %s", ErrCommandExists, command)
+	}
 	d.handlers[command] = HandlerEntry{
-		Handler:     handler,
-		RequestType: requestType,
+		Handler:      handler,
+		RequestType:  requestType,
+		ResponseType: responseType,
 	}
+	return nil
+}
+
+// Use appends middleware to the chain wrapped around every handler.
+// Middleware runs in the order it was added: the first one added is the outermost.
+func (d *Dispatcher) Use(middleware ...Middleware) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.middleware = append(d.middleware, middleware...)
 }
 
 // Dispatch routes a command to its handler.
 // Returns the serialized response or an error.
 func (d *Dispatcher) Dispatch(ctx context.Context, command string, payload []byte) ([]byte, error) {
+	d.mu.RLock()
 	entry, ok := d.handlers[command]
+	middleware := d.middleware
+	d.mu.RUnlock()
+
 	if !ok {
-		return nil, fmt.Errorf("unknown command: %s", command)
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCommand, command)
+	}
+	if entry.Handler == nil {
+		return nil, fmt.Errorf("%w: %s is a streaming command, use DispatchStream", ErrCommandKind, command)
 	}
 
-	// Create a new instance of the request type
-	req := proto.Clone(entry.RequestType)
-	if err := proto.Unmarshal(payload, req); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal request: %w", err)
+	req, err := entry.newRequest(payload)
+	if err != nil {
+		return nil, err
 	}
 
-	// Call the handler
-	resp, err := entry.Handler(ctx, req)
+	// Call the handler through the middleware chain
+	resp, err := chain(middleware, command, entry.Handler)(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -68,11 +146,90 @@ func (d *Dispatcher) Dispatch(ctx context.Context, command string, payload []byt
 	return respBytes, nil
 }
 
+// newRequest decodes payload into a new instance of the request type.
+func (e HandlerEntry) newRequest(payload []byte) (proto.Message, error) {
+	req := proto.Clone(e.RequestType)
+	if err := proto.Unmarshal(payload, req); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal request: %w", ErrInvalidPayload, err)
+	}
+	return req, nil
+}
+
+// chain wraps handler with middleware for the given command.
+func chain(middleware []Middleware, command string, handler Handler) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		mw, next := middleware[i], handler
+		handler = func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return mw(ctx, command, req, next)
+		}
+	}
+	return handler
+}
+
 // Commands returns the list of registered commands.
 func (d *Dispatcher) Commands() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
 	commands := make([]string, 0, len(d.handlers))
 	for cmd := range d.handlers {
 		commands = append(commands, cmd)
 	}
 	return commands
 }
+
+// Describe returns the registered commands with their message types, sorted by name.
+func (d *Dispatcher) Describe() []CommandInfo {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	infos := make([]CommandInfo, 0, len(d.handlers))
+	for cmd, entry := range d.handlers {
+		infos = append(infos, CommandInfo{
+			Name:         cmd,
+			RequestType:  string(entry.RequestType.ProtoReflect().Descriptor().FullName()),
+			ResponseType: string(entry.ResponseType.ProtoReflect().Descriptor().FullName()),
+			Streaming:    entry.StreamHandler != nil,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// FileDescriptorSet returns the proto files that define the request and
+// response messages of all registered commands, including their imports.
+// Files are ordered so that every file comes after its dependencies.
+func (d *Dispatcher) FileDescriptorSet() *descriptorpb.FileDescriptorSet {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+	}
+
+	commands := make([]string, 0, len(d.handlers))
+	for cmd := range d.handlers {
+		commands = append(commands, cmd)
+	}
+	sort.Strings(commands)
+
+	for _, cmd := range commands {
+		entry := d.handlers[cmd]
+		add(entry.RequestType.ProtoReflect().Descriptor().ParentFile())
+		add(entry.ResponseType.ProtoReflect().Descriptor().ParentFile())
+	}
+
+	return set
+}
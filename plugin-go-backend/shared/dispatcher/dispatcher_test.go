@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -17,7 +19,7 @@ func TestDispatcher_Register(t *testing.T) {
 		return &emptypb.Empty{}, nil
 	}
 
-	d.Register("test", handler, &emptypb.Empty{})
+	d.Register("test", handler, &emptypb.Empty{}, &emptypb.Empty{})
 
 	commands := d.Commands()
 	if len(commands) != 1 {
@@ -28,6 +30,37 @@ func TestDispatcher_Register(t *testing.T) {
 	}
 }
 
+func TestDispatcher_RegisterNew(t *testing.T) {
+	d := New()
+
+	first := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		return wrapperspb.String("first"), nil
+	}
+	second := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		return wrapperspb.String("second"), nil
+	}
+
+	if err := d.RegisterNew("test", first, &emptypb.Empty{}, &wrapperspb.StringValue{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.RegisterNew("test", second, &emptypb.Empty{}, &wrapperspb.StringValue{}); !errors.Is(err, ErrCommandExists) {
+		t.Errorf("expected ErrCommandExists, got %v", err)
+	}
+
+	// The original handler is kept
+	respBytes, err := d.Dispatch(context.Background(), "test", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var resp wrapperspb.StringValue
+	if err := proto.Unmarshal(respBytes, &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Value != "first" {
+		t.Errorf("expected 'first', got '%s'", resp.Value)
+	}
+}
+
 func TestDispatcher_Dispatch_Success(t *testing.T) {
 	d := New()
 
@@ -37,7 +70,7 @@ func TestDispatcher_Dispatch_Success(t *testing.T) {
 		return wrapperspb.String(stringReq.Value + "_response"), nil
 	}
 
-	d.Register("echo", handler, &wrapperspb.StringValue{})
+	d.Register("echo", handler, &wrapperspb.StringValue{}, &wrapperspb.StringValue{})
 
 	req := wrapperspb.String("hello")
 	reqBytes, _ := proto.Marshal(req)
@@ -70,6 +103,9 @@ func TestDispatcher_Dispatch_UnknownCommand(t *testing.T) {
 	if err.Error() != expectedErr {
 		t.Errorf("expected error '%s', got '%s'", expectedErr, err.Error())
 	}
+	if !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("expected ErrUnknownCommand, got %v", err)
+	}
 }
 
 func TestDispatcher_Dispatch_InvalidPayload(t *testing.T) {
@@ -79,13 +115,16 @@ func TestDispatcher_Dispatch_InvalidPayload(t *testing.T) {
 		return &emptypb.Empty{}, nil
 	}
 
-	d.Register("test", handler, &emptypb.Empty{})
+	d.Register("test", handler, &emptypb.Empty{}, &emptypb.Empty{})
 
 	// Invalid protobuf payload
 	_, err := d.Dispatch(context.Background(), "test", []byte{0xFF, 0xFF})
 	if err == nil {
 		t.Fatal("expected error for invalid payload")
 	}
+	if !errors.Is(err, ErrInvalidPayload) {
+		t.Errorf("expected ErrInvalidPayload, got %v", err)
+	}
 }
 
 func TestDispatcher_Dispatch_HandlerError(t *testing.T) {
@@ -96,7 +135,7 @@ func TestDispatcher_Dispatch_HandlerError(t *testing.T) {
 		return nil, expectedErr
 	}
 
-	d.Register("test", handler, &emptypb.Empty{})
+	d.Register("test", handler, &emptypb.Empty{}, &emptypb.Empty{})
 
 	req := &emptypb.Empty{}
 	reqBytes, _ := proto.Marshal(req)
@@ -110,3 +149,382 @@ func TestDispatcher_Dispatch_HandlerError(t *testing.T) {
 		t.Errorf("expected error '%v', got '%v'", expectedErr, err)
 	}
 }
+
+func TestDispatcher_Use_MiddlewareOrder(t *testing.T) {
+	d := New()
+
+	var calls []string
+	record := func(name string) Middleware {
+		return func(ctx context.Context, command string, req proto.Message, next Handler) (proto.Message, error) {
+			calls = append(calls, name+":before")
+			resp, err := next(ctx, req)
+			calls = append(calls, name+":after")
+			return resp, err
+		}
+	}
+
+	handler := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		calls = append(calls, "handler")
+		return &emptypb.Empty{}, nil
+	}
+
+	d.Register("test", handler, &emptypb.Empty{}, &emptypb.Empty{})
+	d.Use(record("outer"), record("inner"))
+
+	reqBytes, _ := proto.Marshal(&emptypb.Empty{})
+	if _, err := d.Dispatch(context.Background(), "test", reqBytes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"outer:before", "inner:before", "handler", "inner:after", "outer:after"}
+	if len(calls) != len(expected) {
+		t.Fatalf("expected calls %v, got %v", expected, calls)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Errorf("call %d: expected '%s', got '%s'", i, expected[i], calls[i])
+		}
+	}
+}
+
+func TestDispatcher_Use_MiddlewareSeesCommandAndMessages(t *testing.T) {
+	d := New()
+
+	handler := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		return wrapperspb.String(req.(*wrapperspb.StringValue).Value + "_response"), nil
+	}
+	d.Register("echo", handler, &wrapperspb.StringValue{}, &wrapperspb.StringValue{})
+
+	var gotCommand, gotReq, gotResp string
+	d.Use(func(ctx context.Context, command string, req proto.Message, next Handler) (proto.Message, error) {
+		gotCommand = command
+		gotReq = req.(*wrapperspb.StringValue).Value
+		resp, err := next(ctx, req)
+		if err == nil {
+			gotResp = resp.(*wrapperspb.StringValue).Value
+		}
+		return resp, err
+	})
+
+	reqBytes, _ := proto.Marshal(wrapperspb.String("hello"))
+	if _, err := d.Dispatch(context.Background(), "echo", reqBytes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotCommand != "echo" {
+		t.Errorf("expected command 'echo', got '%s'", gotCommand)
+	}
+	if gotReq != "hello" {
+		t.Errorf("expected request 'hello', got '%s'", gotReq)
+	}
+	if gotResp != "hello_response" {
+		t.Errorf("expected response 'hello_response', got '%s'", gotResp)
+	}
+}
+
+func TestDispatcher_Use_MiddlewareShortCircuit(t *testing.T) {
+	d := New()
+
+	handlerCalled := false
+	handler := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		handlerCalled = true
+		return &emptypb.Empty{}, nil
+	}
+	d.Register("test", handler, &emptypb.Empty{}, &emptypb.Empty{})
+
+	denied := errors.New("denied")
+	d.Use(func(ctx context.Context, command string, req proto.Message, next Handler) (proto.Message, error) {
+		return nil, denied
+	})
+
+	reqBytes, _ := proto.Marshal(&emptypb.Empty{})
+	_, err := d.Dispatch(context.Background(), "test", reqBytes)
+	if !errors.Is(err, denied) {
+		t.Errorf("expected error '%v', got '%v'", denied, err)
+	}
+	if handlerCalled {
+		t.Error("expected handler not to be called")
+	}
+}
+
+func TestDispatcher_Use_MiddlewareSeesHandlerError(t *testing.T) {
+	d := New()
+
+	expectedErr := errors.New("handler error")
+	handler := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		return nil, expectedErr
+	}
+	d.Register("test", handler, &emptypb.Empty{}, &emptypb.Empty{})
+
+	var seenErr error
+	d.Use(func(ctx context.Context, command string, req proto.Message, next Handler) (proto.Message, error) {
+		resp, err := next(ctx, req)
+		seenErr = err
+		return resp, err
+	})
+
+	reqBytes, _ := proto.Marshal(&emptypb.Empty{})
+	if _, err := d.Dispatch(context.Background(), "test", reqBytes); !errors.Is(err, expectedErr) {
+		t.Errorf("expected error '%v', got '%v'", expectedErr, err)
+	}
+	if !errors.Is(seenErr, expectedErr) {
+		t.Errorf("expected middleware to see '%v', got '%v'", expectedErr, seenErr)
+	}
+}
+
+func TestTimeout_AbortsSlowCommand(t *testing.T) {
+	d := New()
+
+	handler := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	d.Register("slow", handler, &emptypb.Empty{}, &emptypb.Empty{})
+	d.Use(Timeout(func(command string) time.Duration {
+		if command != "slow" {
+			t.Errorf("expected command 'slow', got '%s'", command)
+		}
+		return 10 * time.Millisecond
+	}))
+
+	reqBytes, _ := proto.Marshal(&emptypb.Empty{})
+	_, err := d.Dispatch(context.Background(), "slow", reqBytes)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestTimeout_CallerDeadlineTakesPrecedence(t *testing.T) {
+	d := New()
+
+	callerDeadline := time.Now().Add(time.Hour)
+	var gotDeadline time.Time
+	handler := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		gotDeadline, _ = ctx.Deadline()
+		return &emptypb.Empty{}, nil
+	}
+	d.Register("test", handler, &emptypb.Empty{}, &emptypb.Empty{})
+	d.Use(Timeout(func(string) time.Duration { return time.Millisecond }))
+
+	ctx, cancel := context.WithDeadline(context.Background(), callerDeadline)
+	defer cancel()
+
+	reqBytes, _ := proto.Marshal(&emptypb.Empty{})
+	if _, err := d.Dispatch(ctx, "test", reqBytes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !gotDeadline.Equal(callerDeadline) {
+		t.Errorf("expected caller deadline %v, got %v", callerDeadline, gotDeadline)
+	}
+}
+
+func TestTimeout_NoLimit(t *testing.T) {
+	d := New()
+
+	hasDeadline := true
+	handler := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		_, hasDeadline = ctx.Deadline()
+		return &emptypb.Empty{}, nil
+	}
+	d.Register("test", handler, &emptypb.Empty{}, &emptypb.Empty{})
+	d.Use(Timeout(func(string) time.Duration { return 0 }))
+
+	reqBytes, _ := proto.Marshal(&emptypb.Empty{})
+	if _, err := d.Dispatch(context.Background(), "test", reqBytes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hasDeadline {
+		t.Error("expected no deadline when the timeout is zero")
+	}
+}
+
+func TestRecover_HandlerPanic(t *testing.T) {
+	d := New()
+
+	d.Register("explode", func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		var m map[string]string
+		m["boom"] = "x" // assignment to nil map
+		return &emptypb.Empty{}, nil
+	}, &emptypb.Empty{}, &emptypb.Empty{})
+	d.Register("ok", func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		return &emptypb.Empty{}, nil
+	}, &emptypb.Empty{}, &emptypb.Empty{})
+
+	var reported *PanicError
+	d.Use(Recover(func(err *PanicError) { reported = err }))
+
+	reqBytes, _ := proto.Marshal(&emptypb.Empty{})
+	_, err := d.Dispatch(context.Background(), "explode", reqBytes)
+	if !errors.Is(err, ErrPanic) {
+		t.Fatalf("expected ErrPanic, got %v", err)
+	}
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("expected *PanicError, got %T", err)
+	}
+	if panicErr.Command != "explode" {
+		t.Errorf("expected command 'explode', got '%s'", panicErr.Command)
+	}
+	if len(panicErr.Stack) == 0 {
+		t.Error("expected stack to be captured")
+	}
+	if reported != panicErr {
+		t.Error("expected onPanic to receive the returned error")
+	}
+
+	// The dispatcher keeps serving other commands
+	if _, err := d.Dispatch(context.Background(), "ok", reqBytes); err != nil {
+		t.Errorf("unexpected error after panic: %v", err)
+	}
+}
+
+func TestDispatcher_DispatchStream(t *testing.T) {
+	d := New()
+
+	// Streams the request value n times, where n is the request length
+	handler := func(ctx context.Context, req proto.Message, send func(proto.Message) error) error {
+		value := req.(*wrapperspb.StringValue).Value
+		for i := 0; i < len(value); i++ {
+			if err := send(wrapperspb.String(value[:i+1])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	d.RegisterStream("prefixes", handler, &wrapperspb.StringValue{}, &wrapperspb.StringValue{})
+
+	var calls []string
+	d.UseStream(func(ctx context.Context, command string, req proto.Message, send func(proto.Message) error, next StreamHandler) error {
+		calls = append(calls, command)
+		return next(ctx, req, send)
+	})
+
+	reqBytes, _ := proto.Marshal(wrapperspb.String("abc"))
+	var got []string
+	err := d.DispatchStream(context.Background(), "prefixes", reqBytes, func(msgBytes []byte) error {
+		var msg wrapperspb.StringValue
+		if err := proto.Unmarshal(msgBytes, &msg); err != nil {
+			return err
+		}
+		got = append(got, msg.Value)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"a", "ab", "abc"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected messages %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("message %d: expected '%s', got '%s'", i, expected[i], got[i])
+		}
+	}
+	if len(calls) != 1 || calls[0] != "prefixes" {
+		t.Errorf("expected stream middleware to see 'prefixes' once, got %v", calls)
+	}
+
+	// A failing send stops the stream
+	stop := errors.New("stop")
+	err = d.DispatchStream(context.Background(), "prefixes", reqBytes, func([]byte) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Errorf("expected send error, got %v", err)
+	}
+}
+
+func TestDispatcher_DispatchStream_CommandKind(t *testing.T) {
+	d := New()
+
+	d.Register("unary", func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		return &emptypb.Empty{}, nil
+	}, &emptypb.Empty{}, &emptypb.Empty{})
+	d.RegisterStream("stream", func(ctx context.Context, req proto.Message, send func(proto.Message) error) error {
+		return nil
+	}, &emptypb.Empty{}, &emptypb.Empty{})
+
+	if _, err := d.Dispatch(context.Background(), "stream", nil); !errors.Is(err, ErrCommandKind) {
+		t.Errorf("Dispatch of a stream: expected ErrCommandKind, got %v", err)
+	}
+	err := d.DispatchStream(context.Background(), "unary", nil, func([]byte) error { return nil })
+	if !errors.Is(err, ErrCommandKind) {
+		t.Errorf("DispatchStream of a unary command: expected ErrCommandKind, got %v", err)
+	}
+	err = d.DispatchStream(context.Background(), "unknown", nil, func([]byte) error { return nil })
+	if !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("expected ErrUnknownCommand, got %v", err)
+	}
+
+	for _, info := range d.Describe() {
+		if info.Streaming != (info.Name == "stream") {
+			t.Errorf("%s: unexpected Streaming=%v", info.Name, info.Streaming)
+		}
+	}
+}
+
+func TestRecoverStream_HandlerPanic(t *testing.T) {
+	d := New()
+
+	d.RegisterStream("explode", func(ctx context.Context, req proto.Message, send func(proto.Message) error) error {
+		panic("boom")
+	}, &emptypb.Empty{}, &emptypb.Empty{})
+
+	var reported *PanicError
+	d.UseStream(RecoverStream(func(err *PanicError) { reported = err }))
+
+	err := d.DispatchStream(context.Background(), "explode", nil, func([]byte) error { return nil })
+	if !errors.Is(err, ErrPanic) {
+		t.Fatalf("expected ErrPanic, got %v", err)
+	}
+	if reported == nil || reported.Command != "explode" {
+		t.Errorf("expected onPanic for 'explode', got %v", reported)
+	}
+}
+
+func TestDispatcher_Describe(t *testing.T) {
+	d := New()
+
+	handler := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		return &emptypb.Empty{}, nil
+	}
+
+	d.Register("b", handler, &wrapperspb.StringValue{}, &emptypb.Empty{})
+	d.Register("a", handler, &emptypb.Empty{}, &emptypb.Empty{})
+
+	infos := d.Describe()
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 commands, got %d", len(infos))
+	}
+	if infos[0].Name != "a" || infos[1].Name != "b" {
+		t.Errorf("expected commands sorted by name, got %s, %s", infos[0].Name, infos[1].Name)
+	}
+	if infos[1].RequestType != "google.protobuf.StringValue" {
+		t.Errorf("expected request type 'google.protobuf.StringValue', got '%s'", infos[1].RequestType)
+	}
+	if infos[1].ResponseType != "google.protobuf.Empty" {
+		t.Errorf("expected response type 'google.protobuf.Empty', got '%s'", infos[1].ResponseType)
+	}
+}
+
+func TestDispatcher_FileDescriptorSet(t *testing.T) {
+	d := New()
+
+	handler := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		return &emptypb.Empty{}, nil
+	}
+
+	d.Register("a", handler, &wrapperspb.StringValue{}, &emptypb.Empty{})
+	d.Register("b", handler, &emptypb.Empty{}, &emptypb.Empty{})
+
+	set := d.FileDescriptorSet()
+	if len(set.File) != 2 {
+		t.Fatalf("expected 2 files (each included once), got %d", len(set.File))
+	}
+
+	// The set must be self-contained
+	if _, err := protodesc.NewFiles(set); err != nil {
+		t.Errorf("file descriptor set is not self-contained: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrPanic is matched by the error returned for a command whose handler panicked.
+var ErrPanic = errors.New("panic")
+
+// PanicError is returned by the Recover middleware when a handler panics.
+type PanicError struct {
+	Command string // Command whose handler panicked
+	Value   any    // Value passed to panic
+	Stack   []byte // Stack of the panicking goroutine; not part of Error()
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic in %s: %v", e.Command, e.Value)
+}
+
+// Is reports whether target is ErrPanic.
+func (e *PanicError) Is(target error) bool {
+	return target == ErrPanic
+}
+
+// Recover returns middleware that turns a panic in the rest of the chain into
+// a *PanicError, so one faulty command cannot take down the process. onPanic,
+// if not nil, is called with the error before it is returned; use it to log
+// the stack or notify clients.
+//
+// Add it before other middleware so it also covers them.
+func Recover(onPanic func(err *PanicError)) Middleware {
+	return func(ctx context.Context, command string, req proto.Message, next Handler) (resp proto.Message, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				panicErr := &PanicError{Command: command, Value: r, Stack: debug.Stack()}
+				if onPanic != nil {
+					onPanic(panicErr)
+				}
+				resp, err = nil, panicErr
+			}
+		}()
+		return next(ctx, req)
+	}
+}
+
+// RecoverStream is the streaming counterpart of Recover. Only panics on the
+// goroutine running the handler are recovered.
+func RecoverStream(onPanic func(err *PanicError)) StreamMiddleware {
+	return func(ctx context.Context, command string, req proto.Message, send func(proto.Message) error, next StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				panicErr := &PanicError{Command: command, Value: r, Stack: debug.Stack()}
+				if onPanic != nil {
+					onPanic(panicErr)
+				}
+				err = panicErr
+			}
+		}()
+		return next(ctx, req, send)
+	}
+}
@@ -0,0 +1,84 @@
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// StreamHandler processes a server-streaming command. It calls send for each
+// response message and returns when the stream is complete, when ctx is done
+// or when send fails. Returning nil ends the stream normally.
+type StreamHandler func(ctx context.Context, req proto.Message, send func(proto.Message) error) error
+
+// StreamMiddleware intercepts a streaming command on its way to the handler,
+// like Middleware does for unary commands. It must call next to continue the
+// chain, and may wrap send to inspect or filter the messages.
+type StreamMiddleware func(ctx context.Context, command string, req proto.Message, send func(proto.Message) error, next StreamHandler) error
+
+// RegisterStream registers a streaming handler for a command.
+// requestType and responseType should be zero-value instances of the request
+// message and of the message type sent on the stream.
+func (d *Dispatcher) RegisterStream(command string, handler StreamHandler, requestType, responseType proto.Message) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.handlers[command] = HandlerEntry{
+		StreamHandler: handler,
+		RequestType:   requestType,
+		ResponseType:  responseType,
+	}
+}
+
+// UseStream appends middleware to the chain wrapped around every streaming
+// handler. Middleware added with Use does not apply to streaming commands.
+func (d *Dispatcher) UseStream(middleware ...StreamMiddleware) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.streamMiddleware = append(d.streamMiddleware, middleware...)
+}
+
+// DispatchStream routes a streaming command to its handler. send is called
+// with each serialized response message, from the calling goroutine, and
+// DispatchStream returns once the stream ends. Cancel ctx to stop the stream.
+func (d *Dispatcher) DispatchStream(ctx context.Context, command string, payload []byte, send func([]byte) error) error {
+	d.mu.RLock()
+	entry, ok := d.handlers[command]
+	middleware := d.streamMiddleware
+	d.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownCommand, command)
+	}
+	if entry.StreamHandler == nil {
+		return fmt.Errorf("%w: %s is not a streaming command, use Dispatch", ErrCommandKind, command)
+	}
+
+	req, err := entry.newRequest(payload)
+	if err != nil {
+		return err
+	}
+
+	sendMessage := func(msg proto.Message) error {
+		msgBytes, err := proto.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal stream message: %w", err)
+		}
+		return send(msgBytes)
+	}
+
+	return chainStream(middleware, command, entry.StreamHandler)(ctx, req, sendMessage)
+}
+
+// chainStream wraps handler with stream middleware for the given command.
+func chainStream(middleware []StreamMiddleware, command string, handler StreamHandler) StreamHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		mw, next := middleware[i], handler
+		handler = func(ctx context.Context, req proto.Message, send func(proto.Message) error) error {
+			return mw(ctx, command, req, send, next)
+		}
+	}
+	return handler
+}
@@ -0,0 +1,30 @@
+package dispatcher
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Timeout returns middleware that bounds each command with a deadline.
+// timeout is called with the command name and returns how long the command
+// may run; zero or a negative duration means no limit. A deadline already set
+// by the caller takes precedence, so nested dispatches (such as commands in a
+// Batch) share the deadline of the outer command.
+func Timeout(timeout func(command string) time.Duration) Middleware {
+	return func(ctx context.Context, command string, req proto.Message, next Handler) (proto.Message, error) {
+		if _, ok := ctx.Deadline(); ok {
+			return next(ctx, req)
+		}
+
+		d := timeout(command)
+		if d <= 0 {
+			return next(ctx, req)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+		return next(ctx, req)
+	}
+}
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"anysync-backend/shared/anysync"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// SetPassphrase seals the unprotected account key with a passphrase.
+func (b *Backend) SetPassphrase(ctx context.Context, req proto.Message) (proto.Message, error) {
+	setReq := req.(*pb.SetPassphraseRequest)
+
+	if setReq.Passphrase == "" {
+		return nil, fmt.Errorf("%w: passphrase is required", anysync.ErrInvalidArgument)
+	}
+
+	err := b.withAccount(func(am *anysync.AccountManager, protected bool) error {
+		if protected {
+			return fmt.Errorf("%w: a passphrase is already set, use ChangePassphrase", anysync.ErrAlreadyExists)
+		}
+		return am.ChangePassphrase("", setReq.Passphrase)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SetPassphraseResponse{Success: true}, nil
+}
+
+// ChangePassphrase re-seals the account key with a new passphrase.
+func (b *Backend) ChangePassphrase(ctx context.Context, req proto.Message) (proto.Message, error) {
+	changeReq := req.(*pb.ChangePassphraseRequest)
+
+	if changeReq.NewPassphrase == "" {
+		return nil, fmt.Errorf("%w: new_passphrase is required, use RemovePassphrase to remove it", anysync.ErrInvalidArgument)
+	}
+
+	err := b.withAccount(func(am *anysync.AccountManager, protected bool) error {
+		if !protected {
+			return fmt.Errorf("%w: no passphrase is set, use SetPassphrase", anysync.ErrInvalidArgument)
+		}
+		if changeReq.CurrentPassphrase == "" {
+			return fmt.Errorf("%w: current_passphrase is required", anysync.ErrInvalidArgument)
+		}
+		return am.ChangePassphrase(changeReq.CurrentPassphrase, changeReq.NewPassphrase)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ChangePassphraseResponse{Success: true}, nil
+}
+
+// RemovePassphrase stores the account key without a passphrase. Removing it
+// from an unprotected account is a no-op.
+func (b *Backend) RemovePassphrase(ctx context.Context, req proto.Message) (proto.Message, error) {
+	removeReq := req.(*pb.RemovePassphraseRequest)
+
+	err := b.withAccount(func(am *anysync.AccountManager, protected bool) error {
+		if !protected {
+			return nil
+		}
+		if removeReq.CurrentPassphrase == "" {
+			return fmt.Errorf("%w: current_passphrase is required", anysync.ErrInvalidArgument)
+		}
+		return am.ChangePassphrase(removeReq.CurrentPassphrase, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RemovePassphraseResponse{Success: true}, nil
+}
+
+// ExportMnemonic returns the recovery phrase of the account. A
+// passphrase-protected account needs its passphrase, so an unattended session
+// cannot reveal the phrase.
+func (b *Backend) ExportMnemonic(ctx context.Context, req proto.Message) (proto.Message, error) {
+	exportReq := req.(*pb.ExportMnemonicRequest)
+
+	var mnemonic string
+	err := b.withAccount(func(am *anysync.AccountManager, protected bool) error {
+		if protected && exportReq.Passphrase == "" {
+			return fmt.Errorf("%w: passphrase is required", anysync.ErrInvalidArgument)
+		}
+		var err error
+		mnemonic, err = am.ExportMnemonic(exportReq.Passphrase)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ExportMnemonicResponse{Mnemonic: mnemonic}, nil
+}
+
+// DeleteAccount shuts the backend down and removes the account from its
+// data directory and key store. A passphrase-protected account needs its
+// passphrase. Like the other account changes, it fails on a locked session;
+// as Shutdown waits for the running commands, it cannot run within one.
+// When only part of the account could be removed, it returns the response
+// with what was removed along with the error, which carries the same in its
+// details for the transports that drop the response.
+func (b *Backend) DeleteAccount(ctx context.Context, req proto.Message) (proto.Message, error) {
+	deleteReq := req.(*pb.DeleteAccountRequest)
+
+	if err := b.checkDeleteAccount(deleteReq); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	dataDir := b.dataDir
+	b.mu.RUnlock()
+
+	if _, err := b.Shutdown(ctx, &pb.ShutdownRequest{}); err != nil {
+		return nil, err
+	}
+
+	report, err := anysync.WipeAccount(dataDir, b.keyStore.open(dataDir))
+	resp := &pb.DeleteAccountResponse{
+		RemovedFiles: report.Files,
+		RemovedKeys:  report.Keys,
+		RemovedBytes: report.Bytes,
+	}
+	if err != nil {
+		return resp, errWipeIncomplete(report, err)
+	}
+	return resp, nil
+}
+
+// errWipeIncomplete returns the error of a DeleteAccount that removed only
+// part of the account, with what it removed in its details. It has no
+// sentinel kind, so it maps to ERROR_CODE_INTERNAL.
+func errWipeIncomplete(report *anysync.WipeReport, err error) error {
+	return &anysync.Error{
+		Message: "failed to delete account data: " + err.Error(),
+		Details: map[string]string{
+			"removed_files": strings.Join(report.Files, ","),
+			"removed_keys":  strings.Join(report.Keys, ","),
+			"removed_bytes": strconv.FormatInt(report.Bytes, 10),
+		},
+	}
+}
+
+// checkDeleteAccount checks that the account can be deleted: the backend
+// runs, its session is unlocked, and the passphrase matches the stored key.
+// It holds the session like a command, so the session cannot lock meanwhile.
+func (b *Backend) checkDeleteAccount(deleteReq *pb.DeleteAccountRequest) error {
+	b.session.RLock()
+	defer b.session.RUnlock()
+
+	if err := b.ensureInitialized(); err != nil {
+		return err
+	}
+
+	b.mu.RLock()
+	closing := b.closing
+	am := b.accountManager
+	b.mu.RUnlock()
+
+	if closing {
+		return ErrShuttingDown
+	}
+	if am == nil {
+		return fmt.Errorf("account manager %w", ErrNotInitialized)
+	}
+
+	b.accountMu.Lock()
+	defer b.accountMu.Unlock()
+
+	protected, err := am.PassphraseProtected()
+	if err != nil || !protected {
+		return err
+	}
+	if deleteReq.Passphrase == "" {
+		return fmt.Errorf("%w: passphrase is required", anysync.ErrInvalidArgument)
+	}
+	_, err = am.ExportAccountKey(deleteReq.Passphrase)
+	return err
+}
+
+// withAccount runs change with the account manager and whether its key file
+// is currently protected, one account change (or export) at a time.
+func (b *Backend) withAccount(change func(am *anysync.AccountManager, protected bool) error) error {
+	if err := b.ensureInitialized(); err != nil {
+		return err
+	}
+
+	b.mu.RLock()
+	am := b.accountManager
+	b.mu.RUnlock()
+
+	if am == nil {
+		return fmt.Errorf("account manager %w", ErrNotInitialized)
+	}
+
+	b.accountMu.Lock()
+	defer b.accountMu.Unlock()
+
+	protected, err := am.PassphraseProtected()
+	if err != nil {
+		return err
+	}
+	return change(am, protected)
+}
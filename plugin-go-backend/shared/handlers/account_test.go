@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"anysync-backend/shared/anysync"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestIntegration_Passphrase_Init tests that a protected account needs its passphrase on Init.
+func TestIntegration_Passphrase_Init(t *testing.T) {
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	b := NewBackend()
+
+	// A new account is created with the passphrase
+	_, err := b.Init(ctx, &pb.InitRequest{DataDir: dataDir, Passphrase: "correct horse"})
+	require.NoError(t, err)
+	resp, err := b.GetStatus(ctx, &pb.GetStatusRequest{})
+	require.NoError(t, err)
+	assert.True(t, resp.(*pb.GetStatusResponse).PassphraseProtected)
+	_, err = b.Shutdown(ctx, &pb.ShutdownRequest{})
+	require.NoError(t, err)
+
+	_, err = b.Init(ctx, &pb.InitRequest{DataDir: dataDir})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_LOCKED, ErrorCodeOf(err))
+	assert.Equal(t, "passphrase_required", ToProtoError(err).Details["reason"])
+
+	_, err = b.Init(ctx, &pb.InitRequest{DataDir: dataDir, Passphrase: "wrong horse"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_LOCKED, ErrorCodeOf(err))
+	assert.Equal(t, "wrong_passphrase", ToProtoError(err).Details["reason"])
+	assert.ErrorIs(t, b.ensureInitialized(), ErrNotInitialized)
+
+	_, err = b.Init(ctx, &pb.InitRequest{DataDir: dataDir, Passphrase: "correct horse"})
+	require.NoError(t, err)
+	_, err = b.Shutdown(ctx, &pb.ShutdownRequest{})
+	require.NoError(t, err)
+}
+
+// TestIntegration_Passphrase_Commands tests setting, changing and removing the passphrase.
+func TestIntegration_Passphrase_Commands(t *testing.T) {
+	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
+	ctx := tc.Context()
+
+	// Set
+	_, err := b.SetPassphrase(ctx, &pb.SetPassphraseRequest{})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, ErrorCodeOf(err))
+	_, err = b.ChangePassphrase(ctx, &pb.ChangePassphraseRequest{NewPassphrase: "second"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, ErrorCodeOf(err), "nothing to change yet")
+	_, err = b.SetPassphrase(ctx, &pb.SetPassphraseRequest{Passphrase: "first"})
+	require.NoError(t, err)
+	_, err = b.SetPassphrase(ctx, &pb.SetPassphraseRequest{Passphrase: "again"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_ALREADY_EXISTS, ErrorCodeOf(err))
+
+	// Change
+	_, err = b.ChangePassphrase(ctx, &pb.ChangePassphraseRequest{CurrentPassphrase: "wrong", NewPassphrase: "second"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_LOCKED, ErrorCodeOf(err))
+	_, err = b.ChangePassphrase(ctx, &pb.ChangePassphraseRequest{CurrentPassphrase: "first", NewPassphrase: "second"})
+	require.NoError(t, err)
+
+	// The running backend is unaffected; a restart needs the new passphrase
+	tc.CreateDocument([]byte("still writable"), nil)
+	_, err = b.Shutdown(ctx, &pb.ShutdownRequest{})
+	require.NoError(t, err)
+	_, err = b.Init(ctx, &pb.InitRequest{DataDir: tc.DataDir(), Passphrase: "first"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_LOCKED, ErrorCodeOf(err))
+	_, err = b.Init(ctx, &pb.InitRequest{DataDir: tc.DataDir(), Passphrase: "second"})
+	require.NoError(t, err)
+
+	listResp, err := b.ListDocuments(ctx, &pb.ListDocumentsRequest{SpaceId: tc.SpaceID()})
+	require.NoError(t, err)
+	assert.Len(t, listResp.(*pb.ListDocumentsResponse).Documents, 1)
+
+	// Remove
+	_, err = b.RemovePassphrase(ctx, &pb.RemovePassphraseRequest{})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, ErrorCodeOf(err))
+	_, err = b.RemovePassphrase(ctx, &pb.RemovePassphraseRequest{CurrentPassphrase: "second"})
+	require.NoError(t, err)
+	_, err = b.Shutdown(ctx, &pb.ShutdownRequest{})
+	require.NoError(t, err)
+	_, err = b.Init(ctx, &pb.InitRequest{DataDir: tc.DataDir()})
+	require.NoError(t, err)
+}
+
+// TestIntegration_Passphrase_Profiles tests that each profile is opened with its own passphrase.
+func TestIntegration_Passphrase_Profiles(t *testing.T) {
+	p, _ := setupProfiles(t)
+	ctx := context.Background()
+
+	_, err := p.CreateProfile(ctx, &pb.CreateProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "work", Passphrase: "work secret"})
+	require.NoError(t, err)
+	_, err = p.CloseProfile(ctx, &pb.CloseProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "work"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_LOCKED, ErrorCodeOf(err))
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "work", Passphrase: "work secret"})
+	require.NoError(t, err)
+}
+
+// TestIntegration_KeyStore tests that profiles keep their account keys in the host key store, not in files.
+func TestIntegration_KeyStore(t *testing.T) {
+	ctx := context.Background()
+	dataDir := t.TempDir()
+
+	var mu sync.Mutex
+	stores := make(map[string]*anysync.MemoryKeyStore)
+	keyStore := func(dir string) anysync.KeyStore {
+		mu.Lock()
+		defer mu.Unlock()
+		if stores[dir] == nil {
+			stores[dir] = anysync.NewMemoryKeyStore()
+		}
+		return stores[dir]
+	}
+
+	p := NewProfilesWithKeyStore(keyStore)
+	_, err := p.Init(ctx, &pb.InitRequest{DataDir: dataDir, NetworkId: "test-network"})
+	require.NoError(t, err)
+	defer p.Shutdown(ctx, &pb.ShutdownRequest{})
+
+	_, err = p.CreateProfile(ctx, &pb.CreateProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "work", Passphrase: "secret"})
+	require.NoError(t, err)
+
+	workDir := filepath.Join(dataDir, "profiles", "work")
+	for _, dir := range []string{dataDir, workDir} {
+		assert.NoFileExists(t, filepath.Join(dir, "account.key"))
+		assert.NoFileExists(t, filepath.Join(dir, "device.key"))
+		exists, err := keyStore(dir).Exists("account.key")
+		require.NoError(t, err)
+		assert.True(t, exists, "keys of %s are in the key store", dir)
+	}
+
+	// Reopening the profile loads the same account from the key store
+	peerID := p.current.Load().accountManager.GetKeys().PeerId
+	_, err = p.CloseProfile(ctx, &pb.CloseProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "work", Passphrase: "wrong"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_LOCKED, ErrorCodeOf(err))
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "work", Passphrase: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, peerID, p.current.Load().accountManager.GetKeys().PeerId)
+
+	// Deleting the profile deletes its keys
+	_, err = p.DeleteProfile(ctx, &pb.DeleteProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	exists, err := keyStore(workDir).Exists("account.key")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+// TestIntegration_Mnemonic tests exporting the recovery phrase and restoring the account on a new device.
+func TestIntegration_Mnemonic(t *testing.T) {
+	ctx := context.Background()
+	original := NewBackend()
+	d := original.NewDispatcher()
+	_, err := original.Init(ctx, &pb.InitRequest{DataDir: t.TempDir(), Passphrase: "secret"})
+	require.NoError(t, err)
+	defer original.Shutdown(ctx, &pb.ShutdownRequest{})
+
+	// A protected account needs its passphrase
+	_, err = original.ExportMnemonic(ctx, &pb.ExportMnemonicRequest{})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, ErrorCodeOf(err))
+	_, err = original.ExportMnemonic(ctx, &pb.ExportMnemonicRequest{Passphrase: "wrong"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_LOCKED, ErrorCodeOf(err))
+
+	reqBytes, err := proto.Marshal(&pb.ExportMnemonicRequest{Passphrase: "secret"})
+	require.NoError(t, err)
+	respBytes, err := d.Dispatch(ctx, "ExportMnemonic", reqBytes)
+	require.NoError(t, err)
+	var exportResp pb.ExportMnemonicResponse
+	require.NoError(t, proto.Unmarshal(respBytes, &exportResp))
+	mnemonic := exportResp.Mnemonic
+	require.NotEmpty(t, mnemonic)
+
+	// Restore on a new device
+	dataDir := t.TempDir()
+	restored := NewBackend()
+	resp, err := restored.Init(ctx, &pb.InitRequest{DataDir: dataDir, Mnemonic: mnemonic})
+	require.NoError(t, err)
+	assert.True(t, resp.(*pb.InitResponse).Restored)
+
+	originalKeys := original.accountManager.GetKeys()
+	restoredKeys := restored.accountManager.GetKeys()
+	assert.Equal(t, originalKeys.SignKey.GetPublic().Account(), restoredKeys.SignKey.GetPublic().Account())
+	assert.NotEqual(t, originalKeys.PeerId, restoredKeys.PeerId, "a new device key is generated")
+	_, err = restored.Shutdown(ctx, &pb.ShutdownRequest{})
+	require.NoError(t, err)
+
+	// The same phrase reopens the account; another one is rejected
+	resp, err = restored.Init(ctx, &pb.InitRequest{DataDir: dataDir, Mnemonic: mnemonic})
+	require.NoError(t, err)
+	assert.False(t, resp.(*pb.InitResponse).Restored)
+	assert.Equal(t, restoredKeys.PeerId, restored.accountManager.GetKeys().PeerId)
+	_, err = restored.Shutdown(ctx, &pb.ShutdownRequest{})
+	require.NoError(t, err)
+
+	other := anysync.NewAccountManagerWithKeyStore(anysync.NewMemoryKeyStore())
+	require.NoError(t, other.GenerateKeys())
+	require.NoError(t, other.StoreKeys())
+	otherMnemonic, err := other.ExportMnemonic("")
+	require.NoError(t, err)
+	_, err = restored.Init(ctx, &pb.InitRequest{DataDir: dataDir, Mnemonic: otherMnemonic})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_ALREADY_EXISTS, ErrorCodeOf(err))
+
+	// An invalid phrase creates no account
+	invalidDir := t.TempDir()
+	_, err = NewBackend().Init(ctx, &pb.InitRequest{DataDir: invalidDir, Mnemonic: "not a recovery phrase"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, ErrorCodeOf(err))
+	assert.NoFileExists(t, filepath.Join(invalidDir, "account.key"))
+}
+
+// TestIntegration_DeleteAccount tests that DeleteAccount shuts down and removes the account data and keys.
+func TestIntegration_DeleteAccount(t *testing.T) {
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	b := NewBackend()
+	_, err := b.Init(ctx, &pb.InitRequest{DataDir: dataDir, Passphrase: "correct horse"})
+	require.NoError(t, err)
+	accountID := b.accountManager.GetKeys().SignKey.GetPublic().Account()
+
+	spaceResp, err := b.CreateSpace(ctx, &pb.CreateSpaceRequest{SpaceId: "notes", Name: "Notes"})
+	require.NoError(t, err)
+	spaceID := spaceResp.(*pb.CreateSpaceResponse).SpaceId
+	_, err = b.CreateDocument(ctx, &pb.CreateDocumentRequest{SpaceId: spaceID, Data: []byte("secret")})
+	require.NoError(t, err)
+
+	_, err = b.DeleteAccount(ctx, &pb.DeleteAccountRequest{})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, ErrorCodeOf(err))
+	_, err = b.DeleteAccount(ctx, &pb.DeleteAccountRequest{Passphrase: "wrong horse"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_LOCKED, ErrorCodeOf(err))
+	require.NoError(t, b.ensureInitialized(), "a failed check keeps the backend running")
+
+	// It cannot run inside a batch, nor on a locked session
+	d := b.NewDispatcher()
+	payload, err := proto.Marshal(&pb.DeleteAccountRequest{Passphrase: "correct horse"})
+	require.NoError(t, err)
+	var batchResp pb.BatchResponse
+	require.NoError(t, dispatchMessage(d, "Batch", &pb.BatchRequest{Commands: []*pb.Command{{Name: "DeleteAccount", Payload: payload}}}, &batchResp))
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, batchResp.Results[0].ErrorDetail.GetCode())
+	_, err = b.Lock(ctx, &pb.LockRequest{})
+	require.NoError(t, err)
+	_, err = d.Dispatch(ctx, "DeleteAccount", payload)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_LOCKED, ErrorCodeOf(err))
+	_, err = b.Unlock(ctx, &pb.UnlockRequest{Passphrase: "correct horse"})
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dataDir, "spaces", spaceID+".db"))
+
+	resp, err := b.DeleteAccount(ctx, &pb.DeleteAccountRequest{Passphrase: "correct horse"})
+	require.NoError(t, err)
+	deleteResp := resp.(*pb.DeleteAccountResponse)
+	assert.Equal(t, []string{"account.key", "device.key"}, deleteResp.RemovedKeys)
+	assert.Contains(t, deleteResp.RemovedFiles, "spaces/"+spaceID+".db")
+	assert.Contains(t, deleteResp.RemovedFiles, "documents/"+spaceID+".json")
+	assert.Contains(t, deleteResp.RemovedFiles, "spaces_metadata.json")
+	assert.Contains(t, deleteResp.RemovedFiles, "devices.json")
+	assert.Positive(t, deleteResp.RemovedBytes)
+	assert.ErrorIs(t, b.ensureInitialized(), ErrNotInitialized)
+
+	entries, err := os.ReadDir(dataDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// Init creates a new, empty account
+	_, err = b.Init(ctx, &pb.InitRequest{DataDir: dataDir})
+	require.NoError(t, err)
+	defer b.Shutdown(ctx, &pb.ShutdownRequest{})
+	assert.NotEqual(t, accountID, b.accountManager.GetKeys().SignKey.GetPublic().Account())
+	spaces, err := b.ListSpaces(ctx, &pb.ListSpacesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, spaces.(*pb.ListSpacesResponse).Spaces)
+}
+
+// undeletableKeyStore is a key store that fails to delete the device key.
+type undeletableKeyStore struct {
+	*anysync.MemoryKeyStore
+}
+
+func (s undeletableKeyStore) Delete(name string) error {
+	if name == "device.key" {
+		return errors.New("device key is read-only")
+	}
+	return s.MemoryKeyStore.Delete(name)
+}
+
+// TestIntegration_DeleteAccount_Partial tests that DeleteAccount reports what it removed when it cannot remove everything.
+func TestIntegration_DeleteAccount_Partial(t *testing.T) {
+	ctx := context.Background()
+	store := undeletableKeyStore{anysync.NewMemoryKeyStore()}
+	b := NewBackendWithKeyStore(func(string) anysync.KeyStore { return store })
+	_, err := b.Init(ctx, &pb.InitRequest{DataDir: t.TempDir()})
+	require.NoError(t, err)
+
+	resp, err := b.DeleteAccount(ctx, &pb.DeleteAccountRequest{})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INTERNAL, ErrorCodeOf(err))
+	assert.Contains(t, err.Error(), "device key is read-only")
+	deleteResp := resp.(*pb.DeleteAccountResponse)
+	assert.Equal(t, []string{"account.key"}, deleteResp.RemovedKeys)
+	assert.Contains(t, deleteResp.RemovedFiles, "devices.json")
+	assert.ErrorIs(t, b.ensureInitialized(), ErrNotInitialized)
+
+	details := ToProtoError(err).Details
+	assert.Equal(t, "account.key", details["removed_keys"])
+	assert.Contains(t, details["removed_files"], "devices.json")
+	assert.Equal(t, strconv.FormatInt(deleteResp.RemovedBytes, 10), details["removed_bytes"])
+}
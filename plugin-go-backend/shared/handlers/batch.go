@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"anysync-backend/shared/anysync"
+	"anysync-backend/shared/dispatcher"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// atomicBatchCommands lists the commands allowed in an atomic batch.
+// Only document operations can be rolled back.
+var atomicBatchCommands = map[string]bool{
+	"CreateDocument": true,
+	"GetDocument":    true,
+	"UpdateDocument": true,
+	"DeleteDocument": true,
+	"ListDocuments":  true,
+	"QueryDocuments": true,
+}
+
+// NewBatchHandler returns the Batch handler of b. Batched commands are
+// executed through d, so they go through the same middleware as individual
+// commands.
+func (b *Backend) NewBatchHandler(d *dispatcher.Dispatcher) dispatcher.Handler {
+	return newBatchHandler(d, b.self)
+}
+
+// newBatchHandler returns a Batch handler that runs on the backend resolved
+// when the batch starts; its commands stay on that backend.
+func newBatchHandler(d *dispatcher.Dispatcher, backend backendFunc) dispatcher.Handler {
+	return func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		b, ctx, done, err := enter(ctx, backend, false)
+		if err != nil {
+			return nil, err
+		}
+		defer done()
+		if err := b.ensureInitialized(); err != nil {
+			return nil, err
+		}
+
+		batchReq := req.(*pb.BatchRequest)
+
+		// Validate the whole batch before running anything
+		for i, cmd := range batchReq.Commands {
+			if cmd.Name == "Batch" {
+				return nil, fmt.Errorf("%w: batch command %d: nested Batch is not allowed", anysync.ErrInvalidArgument, i)
+			}
+			if batchReq.Atomic && !atomicBatchCommands[cmd.Name] {
+				return nil, fmt.Errorf("%w: batch command %d: %s is not allowed in an atomic batch", anysync.ErrInvalidArgument, i, cmd.Name)
+			}
+		}
+
+		if !batchReq.Atomic {
+			return runBatch(ctx, d, batchReq.Commands), nil
+		}
+
+		b.mu.RLock()
+		docManager := b.documentManager
+		b.mu.RUnlock()
+
+		if docManager == nil {
+			return nil, fmt.Errorf("document manager %w", ErrNotInitialized)
+		}
+
+		// Rollback is a no-op after a successful Commit, and otherwise undoes
+		// the batch on error or panic
+		tx := docManager.Begin()
+		defer func() {
+			if err := tx.Rollback(); err != nil {
+				fmt.Printf("Warning: failed to roll back batch: %v\n", err)
+			}
+		}()
+
+		txCtx := withDocumentTx(ctx, tx)
+		results := make([]*pb.CommandResponse, 0, len(batchReq.Commands))
+		for i, cmd := range batchReq.Commands {
+			payload, err := d.Dispatch(txCtx, cmd.Name, cmd.Payload)
+			if err != nil {
+				return nil, fmt.Errorf("batch command %d (%s) failed: %w", i, cmd.Name, err)
+			}
+			results = append(results, &pb.CommandResponse{Payload: payload})
+		}
+
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed to commit batch: %w", err)
+		}
+
+		return &pb.BatchResponse{Results: results}, nil
+	}
+}
+
+// runBatch executes every command and records each result or error.
+func runBatch(ctx context.Context, d *dispatcher.Dispatcher, commands []*pb.Command) *pb.BatchResponse {
+	results := make([]*pb.CommandResponse, 0, len(commands))
+	for _, cmd := range commands {
+		payload, err := d.Dispatch(ctx, cmd.Name, cmd.Payload)
+		if err != nil {
+			results = append(results, &pb.CommandResponse{
+				Error:       err.Error(),
+				ErrorDetail: ToProtoError(err),
+			})
+			continue
+		}
+		results = append(results, &pb.CommandResponse{Payload: payload})
+	}
+	return &pb.BatchResponse{Results: results}
+}
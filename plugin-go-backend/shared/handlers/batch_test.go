@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// batchCommand builds a batch entry from a command name and request message.
+func batchCommand(t *testing.T, name string, req proto.Message) *pb.Command {
+	t.Helper()
+	payload, err := proto.Marshal(req)
+	require.NoError(t, err)
+	return &pb.Command{Name: name, Payload: payload}
+}
+
+// TestIntegration_Batch_NonAtomic tests that every command runs and reports its own result.
+func TestIntegration_Batch_NonAtomic(t *testing.T) {
+	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
+	d := b.NewDispatcher()
+
+	resp, err := b.NewBatchHandler(d)(tc.Context(), &pb.BatchRequest{
+		Commands: []*pb.Command{
+			batchCommand(t, "CreateDocument", &pb.CreateDocumentRequest{SpaceId: tc.SpaceID(), Data: []byte("first")}),
+			batchCommand(t, "UpdateDocument", &pb.UpdateDocumentRequest{SpaceId: tc.SpaceID(), DocumentId: "missing", Data: []byte("x")}),
+			batchCommand(t, "CreateDocument", &pb.CreateDocumentRequest{SpaceId: tc.SpaceID(), Data: []byte("second")}),
+		},
+	})
+	require.NoError(t, err)
+
+	results := resp.(*pb.BatchResponse).Results
+	require.Len(t, results, 3)
+	assert.Empty(t, results[0].Error)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_NOT_FOUND, results[1].ErrorDetail.GetCode())
+	assert.NotEmpty(t, results[1].Error)
+	assert.Empty(t, results[2].Error)
+
+	var created pb.CreateDocumentResponse
+	require.NoError(t, proto.Unmarshal(results[2].Payload, &created))
+	assert.NotEmpty(t, created.DocumentId)
+
+	listResp, err := b.ListDocuments(tc.Context(), &pb.ListDocumentsRequest{SpaceId: tc.SpaceID()})
+	require.NoError(t, err)
+	assert.Len(t, listResp.(*pb.ListDocumentsResponse).Documents, 2)
+}
+
+// TestIntegration_Batch_AtomicCommit tests that a successful atomic batch applies all changes and emits events.
+func TestIntegration_Batch_AtomicCommit(t *testing.T) {
+	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
+	d := b.NewDispatcher()
+
+	existingID := tc.CreateDocument([]byte("v1"), nil)
+
+	subscriberID, eventChan, err := b.Subscribe(tc.Context(), &pb.SubscribeRequest{SpaceIds: []string{tc.SpaceID()}})
+	require.NoError(t, err)
+	defer b.Unsubscribe(subscriberID)
+
+	resp, err := b.NewBatchHandler(d)(tc.Context(), &pb.BatchRequest{
+		Atomic: true,
+		Commands: []*pb.Command{
+			batchCommand(t, "CreateDocument", &pb.CreateDocumentRequest{SpaceId: tc.SpaceID(), Data: []byte("note")}),
+			batchCommand(t, "UpdateDocument", &pb.UpdateDocumentRequest{SpaceId: tc.SpaceID(), DocumentId: existingID, Data: []byte("v2")}),
+			batchCommand(t, "GetDocument", &pb.GetDocumentRequest{SpaceId: tc.SpaceID(), DocumentId: existingID}),
+		},
+	})
+	require.NoError(t, err)
+
+	results := resp.(*pb.BatchResponse).Results
+	require.Len(t, results, 3)
+
+	// Reads inside the batch see earlier writes
+	var got pb.GetDocumentResponse
+	require.NoError(t, proto.Unmarshal(results[2].Payload, &got))
+	assert.Equal(t, []byte("v2"), got.Document.Data)
+	assert.Equal(t, int64(2), got.Document.Version)
+
+	// Events are emitted after commit, in order
+	for _, want := range []string{"document.created", "document.updated"} {
+		select {
+		case event := <-eventChan:
+			assert.Equal(t, want, string(event.Type))
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for %s event", want)
+		}
+	}
+}
+
+// TestIntegration_Batch_AtomicRollback tests that a failing atomic batch leaves no trace.
+func TestIntegration_Batch_AtomicRollback(t *testing.T) {
+	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
+	d := b.NewDispatcher()
+
+	existingID := tc.CreateDocument([]byte("v1"), map[string]string{"title": "Original"})
+	deletedID := tc.CreateDocument([]byte("keep me"), nil)
+
+	subscriberID, eventChan, err := b.Subscribe(tc.Context(), &pb.SubscribeRequest{SpaceIds: []string{tc.SpaceID()}})
+	require.NoError(t, err)
+	defer b.Unsubscribe(subscriberID)
+
+	_, err = b.NewBatchHandler(d)(tc.Context(), &pb.BatchRequest{
+		Atomic: true,
+		Commands: []*pb.Command{
+			batchCommand(t, "CreateDocument", &pb.CreateDocumentRequest{SpaceId: tc.SpaceID(), Data: []byte("note")}),
+			batchCommand(t, "UpdateDocument", &pb.UpdateDocumentRequest{
+				SpaceId:    tc.SpaceID(),
+				DocumentId: existingID,
+				Data:       []byte("v2"),
+				Metadata:   map[string]string{"title": "Changed"},
+			}),
+			batchCommand(t, "DeleteDocument", &pb.DeleteDocumentRequest{SpaceId: tc.SpaceID(), DocumentId: deletedID}),
+			batchCommand(t, "UpdateDocument", &pb.UpdateDocumentRequest{SpaceId: tc.SpaceID(), DocumentId: "missing", Data: []byte("x")}),
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "batch command 3 (UpdateDocument)")
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_NOT_FOUND, ErrorCodeOf(err))
+
+	// Only the two original documents remain
+	listResp, err := b.ListDocuments(tc.Context(), &pb.ListDocumentsRequest{SpaceId: tc.SpaceID()})
+	require.NoError(t, err)
+	docs := listResp.(*pb.ListDocumentsResponse).Documents
+	require.Len(t, docs, 2)
+
+	// The updated document has its content, metadata and version restored
+	getResp, err := b.GetDocument(tc.Context(), &pb.GetDocumentRequest{SpaceId: tc.SpaceID(), DocumentId: existingID})
+	require.NoError(t, err)
+	doc := getResp.(*pb.GetDocumentResponse).Document
+	assert.Equal(t, []byte("v1"), doc.Data)
+	assert.Equal(t, "Original", doc.Metadata["title"])
+	assert.Equal(t, int64(1), doc.Version)
+
+	// The deleted document is still readable
+	getResp, err = b.GetDocument(tc.Context(), &pb.GetDocumentRequest{SpaceId: tc.SpaceID(), DocumentId: deletedID})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("keep me"), getResp.(*pb.GetDocumentResponse).Document.Data)
+
+	// No events were emitted
+	select {
+	case event := <-eventChan:
+		t.Fatalf("unexpected event after rollback: %s", event.Type)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+// TestIntegration_Batch_Validation tests that invalid batches are rejected before anything runs.
+func TestIntegration_Batch_Validation(t *testing.T) {
+	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
+	handler := b.NewBatchHandler(b.NewDispatcher())
+
+	tests := []struct {
+		name string
+		req  *pb.BatchRequest
+	}{
+		{
+			name: "NestedBatch",
+			req: &pb.BatchRequest{Commands: []*pb.Command{
+				batchCommand(t, "Batch", &pb.BatchRequest{}),
+			}},
+		},
+		{
+			name: "NonDocumentCommandInAtomicBatch",
+			req: &pb.BatchRequest{Atomic: true, Commands: []*pb.Command{
+				batchCommand(t, "CreateDocument", &pb.CreateDocumentRequest{SpaceId: tc.SpaceID(), Data: []byte("x")}),
+				batchCommand(t, "CreateSpace", &pb.CreateSpaceRequest{Name: "Other"}),
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler(tc.Context(), tt.req)
+			require.Error(t, err)
+			assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, ErrorCodeOf(err))
+		})
+	}
+
+	listResp, err := b.ListDocuments(tc.Context(), &pb.ListDocumentsRequest{SpaceId: tc.SpaceID()})
+	require.NoError(t, err)
+	assert.Empty(t, listResp.(*pb.ListDocumentsResponse).Documents)
+}
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"anysync-backend/shared/anysync"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"github.com/anyproto/any-sync/app/logger"
+	"github.com/anyproto/any-sync/commonspace/config"
+	"google.golang.org/protobuf/proto"
+)
+
+// NetworkModeLocal keeps all data on this device. It is the only mode until
+// network sync is implemented.
+const NetworkModeLocal = "local"
+
+// logLevels lists the accepted log_level values.
+var logLevels = map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+
+// Config is the typed backend configuration, parsed and validated from an
+// InitRequest by ParseConfig.
+type Config struct {
+	DataDir     string
+	NetworkID   string
+	DeviceID    string
+	NetworkMode string
+	LogLevel    string
+	// Command deadlines; a zero or negative duration means no deadline
+	CommandTimeout  time.Duration
+	CommandTimeouts map[string]time.Duration
+	// Settings handed to Any-Sync spaces (SyncPeriod, GCTTL, ...)
+	Space config.Config
+	// Space objects kept open at once; zero keeps every space open
+	SpaceCacheSize int
+	// Idle time after which the session locks itself; zero never locks it
+	AutoLock time.Duration
+}
+
+// ParseConfig builds the backend configuration from an InitRequest. Settings
+// are read from config_json, then from the config map, then from the timeout
+// fields; later sources override earlier ones. Invalid values are rejected
+// with ErrInvalidArgument naming the source and key.
+func ParseConfig(req *pb.InitRequest) (*Config, error) {
+	cfg := &Config{
+		DataDir:         req.DataDir,
+		NetworkID:       req.NetworkId,
+		DeviceID:        req.DeviceId,
+		NetworkMode:     NetworkModeLocal,
+		LogLevel:        "info",
+		CommandTimeout:  DefaultCommandTimeout,
+		CommandTimeouts: make(map[string]time.Duration),
+		Space:           anysync.DefaultSpaceConfig(),
+	}
+
+	if req.ConfigJson != "" {
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(req.ConfigJson), &values); err != nil {
+			return nil, fmt.Errorf("%w: config_json must be a JSON object: %v", anysync.ErrInvalidArgument, err)
+		}
+		for key, raw := range values {
+			if err := cfg.apply(configValue{source: "config_json", key: key, raw: raw}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for key, value := range req.Config {
+		if err := cfg.apply(configValue{source: "config", key: key, str: value}); err != nil {
+			return nil, err
+		}
+	}
+
+	// A zero default keeps the configured timeout; zero per-command entries
+	// are ignored so they fall back to the default
+	if req.CommandTimeoutMs != 0 {
+		cfg.CommandTimeout = time.Duration(req.CommandTimeoutMs) * time.Millisecond
+	}
+	for command, ms := range req.CommandTimeoutsMs {
+		if ms != 0 {
+			cfg.CommandTimeouts[command] = time.Duration(ms) * time.Millisecond
+		}
+	}
+
+	return cfg, nil
+}
+
+// apply sets one configuration key.
+func (c *Config) apply(v configValue) error {
+	switch v.key {
+	case "network_mode":
+		mode, err := v.string()
+		if err != nil {
+			return err
+		}
+		if mode != NetworkModeLocal {
+			return v.errorf("unsupported mode %q, only %q is available", mode, NetworkModeLocal)
+		}
+		c.NetworkMode = mode
+
+	case "log_level":
+		level, err := v.string()
+		if err != nil {
+			return err
+		}
+		if !logLevels[level] {
+			return v.errorf("must be one of debug, info, warn or error, got %q", level)
+		}
+		c.LogLevel = level
+
+	case "command_timeout_ms":
+		ms, err := v.int64()
+		if err != nil {
+			return err
+		}
+		if ms != 0 {
+			c.CommandTimeout = time.Duration(ms) * time.Millisecond
+		}
+
+	case "command_timeouts_ms":
+		if v.raw == nil {
+			return v.errorf("is only supported in config_json")
+		}
+		var timeouts map[string]int64
+		if err := json.Unmarshal(v.raw, &timeouts); err != nil {
+			return v.errorf("must be an object of command names to milliseconds")
+		}
+		for command, ms := range timeouts {
+			if ms != 0 {
+				c.CommandTimeouts[command] = time.Duration(ms) * time.Millisecond
+			}
+		}
+
+	case "sync_period_sec":
+		sec, err := v.positiveInt()
+		if err != nil {
+			return err
+		}
+		c.Space.SyncPeriod = sec
+
+	case "gc_ttl_sec":
+		sec, err := v.positiveInt()
+		if err != nil {
+			return err
+		}
+		c.Space.GCTTL = sec
+
+	case "space_cache_size":
+		size, err := v.int64()
+		if err != nil {
+			return err
+		}
+		if size < 0 || size > 1<<31-1 {
+			return v.errorf("must be a number of spaces, or 0 for no limit, got %d", size)
+		}
+		c.SpaceCacheSize = int(size)
+
+	case "auto_lock_sec":
+		sec, err := v.int64()
+		if err != nil {
+			return err
+		}
+		if sec < 0 || sec > 1<<31-1 {
+			return v.errorf("must be a number of seconds, or 0 to never lock, got %d", sec)
+		}
+		c.AutoLock = time.Duration(sec) * time.Second
+
+	case "keep_tree_data_in_memory":
+		keep, err := v.bool()
+		if err != nil {
+			return err
+		}
+		c.Space.KeepTreeDataInMemory = keep
+
+	default:
+		return v.errorf("unknown setting")
+	}
+	return nil
+}
+
+// applyLogLevel sets the level of the Any-Sync loggers. Loggers are global to
+// the process, so the backend initialized last decides the level.
+func (c *Config) applyLogLevel() {
+	logger.SetNamedLevels([]logger.NamedLevel{{Name: "*", Level: c.LogLevel}})
+}
+
+// toProto converts the configuration for GetConfig.
+func (c *Config) toProto() *pb.BackendConfig {
+	timeouts := make(map[string]int64, len(c.CommandTimeouts))
+	for command, timeout := range c.CommandTimeouts {
+		timeouts[command] = timeout.Milliseconds()
+	}
+
+	return &pb.BackendConfig{
+		DataDir:              c.DataDir,
+		NetworkId:            c.NetworkID,
+		DeviceId:             c.DeviceID,
+		NetworkMode:          c.NetworkMode,
+		LogLevel:             c.LogLevel,
+		CommandTimeoutMs:     c.CommandTimeout.Milliseconds(),
+		CommandTimeoutsMs:    timeouts,
+		SyncPeriodSec:        int32(c.Space.SyncPeriod),
+		GcTtlSec:             int32(c.Space.GCTTL),
+		KeepTreeDataInMemory: c.Space.KeepTreeDataInMemory,
+		AutoLockSec:          int32(c.AutoLock / time.Second),
+		SpaceCacheSize:       int32(c.SpaceCacheSize),
+	}
+}
+
+// configValue is one setting from the config map (str) or from config_json (raw).
+type configValue struct {
+	source string // "config" or "config_json"
+	key    string
+	str    string
+	raw    json.RawMessage
+}
+
+func (v configValue) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s %s: %s", anysync.ErrInvalidArgument, v.source, v.key, fmt.Sprintf(format, args...))
+}
+
+func (v configValue) string() (string, error) {
+	if v.raw == nil {
+		return v.str, nil
+	}
+	var s string
+	if err := json.Unmarshal(v.raw, &s); err != nil {
+		return "", v.errorf("must be a string, got %s", v.raw)
+	}
+	return s, nil
+}
+
+func (v configValue) int64() (int64, error) {
+	if v.raw == nil {
+		n, err := strconv.ParseInt(v.str, 10, 64)
+		if err != nil {
+			return 0, v.errorf("must be an integer, got %q", v.str)
+		}
+		return n, nil
+	}
+	var n int64
+	if err := json.Unmarshal(v.raw, &n); err != nil {
+		return 0, v.errorf("must be an integer, got %s", v.raw)
+	}
+	return n, nil
+}
+
+func (v configValue) positiveInt() (int, error) {
+	n, err := v.int64()
+	if err != nil {
+		return 0, err
+	}
+	if n <= 0 || n > 1<<31-1 {
+		return 0, v.errorf("must be a positive number of seconds, got %d", n)
+	}
+	return int(n), nil
+}
+
+func (v configValue) bool() (bool, error) {
+	if v.raw == nil {
+		b, err := strconv.ParseBool(v.str)
+		if err != nil {
+			return false, v.errorf("must be true or false, got %q", v.str)
+		}
+		return b, nil
+	}
+	var b bool
+	if err := json.Unmarshal(v.raw, &b); err != nil {
+		return false, v.errorf("must be true or false, got %s", v.raw)
+	}
+	return b, nil
+}
+
+// GetConfig returns the configuration the backend was initialized with.
+func (b *Backend) GetConfig(ctx context.Context, req proto.Message) (proto.Message, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if !b.initialized {
+		return nil, fmt.Errorf("%w: call Init first", ErrNotInitialized)
+	}
+
+	return &pb.GetConfigResponse{Config: b.config.toProto()}, nil
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"anysync-backend/shared/anysync"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"github.com/anyproto/any-sync/app/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestUnit_ParseConfig_Defaults tests the configuration of a bare InitRequest.
+func TestUnit_ParseConfig_Defaults(t *testing.T) {
+	cfg, err := ParseConfig(&pb.InitRequest{DataDir: "/data"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/data", cfg.DataDir)
+	assert.Equal(t, NetworkModeLocal, cfg.NetworkMode)
+	assert.Equal(t, "info", cfg.LogLevel)
+	assert.Equal(t, DefaultCommandTimeout, cfg.CommandTimeout)
+	assert.Empty(t, cfg.CommandTimeouts)
+	assert.Equal(t, anysync.DefaultSpaceConfig(), cfg.Space)
+	assert.Zero(t, cfg.SpaceCacheSize)
+}
+
+// TestUnit_ParseConfig_Sources tests that config overrides config_json and the timeout fields override both.
+func TestUnit_ParseConfig_Sources(t *testing.T) {
+	cfg, err := ParseConfig(&pb.InitRequest{
+		DataDir: "/data",
+		ConfigJson: `{
+			"log_level": "warn",
+			"sync_period_sec": 10,
+			"gc_ttl_sec": 300,
+			"keep_tree_data_in_memory": false,
+			"auto_lock_sec": 300,
+			"command_timeout_ms": 1000,
+			"command_timeouts_ms": {"CreateSpace": 60000, "ListSpaces": 0},
+			"space_cache_size": 4
+		}`,
+		Config: map[string]string{
+			"log_level":        "debug",
+			"sync_period_sec":  "20",
+			"space_cache_size": "8",
+		},
+		CommandTimeoutsMs: map[string]int64{"CreateSpace": -1},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "debug", cfg.LogLevel)
+	assert.Equal(t, 20, cfg.Space.SyncPeriod)
+	assert.Equal(t, 300, cfg.Space.GCTTL)
+	assert.False(t, cfg.Space.KeepTreeDataInMemory)
+	assert.Equal(t, 5*time.Minute, cfg.AutoLock)
+	assert.Equal(t, time.Second, cfg.CommandTimeout)
+	assert.Equal(t, map[string]time.Duration{"CreateSpace": -time.Millisecond}, cfg.CommandTimeouts)
+	assert.Equal(t, 8, cfg.SpaceCacheSize)
+}
+
+// TestUnit_ParseConfig_Invalid tests that invalid settings are rejected with the offending key.
+func TestUnit_ParseConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *pb.InitRequest
+		message string
+	}{
+		{
+			name:    "AutoLock",
+			req:     &pb.InitRequest{Config: map[string]string{"auto_lock_sec": "-1"}},
+			message: "config auto_lock_sec: must be a number of seconds, or 0 to never lock, got -1",
+		},
+		{
+			name:    "MalformedJSON",
+			req:     &pb.InitRequest{ConfigJson: `{"log_level":`},
+			message: "config_json must be a JSON object",
+		},
+		{
+			name:    "NetworkMode",
+			req:     &pb.InitRequest{Config: map[string]string{"network_mode": "network"}},
+			message: `config network_mode: unsupported mode "network", only "local" is available`,
+		},
+		{
+			name:    "LogLevel",
+			req:     &pb.InitRequest{Config: map[string]string{"log_level": "verbose"}},
+			message: `config log_level: must be one of debug, info, warn or error, got "verbose"`,
+		},
+		{
+			name:    "SyncPeriodNotANumber",
+			req:     &pb.InitRequest{Config: map[string]string{"sync_period_sec": "soon"}},
+			message: `config sync_period_sec: must be an integer, got "soon"`,
+		},
+		{
+			name:    "GCTTLNotPositive",
+			req:     &pb.InitRequest{ConfigJson: `{"gc_ttl_sec": 0}`},
+			message: "config_json gc_ttl_sec: must be a positive number of seconds, got 0",
+		},
+		{
+			name:    "KeepTreeDataNotABool",
+			req:     &pb.InitRequest{ConfigJson: `{"keep_tree_data_in_memory": "yes"}`},
+			message: `config_json keep_tree_data_in_memory: must be true or false, got "yes"`,
+		},
+		{
+			name:    "UnknownKey",
+			req:     &pb.InitRequest{Config: map[string]string{"log_levle": "debug"}},
+			message: "config log_levle: unknown setting",
+		},
+		{
+			name:    "UnknownJSONKey",
+			req:     &pb.InitRequest{ConfigJson: `{"theme": {"dark": true}}`},
+			message: "config_json theme: unknown setting",
+		},
+		{
+			name:    "SpaceCacheSize",
+			req:     &pb.InitRequest{Config: map[string]string{"space_cache_size": "-1"}},
+			message: "config space_cache_size: must be a number of spaces, or 0 for no limit, got -1",
+		},
+		{
+			name:    "CommandTimeoutsInMap",
+			req:     &pb.InitRequest{Config: map[string]string{"command_timeouts_ms": "{}"}},
+			message: "config command_timeouts_ms: is only supported in config_json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConfig(tt.req)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, anysync.ErrInvalidArgument)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+// TestUnit_Config_ApplyLogLevel tests that log_level sets the level of the Any-Sync loggers.
+func TestUnit_Config_ApplyLogLevel(t *testing.T) {
+	(&Config{LogLevel: "warn"}).applyLogLevel()
+	defer (&Config{LogLevel: "info"}).applyLogLevel()
+
+	log := logger.NewNamed("handlers.test")
+	assert.False(t, log.Core().Enabled(zapcore.InfoLevel))
+	assert.True(t, log.Core().Enabled(zapcore.WarnLevel))
+
+	(&Config{LogLevel: "debug"}).applyLogLevel()
+	assert.True(t, log.Core().Enabled(zapcore.DebugLevel))
+}
+
+// TestIntegration_GetConfig tests that Init validates the configuration and GetConfig reports it.
+func TestIntegration_GetConfig(t *testing.T) {
+	b := NewBackend()
+	d := b.NewDispatcher()
+	ctx := context.Background()
+
+	_, err := d.Dispatch(ctx, "GetConfig", nil)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_NOT_INITIALIZED, ErrorCodeOf(err))
+
+	// Invalid settings fail Init without initializing the backend
+	_, err = b.Init(ctx, &pb.InitRequest{
+		DataDir: t.TempDir(),
+		Config:  map[string]string{"sync_period_sec": "-5"},
+	})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, ErrorCodeOf(err))
+	assert.ErrorIs(t, b.ensureInitialized(), ErrNotInitialized)
+
+	dataDir := t.TempDir()
+	_, err = b.Init(ctx, &pb.InitRequest{
+		DataDir:    dataDir,
+		NetworkId:  "test-network",
+		ConfigJson: `{"sync_period_sec": 15, "space_cache_size": 2}`,
+	})
+	require.NoError(t, err)
+	defer b.Shutdown(ctx, &pb.ShutdownRequest{})
+
+	respBytes, err := d.Dispatch(ctx, "GetConfig", nil)
+	require.NoError(t, err)
+	var resp pb.GetConfigResponse
+	require.NoError(t, proto.Unmarshal(respBytes, &resp))
+
+	assert.Equal(t, dataDir, resp.Config.DataDir)
+	assert.Equal(t, "test-network", resp.Config.NetworkId)
+	assert.Equal(t, NetworkModeLocal, resp.Config.NetworkMode)
+	assert.Equal(t, int64(30000), resp.Config.CommandTimeoutMs)
+	assert.Equal(t, int32(15), resp.Config.SyncPeriodSec)
+	assert.Equal(t, int32(60), resp.Config.GcTtlSec)
+	assert.True(t, resp.Config.KeepTreeDataInMemory)
+	assert.Equal(t, int32(2), resp.Config.SpaceCacheSize)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"anysync-backend/shared/anysync"
+	"anysync-backend/shared/dispatcher"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Managers gives custom commands access to the initialized backend.
+type Managers struct {
+	Spaces    *anysync.SpaceManager
+	Documents *anysync.DocumentManager
+	Events    *anysync.EventManager
+}
+
+// CustomHandler processes a custom command. It is only called after Init,
+// with the managers of the running backend.
+type CustomHandler func(ctx context.Context, m *Managers, req proto.Message) (proto.Message, error)
+
+// customCommandName matches namespaced names such as "myapp.Reindex". The
+// namespace keeps custom commands apart from the built-in ones, which have
+// no dot.
+var customCommandName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*(\.[A-Za-z][A-Za-z0-9_]*)+$`)
+
+// RegisterCommand adds a custom command to d, next to the built-in commands.
+// The handler receives the managers of b, so d should be bound to b.
+// name must be namespaced ("myapp.Reindex"); it is rejected with
+// ErrInvalidArgument otherwise, and with ErrAlreadyExists if it is taken.
+// requestType and responseType are zero-value instances of the command's
+// messages, used for decoding and for DescribeCommands.
+//
+// It is safe to call while d is dispatching other commands. Custom commands
+// run through the same middleware as built-in ones.
+func (b *Backend) RegisterCommand(d *dispatcher.Dispatcher, name string, handler CustomHandler, requestType, responseType proto.Message) error {
+	return registerCommand(d, b.self, name, handler, requestType, responseType)
+}
+
+// registerCommand registers a custom command that runs on the backend
+// resolved for each call.
+func registerCommand(d *dispatcher.Dispatcher, backend backendFunc, name string, handler CustomHandler, requestType, responseType proto.Message) error {
+	if !customCommandName.MatchString(name) {
+		return fmt.Errorf("%w: custom command %q must be namespaced, e.g. \"myapp.Reindex\"", anysync.ErrInvalidArgument, name)
+	}
+	if handler == nil || requestType == nil || responseType == nil {
+		return fmt.Errorf("%w: custom command %q needs a handler and message types", anysync.ErrInvalidArgument, name)
+	}
+
+	err := d.RegisterNew(name, func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		b, ctx, done, err := enter(ctx, backend, false)
+		if err != nil {
+			return nil, err
+		}
+		defer done()
+
+		// Check and snapshot under one lock so a concurrent Shutdown cannot
+		// hand the command nil managers
+		b.mu.RLock()
+		initialized := b.initialized
+		m := &Managers{
+			Spaces:    b.spaceManager,
+			Documents: b.documentManager,
+			Events:    b.eventManager,
+		}
+		b.mu.RUnlock()
+
+		if !initialized {
+			return nil, fmt.Errorf("%w: call Init first", ErrNotInitialized)
+		}
+		return handler(ctx, m, req)
+	}, requestType, responseType)
+	if errors.Is(err, dispatcher.ErrCommandExists) {
+		return fmt.Errorf("%w: %w", anysync.ErrAlreadyExists, err)
+	}
+	return err
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"anysync-backend/shared/anysync"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// countDocuments is a custom command that counts the documents of a space.
+func countDocuments(ctx context.Context, m *Managers, req proto.Message) (proto.Message, error) {
+	docs, err := m.Documents.ListDocuments(ctx, req.(*wrapperspb.StringValue).Value)
+	if err != nil {
+		return nil, err
+	}
+	return wrapperspb.Int64(int64(len(docs))), nil
+}
+
+// TestUnit_RegisterCommand_Validation tests that only new namespaced commands are accepted.
+func TestUnit_RegisterCommand_Validation(t *testing.T) {
+	b := NewBackend()
+	d := b.NewDispatcher()
+
+	for _, name := range []string{"", "Reindex", "CreateSpace", ".Reindex", "myapp.", "myapp..Reindex", "my app.Reindex"} {
+		err := b.RegisterCommand(d, name, countDocuments, &wrapperspb.StringValue{}, &wrapperspb.Int64Value{})
+		assert.ErrorIs(t, err, anysync.ErrInvalidArgument, "name %q", name)
+	}
+
+	require.NoError(t, b.RegisterCommand(d, "myapp.Count", countDocuments, &wrapperspb.StringValue{}, &wrapperspb.Int64Value{}))
+	err := b.RegisterCommand(d, "myapp.Count", countDocuments, &wrapperspb.StringValue{}, &wrapperspb.Int64Value{})
+	assert.ErrorIs(t, err, anysync.ErrAlreadyExists)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_ALREADY_EXISTS, ErrorCodeOf(err))
+}
+
+// TestIntegration_RegisterCommand tests that a custom command uses the managers and shows up in discovery.
+func TestIntegration_RegisterCommand(t *testing.T) {
+	payload, err := proto.Marshal(wrapperspb.String("any"))
+	require.NoError(t, err)
+
+	// Custom commands require Init like the built-in ones
+	uninitialized := NewBackend()
+	d := uninitialized.NewDispatcher()
+	require.NoError(t, uninitialized.RegisterCommand(d, "myapp.Count", countDocuments, &wrapperspb.StringValue{}, &wrapperspb.Int64Value{}))
+	_, err = d.Dispatch(context.Background(), "myapp.Count", payload)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_NOT_INITIALIZED, ErrorCodeOf(err))
+
+	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
+	d = b.NewDispatcher()
+	require.NoError(t, b.RegisterCommand(d, "myapp.Count", countDocuments, &wrapperspb.StringValue{}, &wrapperspb.Int64Value{}))
+	tc.CreateDocument([]byte("one"), nil)
+	tc.CreateDocument([]byte("two"), nil)
+
+	payload, err = proto.Marshal(wrapperspb.String(tc.SpaceID()))
+	require.NoError(t, err)
+	respBytes, err := d.Dispatch(tc.Context(), "myapp.Count", payload)
+	require.NoError(t, err)
+
+	var count wrapperspb.Int64Value
+	require.NoError(t, proto.Unmarshal(respBytes, &count))
+	assert.Equal(t, int64(2), count.Value)
+
+	resp, err := NewDescribeCommandsHandler(d)(tc.Context(), &pb.DescribeCommandsRequest{})
+	require.NoError(t, err)
+	var found *pb.CommandInfo
+	for _, info := range resp.(*pb.DescribeCommandsResponse).Commands {
+		if info.Name == "myapp.Count" {
+			found = info
+		}
+	}
+	require.NotNil(t, found, "custom command missing from DescribeCommands")
+	assert.Equal(t, "google.protobuf.StringValue", found.RequestType)
+	assert.Equal(t, "google.protobuf.Int64Value", found.ResponseType)
+}
+
+// TestIntegration_RegisterCommand_Concurrent tests registering commands while others are dispatched.
+func TestIntegration_RegisterCommand_Concurrent(t *testing.T) {
+	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
+	d := b.NewDispatcher()
+
+	payload, err := proto.Marshal(&pb.ListSpacesRequest{})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				_, err := d.Dispatch(tc.Context(), "ListSpaces", payload)
+				assert.NoError(t, err)
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				name := fmt.Sprintf("myapp.Command%d_%d", i, j)
+				assert.NoError(t, b.RegisterCommand(d, name, countDocuments, &wrapperspb.StringValue{}, &wrapperspb.Int64Value{}))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, d.Commands(), len(b.NewDispatcher().Commands())+80)
+}
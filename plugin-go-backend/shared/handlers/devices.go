@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"anysync-backend/shared/anysync"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ListDevices lists the devices of the account, including revoked ones.
+func (b *Backend) ListDevices(ctx context.Context, req proto.Message) (proto.Message, error) {
+	devices, err := b.deviceRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	self := devices.Self()
+	list := devices.List()
+	infos := make([]*pb.DeviceInfo, 0, len(list))
+	for _, device := range list {
+		infos = append(infos, deviceToProto(device, self))
+	}
+
+	return &pb.ListDevicesResponse{Devices: infos}, nil
+}
+
+// RotateDeviceKey replaces the key of this device with a new one. The open
+// spaces are closed and reopened with the new key, and the previous key is
+// removed from the ACL of the spaces the account owns. Like Lock, it waits
+// for the running commands and cannot run within another command.
+func (b *Backend) RotateDeviceKey(ctx context.Context, req proto.Message) (proto.Message, error) {
+	b.session.Lock()
+	defer b.session.Unlock()
+
+	b.accountMu.Lock()
+	defer b.accountMu.Unlock()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.initialized {
+		return nil, fmt.Errorf("%w: call Init first", ErrNotInitialized)
+	}
+	if b.closing {
+		return nil, ErrShuttingDown
+	}
+	if b.locked {
+		return nil, errSessionLocked()
+	}
+
+	previous := b.accountManager.GetKeys().PeerKey.GetPublic()
+	if err := b.accountManager.RotateDeviceKey(); err != nil {
+		return nil, err
+	}
+
+	// The spaces run with the device key they were opened with
+	b.closeManagers()
+	if err := b.openManagers(); err != nil {
+		b.accountManager.ClearKeys()
+		b.locked = true
+		if b.eventManager != nil {
+			b.eventManager.EmitEvent(anysync.EventAccountLocked, "", map[string]string{"reason": lockReasonFailed})
+		}
+		return nil, err
+	}
+
+	device, err := b.devices.RegisterSelf(b.devices.Self(), "", b.accountManager.GetKeys().PeerKey.GetPublic())
+	if err != nil {
+		return nil, err
+	}
+
+	if !b.devices.InUse(previous.PeerId()) {
+		if err := b.spaceManager.RemoveDevice(ctx, previous); err != nil {
+			return nil, fmt.Errorf("failed to remove the previous device key: %w", err)
+		}
+	}
+
+	return &pb.RotateDeviceKeyResponse{Device: deviceToProto(device, device.DeviceID)}, nil
+}
+
+// RevokeDevice revokes another device of the account, so it can no longer
+// open the account, and removes its key from the ACL of the spaces the
+// account owns. Revoking a revoked device only retries the ACL removal.
+func (b *Backend) RevokeDevice(ctx context.Context, req proto.Message) (proto.Message, error) {
+	revokeReq := req.(*pb.RevokeDeviceRequest)
+
+	if revokeReq.DeviceId == "" {
+		return nil, fmt.Errorf("%w: device_id is required", anysync.ErrInvalidArgument)
+	}
+
+	devices, err := b.deviceRegistry()
+	if err != nil {
+		return nil, err
+	}
+	sm, err := b.spaces()
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := devices.Get(revokeReq.DeviceId)
+	if err != nil {
+		return nil, err
+	}
+	if self, err := devices.Get(devices.Self()); err == nil && device.DeviceID != self.DeviceID && device.PeerID == self.PeerID {
+		return nil, fmt.Errorf("%w: the device shares the key of this device, rotate it first", anysync.ErrInvalidArgument)
+	}
+
+	device, err = devices.Revoke(revokeReq.DeviceId)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := device.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if !devices.InUse(device.PeerID) {
+		if err := sm.RemoveDevice(ctx, key); err != nil {
+			return nil, fmt.Errorf("failed to remove device from spaces: %w", err)
+		}
+	}
+
+	return &pb.RevokeDeviceResponse{Device: deviceToProto(device, devices.Self())}, nil
+}
+
+// deviceRegistry returns the device registry of the running backend.
+func (b *Backend) deviceRegistry() (*anysync.DeviceRegistry, error) {
+	if err := b.ensureInitialized(); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.devices == nil {
+		return nil, fmt.Errorf("device registry %w", ErrNotInitialized)
+	}
+	return b.devices, nil
+}
+
+// deviceToProto converts a device, marking it current if it is self.
+func deviceToProto(device *anysync.Device, self string) *pb.DeviceInfo {
+	return &pb.DeviceInfo{
+		DeviceId:   device.DeviceID,
+		Name:       device.Name,
+		PeerId:     device.PeerID,
+		PeerKey:    device.PeerKey,
+		AddedAt:    device.AddedAt,
+		LastSeenAt: device.LastSeenAt,
+		RevokedAt:  device.RevokedAt,
+		Current:    device.DeviceID == self,
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_Devices tests listing devices, rotating this device's key and revoking another device.
+func TestIntegration_Devices(t *testing.T) {
+	ctx := context.Background()
+	dataDir := t.TempDir()
+
+	// Register a second device in the same registry, as if it had opened the account here
+	phone := NewBackend()
+	_, err := phone.Init(ctx, &pb.InitRequest{DataDir: dataDir, DeviceId: "phone", DeviceName: "Phone"})
+	require.NoError(t, err)
+	_, err = phone.CreateSpace(ctx, &pb.CreateSpaceRequest{SpaceId: "team", Name: "Team"})
+	require.NoError(t, err)
+	_, err = phone.Shutdown(ctx, &pb.ShutdownRequest{})
+	require.NoError(t, err)
+
+	b := NewBackend()
+	_, err = b.Init(ctx, &pb.InitRequest{DataDir: dataDir, DeviceId: "laptop", DeviceName: "Laptop"})
+	require.NoError(t, err)
+	defer b.Shutdown(ctx, &pb.ShutdownRequest{})
+
+	resp, err := b.ListDevices(ctx, &pb.ListDevicesRequest{})
+	require.NoError(t, err)
+	devices := make(map[string]*pb.DeviceInfo)
+	for _, device := range resp.(*pb.ListDevicesResponse).Devices {
+		devices[device.DeviceId] = device
+	}
+	require.Len(t, devices, 2)
+	assert.Equal(t, "Phone", devices["phone"].Name)
+	assert.False(t, devices["phone"].Current)
+	assert.Equal(t, "Laptop", devices["laptop"].Name)
+	assert.True(t, devices["laptop"].Current)
+	assert.Equal(t, b.accountManager.GetKeys().PeerId, devices["laptop"].PeerId)
+
+	// permissions returns the ACL permissions of a device key in the space
+	permissions := func(peerKey []byte) list.AclPermissions {
+		t.Helper()
+		key, err := crypto.UnmarshalEd25519PublicKeyProto(peerKey)
+		require.NoError(t, err)
+		space, err := b.spaceManager.GetSpaceObject(ctx, "team")
+		require.NoError(t, err)
+		space.Acl().RLock()
+		defer space.Acl().RUnlock()
+		return space.Acl().AclState().Permissions(key)
+	}
+	assert.True(t, permissions(devices["laptop"].PeerKey).CanWrite())
+
+	// Rotate; the spaces reopen with the new key, and the previous one stays
+	// in the ACL while the phone runs with it
+	spaceManager := b.spaceManager
+	rotateResp, err := b.RotateDeviceKey(ctx, &pb.RotateDeviceKeyRequest{})
+	require.NoError(t, err)
+	rotated := rotateResp.(*pb.RotateDeviceKeyResponse).Device
+	assert.Equal(t, "laptop", rotated.DeviceId)
+	assert.NotEqual(t, devices["laptop"].PeerId, rotated.PeerId)
+	assert.Equal(t, b.accountManager.GetKeys().PeerId, rotated.PeerId)
+	assert.NotSame(t, spaceManager, b.spaceManager)
+	assert.True(t, permissions(rotated.PeerKey).CanWrite())
+	assert.True(t, permissions(devices["phone"].PeerKey).CanWrite())
+
+	// Revoke
+	_, err = b.RevokeDevice(ctx, &pb.RevokeDeviceRequest{DeviceId: "laptop"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, ErrorCodeOf(err))
+	_, err = b.RevokeDevice(ctx, &pb.RevokeDeviceRequest{DeviceId: "tablet"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_NOT_FOUND, ErrorCodeOf(err))
+	revokeResp, err := b.RevokeDevice(ctx, &pb.RevokeDeviceRequest{DeviceId: "phone"})
+	require.NoError(t, err)
+	assert.NotZero(t, revokeResp.(*pb.RevokeDeviceResponse).Device.RevokedAt)
+	assert.True(t, permissions(devices["phone"].PeerKey).NoPermissions(), "the revoked key is removed from the ACL")
+	assert.True(t, permissions(rotated.PeerKey).CanWrite())
+	_, err = b.CreateDocument(ctx, &pb.CreateDocumentRequest{SpaceId: "team", DocumentId: "note", Collection: "notes", Data: []byte("hello")})
+	require.NoError(t, err)
+
+	// The revoked device can no longer open the account
+	_, err = phone.Init(ctx, &pb.InitRequest{DataDir: dataDir, DeviceId: "phone"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_PERMISSION_DENIED, ErrorCodeOf(err))
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"time"
+
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// GetStatus reports the state of the backend for health checks and bug
+// reports. Unlike other commands it succeeds before Init.
+func (b *Backend) GetStatus(ctx context.Context, req proto.Message) (proto.Message, error) {
+	resp := &pb.GetStatusResponse{
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	b.mu.RLock()
+	initialized := b.initialized
+	startedAt := b.startedAt
+	resp.Locked = b.locked
+	resp.DataDir = b.dataDir
+	am := b.accountManager
+	sm := b.spaceManager
+	dm := b.documentManager
+	em := b.eventManager
+	b.mu.RUnlock()
+
+	if !initialized {
+		return resp, nil
+	}
+
+	resp.Initialized = true
+	resp.StartedAt = startedAt.Unix()
+	resp.UptimeMs = time.Since(startedAt).Milliseconds()
+	if em != nil {
+		resp.SubscriberCount = int32(em.GetSubscriberCount())
+	}
+	if am != nil {
+		resp.PassphraseProtected, _ = am.PassphraseProtected()
+	}
+	if sm == nil || dm == nil {
+		return resp, nil
+	}
+
+	resp.OpenSpaceCount = int32(sm.OpenSpaceCount())
+	counts := dm.DocumentCounts()
+	for _, space := range sm.SpaceStatuses() {
+		resp.StorageBytes += space.StorageBytes
+		resp.Spaces = append(resp.Spaces, &pb.SpaceDiagnostics{
+			SpaceId:       space.SpaceID,
+			Name:          space.Name,
+			Open:          space.Open,
+			DocumentCount: int32(counts[space.SpaceID]),
+			StorageBytes:  space.StorageBytes,
+			LoadError:     space.LoadError,
+		})
+	}
+	sort.Slice(resp.Spaces, func(i, j int) bool {
+		return resp.Spaces[i].SpaceId < resp.Spaces[j].SpaceId
+	})
+
+	return resp, nil
+}
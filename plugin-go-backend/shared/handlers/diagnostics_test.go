@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"anysync-backend/shared/dispatcher"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestUnit_GetStatus_NotInitialized tests that GetStatus succeeds before Init.
+func TestUnit_GetStatus_NotInitialized(t *testing.T) {
+	for name, d := range map[string]*dispatcher.Dispatcher{
+		"Backend":  NewBackend().NewDispatcher(),
+		"Profiles": NewProfiles().NewDispatcher(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			respBytes, err := d.Dispatch(context.Background(), "GetStatus", nil)
+			require.NoError(t, err)
+			var resp pb.GetStatusResponse
+			require.NoError(t, proto.Unmarshal(respBytes, &resp))
+
+			assert.False(t, resp.Initialized)
+			assert.Empty(t, resp.DataDir)
+			assert.Zero(t, resp.UptimeMs)
+			assert.Empty(t, resp.Spaces)
+			assert.Equal(t, runtime.Version(), resp.GoVersion)
+			assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, resp.Platform)
+		})
+	}
+}
+
+// TestIntegration_GetStatus tests that GetStatus reports spaces, documents and subscribers.
+func TestIntegration_GetStatus(t *testing.T) {
+	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
+	d := b.NewDispatcher()
+
+	tc.CreateDocument([]byte("first"), nil)
+	tc.CreateDocument([]byte("second"), nil)
+	emptySpaceID := tc.CreateSpace("Empty Space", nil)
+
+	subscriberID, _, err := b.Subscribe(tc.Context(), &pb.SubscribeRequest{})
+	require.NoError(t, err)
+	defer b.Unsubscribe(subscriberID)
+
+	respBytes, err := d.Dispatch(tc.Context(), "GetStatus", nil)
+	require.NoError(t, err)
+	var resp pb.GetStatusResponse
+	require.NoError(t, proto.Unmarshal(respBytes, &resp))
+
+	assert.True(t, resp.Initialized)
+	assert.Equal(t, tc.DataDir(), resp.DataDir)
+	assert.NotZero(t, resp.StartedAt)
+	assert.GreaterOrEqual(t, resp.UptimeMs, int64(0))
+	assert.Equal(t, int32(1), resp.SubscriberCount)
+	assert.Equal(t, int32(2), resp.OpenSpaceCount)
+
+	require.Len(t, resp.Spaces, 2)
+	documentCounts := make(map[string]int32)
+	var storageBytes int64
+	for _, space := range resp.Spaces {
+		assert.True(t, space.Open, space.Name)
+		assert.Positive(t, space.StorageBytes, space.Name)
+		assert.Empty(t, space.LoadError, space.Name)
+		documentCounts[space.SpaceId] = space.DocumentCount
+		storageBytes += space.StorageBytes
+	}
+	assert.Equal(t, map[string]int32{tc.SpaceID(): 2, emptySpaceID: 0}, documentCounts)
+	assert.Equal(t, storageBytes, resp.StorageBytes)
+	assert.Less(t, resp.Spaces[0].SpaceId, resp.Spaces[1].SpaceId)
+}
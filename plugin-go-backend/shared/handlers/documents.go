@@ -2,27 +2,62 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"anysync-backend/shared/anysync"
 	pb "anysync-backend/shared/proto/syncspace/v1"
 
 	"google.golang.org/protobuf/proto"
 )
 
+// documentStore is the set of document operations used by the handlers.
+// It is implemented by *anysync.DocumentManager and by *anysync.DocumentTx,
+// so the same handlers work inside an atomic Batch.
+type documentStore interface {
+	CreateDocument(ctx context.Context, spaceID, title string, data []byte, metadata map[string]string) (string, error)
+	GetDocument(ctx context.Context, spaceID, documentID string) ([]byte, *anysync.DocumentMetadata, error)
+	UpdateDocument(ctx context.Context, spaceID, documentID string, data []byte, metadata map[string]string, expectedVersion int64) (int64, error)
+	DeleteDocument(ctx context.Context, spaceID, documentID string) error
+	ListDocuments(ctx context.Context, spaceID string) ([]*anysync.DocumentMetadata, error)
+	QueryDocuments(ctx context.Context, spaceID string, tags []string) ([]*anysync.DocumentMetadata, error)
+}
+
+type documentTxKey struct{}
+
+// withDocumentTx returns a context that routes document handlers through tx.
+func withDocumentTx(ctx context.Context, tx *anysync.DocumentTx) context.Context {
+	return context.WithValue(ctx, documentTxKey{}, tx)
+}
+
+// getDocumentStore returns the transaction carried by ctx, if any, and the
+// backend's DocumentManager otherwise.
+func (b *Backend) getDocumentStore(ctx context.Context) (documentStore, error) {
+	if tx, ok := ctx.Value(documentTxKey{}).(*anysync.DocumentTx); ok {
+		return tx, nil
+	}
+
+	b.mu.RLock()
+	docManager := b.documentManager
+	b.mu.RUnlock()
+
+	if docManager == nil {
+		return nil, fmt.Errorf("document manager %w", ErrNotInitialized)
+	}
+	return docManager, nil
+}
+
 // CreateDocument handles document creation.
-func CreateDocument(ctx context.Context, req proto.Message) (proto.Message, error) {
-	if err := ensureInitialized(); err != nil {
+func (b *Backend) CreateDocument(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
 		return nil, err
 	}
 
 	docReq := req.(*pb.CreateDocumentRequest)
 
-	globalState.mu.RLock()
-	docManager := globalState.documentManager
-	globalState.mu.RUnlock()
-
-	if docManager == nil {
-		return nil, fmt.Errorf("document manager not initialized")
+	docManager, err := b.getDocumentStore(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// Extract title from metadata if present, otherwise use empty string
@@ -35,6 +70,7 @@ func CreateDocument(ctx context.Context, req proto.Message) (proto.Message, erro
 
 	// Create document using DocumentManager
 	documentID, err := docManager.CreateDocument(
+		ctx,
 		docReq.SpaceId,
 		title,
 		docReq.Data,
@@ -51,29 +87,28 @@ func CreateDocument(ctx context.Context, req proto.Message) (proto.Message, erro
 }
 
 // GetDocument retrieves a document.
-func GetDocument(ctx context.Context, req proto.Message) (proto.Message, error) {
-	if err := ensureInitialized(); err != nil {
+func (b *Backend) GetDocument(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
 		return nil, err
 	}
 
 	getReq := req.(*pb.GetDocumentRequest)
 
-	globalState.mu.RLock()
-	docManager := globalState.documentManager
-	globalState.mu.RUnlock()
-
-	if docManager == nil {
-		return nil, fmt.Errorf("document manager not initialized")
+	docManager, err := b.getDocumentStore(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get document using DocumentManager
-	data, metadata, err := docManager.GetDocument(getReq.SpaceId, getReq.DocumentId)
-	if err != nil {
-		// Document not found or other error
+	data, metadata, err := docManager.GetDocument(ctx, getReq.SpaceId, getReq.DocumentId)
+	if errors.Is(err, anysync.ErrNotFound) {
 		return &pb.GetDocumentResponse{
 			Found: false,
 		}, nil
 	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
 
 	return &pb.GetDocumentResponse{
 		Found: true,
@@ -83,7 +118,7 @@ func GetDocument(ctx context.Context, req proto.Message) (proto.Message, error)
 			Collection: "", // TODO: Add collection support
 			Data:       data,
 			Metadata:   metadata.Metadata,
-			Version:    1, // TODO: Add version tracking
+			Version:    metadata.Version,
 			CreatedAt:  metadata.CreatedAt,
 			UpdatedAt:  metadata.UpdatedAt,
 		},
@@ -91,61 +126,55 @@ func GetDocument(ctx context.Context, req proto.Message) (proto.Message, error)
 }
 
 // UpdateDocument updates a document.
-func UpdateDocument(ctx context.Context, req proto.Message) (proto.Message, error) {
-	if err := ensureInitialized(); err != nil {
+func (b *Backend) UpdateDocument(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
 		return nil, err
 	}
 
 	updateReq := req.(*pb.UpdateDocumentRequest)
 
-	globalState.mu.RLock()
-	docManager := globalState.documentManager
-	globalState.mu.RUnlock()
-
-	if docManager == nil {
-		return nil, fmt.Errorf("document manager not initialized")
+	docManager, err := b.getDocumentStore(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// Update document using DocumentManager
-	err := docManager.UpdateDocument(
+	version, err := docManager.UpdateDocument(
+		ctx,
 		updateReq.SpaceId,
 		updateReq.DocumentId,
 		updateReq.Data,
 		updateReq.Metadata,
+		updateReq.ExpectedVersion,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update document: %w", err)
 	}
 
-	// TODO: Implement version checking for optimistic locking
-
 	return &pb.UpdateDocumentResponse{
-		Version: 2, // TODO: Return actual version
+		Version: version,
 	}, nil
 }
 
 // DeleteDocument deletes a document.
-func DeleteDocument(ctx context.Context, req proto.Message) (proto.Message, error) {
-	if err := ensureInitialized(); err != nil {
+func (b *Backend) DeleteDocument(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
 		return nil, err
 	}
 
 	deleteReq := req.(*pb.DeleteDocumentRequest)
 
-	globalState.mu.RLock()
-	docManager := globalState.documentManager
-	globalState.mu.RUnlock()
-
-	if docManager == nil {
-		return nil, fmt.Errorf("document manager not initialized")
+	docManager, err := b.getDocumentStore(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// Check if document exists before deletion
-	_, _, err := docManager.GetDocument(deleteReq.SpaceId, deleteReq.DocumentId)
+	_, _, err = docManager.GetDocument(ctx, deleteReq.SpaceId, deleteReq.DocumentId)
 	existed := err == nil
 
 	// Delete document using DocumentManager
-	err = docManager.DeleteDocument(deleteReq.SpaceId, deleteReq.DocumentId)
+	err = docManager.DeleteDocument(ctx, deleteReq.SpaceId, deleteReq.DocumentId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete document: %w", err)
 	}
@@ -156,23 +185,20 @@ func DeleteDocument(ctx context.Context, req proto.Message) (proto.Message, erro
 }
 
 // ListDocuments lists documents.
-func ListDocuments(ctx context.Context, req proto.Message) (proto.Message, error) {
-	if err := ensureInitialized(); err != nil {
+func (b *Backend) ListDocuments(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
 		return nil, err
 	}
 
 	listReq := req.(*pb.ListDocumentsRequest)
 
-	globalState.mu.RLock()
-	docManager := globalState.documentManager
-	globalState.mu.RUnlock()
-
-	if docManager == nil {
-		return nil, fmt.Errorf("document manager not initialized")
+	docManager, err := b.getDocumentStore(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// List documents using DocumentManager
-	metadataList, err := docManager.ListDocuments(listReq.SpaceId)
+	metadataList, err := docManager.ListDocuments(ctx, listReq.SpaceId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list documents: %w", err)
 	}
@@ -192,7 +218,7 @@ func ListDocuments(ctx context.Context, req proto.Message) (proto.Message, error
 			DocumentId: metadata.DocumentID,
 			Collection: listReq.Collection, // Echo back the requested collection
 			Metadata:   metadata.Metadata,
-			Version:    1, // TODO: Add version tracking
+			Version:    metadata.Version,
 			CreatedAt:  metadata.CreatedAt,
 			UpdatedAt:  metadata.UpdatedAt,
 		})
@@ -205,19 +231,16 @@ func ListDocuments(ctx context.Context, req proto.Message) (proto.Message, error
 }
 
 // QueryDocuments queries documents.
-func QueryDocuments(ctx context.Context, req proto.Message) (proto.Message, error) {
-	if err := ensureInitialized(); err != nil {
+func (b *Backend) QueryDocuments(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
 		return nil, err
 	}
 
 	queryReq := req.(*pb.QueryDocumentsRequest)
 
-	globalState.mu.RLock()
-	docManager := globalState.documentManager
-	globalState.mu.RUnlock()
-
-	if docManager == nil {
-		return nil, fmt.Errorf("document manager not initialized")
+	docManager, err := b.getDocumentStore(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// Extract tags from filters (simple implementation for now)
@@ -230,7 +253,7 @@ func QueryDocuments(ctx context.Context, req proto.Message) (proto.Message, erro
 	}
 
 	// Query documents using DocumentManager
-	metadataList, err := docManager.QueryDocuments(queryReq.SpaceId, tags)
+	metadataList, err := docManager.QueryDocuments(ctx, queryReq.SpaceId, tags)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query documents: %w", err)
 	}
@@ -250,7 +273,7 @@ func QueryDocuments(ctx context.Context, req proto.Message) (proto.Message, erro
 			DocumentId: metadata.DocumentID,
 			Collection: queryReq.Collection, // Echo back the requested collection
 			Metadata:   metadata.Metadata,
-			Version:    1, // TODO: Add version tracking
+			Version:    metadata.Version,
 			CreatedAt:  metadata.CreatedAt,
 			UpdatedAt:  metadata.UpdatedAt,
 		})
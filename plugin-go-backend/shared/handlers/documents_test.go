@@ -11,7 +11,7 @@ import (
 
 // TestUnit_DocumentHandlers_NotInitialized tests error handling when handlers are called before Init.
 func TestUnit_DocumentHandlers_NotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	ctx := context.Background()
 
@@ -22,32 +22,32 @@ func TestUnit_DocumentHandlers_NotInitialized(t *testing.T) {
 	}{
 		{
 			name:    "CreateDocument",
-			handler: CreateDocument,
+			handler: b.CreateDocument,
 			req:     &pb.CreateDocumentRequest{SpaceId: "test", Data: []byte("test")},
 		},
 		{
 			name:    "GetDocument",
-			handler: GetDocument,
+			handler: b.GetDocument,
 			req:     &pb.GetDocumentRequest{SpaceId: "test", DocumentId: "test"},
 		},
 		{
 			name:    "UpdateDocument",
-			handler: UpdateDocument,
+			handler: b.UpdateDocument,
 			req:     &pb.UpdateDocumentRequest{SpaceId: "test", DocumentId: "test", Data: []byte("test")},
 		},
 		{
 			name:    "DeleteDocument",
-			handler: DeleteDocument,
+			handler: b.DeleteDocument,
 			req:     &pb.DeleteDocumentRequest{SpaceId: "test", DocumentId: "test"},
 		},
 		{
 			name:    "ListDocuments",
-			handler: ListDocuments,
+			handler: b.ListDocuments,
 			req:     &pb.ListDocumentsRequest{SpaceId: "test"},
 		},
 		{
 			name:    "QueryDocuments",
-			handler: QueryDocuments,
+			handler: b.QueryDocuments,
 			req:     &pb.QueryDocumentsRequest{SpaceId: "test"},
 		},
 	}
@@ -66,7 +66,7 @@ func TestUnit_DocumentHandlers_NotInitialized(t *testing.T) {
 }
 
 func TestUnit_Documents_CreateDocumentNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.CreateDocumentRequest{
 		SpaceId:    "space1",
@@ -75,36 +75,36 @@ func TestUnit_Documents_CreateDocumentNotInitialized(t *testing.T) {
 		Data:       []byte("test data"),
 	}
 
-	_, err := CreateDocument(context.Background(), req)
+	_, err := b.CreateDocument(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
 }
 
 func TestUnit_Documents_GetDocumentNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.GetDocumentRequest{
 		SpaceId:    "space1",
 		DocumentId: "doc1",
 	}
 
-	_, err := GetDocument(context.Background(), req)
+	_, err := b.GetDocument(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
 }
 
 func TestUnit_Documents_GetDocumentNotFound(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 	initReq := &pb.InitRequest{
 		DataDir:   t.TempDir(),
 		NetworkId: "test-network",
 		DeviceId:  "test-device",
 	}
-	Init(context.Background(), initReq)
+	b.Init(context.Background(), initReq)
 	t.Cleanup(func() {
-		Shutdown(context.Background(), &pb.ShutdownRequest{})
+		b.Shutdown(context.Background(), &pb.ShutdownRequest{})
 	})
 
 	req := &pb.GetDocumentRequest{
@@ -112,7 +112,7 @@ func TestUnit_Documents_GetDocumentNotFound(t *testing.T) {
 		DocumentId: "nonexistent",
 	}
 
-	resp, err := GetDocument(context.Background(), req)
+	resp, err := b.GetDocument(context.Background(), req)
 	if err != nil {
 		t.Fatalf("GetDocument failed: %v", err)
 	}
@@ -124,7 +124,7 @@ func TestUnit_Documents_GetDocumentNotFound(t *testing.T) {
 }
 
 func TestUnit_Documents_UpdateDocumentNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.UpdateDocumentRequest{
 		SpaceId:    "space1",
@@ -132,36 +132,36 @@ func TestUnit_Documents_UpdateDocumentNotInitialized(t *testing.T) {
 		Data:       []byte("updated data"),
 	}
 
-	_, err := UpdateDocument(context.Background(), req)
+	_, err := b.UpdateDocument(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
 }
 
 func TestUnit_Documents_DeleteDocumentNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.DeleteDocumentRequest{
 		SpaceId:    "space1",
 		DocumentId: "doc1",
 	}
 
-	_, err := DeleteDocument(context.Background(), req)
+	_, err := b.DeleteDocument(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
 }
 
 func TestUnit_Documents_DeleteDocumentNotFound(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 	initReq := &pb.InitRequest{
 		DataDir:   t.TempDir(),
 		NetworkId: "test-network",
 		DeviceId:  "test-device",
 	}
-	Init(context.Background(), initReq)
+	b.Init(context.Background(), initReq)
 	t.Cleanup(func() {
-		Shutdown(context.Background(), &pb.ShutdownRequest{})
+		b.Shutdown(context.Background(), &pb.ShutdownRequest{})
 	})
 
 	// Test with invalid space ID - should error
@@ -170,42 +170,42 @@ func TestUnit_Documents_DeleteDocumentNotFound(t *testing.T) {
 		DocumentId: "nonexistent",
 	}
 
-	_, err := DeleteDocument(context.Background(), req)
+	_, err := b.DeleteDocument(context.Background(), req)
 	if err == nil {
 		t.Error("Expected error for invalid space ID")
 	}
 }
 
 func TestUnit_Documents_ListDocumentsNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.ListDocumentsRequest{
 		SpaceId: "space1",
 	}
 
-	_, err := ListDocuments(context.Background(), req)
+	_, err := b.ListDocuments(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
 }
 
 func TestUnit_Documents_ListDocumentsEmpty(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 	initReq := &pb.InitRequest{
 		DataDir:   t.TempDir(),
 		NetworkId: "test-network",
 		DeviceId:  "test-device",
 	}
-	Init(context.Background(), initReq)
+	b.Init(context.Background(), initReq)
 	t.Cleanup(func() {
-		Shutdown(context.Background(), &pb.ShutdownRequest{})
+		b.Shutdown(context.Background(), &pb.ShutdownRequest{})
 	})
 
 	req := &pb.ListDocumentsRequest{
 		SpaceId: "space1",
 	}
 
-	resp, err := ListDocuments(context.Background(), req)
+	resp, err := b.ListDocuments(context.Background(), req)
 	if err != nil {
 		t.Fatalf("ListDocuments failed: %v", err)
 	}
@@ -217,29 +217,29 @@ func TestUnit_Documents_ListDocumentsEmpty(t *testing.T) {
 }
 
 func TestUnit_Documents_QueryDocumentsNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.QueryDocumentsRequest{
 		SpaceId:    "space1",
 		Collection: "notes",
 	}
 
-	_, err := QueryDocuments(context.Background(), req)
+	_, err := b.QueryDocuments(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
 }
 
 func TestUnit_Documents_QueryDocumentsEmpty(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 	initReq := &pb.InitRequest{
 		DataDir:   t.TempDir(),
 		NetworkId: "test-network",
 		DeviceId:  "test-device",
 	}
-	Init(context.Background(), initReq)
+	b.Init(context.Background(), initReq)
 	t.Cleanup(func() {
-		Shutdown(context.Background(), &pb.ShutdownRequest{})
+		b.Shutdown(context.Background(), &pb.ShutdownRequest{})
 	})
 
 	req := &pb.QueryDocumentsRequest{
@@ -247,7 +247,7 @@ func TestUnit_Documents_QueryDocumentsEmpty(t *testing.T) {
 		Collection: "notes",
 	}
 
-	resp, err := QueryDocuments(context.Background(), req)
+	resp, err := b.QueryDocuments(context.Background(), req)
 	if err != nil {
 		t.Fatalf("QueryDocuments failed: %v", err)
 	}
@@ -3,12 +3,12 @@ package handlers
 // End-to-End Integration Tests
 //
 // These tests validate the complete system lifecycle and data persistence
-// across Init/Shutdown cycles. They use global state (globalState variable)
-// but are now properly isolated using t.Cleanup() and resetGlobalState().
+// across Init/Shutdown cycles. Each test runs its own Backend, restarted in
+// place where a test needs to reopen the same data directory.
 //
-// Test Isolation: ✅ FIXED
+// Test Isolation:
+// - Backends share no state, so tests do not depend on each other
 // - All tests properly clean up using t.Cleanup()
-// - State is reset between test iterations
 // - Tests pass individually, together, and with -count=N
 //
 // Running tests:
@@ -43,6 +43,7 @@ func TestE2E_FullLifecycle(t *testing.T) {
 	}
 
 	ctx := context.Background()
+	b := NewBackend()
 
 	// Step 1: Initialize
 	t.Log("Step 1: Initialize")
@@ -50,9 +51,9 @@ func TestE2E_FullLifecycle(t *testing.T) {
 		DataDir:   dataDir,
 		NetworkId: "test-network-e2e",
 		DeviceId:  "test-device-e2e",
-		Config:    map[string]string{"test": "e2e"},
+		Config:    map[string]string{"network_mode": "local"},
 	}
-	initResp, err := Init(ctx, initReq)
+	initResp, err := b.Init(ctx, initReq)
 	if err != nil {
 		t.Fatalf("Init failed: %v", err)
 	}
@@ -69,7 +70,7 @@ func TestE2E_FullLifecycle(t *testing.T) {
 			"purpose": "full lifecycle test",
 		},
 	}
-	createSpaceResp, err := CreateSpace(ctx, createSpaceReq)
+	createSpaceResp, err := b.CreateSpace(ctx, createSpaceReq)
 	if err != nil {
 		t.Fatalf("CreateSpace failed: %v", err)
 	}
@@ -88,7 +89,7 @@ func TestE2E_FullLifecycle(t *testing.T) {
 			"type":  "test",
 		},
 	}
-	createDocResp, err := CreateDocument(ctx, createDocReq)
+	createDocResp, err := b.CreateDocument(ctx, createDocReq)
 	if err != nil {
 		t.Fatalf("CreateDocument failed: %v", err)
 	}
@@ -103,7 +104,7 @@ func TestE2E_FullLifecycle(t *testing.T) {
 		SpaceId:    spaceID,
 		DocumentId: documentID,
 	}
-	getDocResp, err := GetDocument(ctx, getDocReq)
+	getDocResp, err := b.GetDocument(ctx, getDocReq)
 	if err != nil {
 		t.Fatalf("GetDocument failed: %v", err)
 	}
@@ -118,7 +119,7 @@ func TestE2E_FullLifecycle(t *testing.T) {
 	// Step 5: List Spaces
 	t.Log("Step 5: List Spaces")
 	listSpacesReq := &pb.ListSpacesRequest{}
-	listSpacesResp, err := ListSpaces(ctx, listSpacesReq)
+	listSpacesResp, err := b.ListSpaces(ctx, listSpacesReq)
 	if err != nil {
 		t.Fatalf("ListSpaces failed: %v", err)
 	}
@@ -129,7 +130,7 @@ func TestE2E_FullLifecycle(t *testing.T) {
 	// Step 6: List Documents
 	t.Log("Step 6: List Documents")
 	listDocsReq := &pb.ListDocumentsRequest{SpaceId: spaceID}
-	listDocsResp, err := ListDocuments(ctx, listDocsReq)
+	listDocsResp, err := b.ListDocuments(ctx, listDocsReq)
 	if err != nil {
 		t.Fatalf("ListDocuments failed: %v", err)
 	}
@@ -140,7 +141,7 @@ func TestE2E_FullLifecycle(t *testing.T) {
 	// Step 7: Shutdown
 	t.Log("Step 7: Shutdown")
 	shutdownReq := &pb.ShutdownRequest{}
-	shutdownResp, err := Shutdown(ctx, shutdownReq)
+	shutdownResp, err := b.Shutdown(ctx, shutdownReq)
 	if err != nil {
 		t.Fatalf("Shutdown failed: %v", err)
 	}
@@ -158,6 +159,7 @@ func TestE2E_Persistence(t *testing.T) {
 	}
 
 	ctx := context.Background()
+	b := NewBackend()
 	var spaceID, documentID string
 
 	// First session: Create space and document
@@ -168,7 +170,7 @@ func TestE2E_Persistence(t *testing.T) {
 			NetworkId: "test-network-persist",
 			DeviceId:  "test-device-persist",
 		}
-		_, err := Init(ctx, initReq)
+		_, err := b.Init(ctx, initReq)
 		if err != nil {
 			t.Fatalf("Init failed: %v", err)
 		}
@@ -177,7 +179,7 @@ func TestE2E_Persistence(t *testing.T) {
 			Name:     "Persistence Test Space",
 			Metadata: map[string]string{"test": "persistence"},
 		}
-		createSpaceResp, err := CreateSpace(ctx, createSpaceReq)
+		createSpaceResp, err := b.CreateSpace(ctx, createSpaceReq)
 		if err != nil {
 			t.Fatalf("CreateSpace failed: %v", err)
 		}
@@ -188,13 +190,13 @@ func TestE2E_Persistence(t *testing.T) {
 			Data:     []byte("Persistent document content"),
 			Metadata: map[string]string{"title": "Persistent Doc"},
 		}
-		createDocResp, err := CreateDocument(ctx, createDocReq)
+		createDocResp, err := b.CreateDocument(ctx, createDocReq)
 		if err != nil {
 			t.Fatalf("CreateDocument failed: %v", err)
 		}
 		documentID = createDocResp.(*pb.CreateDocumentResponse).DocumentId
 
-		_, err = Shutdown(ctx, &pb.ShutdownRequest{})
+		_, err = b.Shutdown(ctx, &pb.ShutdownRequest{})
 		if err != nil {
 			t.Fatalf("Shutdown failed: %v", err)
 		}
@@ -211,17 +213,17 @@ func TestE2E_Persistence(t *testing.T) {
 			NetworkId: "test-network-persist",
 			DeviceId:  "test-device-persist",
 		}
-		_, err := Init(ctx, initReq)
+		_, err := b.Init(ctx, initReq)
 		if err != nil {
 			t.Fatalf("Init failed after restart: %v", err)
 		}
 		t.Cleanup(func() {
-			Shutdown(ctx, &pb.ShutdownRequest{})
+			b.Shutdown(ctx, &pb.ShutdownRequest{})
 		})
 
 		// Verify space still exists
 		listSpacesReq := &pb.ListSpacesRequest{}
-		listSpacesResp, err := ListSpaces(ctx, listSpacesReq)
+		listSpacesResp, err := b.ListSpaces(ctx, listSpacesReq)
 		if err != nil {
 			t.Fatalf("ListSpaces failed: %v", err)
 		}
@@ -249,7 +251,7 @@ func TestE2E_Persistence(t *testing.T) {
 			SpaceId:    spaceID,
 			DocumentId: documentID,
 		}
-		getDocResp, err := GetDocument(ctx, getDocReq)
+		getDocResp, err := b.GetDocument(ctx, getDocReq)
 		if err != nil {
 			t.Fatalf("GetDocument failed: %v", err)
 		}
@@ -273,18 +275,19 @@ func TestE2E_MultipleSpaces(t *testing.T) {
 	}
 
 	ctx := context.Background()
+	b := NewBackend()
 
 	initReq := &pb.InitRequest{
 		DataDir:   dataDir,
 		NetworkId: "test-network-multi",
 		DeviceId:  "test-device-multi",
 	}
-	_, err := Init(ctx, initReq)
+	_, err := b.Init(ctx, initReq)
 	if err != nil {
 		t.Fatalf("Init failed: %v", err)
 	}
 	t.Cleanup(func() {
-		Shutdown(ctx, &pb.ShutdownRequest{})
+		b.Shutdown(ctx, &pb.ShutdownRequest{})
 	})
 
 	// Create multiple spaces
@@ -297,7 +300,7 @@ func TestE2E_MultipleSpaces(t *testing.T) {
 				"index": fmt.Sprintf("%d", i),
 			},
 		}
-		createSpaceResp, err := CreateSpace(ctx, createSpaceReq)
+		createSpaceResp, err := b.CreateSpace(ctx, createSpaceReq)
 		if err != nil {
 			t.Fatalf("CreateSpace %d failed: %v", i, err)
 		}
@@ -315,7 +318,7 @@ func TestE2E_MultipleSpaces(t *testing.T) {
 					"doc":   fmt.Sprintf("%d", j),
 				},
 			}
-			_, err := CreateDocument(ctx, createDocReq)
+			_, err := b.CreateDocument(ctx, createDocReq)
 			if err != nil {
 				t.Fatalf("CreateDocument failed for space %d, doc %d: %v", i, j, err)
 			}
@@ -325,7 +328,7 @@ func TestE2E_MultipleSpaces(t *testing.T) {
 	// Verify each space has its documents
 	for i, spaceID := range spaceIDs {
 		listDocsReq := &pb.ListDocumentsRequest{SpaceId: spaceID}
-		listDocsResp, err := ListDocuments(ctx, listDocsReq)
+		listDocsResp, err := b.ListDocuments(ctx, listDocsReq)
 		if err != nil {
 			t.Fatalf("ListDocuments failed for space %d: %v", i, err)
 		}
@@ -337,7 +340,7 @@ func TestE2E_MultipleSpaces(t *testing.T) {
 
 	// Verify total space count
 	listSpacesReq := &pb.ListSpacesRequest{}
-	listSpacesResp, err := ListSpaces(ctx, listSpacesReq)
+	listSpacesResp, err := b.ListSpaces(ctx, listSpacesReq)
 	if err != nil {
 		t.Fatalf("ListSpaces failed: %v", err)
 	}
@@ -355,13 +358,14 @@ func TestE2E_ErrorHandling(t *testing.T) {
 	}
 
 	ctx := context.Background()
+	b := NewBackend()
 
 	t.Run("Operations before Init", func(t *testing.T) {
 		// Ensure clean state
-		Shutdown(ctx, &pb.ShutdownRequest{})
+		b.Shutdown(ctx, &pb.ShutdownRequest{})
 
 		// Try creating space before init
-		_, err := CreateSpace(ctx, &pb.CreateSpaceRequest{Name: "Test"})
+		_, err := b.CreateSpace(ctx, &pb.CreateSpaceRequest{Name: "Test"})
 		if err == nil {
 			t.Error("Expected error when creating space before Init")
 		}
@@ -374,18 +378,18 @@ func TestE2E_ErrorHandling(t *testing.T) {
 			NetworkId: "test-network-error",
 			DeviceId:  "test-device-error",
 		}
-		_, err := Init(ctx, initReq)
+		_, err := b.Init(ctx, initReq)
 		if err != nil {
 			t.Fatalf("First Init failed: %v", err)
 		}
 
 		// Try second init
-		_, err = Init(ctx, initReq)
+		_, err = b.Init(ctx, initReq)
 		if err == nil {
 			t.Error("Expected error on double Init")
 		}
 
-		Shutdown(ctx, &pb.ShutdownRequest{})
+		b.Shutdown(ctx, &pb.ShutdownRequest{})
 	})
 
 	t.Run("Invalid Space ID", func(t *testing.T) {
@@ -394,14 +398,14 @@ func TestE2E_ErrorHandling(t *testing.T) {
 			NetworkId: "test-network-error",
 			DeviceId:  "test-device-error",
 		}
-		_, err := Init(ctx, initReq)
+		_, err := b.Init(ctx, initReq)
 		if err != nil {
 			t.Fatalf("Init failed: %v", err)
 		}
-		defer Shutdown(ctx, &pb.ShutdownRequest{})
+		defer b.Shutdown(ctx, &pb.ShutdownRequest{})
 
 		// Try to create document in nonexistent space
-		_, err = CreateDocument(ctx, &pb.CreateDocumentRequest{
+		_, err = b.CreateDocument(ctx, &pb.CreateDocumentRequest{
 			SpaceId: "invalid-space-id-12345",
 			Data:    []byte("test"),
 		})
@@ -412,10 +416,10 @@ func TestE2E_ErrorHandling(t *testing.T) {
 
 	t.Run("Shutdown before Init", func(t *testing.T) {
 		// Ensure clean state
-		Shutdown(ctx, &pb.ShutdownRequest{})
+		b.Shutdown(ctx, &pb.ShutdownRequest{})
 
 		// Try shutdown before init
-		_, err := Shutdown(ctx, &pb.ShutdownRequest{})
+		_, err := b.Shutdown(ctx, &pb.ShutdownRequest{})
 		if err == nil {
 			t.Error("Expected error when shutting down before Init")
 		}
@@ -431,21 +435,22 @@ func TestE2E_DocumentVersioning(t *testing.T) {
 	}
 
 	ctx := context.Background()
+	b := NewBackend()
 
 	initReq := &pb.InitRequest{
 		DataDir:   dataDir,
 		NetworkId: "test-network-version",
 		DeviceId:  "test-device-version",
 	}
-	_, err := Init(ctx, initReq)
+	_, err := b.Init(ctx, initReq)
 	if err != nil {
 		t.Fatalf("Init failed: %v", err)
 	}
-	defer Shutdown(ctx, &pb.ShutdownRequest{})
+	defer b.Shutdown(ctx, &pb.ShutdownRequest{})
 
 	// Create space
 	createSpaceReq := &pb.CreateSpaceRequest{Name: "Version Test Space"}
-	createSpaceResp, err := CreateSpace(ctx, createSpaceReq)
+	createSpaceResp, err := b.CreateSpace(ctx, createSpaceReq)
 	if err != nil {
 		t.Fatalf("CreateSpace failed: %v", err)
 	}
@@ -456,7 +461,7 @@ func TestE2E_DocumentVersioning(t *testing.T) {
 		SpaceId: spaceID,
 		Data:    []byte("version 1"),
 	}
-	createDocResp, err := CreateDocument(ctx, createDocReq)
+	createDocResp, err := b.CreateDocument(ctx, createDocReq)
 	if err != nil {
 		t.Fatalf("CreateDocument failed: %v", err)
 	}
@@ -467,7 +472,7 @@ func TestE2E_DocumentVersioning(t *testing.T) {
 		SpaceId:    spaceID,
 		DocumentId: documentID,
 	}
-	getDocResp, err := GetDocument(ctx, getDocReq)
+	getDocResp, err := b.GetDocument(ctx, getDocReq)
 	if err != nil {
 		t.Fatalf("GetDocument failed: %v", err)
 	}
@@ -483,13 +488,13 @@ func TestE2E_DocumentVersioning(t *testing.T) {
 			DocumentId: documentID,
 			Data:       []byte(fmt.Sprintf("version %d", i)),
 		}
-		_, err := UpdateDocument(ctx, updateDocReq)
+		_, err := b.UpdateDocument(ctx, updateDocReq)
 		if err != nil {
 			t.Fatalf("UpdateDocument (v%d) failed: %v", i, err)
 		}
 
 		// Verify update
-		getDocResp, err := GetDocument(ctx, getDocReq)
+		getDocResp, err := b.GetDocument(ctx, getDocReq)
 		if err != nil {
 			t.Fatalf("GetDocument (v%d) failed: %v", i, err)
 		}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"anysync-backend/shared/anysync"
+	"anysync-backend/shared/dispatcher"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+)
+
+// Lifecycle errors returned by handlers.
+var (
+	// ErrNotInitialized is returned when a command is called before Init.
+	ErrNotInitialized = errors.New("not initialized")
+	// ErrAlreadyInitialized is returned when Init is called twice without Shutdown.
+	ErrAlreadyInitialized = errors.New("already initialized")
+	// ErrNotImplemented is returned by commands that are not implemented yet.
+	ErrNotImplemented = errors.New("not implemented yet")
+	// ErrShuttingDown is returned for commands received while Shutdown drains the backend.
+	ErrShuttingDown = errors.New("backend is shutting down")
+)
+
+// ToProtoError converts an error returned by Dispatch into a structured
+// CommandError. The code is derived from the sentinel errors of the handlers,
+// anysync and dispatcher packages; anything else maps to ERROR_CODE_INTERNAL.
+// A recovered panic reports the failing command in the "command" detail.
+// Returns nil for a nil error.
+func ToProtoError(err error) *pb.CommandError {
+	if err == nil {
+		return nil
+	}
+
+	pbErr := &pb.CommandError{
+		Code:    ErrorCodeOf(err),
+		Message: err.Error(),
+	}
+
+	var detailed *anysync.Error
+	if errors.As(err, &detailed) && len(detailed.Details) > 0 {
+		pbErr.Details = make(map[string]string, len(detailed.Details))
+		for k, v := range detailed.Details {
+			pbErr.Details[k] = v
+		}
+	}
+
+	var panicErr *dispatcher.PanicError
+	if errors.As(err, &panicErr) {
+		pbErr.Details = map[string]string{"command": panicErr.Command}
+	}
+
+	return pbErr
+}
+
+// ErrorCodeOf returns the ErrorCode that classifies err.
+func ErrorCodeOf(err error) pb.ErrorCode {
+	switch {
+	case err == nil:
+		return pb.ErrorCode_ERROR_CODE_UNSPECIFIED
+	case errors.Is(err, dispatcher.ErrPanic):
+		return pb.ErrorCode_ERROR_CODE_INTERNAL
+	case errors.Is(err, ErrNotInitialized):
+		return pb.ErrorCode_ERROR_CODE_NOT_INITIALIZED
+	case errors.Is(err, ErrAlreadyInitialized):
+		return pb.ErrorCode_ERROR_CODE_ALREADY_INITIALIZED
+	case errors.Is(err, ErrShuttingDown), errors.Is(err, anysync.ErrUnavailable):
+		return pb.ErrorCode_ERROR_CODE_UNAVAILABLE
+	case errors.Is(err, ErrNotImplemented), errors.Is(err, dispatcher.ErrUnknownCommand):
+		return pb.ErrorCode_ERROR_CODE_UNIMPLEMENTED
+	case errors.Is(err, anysync.ErrInvalidArgument), errors.Is(err, dispatcher.ErrInvalidPayload),
+		errors.Is(err, dispatcher.ErrCommandKind):
+		return pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT
+	case errors.Is(err, anysync.ErrNotFound):
+		return pb.ErrorCode_ERROR_CODE_NOT_FOUND
+	case errors.Is(err, anysync.ErrAlreadyExists):
+		return pb.ErrorCode_ERROR_CODE_ALREADY_EXISTS
+	case errors.Is(err, anysync.ErrVersionConflict):
+		return pb.ErrorCode_ERROR_CODE_VERSION_CONFLICT
+	case errors.Is(err, anysync.ErrLocked):
+		return pb.ErrorCode_ERROR_CODE_LOCKED
+	case errors.Is(err, anysync.ErrPermissionDenied):
+		return pb.ErrorCode_ERROR_CODE_PERMISSION_DENIED
+	case errors.Is(err, context.DeadlineExceeded):
+		return pb.ErrorCode_ERROR_CODE_DEADLINE_EXCEEDED
+	case errors.Is(err, context.Canceled):
+		return pb.ErrorCode_ERROR_CODE_CANCELLED
+	default:
+		return pb.ErrorCode_ERROR_CODE_INTERNAL
+	}
+}
+
+// reportPanic logs a recovered handler panic with its stack and emits a
+// backend.panic event so clients learn that a command crashed.
+func (b *Backend) reportPanic(err *dispatcher.PanicError) {
+	fmt.Printf("Error: %v\n%s\n", err, err.Stack)
+
+	b.mu.RLock()
+	eventManager := b.eventManager
+	b.mu.RUnlock()
+
+	if eventManager != nil {
+		eventManager.EmitEvent(anysync.EventBackendPanic, "", map[string]string{
+			"command": err.Command,
+			"error":   fmt.Sprint(err.Value),
+		})
+	}
+}
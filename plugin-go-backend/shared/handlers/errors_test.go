@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"anysync-backend/shared/anysync"
+	"anysync-backend/shared/dispatcher"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// TestUnit_ToProtoError tests classification of errors into error codes.
+func TestUnit_ToProtoError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code pb.ErrorCode
+	}{
+		{"NotInitialized", fmt.Errorf("%w: call Init first", ErrNotInitialized), pb.ErrorCode_ERROR_CODE_NOT_INITIALIZED},
+		{"AlreadyInitialized", ErrAlreadyInitialized, pb.ErrorCode_ERROR_CODE_ALREADY_INITIALIZED},
+		{"NotImplemented", ErrNotImplemented, pb.ErrorCode_ERROR_CODE_UNIMPLEMENTED},
+		{"UnknownCommand", fmt.Errorf("%w: Foo", dispatcher.ErrUnknownCommand), pb.ErrorCode_ERROR_CODE_UNIMPLEMENTED},
+		{"InvalidPayload", dispatcher.ErrInvalidPayload, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT},
+		{"InvalidArgument", anysync.ErrInvalidArgument, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT},
+		{"NotFound", fmt.Errorf("failed: %w", anysync.ErrNotFound), pb.ErrorCode_ERROR_CODE_NOT_FOUND},
+		{"AlreadyExists", anysync.ErrAlreadyExists, pb.ErrorCode_ERROR_CODE_ALREADY_EXISTS},
+		{"VersionConflict", anysync.ErrVersionConflict, pb.ErrorCode_ERROR_CODE_VERSION_CONFLICT},
+		{"DeadlineExceeded", context.DeadlineExceeded, pb.ErrorCode_ERROR_CODE_DEADLINE_EXCEEDED},
+		{"Cancelled", context.Canceled, pb.ErrorCode_ERROR_CODE_CANCELLED},
+		{"ShuttingDown", ErrShuttingDown, pb.ErrorCode_ERROR_CODE_UNAVAILABLE},
+		{"Unavailable", &anysync.Error{Kind: anysync.ErrUnavailable, Message: "peer is not reachable"}, pb.ErrorCode_ERROR_CODE_UNAVAILABLE},
+		{"Locked", &anysync.Error{Kind: anysync.ErrLocked, Message: "wrong passphrase"}, pb.ErrorCode_ERROR_CODE_LOCKED},
+		{"PermissionDenied", &anysync.Error{Kind: anysync.ErrPermissionDenied, Message: "device was revoked"}, pb.ErrorCode_ERROR_CODE_PERMISSION_DENIED},
+		{"Panic", &dispatcher.PanicError{Command: "Foo", Value: context.Canceled}, pb.ErrorCode_ERROR_CODE_INTERNAL},
+		{"Other", errors.New("boom"), pb.ErrorCode_ERROR_CODE_INTERNAL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pbErr := ToProtoError(tt.err)
+			if pbErr.Code != tt.code {
+				t.Errorf("expected code %v, got %v", tt.code, pbErr.Code)
+			}
+			if pbErr.Message != tt.err.Error() {
+				t.Errorf("expected message %q, got %q", tt.err.Error(), pbErr.Message)
+			}
+		})
+	}
+
+	if ToProtoError(nil) != nil {
+		t.Error("expected nil for nil error")
+	}
+}
+
+// TestUnit_ToProtoError_Details tests that manager error details are preserved through wrapping.
+func TestUnit_ToProtoError_Details(t *testing.T) {
+	err := fmt.Errorf("failed to update document: %w", &anysync.Error{
+		Kind:    anysync.ErrNotFound,
+		Message: "document not found: doc-1",
+		Details: map[string]string{"space_id": "space-1", "document_id": "doc-1"},
+	})
+
+	pbErr := ToProtoError(err)
+	if pbErr.Code != pb.ErrorCode_ERROR_CODE_NOT_FOUND {
+		t.Errorf("expected NOT_FOUND, got %v", pbErr.Code)
+	}
+	if pbErr.Details["document_id"] != "doc-1" || pbErr.Details["space_id"] != "space-1" {
+		t.Errorf("unexpected details: %v", pbErr.Details)
+	}
+}
+
+// TestUnit_Dispatch_ErrorCodes tests error codes for failures before and around handlers.
+func TestUnit_Dispatch_ErrorCodes(t *testing.T) {
+	b := NewBackend()
+
+	d := b.NewDispatcher()
+	ctx := context.Background()
+
+	_, err := d.Dispatch(ctx, "NoSuchCommand", nil)
+	if code := ErrorCodeOf(err); code != pb.ErrorCode_ERROR_CODE_UNIMPLEMENTED {
+		t.Errorf("unknown command: expected UNIMPLEMENTED, got %v", code)
+	}
+
+	payload, _ := proto.Marshal(&pb.ListSpacesRequest{})
+	_, err = d.Dispatch(ctx, "ListSpaces", payload)
+	if code := ErrorCodeOf(err); code != pb.ErrorCode_ERROR_CODE_NOT_INITIALIZED {
+		t.Errorf("before Init: expected NOT_INITIALIZED, got %v", code)
+	}
+
+	payload, _ = proto.Marshal(&pb.InitRequest{})
+	_, err = d.Dispatch(ctx, "Init", payload)
+	if code := ErrorCodeOf(err); code != pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT {
+		t.Errorf("empty data_dir: expected INVALID_ARGUMENT, got %v", code)
+	}
+}
+
+// TestIntegration_Dispatch_Panic tests that a panicking handler becomes an
+// INTERNAL error and a backend.panic event instead of crashing the process.
+func TestIntegration_Dispatch_Panic(t *testing.T) {
+	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
+
+	d := b.NewDispatcher()
+	d.Register("Explode", func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		_ = req.(*pb.StartSyncRequest) // wrong type assertion
+		return nil, nil
+	}, &pb.PauseSyncRequest{}, &pb.PauseSyncResponse{})
+
+	subscriberID, eventChan, err := b.Subscribe(tc.Context(), &pb.SubscribeRequest{EventTypes: []string{"backend.panic"}})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	defer b.Unsubscribe(subscriberID)
+
+	_, err = d.Dispatch(tc.Context(), "Explode", nil)
+	pbErr := ToProtoError(err)
+	if pbErr.GetCode() != pb.ErrorCode_ERROR_CODE_INTERNAL {
+		t.Fatalf("expected INTERNAL, got %v (%v)", pbErr.GetCode(), err)
+	}
+	if pbErr.Details["command"] != "Explode" {
+		t.Errorf("expected command detail 'Explode', got %q", pbErr.Details["command"])
+	}
+
+	select {
+	case event := <-eventChan:
+		if event.Payload["command"] != "Explode" {
+			t.Errorf("expected event for 'Explode', got %v", event.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for backend.panic event")
+	}
+
+	// Other commands keep working
+	payload, _ := proto.Marshal(&pb.ListSpacesRequest{})
+	if _, err := d.Dispatch(tc.Context(), "ListSpaces", payload); err != nil {
+		t.Errorf("ListSpaces failed after panic: %v", err)
+	}
+}
+
+// TestIntegration_ErrorCodes tests error codes produced by the managers.
+func TestIntegration_ErrorCodes(t *testing.T) {
+	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
+	ctx := tc.Context()
+
+	docID := tc.CreateDocument([]byte("v1"), nil)
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		resp, err := b.UpdateDocument(ctx, &pb.UpdateDocumentRequest{
+			SpaceId:         tc.SpaceID(),
+			DocumentId:      docID,
+			Data:            []byte("v2"),
+			ExpectedVersion: 1,
+		})
+		if err != nil {
+			t.Fatalf("UpdateDocument failed: %v", err)
+		}
+		if v := resp.(*pb.UpdateDocumentResponse).Version; v != 2 {
+			t.Fatalf("expected version 2, got %d", v)
+		}
+
+		// Stale expected version must be rejected
+		_, err = b.UpdateDocument(ctx, &pb.UpdateDocumentRequest{
+			SpaceId:         tc.SpaceID(),
+			DocumentId:      docID,
+			Data:            []byte("stale"),
+			ExpectedVersion: 1,
+		})
+		pbErr := ToProtoError(err)
+		if pbErr.GetCode() != pb.ErrorCode_ERROR_CODE_VERSION_CONFLICT {
+			t.Fatalf("expected VERSION_CONFLICT, got %v (%v)", pbErr.GetCode(), err)
+		}
+		if pbErr.Details["current_version"] != "2" {
+			t.Errorf("expected current_version 2, got %q", pbErr.Details["current_version"])
+		}
+	})
+
+	t.Run("DocumentNotFound", func(t *testing.T) {
+		_, err := b.UpdateDocument(ctx, &pb.UpdateDocumentRequest{
+			SpaceId:    tc.SpaceID(),
+			DocumentId: "missing",
+			Data:       []byte("x"),
+		})
+		pbErr := ToProtoError(err)
+		if pbErr.GetCode() != pb.ErrorCode_ERROR_CODE_NOT_FOUND {
+			t.Fatalf("expected NOT_FOUND, got %v (%v)", pbErr.GetCode(), err)
+		}
+		if pbErr.Details["document_id"] != "missing" {
+			t.Errorf("expected document_id detail, got %v", pbErr.Details)
+		}
+	})
+
+	t.Run("SpaceNotFound", func(t *testing.T) {
+		_, err := b.DeleteSpace(ctx, &pb.DeleteSpaceRequest{SpaceId: "missing-space"})
+		if code := ErrorCodeOf(err); code != pb.ErrorCode_ERROR_CODE_NOT_FOUND {
+			t.Errorf("expected NOT_FOUND, got %v (%v)", code, err)
+		}
+	})
+
+	t.Run("AlreadyInitialized", func(t *testing.T) {
+		_, err := b.Init(ctx, &pb.InitRequest{DataDir: tc.DataDir()})
+		if code := ErrorCodeOf(err); code != pb.ErrorCode_ERROR_CODE_ALREADY_INITIALIZED {
+			t.Errorf("expected ALREADY_INITIALIZED, got %v (%v)", code, err)
+		}
+	})
+}
@@ -6,21 +6,26 @@ import (
 
 	"anysync-backend/shared/anysync"
 	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"google.golang.org/protobuf/proto"
 )
 
 // Subscribe creates a subscription to events and returns the subscriber ID and event channel.
-// This is a special handler for streaming RPCs that directly accesses the EventManager.
-func Subscribe(ctx context.Context, req *pb.SubscribeRequest) (string, <-chan *anysync.Event, error) {
-	if err := ensureInitialized(); err != nil {
-		return "", nil, err
-	}
-
-	globalState.mu.RLock()
-	eventManager := globalState.eventManager
-	globalState.mu.RUnlock()
+// It is the channel-based building block of SubscribeStream, for Go callers
+// that consume events directly.
+func (b *Backend) Subscribe(ctx context.Context, req *pb.SubscribeRequest) (string, <-chan *anysync.Event, error) {
+	// A locked session still reports events, such as account.unlocked
+	b.mu.RLock()
+	initialized := b.initialized
+	eventManager := b.eventManager
+	sm := b.spaceManager
+	b.mu.RUnlock()
 
+	if !initialized {
+		return "", nil, fmt.Errorf("%w: call Init first", ErrNotInitialized)
+	}
 	if eventManager == nil {
-		return "", nil, fmt.Errorf("event manager not initialized")
+		return "", nil, fmt.Errorf("event manager %w", ErrNotInitialized)
 	}
 
 	// Convert protobuf event types to anysync.EventType
@@ -29,10 +34,19 @@ func Subscribe(ctx context.Context, req *pb.SubscribeRequest) (string, <-chan *a
 		eventTypes[i] = anysync.EventType(et)
 	}
 
+	// Events carry Any-Sync space IDs, so resolve the aliases of the filter
+	spaceIDs := req.SpaceIds
+	if sm != nil && len(spaceIDs) > 0 {
+		spaceIDs = make([]string, len(req.SpaceIds))
+		for i, spaceID := range req.SpaceIds {
+			spaceIDs[i] = sm.ResolveSpaceID(spaceID)
+		}
+	}
+
 	// Create filter
 	filter := anysync.EventFilter{
 		EventTypes: eventTypes,
-		SpaceIDs:   req.SpaceIds,
+		SpaceIDs:   spaceIDs,
 	}
 
 	// Subscribe to events
@@ -44,14 +58,53 @@ func Subscribe(ctx context.Context, req *pb.SubscribeRequest) (string, <-chan *a
 	return subscriberID, eventChan, nil
 }
 
+// SubscribeStream handles the streaming Subscribe command. It sends a
+// SubscribeResponse for every matching event until ctx is done or the backend
+// shuts down.
+func (b *Backend) SubscribeStream(ctx context.Context, req proto.Message, send func(proto.Message) error) error {
+	subscriberID, eventChan, err := b.Subscribe(ctx, req.(*pb.SubscribeRequest))
+	if err != nil {
+		return err
+	}
+	// Fails harmlessly if the subscription already ended with ctx or Shutdown
+	defer b.Unsubscribe(subscriberID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-eventChan:
+			if !ok {
+				// Channel closed (unsubscribed or shut down)
+				return nil
+			}
+			if err := send(toSubscribeResponse(event)); err != nil {
+				return fmt.Errorf("failed to send event: %w", err)
+			}
+		}
+	}
+}
+
+// toSubscribeResponse converts an anysync event to its wire representation.
+func toSubscribeResponse(event *anysync.Event) *pb.SubscribeResponse {
+	return &pb.SubscribeResponse{
+		EventId:   event.ID,
+		EventType: string(event.Type),
+		SpaceId:   event.SpaceID,
+		Timestamp: event.Timestamp,
+		// TODO: Marshal specific event payload types based on event.Type
+		Payload: []byte{},
+	}
+}
+
 // Unsubscribe removes a subscription by ID.
-func Unsubscribe(subscriberID string) error {
-	globalState.mu.RLock()
-	eventManager := globalState.eventManager
-	globalState.mu.RUnlock()
+func (b *Backend) Unsubscribe(subscriberID string) error {
+	b.mu.RLock()
+	eventManager := b.eventManager
+	b.mu.RUnlock()
 
 	if eventManager == nil {
-		return fmt.Errorf("event manager not initialized")
+		return fmt.Errorf("event manager %w", ErrNotInitialized)
 	}
 
 	return eventManager.Unsubscribe(subscriberID)
@@ -6,18 +6,19 @@ import (
 	"time"
 
 	"anysync-backend/shared/anysync"
+	"anysync-backend/shared/dispatcher"
 	pb "anysync-backend/shared/proto/syncspace/v1"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
-// setupForEventTests initializes the system for event streaming tests
-func setupForEventTests(t *testing.T) context.Context {
+// setupForEventTests initializes a backend for event streaming tests
+func setupForEventTests(t *testing.T) (*Backend, context.Context) {
 	t.Helper()
 
-	// Reset global state
-	globalState = &State{}
+	b := NewBackend()
 
 	// Initialize
 	tempDir := t.TempDir()
@@ -27,23 +28,23 @@ func setupForEventTests(t *testing.T) context.Context {
 		DeviceId:  "test-device",
 	}
 
-	_, err := Init(context.Background(), initReq)
+	_, err := b.Init(context.Background(), initReq)
 	require.NoError(t, err)
 
-	return context.Background()
+	return b, context.Background()
 }
 
 // teardownForEventTests cleans up after event tests
-func teardownForEventTests(t *testing.T) {
+func teardownForEventTests(t *testing.T, b *Backend) {
 	t.Helper()
 	shutdownReq := &pb.ShutdownRequest{}
-	_, _ = Shutdown(context.Background(), shutdownReq)
+	_, _ = b.Shutdown(context.Background(), shutdownReq)
 }
 
 // TestSubscribe_DocumentCreatedEvent tests that document creation triggers events
 func TestUnit_Events_SubscribeDocumentCreatedEvent(t *testing.T) {
-	ctx := setupForEventTests(t)
-	defer teardownForEventTests(t)
+	b, ctx := setupForEventTests(t)
+	defer teardownForEventTests(t, b)
 
 	// Subscribe to document events
 	subscribeReq := &pb.SubscribeRequest{
@@ -51,16 +52,16 @@ func TestUnit_Events_SubscribeDocumentCreatedEvent(t *testing.T) {
 		SpaceIds:   []string{},
 	}
 
-	subscriberID, eventChan, err := Subscribe(ctx, subscribeReq)
+	subscriberID, eventChan, err := b.Subscribe(ctx, subscribeReq)
 	require.NoError(t, err)
 	require.NotEmpty(t, subscriberID)
-	defer Unsubscribe(subscriberID)
+	defer b.Unsubscribe(subscriberID)
 
 	// Create a space first
 	createSpaceReq := &pb.CreateSpaceRequest{
 		Name: "Test Space",
 	}
-	createSpaceResp, err := CreateSpace(context.Background(), createSpaceReq)
+	createSpaceResp, err := b.CreateSpace(context.Background(), createSpaceReq)
 	require.NoError(t, err)
 	spaceResp := createSpaceResp.(*pb.CreateSpaceResponse)
 	spaceID := spaceResp.SpaceId
@@ -70,11 +71,11 @@ func TestUnit_Events_SubscribeDocumentCreatedEvent(t *testing.T) {
 
 	// Create a document using DocumentManager directly
 	docData := []byte("test document content")
-	globalState.mu.RLock()
-	dm := globalState.documentManager
-	globalState.mu.RUnlock()
+	b.mu.RLock()
+	dm := b.documentManager
+	b.mu.RUnlock()
 
-	documentID, err := dm.CreateDocument(spaceID, "Test Doc", docData, nil)
+	documentID, err := dm.CreateDocument(context.Background(), spaceID, "Test Doc", docData, nil)
 	require.NoError(t, err)
 
 	// Wait for event
@@ -92,26 +93,26 @@ func TestUnit_Events_SubscribeDocumentCreatedEvent(t *testing.T) {
 
 // TestSubscribe_DocumentUpdatedEvent tests that document updates trigger events
 func TestUnit_Events_SubscribeDocumentUpdatedEvent(t *testing.T) {
-	ctx := setupForEventTests(t)
-	defer teardownForEventTests(t)
+	b, ctx := setupForEventTests(t)
+	defer teardownForEventTests(t, b)
 
 	// Create space and document first
 	createSpaceReq := &pb.CreateSpaceRequest{
 		Name: "Test Space",
 	}
-	createSpaceResp, err := CreateSpace(context.Background(), createSpaceReq)
+	createSpaceResp, err := b.CreateSpace(context.Background(), createSpaceReq)
 	require.NoError(t, err)
 	spaceResp := createSpaceResp.(*pb.CreateSpaceResponse)
 	spaceID := spaceResp.SpaceId
 
 	time.Sleep(100 * time.Millisecond)
 
-	globalState.mu.RLock()
-	dm := globalState.documentManager
-	globalState.mu.RUnlock()
+	b.mu.RLock()
+	dm := b.documentManager
+	b.mu.RUnlock()
 
 	docData := []byte("initial content")
-	documentID, err := dm.CreateDocument(spaceID, "Test Doc", docData, nil)
+	documentID, err := dm.CreateDocument(context.Background(), spaceID, "Test Doc", docData, nil)
 	require.NoError(t, err)
 
 	// Subscribe to update events
@@ -120,13 +121,13 @@ func TestUnit_Events_SubscribeDocumentUpdatedEvent(t *testing.T) {
 		SpaceIds:   []string{spaceID},
 	}
 
-	subscriberID, eventChan, err := Subscribe(ctx, subscribeReq)
+	subscriberID, eventChan, err := b.Subscribe(ctx, subscribeReq)
 	require.NoError(t, err)
-	defer Unsubscribe(subscriberID)
+	defer b.Unsubscribe(subscriberID)
 
 	// Update the document
 	updatedData := []byte("updated content")
-	err = dm.UpdateDocument(spaceID, documentID, updatedData, nil)
+	_, err = dm.UpdateDocument(context.Background(), spaceID, documentID, updatedData, nil, 0)
 	require.NoError(t, err)
 
 	// Wait for event
@@ -142,8 +143,8 @@ func TestUnit_Events_SubscribeDocumentUpdatedEvent(t *testing.T) {
 
 // TestSubscribe_SpaceDeletedEvent tests that space deletion triggers events
 func TestUnit_Events_SubscribeSpaceDeletedEvent(t *testing.T) {
-	ctx := setupForEventTests(t)
-	defer teardownForEventTests(t)
+	b, ctx := setupForEventTests(t)
+	defer teardownForEventTests(t, b)
 
 	// Subscribe to space events
 	subscribeReq := &pb.SubscribeRequest{
@@ -151,15 +152,15 @@ func TestUnit_Events_SubscribeSpaceDeletedEvent(t *testing.T) {
 		SpaceIds:   []string{},
 	}
 
-	subscriberID, eventChan, err := Subscribe(ctx, subscribeReq)
+	subscriberID, eventChan, err := b.Subscribe(ctx, subscribeReq)
 	require.NoError(t, err)
-	defer Unsubscribe(subscriberID)
+	defer b.Unsubscribe(subscriberID)
 
 	// Create a space
 	createSpaceReq := &pb.CreateSpaceRequest{
 		Name: "Test Space",
 	}
-	createSpaceResp, err := CreateSpace(context.Background(), createSpaceReq)
+	createSpaceResp, err := b.CreateSpace(context.Background(), createSpaceReq)
 	require.NoError(t, err)
 	spaceResp := createSpaceResp.(*pb.CreateSpaceResponse)
 	spaceID := spaceResp.SpaceId
@@ -170,7 +171,7 @@ func TestUnit_Events_SubscribeSpaceDeletedEvent(t *testing.T) {
 	deleteSpaceReq := &pb.DeleteSpaceRequest{
 		SpaceId: spaceID,
 	}
-	_, err = DeleteSpace(context.Background(), deleteSpaceReq)
+	_, err = b.DeleteSpace(context.Background(), deleteSpaceReq)
 	require.NoError(t, err)
 
 	// Wait for event
@@ -185,8 +186,8 @@ func TestUnit_Events_SubscribeSpaceDeletedEvent(t *testing.T) {
 
 // TestSubscribe_EventFiltering tests that event type filtering works
 func TestUnit_Events_SubscribeEventFiltering(t *testing.T) {
-	ctx := setupForEventTests(t)
-	defer teardownForEventTests(t)
+	b, ctx := setupForEventTests(t)
+	defer teardownForEventTests(t, b)
 
 	// Subscribe only to space.created events
 	subscribeReq := &pb.SubscribeRequest{
@@ -194,15 +195,15 @@ func TestUnit_Events_SubscribeEventFiltering(t *testing.T) {
 		SpaceIds:   []string{},
 	}
 
-	subscriberID, eventChan, err := Subscribe(ctx, subscribeReq)
+	subscriberID, eventChan, err := b.Subscribe(ctx, subscribeReq)
 	require.NoError(t, err)
-	defer Unsubscribe(subscriberID)
+	defer b.Unsubscribe(subscriberID)
 
 	// Create a space - should receive event
 	createSpaceReq := &pb.CreateSpaceRequest{
 		Name: "Test Space 1",
 	}
-	createSpaceResp, err := CreateSpace(context.Background(), createSpaceReq)
+	createSpaceResp, err := b.CreateSpace(context.Background(), createSpaceReq)
 	require.NoError(t, err)
 	spaceResp := createSpaceResp.(*pb.CreateSpaceResponse)
 	spaceID := spaceResp.SpaceId
@@ -222,7 +223,7 @@ func TestUnit_Events_SubscribeEventFiltering(t *testing.T) {
 	deleteSpaceReq := &pb.DeleteSpaceRequest{
 		SpaceId: spaceID,
 	}
-	_, err = DeleteSpace(context.Background(), deleteSpaceReq)
+	_, err = b.DeleteSpace(context.Background(), deleteSpaceReq)
 	require.NoError(t, err)
 
 	// Should NOT receive space.deleted event
@@ -236,33 +237,33 @@ func TestUnit_Events_SubscribeEventFiltering(t *testing.T) {
 
 // TestSubscribe_MultipleConcurrentSubscribers tests multiple subscribers receiving events
 func TestUnit_Events_SubscribeMultipleConcurrentSubscribers(t *testing.T) {
-	ctx := setupForEventTests(t)
-	defer teardownForEventTests(t)
+	b, ctx := setupForEventTests(t)
+	defer teardownForEventTests(t, b)
 
 	// Create 3 subscribers
-	subscriber1ID, eventChan1, err := Subscribe(ctx, &pb.SubscribeRequest{
+	subscriber1ID, eventChan1, err := b.Subscribe(ctx, &pb.SubscribeRequest{
 		EventTypes: []string{"space.created"},
 	})
 	require.NoError(t, err)
-	defer Unsubscribe(subscriber1ID)
+	defer b.Unsubscribe(subscriber1ID)
 
-	subscriber2ID, eventChan2, err := Subscribe(ctx, &pb.SubscribeRequest{
+	subscriber2ID, eventChan2, err := b.Subscribe(ctx, &pb.SubscribeRequest{
 		EventTypes: []string{"space.created"},
 	})
 	require.NoError(t, err)
-	defer Unsubscribe(subscriber2ID)
+	defer b.Unsubscribe(subscriber2ID)
 
-	subscriber3ID, eventChan3, err := Subscribe(ctx, &pb.SubscribeRequest{
+	subscriber3ID, eventChan3, err := b.Subscribe(ctx, &pb.SubscribeRequest{
 		EventTypes: []string{}, // All events
 	})
 	require.NoError(t, err)
-	defer Unsubscribe(subscriber3ID)
+	defer b.Unsubscribe(subscriber3ID)
 
 	// Create a space
 	createSpaceReq := &pb.CreateSpaceRequest{
 		Name: "Test Space",
 	}
-	createSpaceResp, err := CreateSpace(context.Background(), createSpaceReq)
+	createSpaceResp, err := b.CreateSpace(context.Background(), createSpaceReq)
 	require.NoError(t, err)
 	spaceResp := createSpaceResp.(*pb.CreateSpaceResponse)
 	spaceID := spaceResp.SpaceId
@@ -293,24 +294,80 @@ func TestUnit_Events_SubscribeMultipleConcurrentSubscribers(t *testing.T) {
 
 // TestSubscribe_NotInitialized tests subscribing before initialization
 func TestUnit_Events_SubscribeNotInitialized(t *testing.T) {
-	// Reset global state
-	globalState = &State{}
+	b := NewBackend()
 
 	subscribeReq := &pb.SubscribeRequest{
 		EventTypes: []string{},
 	}
 
-	_, _, err := Subscribe(context.Background(), subscribeReq)
+	_, _, err := b.Subscribe(context.Background(), subscribeReq)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not initialized")
 }
 
 // TestUnsubscribe_NotFound tests unsubscribing with invalid ID
 func TestUnit_Events_UnsubscribeNotFound(t *testing.T) {
-	_ = setupForEventTests(t)
-	defer teardownForEventTests(t)
+	b, _ := setupForEventTests(t)
+	defer teardownForEventTests(t, b)
 
-	err := Unsubscribe("invalid-subscriber-id")
+	err := b.Unsubscribe("invalid-subscriber-id")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "subscriber not found")
 }
+
+// TestSubscribe_DispatchStream tests the Subscribe stream through the dispatcher
+func TestUnit_Events_SubscribeDispatchStream(t *testing.T) {
+	b, _ := setupForEventTests(t)
+	defer teardownForEventTests(t, b)
+
+	d := dispatcher.New()
+	b.RegisterAll(d)
+
+	createSpaceResp, err := b.CreateSpace(context.Background(), &pb.CreateSpaceRequest{Name: "Test Space"})
+	require.NoError(t, err)
+	spaceID := createSpaceResp.(*pb.CreateSpaceResponse).SpaceId
+
+	reqBytes, err := proto.Marshal(&pb.SubscribeRequest{EventTypes: []string{"document.created"}})
+	require.NoError(t, err)
+
+	streamCtx, cancel := context.WithCancel(context.Background())
+	events := make(chan *pb.SubscribeResponse, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- d.DispatchStream(streamCtx, "Subscribe", reqBytes, func(msgBytes []byte) error {
+			var event pb.SubscribeResponse
+			if err := proto.Unmarshal(msgBytes, &event); err != nil {
+				return err
+			}
+			events <- &event
+			return nil
+		})
+	}()
+
+	// Wait for the subscription to be registered before emitting
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = b.CreateDocument(context.Background(), &pb.CreateDocumentRequest{
+		SpaceId: spaceID,
+		Data:    []byte("test document content"),
+	})
+	require.NoError(t, err)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, "document.created", event.EventType)
+		assert.Equal(t, spaceID, event.SpaceId)
+		assert.NotEmpty(t, event.EventId)
+	case <-time.After(1 * time.Second):
+		t.Fatal("Timeout waiting for document.created event")
+	}
+
+	// Cancelling the context ends the stream
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(1 * time.Second):
+		t.Fatal("Timeout waiting for stream to end")
+	}
+}
@@ -10,6 +10,7 @@ import (
 // All sub-tests run within a single Init/Shutdown cycle for efficiency.
 func TestIntegration_DocumentHandlers(t *testing.T) {
 	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
 
 	t.Run("CreateDocument", func(t *testing.T) {
 		req := &pb.CreateDocumentRequest{
@@ -21,7 +22,7 @@ func TestIntegration_DocumentHandlers(t *testing.T) {
 			},
 		}
 
-		resp, err := CreateDocument(tc.Context(), req)
+		resp, err := b.CreateDocument(tc.Context(), req)
 		if err != nil {
 			t.Fatalf("CreateDocument failed: %v", err)
 		}
@@ -41,7 +42,7 @@ func TestIntegration_DocumentHandlers(t *testing.T) {
 			Data:    []byte("test"),
 		}
 
-		_, err := CreateDocument(tc.Context(), req)
+		_, err := b.CreateDocument(tc.Context(), req)
 		if err == nil {
 			t.Error("Expected error for invalid space ID")
 		}
@@ -59,7 +60,7 @@ func TestIntegration_DocumentHandlers(t *testing.T) {
 			DocumentId: docID,
 		}
 
-		resp, err := GetDocument(tc.Context(), req)
+		resp, err := b.GetDocument(tc.Context(), req)
 		if err != nil {
 			t.Fatalf("GetDocument failed: %v", err)
 		}
@@ -82,7 +83,7 @@ func TestIntegration_DocumentHandlers(t *testing.T) {
 			DocumentId: "nonexistent-doc-id",
 		}
 
-		resp, err := GetDocument(tc.Context(), req)
+		resp, err := b.GetDocument(tc.Context(), req)
 		if err != nil {
 			t.Fatalf("GetDocument failed: %v", err)
 		}
@@ -106,7 +107,7 @@ func TestIntegration_DocumentHandlers(t *testing.T) {
 			Data:       []byte("updated content"),
 		}
 
-		updateResp, err := UpdateDocument(tc.Context(), updateReq)
+		updateResp, err := b.UpdateDocument(tc.Context(), updateReq)
 		if err != nil {
 			t.Fatalf("UpdateDocument failed: %v", err)
 		}
@@ -122,7 +123,7 @@ func TestIntegration_DocumentHandlers(t *testing.T) {
 			DocumentId: docID,
 		}
 
-		getResp, err := GetDocument(tc.Context(), getReq)
+		getResp, err := b.GetDocument(tc.Context(), getReq)
 		if err != nil {
 			t.Fatalf("GetDocument failed: %v", err)
 		}
@@ -148,7 +149,7 @@ func TestIntegration_DocumentHandlers(t *testing.T) {
 			DocumentId: docID,
 		}
 
-		_, err := DeleteDocument(tc.Context(), deleteReq)
+		_, err := b.DeleteDocument(tc.Context(), deleteReq)
 		if err != nil {
 			t.Fatalf("DeleteDocument failed: %v", err)
 		}
@@ -159,7 +160,7 @@ func TestIntegration_DocumentHandlers(t *testing.T) {
 			DocumentId: docID,
 		}
 
-		getResp, err := GetDocument(tc.Context(), getReq)
+		getResp, err := b.GetDocument(tc.Context(), getReq)
 		if err != nil {
 			t.Fatalf("GetDocument failed: %v", err)
 		}
@@ -181,7 +182,7 @@ func TestIntegration_DocumentHandlers(t *testing.T) {
 			SpaceId: tc.SpaceID(),
 		}
 
-		resp, err := ListDocuments(tc.Context(), req)
+		resp, err := b.ListDocuments(tc.Context(), req)
 		if err != nil {
 			t.Fatalf("ListDocuments failed: %v", err)
 		}
@@ -199,7 +200,7 @@ func TestIntegration_DocumentHandlers(t *testing.T) {
 			SpaceId: tc.SpaceID(),
 		}
 
-		resp, err := QueryDocuments(tc.Context(), req)
+		resp, err := b.QueryDocuments(tc.Context(), req)
 		if err != nil {
 			t.Fatalf("QueryDocuments failed: %v", err)
 		}
@@ -214,6 +215,7 @@ func TestIntegration_DocumentHandlers(t *testing.T) {
 // TestIntegration_MultipleSpaces tests creating and managing multiple spaces.
 func TestIntegration_MultipleSpaces(t *testing.T) {
 	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
 
 	t.Run("CreateMultipleSpaces", func(t *testing.T) {
 		space1 := tc.CreateSpace("Space 1", map[string]string{"order": "1"})
@@ -232,7 +234,7 @@ func TestIntegration_MultipleSpaces(t *testing.T) {
 
 	t.Run("ListAllSpaces", func(t *testing.T) {
 		req := &pb.ListSpacesRequest{}
-		resp, err := ListSpaces(tc.Context(), req)
+		resp, err := b.ListSpaces(tc.Context(), req)
 		if err != nil {
 			t.Fatalf("ListSpaces failed: %v", err)
 		}
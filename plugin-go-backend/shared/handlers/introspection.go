@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"anysync-backend/shared/dispatcher"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// NewDescribeCommandsHandler returns the DescribeCommands handler, which
+// reports the commands registered in d. It does not require Init, so clients
+// can check compatibility before initializing the backend.
+func NewDescribeCommandsHandler(d *dispatcher.Dispatcher) dispatcher.Handler {
+	return func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		infos := d.Describe()
+		commands := make([]*pb.CommandInfo, 0, len(infos))
+		for _, info := range infos {
+			commands = append(commands, &pb.CommandInfo{
+				Name:         info.Name,
+				RequestType:  info.RequestType,
+				ResponseType: info.ResponseType,
+				Streaming:    info.Streaming,
+			})
+		}
+
+		// Deterministic so the digest only changes when the schema does
+		fdsBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(d.FileDescriptorSet())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal file descriptor set: %w", err)
+		}
+		digest := sha256.Sum256(fdsBytes)
+
+		return &pb.DescribeCommandsResponse{
+			Commands:          commands,
+			FileDescriptorSet: fdsBytes,
+			SchemaDigest:      hex.EncodeToString(digest[:]),
+		}, nil
+	}
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// TestUnit_DescribeCommands tests command discovery, which must work before Init.
+func TestUnit_DescribeCommands(t *testing.T) {
+	b := NewBackend()
+
+	d := b.NewDispatcher()
+	payload, err := proto.Marshal(&pb.DescribeCommandsRequest{})
+	require.NoError(t, err)
+
+	respBytes, err := d.Dispatch(context.Background(), "DescribeCommands", payload)
+	require.NoError(t, err)
+
+	var resp pb.DescribeCommandsResponse
+	require.NoError(t, proto.Unmarshal(respBytes, &resp))
+
+	// Every registered command is listed with its message types
+	require.Len(t, resp.Commands, len(d.Commands()))
+	byName := make(map[string]*pb.CommandInfo)
+	for _, info := range resp.Commands {
+		byName[info.Name] = info
+	}
+	require.Contains(t, byName, "CreateDocument")
+	assert.Equal(t, "syncspace.v1.CreateDocumentRequest", byName["CreateDocument"].RequestType)
+	assert.Equal(t, "syncspace.v1.CreateDocumentResponse", byName["CreateDocument"].ResponseType)
+	assert.Contains(t, byName, "DescribeCommands")
+
+	// The descriptor set resolves every listed message type
+	var set descriptorpb.FileDescriptorSet
+	require.NoError(t, proto.Unmarshal(resp.FileDescriptorSet, &set))
+	files, err := protodesc.NewFiles(&set)
+	require.NoError(t, err)
+	for _, info := range resp.Commands {
+		_, err := files.FindDescriptorByName(protoreflect.FullName(info.RequestType))
+		assert.NoError(t, err, "request type of %s", info.Name)
+		_, err = files.FindDescriptorByName(protoreflect.FullName(info.ResponseType))
+		assert.NoError(t, err, "response type of %s", info.Name)
+	}
+
+	// The digest is stable across calls
+	respBytes, err = d.Dispatch(context.Background(), "DescribeCommands", payload)
+	require.NoError(t, err)
+	var again pb.DescribeCommandsResponse
+	require.NoError(t, proto.Unmarshal(respBytes, &again))
+	assert.Len(t, resp.SchemaDigest, 64)
+	assert.Equal(t, resp.SchemaDigest, again.SchemaDigest)
+}
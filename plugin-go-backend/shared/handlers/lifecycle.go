@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"anysync-backend/shared/anysync"
 	pb "anysync-backend/shared/proto/syncspace/v1"
@@ -12,147 +14,434 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
-// State holds the global state for the SyncSpace backend.
-type State struct {
+// Backend is one instance of the SyncSpace backend: the account, spaces,
+// documents and events of a single data directory. The command handlers are
+// its methods; NewDispatcher binds them to a dispatcher. Backends share no
+// state, so one process can host several of them.
+type Backend struct {
 	mu              sync.RWMutex
 	dataDir         string
 	networkID       string
 	deviceID        string
-	config          map[string]string
+	config          *Config
 	accountManager  *anysync.AccountManager
+	devices         *anysync.DeviceRegistry
 	spaceManager    *anysync.SpaceManager
 	documentManager *anysync.DocumentManager
 	eventManager    *anysync.EventManager
+	keyStore        KeyStoreFunc
+	transport       anysync.Transport // Reaches the peers of other accounts, nil when there is none
+	accountMu       sync.Mutex        // Serializes changes to the account key files
 	initialized     bool
+	startedAt       time.Time
+
+	// Session lock: commands hold session for reading while they run, Lock
+	// and Unlock hold it for writing. locked is guarded by mu; lastActive is
+	// the UnixNano time of the last command, for the auto-lock
+	session    sync.RWMutex
+	locked     bool
+	lastActive atomic.Int64
+
+	// Shutdown draining: closing rejects new commands, calls and streams
+	// track the admitted ones, and stop cancels them if they outlast the
+	// shutdown timeout
+	closing bool
+	calls   sync.WaitGroup
+	streams sync.WaitGroup
+	stopCtx context.Context
+	stop    context.CancelFunc
+}
+
+// DefaultCommandTimeout bounds commands when Init does not configure a timeout.
+const DefaultCommandTimeout = 30 * time.Second
+
+// DefaultShutdownTimeout bounds how long Shutdown waits for in-flight work
+// when the request does not set timeout_ms.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// NewBackend returns an uninitialized backend. Commands other than Init fail
+// with ErrNotInitialized until Init is called.
+func NewBackend() *Backend {
+	return &Backend{}
+}
+
+// KeyStoreFunc returns the store for the account keys of a data directory.
+// Hosts use it to keep keys in a platform keystore; the data directory can
+// serve as a scope, so one host store can hold the keys of several profiles.
+type KeyStoreFunc func(dataDir string) anysync.KeyStore
+
+// open returns the key store for dataDir, the files of dataDir when f is nil.
+func (f KeyStoreFunc) open(dataDir string) anysync.KeyStore {
+	if f == nil {
+		return anysync.NewFileKeyStore(dataDir)
+	}
+	return f(dataDir)
+}
+
+// NewBackendWithKeyStore returns an uninitialized backend that stores its
+// account keys in the key store keyStore returns for the Init data directory.
+func NewBackendWithKeyStore(keyStore KeyStoreFunc) *Backend {
+	return &Backend{keyStore: keyStore}
 }
 
-var globalState = &State{}
+// SetTransport connects the backend to the peers of other accounts through
+// transport, so they can join its spaces with invites and it can join theirs.
+// It applies to the open spaces and to those opened later.
+func (b *Backend) SetTransport(transport anysync.Transport) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.transport = transport
+	if b.spaceManager != nil {
+		b.spaceManager.SetTransport(transport)
+	}
+}
 
-// Init handles the Init operation.
-func Init(ctx context.Context, req proto.Message) (proto.Message, error) {
+// Init handles the Init operation. A failed Init leaves the backend
+// uninitialized, with what it opened closed again.
+func (b *Backend) Init(ctx context.Context, req proto.Message) (_ proto.Message, err error) {
 	initReq := req.(*pb.InitRequest)
 
-	globalState.mu.Lock()
-	defer globalState.mu.Unlock()
+	if initReq.DataDir == "" {
+		return nil, fmt.Errorf("%w: data_dir is required", anysync.ErrInvalidArgument)
+	}
+
+	cfg, err := ParseConfig(initReq)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
 
 	// Prevent double initialization - caller must Shutdown first
-	if globalState.initialized {
-		return nil, fmt.Errorf("already initialized (dataDir: %s, networkId: %s, deviceId: %s) - call Shutdown first",
-			globalState.dataDir, globalState.networkID, globalState.deviceID)
+	if b.initialized {
+		return nil, fmt.Errorf("%w (dataDir: %s, networkId: %s, deviceId: %s) - call Shutdown first",
+			ErrAlreadyInitialized, b.dataDir, b.networkID, b.deviceID)
 	}
+	defer func() {
+		if err != nil {
+			b.release()
+		}
+	}()
 
 	// Store configuration
-	globalState.dataDir = initReq.DataDir
-	globalState.networkID = initReq.NetworkId
-	globalState.deviceID = initReq.DeviceId
-	globalState.config = initReq.Config
+	b.dataDir = initReq.DataDir
+	b.networkID = initReq.NetworkId
+	b.deviceID = initReq.DeviceId
+	b.config = cfg
+	cfg.applyLogLevel()
 
 	// Initialize AccountManager
-	globalState.accountManager = anysync.NewAccountManager(initReq.DataDir)
+	b.accountManager = anysync.NewAccountManagerWithKeyStore(b.keyStore.open(initReq.DataDir))
 
-	// Check if keys already exist on disk
-	if globalState.accountManager.KeysExist() {
-		// Load existing keys
-		if err := globalState.accountManager.LoadKeys(); err != nil {
+	// Check if keys already exist
+	restored := false
+	if b.accountManager.KeysExist() {
+		// Load existing keys, opening them with the passphrase if protected
+		if err := b.accountManager.LoadKeysWithPassphrase(initReq.Passphrase); err != nil {
 			return nil, fmt.Errorf("failed to load existing keys: %w", err)
 		}
+		// A recovery phrase must not silently open another account
+		if initReq.Mnemonic != "" {
+			matches, err := b.accountManager.MatchesMnemonic(initReq.Mnemonic)
+			if err != nil {
+				return nil, err
+			}
+			if !matches {
+				return nil, fmt.Errorf("%w: data_dir already holds a different account", anysync.ErrAlreadyExists)
+			}
+		}
+	} else if initReq.Mnemonic != "" {
+		// Restore the account of the recovery phrase on this device
+		if err := b.accountManager.RestoreKeys(initReq.Mnemonic); err != nil {
+			return nil, fmt.Errorf("failed to restore keys: %w", err)
+		}
+		if err := b.accountManager.StoreKeysWithPassphrase(initReq.Passphrase); err != nil {
+			return nil, fmt.Errorf("failed to store keys: %w", err)
+		}
+		restored = true
 	} else {
 		// Generate new keys
-		if err := globalState.accountManager.GenerateKeys(); err != nil {
+		if err := b.accountManager.GenerateKeys(); err != nil {
 			return nil, fmt.Errorf("failed to generate keys: %w", err)
 		}
 
-		// Store keys to disk
-		if err := globalState.accountManager.StoreKeys(); err != nil {
+		// Store keys, sealed with the passphrase if one is given
+		if err := b.accountManager.StoreKeysWithPassphrase(initReq.Passphrase); err != nil {
 			return nil, fmt.Errorf("failed to store keys: %w", err)
 		}
 	}
 
 	// Verify keys are loaded
-	if !globalState.accountManager.HasKeys() {
+	if !b.accountManager.HasKeys() {
 		return nil, fmt.Errorf("keys not loaded after initialization")
 	}
 
-	// Initialize EventManager
-	globalState.eventManager = anysync.NewEventManager()
-
-	// Initialize SpaceManager with loaded keys
-	spaceManager, err := anysync.NewSpaceManager(initReq.DataDir, globalState.accountManager.GetKeys(), globalState.eventManager)
+	// Register this device; a revoked one may not open the account
+	devices, err := anysync.NewDeviceRegistry(initReq.DataDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize space manager: %w", err)
+		return nil, err
+	}
+	if _, err := devices.RegisterSelf(initReq.DeviceId, initReq.DeviceName, b.accountManager.GetKeys().PeerKey.GetPublic()); err != nil {
+		return nil, err
 	}
-	globalState.spaceManager = spaceManager
+	b.devices = devices
 
-	// Initialize DocumentManager
-	documentManager, err := anysync.NewDocumentManager(globalState.spaceManager, globalState.accountManager.GetKeys(), globalState.eventManager)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize document manager: %w", err)
+	// Initialize EventManager
+	b.eventManager = anysync.NewEventManager()
+
+	// Initialize SpaceManager and DocumentManager with loaded keys
+	if err := b.openManagers(); err != nil {
+		return nil, err
 	}
-	globalState.documentManager = documentManager
 
-	globalState.initialized = true
+	b.stopCtx, b.stop = context.WithCancel(context.Background())
+	b.startedAt = time.Now()
+	b.lastActive.Store(b.startedAt.UnixNano())
+	b.initialized = true
+	if cfg.AutoLock > 0 {
+		go b.autoLock(b.stopCtx, cfg.AutoLock)
+	}
 
-	return &pb.InitResponse{Success: true}, nil
+	return &pb.InitResponse{Success: true, Restored: restored}, nil
 }
 
-// Shutdown handles the Shutdown operation.
-func Shutdown(ctx context.Context, req proto.Message) (proto.Message, error) {
-	globalState.mu.Lock()
-	defer globalState.mu.Unlock()
+// openManagers opens the space and document managers with the loaded keys.
+// The caller must hold b.mu for writing.
+func (b *Backend) openManagers() error {
+	spaceManager, err := anysync.NewSpaceManagerWithConfig(b.dataDir, b.accountManager.GetKeys(), b.eventManager, b.config.Space)
+	if err != nil {
+		return fmt.Errorf("failed to initialize space manager: %w", err)
+	}
+
+	documentManager, err := anysync.NewDocumentManager(spaceManager, b.accountManager.GetKeys(), b.eventManager)
+	if err != nil {
+		_ = spaceManager.Close()
+		return fmt.Errorf("failed to initialize document manager: %w", err)
+	}
 
-	if !globalState.initialized {
-		return nil, fmt.Errorf("not initialized")
+	spaceManager.SetSpaceCacheSize(b.config.SpaceCacheSize)
+	if b.transport != nil {
+		spaceManager.SetTransport(b.transport)
 	}
+	b.spaceManager = spaceManager
+	b.documentManager = documentManager
+	return nil
+}
 
-	// Close DocumentManager
-	if globalState.documentManager != nil {
-		if err := globalState.documentManager.Close(); err != nil {
-			// Log error but continue shutdown
+// closeManagers closes the document and space managers, logging failures
+// as there is nothing the caller could do about them. The caller must hold
+// b.mu for writing.
+func (b *Backend) closeManagers() {
+	// Close DocumentManager (flushes metadata to disk)
+	if b.documentManager != nil {
+		if err := b.documentManager.Close(); err != nil {
 			fmt.Printf("Warning: failed to close document manager: %v\n", err)
 		}
-		globalState.documentManager = nil
+		b.documentManager = nil
 	}
 
 	// Close SpaceManager (closes all space storages)
-	if globalState.spaceManager != nil {
-		if err := globalState.spaceManager.Close(); err != nil {
-			// Log error but continue shutdown
+	if b.spaceManager != nil {
+		if err := b.spaceManager.Close(); err != nil {
 			fmt.Printf("Warning: failed to close space manager: %v\n", err)
 		}
-		globalState.spaceManager = nil
+		b.spaceManager = nil
 	}
+}
 
-	// Close EventManager
-	if globalState.eventManager != nil {
-		if err := globalState.eventManager.Close(); err != nil {
-			// Log error but continue shutdown
+// Shutdown handles the Shutdown operation. It stops accepting commands, waits
+// for in-flight commands and then for event streams to finish, cancelling
+// them once timeout_ms has passed, flushes document metadata and closes the
+// managers.
+func (b *Backend) Shutdown(ctx context.Context, req proto.Message) (proto.Message, error) {
+	shutdownReq := req.(*pb.ShutdownRequest)
+
+	// Draining would wait for the calling command itself
+	if running, ok := ctx.Value(runningKey{}).(*Backend); ok && running == b {
+		return nil, fmt.Errorf("%w: Shutdown cannot be called from a command running on the same backend", anysync.ErrInvalidArgument)
+	}
+
+	b.mu.Lock()
+	if !b.initialized {
+		b.mu.Unlock()
+		return nil, ErrNotInitialized
+	}
+	if b.closing {
+		b.mu.Unlock()
+		return nil, ErrShuttingDown
+	}
+	b.closing = true
+	b.mu.Unlock()
+
+	timeout := DefaultShutdownTimeout
+	if shutdownReq.TimeoutMs != 0 {
+		timeout = time.Duration(shutdownReq.TimeoutMs) * time.Millisecond
+	}
+	forced := b.drain(ctx, timeout)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.release()
+	b.stop()
+	b.stopCtx = nil
+	b.stop = nil
+	b.closing = false
+	b.initialized = false
+	b.locked = false
+	b.startedAt = time.Time{}
+
+	return &pb.ShutdownResponse{Success: true, Forced: forced}, nil
+}
+
+// release closes the managers and the event manager, clears the keys from
+// memory and forgets the settings of Init, after Shutdown or a failed Init.
+// The caller must hold b.mu for writing.
+func (b *Backend) release() {
+	b.closeManagers()
+
+	// Shutdown closed it already, while draining
+	if b.eventManager != nil {
+		if err := b.eventManager.Close(); err != nil {
 			fmt.Printf("Warning: failed to close event manager: %v\n", err)
 		}
-		globalState.eventManager = nil
+		b.eventManager = nil
 	}
 
 	// Clear keys from memory
-	if globalState.accountManager != nil {
-		globalState.accountManager.ClearKeys()
-		globalState.accountManager = nil
+	if b.accountManager != nil {
+		b.accountManager.ClearKeys()
+		b.accountManager = nil
+	}
+	b.devices = nil
+
+	b.dataDir = ""
+	b.networkID = ""
+	b.deviceID = ""
+	b.config = nil
+}
+
+// drain waits for the admitted commands, then closes the event manager so
+// event streams end, and waits for the streams. Work still running after
+// timeout, or once ctx is done, is cancelled; a negative timeout waits
+// without a limit. It reports whether work had to be cancelled.
+func (b *Backend) drain(ctx context.Context, timeout time.Duration) bool {
+	if timeout >= 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Cancelled commands return promptly, releasing the locks they hold
+	// (e.g. an atomic batch), so the second wait does not block for long
+	forced := !waitGroup(ctx, &b.calls)
+	if forced {
+		b.stop()
+		b.calls.Wait()
+	}
+
+	// Closing the event manager ends the subscriber streams
+	b.mu.Lock()
+	eventManager := b.eventManager
+	b.eventManager = nil
+	b.mu.Unlock()
+	if eventManager != nil {
+		if err := eventManager.Close(); err != nil {
+			// Log error but continue shutdown
+			fmt.Printf("Warning: failed to close event manager: %v\n", err)
+		}
+	}
+
+	if !waitGroup(ctx, &b.streams) {
+		forced = true
+		b.stop()
+		b.streams.Wait()
+	}
+
+	return forced
+}
+
+// waitGroup waits for wg until ctx is done and reports whether wg finished.
+func waitGroup(ctx context.Context, wg *sync.WaitGroup) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// runningKey is the context key of the backend a command runs on.
+type runningKey struct{}
+
+// admit starts tracking a command (wg is b.calls) or stream (b.streams) until
+// the returned done func is called, so Shutdown can wait for it. It fails with
+// ErrShuttingDown once Shutdown has started. The returned context carries b,
+// so commands dispatched from within it (e.g. batched commands) run on b
+// without being admitted again, and is cancelled if Shutdown times out.
+func (b *Backend) admit(ctx context.Context, wg *sync.WaitGroup) (context.Context, func(), error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closing {
+		return nil, nil, ErrShuttingDown
 	}
 
-	globalState.initialized = false
-	globalState.dataDir = ""
-	globalState.networkID = ""
-	globalState.deviceID = ""
-	globalState.config = nil
+	wg.Add(1)
+	ctx = context.WithValue(ctx, runningKey{}, b)
+	if b.stopCtx == nil {
+		// Not initialized; the handler fails with ErrNotInitialized
+		return ctx, wg.Done, nil
+	}
 
-	return &pb.ShutdownResponse{Success: true}, nil
+	ctx, cancel := context.WithCancel(ctx)
+	stopCancel := context.AfterFunc(b.stopCtx, cancel)
+	return ctx, func() {
+		stopCancel()
+		cancel()
+		wg.Done()
+	}, nil
 }
 
-// ensureInitialized checks if the system is initialized.
-func ensureInitialized() error {
-	globalState.mu.RLock()
-	defer globalState.mu.RUnlock()
+// ensureInitialized checks if the backend is initialized and its session
+// is not locked.
+func (b *Backend) ensureInitialized() error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 
-	if !globalState.initialized {
-		return fmt.Errorf("not initialized: call Init first")
+	if !b.initialized {
+		return fmt.Errorf("%w: call Init first", ErrNotInitialized)
+	}
+	if b.locked {
+		return errSessionLocked()
 	}
 	return nil
 }
+
+// commandTimeout returns the deadline applied to command when the caller did
+// not set one. It is used by the dispatcher's Timeout middleware.
+func (b *Backend) commandTimeout(command string) time.Duration {
+	// Shutdown is bounded by its own timeout_ms
+	if command == "Shutdown" {
+		return 0
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.config == nil {
+		return DefaultCommandTimeout
+	}
+	if timeout, ok := b.config.CommandTimeouts[command]; ok {
+		return timeout
+	}
+	return b.config.CommandTimeout
+}
@@ -2,13 +2,20 @@ package handlers
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"anysync-backend/shared/dispatcher"
 	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func TestUnit_Lifecycle_InitSuccess(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	tmpDir := t.TempDir()
 	req := &pb.InitRequest{
@@ -16,12 +23,12 @@ func TestUnit_Lifecycle_InitSuccess(t *testing.T) {
 		NetworkId: "test-network",
 		DeviceId:  "test-device",
 		Config: map[string]string{
-			"key1": "value1",
-			"key2": "value2",
+			"network_mode": "local",
+			"log_level":    "warn",
 		},
 	}
 
-	resp, err := Init(context.Background(), req)
+	resp, err := b.Init(context.Background(), req)
 	if err != nil {
 		t.Fatalf("Init failed: %v", err)
 	}
@@ -31,39 +38,39 @@ func TestUnit_Lifecycle_InitSuccess(t *testing.T) {
 		t.Error("Expected success=true")
 	}
 
-	globalState.mu.RLock()
-	defer globalState.mu.RUnlock()
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 
-	if !globalState.initialized {
+	if !b.initialized {
 		t.Error("Expected initialized=true")
 	}
-	if globalState.dataDir != req.DataDir {
-		t.Errorf("Expected dataDir=%s, got %s", req.DataDir, globalState.dataDir)
+	if b.dataDir != req.DataDir {
+		t.Errorf("Expected dataDir=%s, got %s", req.DataDir, b.dataDir)
 	}
-	if globalState.networkID != req.NetworkId {
-		t.Errorf("Expected networkID=%s, got %s", req.NetworkId, globalState.networkID)
+	if b.networkID != req.NetworkId {
+		t.Errorf("Expected networkID=%s, got %s", req.NetworkId, b.networkID)
 	}
-	if globalState.deviceID != req.DeviceId {
-		t.Errorf("Expected deviceID=%s, got %s", req.DeviceId, globalState.deviceID)
+	if b.deviceID != req.DeviceId {
+		t.Errorf("Expected deviceID=%s, got %s", req.DeviceId, b.deviceID)
 	}
 
 	// Verify account manager is initialized
-	if globalState.accountManager == nil {
+	if b.accountManager == nil {
 		t.Fatal("Expected accountManager to be initialized")
 	}
-	if !globalState.accountManager.HasKeys() {
+	if !b.accountManager.HasKeys() {
 		t.Fatal("Expected keys to be loaded/generated")
 	}
-	if globalState.accountManager.GetKeys() == nil {
+	if b.accountManager.GetKeys() == nil {
 		t.Fatal("Expected GetKeys() to return non-nil")
 	}
 }
 
 func TestUnit_Lifecycle_InitAlreadyInitialized(t *testing.T) {
-	resetGlobalState()
-	globalState.mu.Lock()
-	globalState.initialized = true
-	globalState.mu.Unlock()
+	b := NewBackend()
+	b.mu.Lock()
+	b.initialized = true
+	b.mu.Unlock()
 
 	req := &pb.InitRequest{
 		DataDir:   "/tmp/test-data",
@@ -71,14 +78,52 @@ func TestUnit_Lifecycle_InitAlreadyInitialized(t *testing.T) {
 		DeviceId:  "test-device",
 	}
 
-	_, err := Init(context.Background(), req)
+	_, err := b.Init(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when already initialized")
 	}
 }
 
+// TestUnit_Lifecycle_InitFailureCleanup tests that an Init failing to open the managers leaves nothing behind.
+func TestUnit_Lifecycle_InitFailureCleanup(t *testing.T) {
+	ctx := context.Background()
+	b := NewBackend()
+	dataDir := t.TempDir()
+
+	// The space storage directory cannot be created over a file
+	spacesPath := filepath.Join(dataDir, "spaces")
+	if err := os.WriteFile(spacesPath, nil, 0600); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	if _, err := b.Init(ctx, &pb.InitRequest{DataDir: dataDir, DeviceId: "test-device"}); err == nil {
+		t.Fatal("Expected Init to fail")
+	}
+
+	b.mu.RLock()
+	if b.initialized || b.config != nil || b.accountManager != nil || b.devices != nil ||
+		b.eventManager != nil || b.spaceManager != nil || b.dataDir != "" || b.deviceID != "" {
+		t.Errorf("Expected a failed Init to leave nothing set, got %+v", b)
+	}
+	b.mu.RUnlock()
+	if _, err := b.ListSpaces(ctx, &pb.ListSpacesRequest{}); ErrorCodeOf(err) != pb.ErrorCode_ERROR_CODE_NOT_INITIALIZED {
+		t.Errorf("Expected NOT_INITIALIZED, got %v", err)
+	}
+
+	// Init succeeds once the cause is gone, with the account it created
+	if err := os.Remove(spacesPath); err != nil {
+		t.Fatalf("Failed to remove file: %v", err)
+	}
+	if _, err := b.Init(ctx, &pb.InitRequest{DataDir: dataDir, DeviceId: "test-device"}); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	defer b.Shutdown(ctx, &pb.ShutdownRequest{})
+	if b.eventManager == nil || b.spaceManager == nil {
+		t.Error("Expected the managers to be open")
+	}
+}
+
 func TestUnit_Lifecycle_ShutdownSuccess(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	tmpDir := t.TempDir()
 
@@ -88,23 +133,23 @@ func TestUnit_Lifecycle_ShutdownSuccess(t *testing.T) {
 		NetworkId: "test-network",
 		DeviceId:  "test-device",
 	}
-	_, err := Init(context.Background(), initReq)
+	_, err := b.Init(context.Background(), initReq)
 	if err != nil {
 		t.Fatalf("Init failed: %v", err)
 	}
 
 	// Verify keys are loaded
-	globalState.mu.RLock()
-	if globalState.accountManager == nil || !globalState.accountManager.HasKeys() {
-		globalState.mu.RUnlock()
+	b.mu.RLock()
+	if b.accountManager == nil || !b.accountManager.HasKeys() {
+		b.mu.RUnlock()
 		t.Fatal("Keys should be loaded after Init")
 	}
-	globalState.mu.RUnlock()
+	b.mu.RUnlock()
 
 	// Now shutdown
 	req := &pb.ShutdownRequest{}
 
-	resp, err := Shutdown(context.Background(), req)
+	resp, err := b.Shutdown(context.Background(), req)
 	if err != nil {
 		t.Fatalf("Shutdown failed: %v", err)
 	}
@@ -114,35 +159,35 @@ func TestUnit_Lifecycle_ShutdownSuccess(t *testing.T) {
 		t.Error("Expected success=true")
 	}
 
-	globalState.mu.RLock()
-	defer globalState.mu.RUnlock()
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 
-	if globalState.initialized {
+	if b.initialized {
 		t.Error("Expected initialized=false")
 	}
-	if globalState.dataDir != "" {
-		t.Errorf("Expected empty dataDir, got %s", globalState.dataDir)
+	if b.dataDir != "" {
+		t.Errorf("Expected empty dataDir, got %s", b.dataDir)
 	}
 
 	// Verify keys are cleared
-	if globalState.accountManager != nil {
+	if b.accountManager != nil {
 		t.Error("Expected accountManager to be nil after Shutdown")
 	}
 }
 
 func TestUnit_Lifecycle_ShutdownNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.ShutdownRequest{}
 
-	_, err := Shutdown(context.Background(), req)
+	_, err := b.Shutdown(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
 }
 
 func TestUnit_Lifecycle_InitKeyPersistenceAcrossRestarts(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	tmpDir := t.TempDir()
 	req := &pb.InitRequest{
@@ -152,7 +197,7 @@ func TestUnit_Lifecycle_InitKeyPersistenceAcrossRestarts(t *testing.T) {
 	}
 
 	// First Init: generates and stores keys
-	resp1, err := Init(context.Background(), req)
+	resp1, err := b.Init(context.Background(), req)
 	if err != nil {
 		t.Fatalf("First Init failed: %v", err)
 	}
@@ -161,18 +206,18 @@ func TestUnit_Lifecycle_InitKeyPersistenceAcrossRestarts(t *testing.T) {
 	}
 
 	// Get the peer ID from first initialization
-	globalState.mu.RLock()
-	firstPeerId := globalState.accountManager.GetKeys().PeerId
-	globalState.mu.RUnlock()
+	b.mu.RLock()
+	firstPeerId := b.accountManager.GetKeys().PeerId
+	b.mu.RUnlock()
 
 	// Shutdown
-	_, err = Shutdown(context.Background(), &pb.ShutdownRequest{})
+	_, err = b.Shutdown(context.Background(), &pb.ShutdownRequest{})
 	if err != nil {
 		t.Fatalf("Shutdown failed: %v", err)
 	}
 
 	// Second Init: should load existing keys
-	resp2, err := Init(context.Background(), req)
+	resp2, err := b.Init(context.Background(), req)
 	if err != nil {
 		t.Fatalf("Second Init failed: %v", err)
 	}
@@ -181,46 +226,282 @@ func TestUnit_Lifecycle_InitKeyPersistenceAcrossRestarts(t *testing.T) {
 	}
 
 	// Verify the peer ID is the same
-	globalState.mu.RLock()
-	secondPeerId := globalState.accountManager.GetKeys().PeerId
-	globalState.mu.RUnlock()
+	b.mu.RLock()
+	secondPeerId := b.accountManager.GetKeys().PeerId
+	b.mu.RUnlock()
 
 	if firstPeerId != secondPeerId {
 		t.Fatalf("PeerId should persist across restarts: first=%s, second=%s", firstPeerId, secondPeerId)
 	}
 }
 
-func resetGlobalState() {
-	globalState.mu.Lock()
-	defer globalState.mu.Unlock()
+func TestUnit_Lifecycle_InitCommandTimeouts(t *testing.T) {
+	b := NewBackend()
 
-	// Close DocumentManager if it exists
-	if globalState.documentManager != nil {
-		globalState.documentManager.Close()
-		globalState.documentManager = nil
+	req := &pb.InitRequest{
+		DataDir:          t.TempDir(),
+		CommandTimeoutMs: 5000,
+		CommandTimeoutsMs: map[string]int64{
+			"CreateSpace": 60000,
+			"ListSpaces":  -1,
+			"GetDocument": 0,
+		},
+	}
+	if _, err := b.Init(context.Background(), req); err != nil {
+		t.Fatalf("Init failed: %v", err)
 	}
 
-	// Close SpaceManager if it exists
-	if globalState.spaceManager != nil {
-		globalState.spaceManager.Close()
-		globalState.spaceManager = nil
+	tests := map[string]time.Duration{
+		"CreateSpace":    time.Minute,
+		"ListSpaces":     -time.Millisecond,
+		"GetDocument":    5 * time.Second,
+		"CreateDocument": 5 * time.Second,
+	}
+	for command, expected := range tests {
+		if got := b.commandTimeout(command); got != expected {
+			t.Errorf("%s: expected timeout %v, got %v", command, expected, got)
+		}
 	}
 
-	// Close EventManager if it exists
-	if globalState.eventManager != nil {
-		globalState.eventManager.Close()
-		globalState.eventManager = nil
+	if _, err := b.Shutdown(context.Background(), &pb.ShutdownRequest{}); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if got := b.commandTimeout("CreateSpace"); got != DefaultCommandTimeout {
+		t.Errorf("expected default timeout after Shutdown, got %v", got)
 	}
+}
+
+func TestIntegration_Lifecycle_CommandDeadline(t *testing.T) {
+	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
+	d := b.NewDispatcher()
 
-	// Clear keys from memory if accountManager exists
-	if globalState.accountManager != nil {
-		globalState.accountManager.ClearKeys()
-		globalState.accountManager = nil
+	payload, err := proto.Marshal(&pb.CreateDocumentRequest{SpaceId: tc.SpaceID(), Data: []byte("late")})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	// An expired caller deadline aborts the command
+	ctx, cancel := context.WithDeadline(tc.Context(), time.Now().Add(-time.Second))
+	defer cancel()
+	if _, err := d.Dispatch(ctx, "CreateDocument", payload); ErrorCodeOf(err) != pb.ErrorCode_ERROR_CODE_DEADLINE_EXCEEDED {
+		t.Errorf("expected DEADLINE_EXCEEDED, got %v", err)
 	}
 
-	globalState.initialized = false
-	globalState.dataDir = ""
-	globalState.networkID = ""
-	globalState.deviceID = ""
-	globalState.config = nil
+	// So does a cancelled caller
+	ctx, cancel = context.WithCancel(tc.Context())
+	cancel()
+	if _, err := d.Dispatch(ctx, "CreateDocument", payload); ErrorCodeOf(err) != pb.ErrorCode_ERROR_CODE_CANCELLED {
+		t.Errorf("expected CANCELLED, got %v", err)
+	}
+
+	listResp, err := b.ListDocuments(tc.Context(), &pb.ListDocumentsRequest{SpaceId: tc.SpaceID()})
+	if err != nil {
+		t.Fatalf("ListDocuments failed: %v", err)
+	}
+	if docs := listResp.(*pb.ListDocumentsResponse).Documents; len(docs) != 0 {
+		t.Errorf("expected no documents, got %d", len(docs))
+	}
+}
+
+func TestIntegration_Lifecycle_IndependentBackends(t *testing.T) {
+	t.Parallel()
+
+	first := SetupIntegrationTest(t)
+	second := SetupIntegrationTest(t)
+	first.CreateSpace("Only In First", nil)
+
+	// Each backend only sees its own spaces
+	for _, tc := range []*TestContext{first, second} {
+		resp, err := tc.Backend().ListSpaces(tc.Context(), &pb.ListSpacesRequest{})
+		if err != nil {
+			t.Fatalf("ListSpaces failed: %v", err)
+		}
+		want := 1
+		if tc == first {
+			want = 2
+		}
+		if spaces := resp.(*pb.ListSpacesResponse).Spaces; len(spaces) != want {
+			t.Errorf("expected %d spaces, got %d", want, len(spaces))
+		}
+	}
+
+	// Shutting one down leaves the other running
+	if _, err := first.Backend().Shutdown(first.Context(), &pb.ShutdownRequest{}); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if _, err := first.Backend().ListSpaces(first.Context(), &pb.ListSpacesRequest{}); err == nil {
+		t.Error("expected error from the shut down backend")
+	}
+	if _, err := second.Backend().ListSpaces(second.Context(), &pb.ListSpacesRequest{}); err != nil {
+		t.Errorf("expected the other backend to keep running, got %v", err)
+	}
+}
+
+// registerBlockingCommand registers "test.Block", which signals started and
+// then waits for release or for its context to be cancelled.
+func registerBlockingCommand(t *testing.T, b *Backend, d *dispatcher.Dispatcher, started chan<- struct{}, release <-chan struct{}) {
+	t.Helper()
+
+	block := func(ctx context.Context, m *Managers, req proto.Message) (proto.Message, error) {
+		started <- struct{}{}
+		select {
+		case <-release:
+			return &emptypb.Empty{}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if err := b.RegisterCommand(d, "test.Block", block, &emptypb.Empty{}, &emptypb.Empty{}); err != nil {
+		t.Fatalf("RegisterCommand failed: %v", err)
+	}
+}
+
+// waitForShuttingDown polls until the backend rejects new commands.
+func waitForShuttingDown(t *testing.T, d *dispatcher.Dispatcher) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, err := d.Dispatch(context.Background(), "ListSpaces", nil)
+		if ErrorCodeOf(err) == pb.ErrorCode_ERROR_CODE_UNAVAILABLE {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected UNAVAILABLE while shutting down, got %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestIntegration_Lifecycle_ShutdownDrains(t *testing.T) {
+	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
+	d := b.NewDispatcher()
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	registerBlockingCommand(t, b, d, started, release)
+
+	// A batch whose second command is dispatched while the backend drains
+	payload, err := proto.Marshal(&pb.BatchRequest{Commands: []*pb.Command{
+		{Name: "test.Block"},
+		{Name: "ListSpaces"},
+	}})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	batchDone := make(chan *pb.BatchResponse, 1)
+	go func() {
+		respBytes, err := d.Dispatch(tc.Context(), "Batch", payload)
+		if err != nil {
+			t.Errorf("Batch failed: %v", err)
+		}
+		var resp pb.BatchResponse
+		_ = proto.Unmarshal(respBytes, &resp)
+		batchDone <- &resp
+	}()
+	<-started
+
+	// A subscriber stream ends cleanly when the backend shuts down
+	streamDone := make(chan error, 1)
+	subscribeBytes, _ := proto.Marshal(&pb.SubscribeRequest{})
+	go func() {
+		streamDone <- d.DispatchStream(tc.Context(), "Subscribe", subscribeBytes, func([]byte) error { return nil })
+	}()
+	for b.eventManager.GetSubscriberCount() == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	shutdownDone := make(chan *pb.ShutdownResponse, 1)
+	go func() {
+		resp, err := b.Shutdown(context.Background(), &pb.ShutdownRequest{TimeoutMs: 10000})
+		if err != nil {
+			t.Errorf("Shutdown failed: %v", err)
+		}
+		shutdownResp, _ := resp.(*pb.ShutdownResponse)
+		shutdownDone <- shutdownResp
+	}()
+
+	waitForShuttingDown(t, d)
+	select {
+	case <-shutdownDone:
+		t.Fatal("Shutdown returned before the in-flight batch finished")
+	default:
+	}
+
+	close(release)
+	batchResp := <-batchDone
+	if len(batchResp.Results) != 2 {
+		t.Fatalf("expected 2 batch results, got %d", len(batchResp.Results))
+	}
+	for i, result := range batchResp.Results {
+		if result.Error != "" {
+			t.Errorf("batch command %d failed during drain: %s", i, result.Error)
+		}
+	}
+
+	if resp := <-shutdownDone; resp == nil || resp.Forced {
+		t.Errorf("expected a graceful shutdown, got %v", resp)
+	}
+	if err := <-streamDone; err != nil {
+		t.Errorf("expected the subscriber stream to end cleanly, got %v", err)
+	}
+
+	// The backend can be initialized again
+	if _, err := b.Init(tc.Context(), &pb.InitRequest{DataDir: t.TempDir()}); err != nil {
+		t.Errorf("Init after Shutdown failed: %v", err)
+	}
+}
+
+func TestIntegration_Lifecycle_ShutdownTimeout(t *testing.T) {
+	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
+	d := b.NewDispatcher()
+
+	started := make(chan struct{}, 1)
+	registerBlockingCommand(t, b, d, started, make(chan struct{}))
+
+	commandErr := make(chan error, 1)
+	go func() {
+		_, err := d.Dispatch(tc.Context(), "test.Block", nil)
+		commandErr <- err
+	}()
+	<-started
+
+	resp, err := b.Shutdown(tc.Context(), &pb.ShutdownRequest{TimeoutMs: 50})
+	if err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if !resp.(*pb.ShutdownResponse).Forced {
+		t.Error("expected Forced when in-flight work outlasts the timeout")
+	}
+	if err := <-commandErr; ErrorCodeOf(err) != pb.ErrorCode_ERROR_CODE_CANCELLED {
+		t.Errorf("expected the in-flight command to be CANCELLED, got %v", err)
+	}
+}
+
+func TestIntegration_Lifecycle_ShutdownFromCommand(t *testing.T) {
+	tc := SetupIntegrationTest(t)
+	b := tc.Backend()
+	d := b.NewDispatcher()
+
+	shutdown := func(ctx context.Context, m *Managers, req proto.Message) (proto.Message, error) {
+		if _, err := b.Shutdown(ctx, &pb.ShutdownRequest{}); err != nil {
+			return nil, err
+		}
+		return &emptypb.Empty{}, nil
+	}
+	if err := b.RegisterCommand(d, "test.Shutdown", shutdown, &emptypb.Empty{}, &emptypb.Empty{}); err != nil {
+		t.Fatalf("RegisterCommand failed: %v", err)
+	}
+
+	// Shutdown would wait for the command calling it
+	_, err := d.Dispatch(tc.Context(), "test.Shutdown", nil)
+	if ErrorCodeOf(err) != pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT {
+		t.Errorf("expected INVALID_ARGUMENT, got %v", err)
+	}
+	if err := b.ensureInitialized(); err != nil {
+		t.Errorf("expected the backend to keep running, got %v", err)
+	}
 }
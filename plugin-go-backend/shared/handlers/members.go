@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"anysync-backend/shared/anysync"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ListMembers lists the members of a space and its pending join requests.
+func (b *Backend) ListMembers(ctx context.Context, req proto.Message) (proto.Message, error) {
+	listReq := req.(*pb.ListMembersRequest)
+
+	sm, err := b.spaces()
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := sm.ListMembers(ctx, listReq.SpaceId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	infos := make([]*pb.SpaceMember, 0, len(members))
+	for _, member := range members {
+		infos = append(infos, memberToProto(member))
+	}
+
+	return &pb.ListMembersResponse{Members: infos}, nil
+}
+
+// ApproveJoinRequest accepts the request of an account to join a space.
+func (b *Backend) ApproveJoinRequest(ctx context.Context, req proto.Message) (proto.Message, error) {
+	approveReq := req.(*pb.ApproveJoinRequestRequest)
+
+	if approveReq.Identity == "" {
+		return nil, fmt.Errorf("%w: identity is required", anysync.ErrInvalidArgument)
+	}
+	permission, err := fromSpacePermission(approveReq.Permission)
+	if err != nil {
+		return nil, err
+	}
+
+	sm, err := b.spaces()
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := sm.ApproveJoinRequest(ctx, approveReq.SpaceId, approveReq.Identity, permission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve join request: %w", err)
+	}
+
+	return &pb.ApproveJoinRequestResponse{Member: memberToProto(member)}, nil
+}
+
+// RemoveMember removes a member from a space, or declines its join request.
+func (b *Backend) RemoveMember(ctx context.Context, req proto.Message) (proto.Message, error) {
+	removeReq := req.(*pb.RemoveMemberRequest)
+
+	if removeReq.Identity == "" {
+		return nil, fmt.Errorf("%w: identity is required", anysync.ErrInvalidArgument)
+	}
+
+	sm, err := b.spaces()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sm.RemoveMember(ctx, removeReq.SpaceId, removeReq.Identity); err != nil {
+		return &pb.RemoveMemberResponse{Success: false}, fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	return &pb.RemoveMemberResponse{Success: true}, nil
+}
+
+// ChangeMemberPermission sets the permission of a member of a space.
+func (b *Backend) ChangeMemberPermission(ctx context.Context, req proto.Message) (proto.Message, error) {
+	changeReq := req.(*pb.ChangeMemberPermissionRequest)
+
+	if changeReq.Identity == "" {
+		return nil, fmt.Errorf("%w: identity is required", anysync.ErrInvalidArgument)
+	}
+	// Unlike for invites and approvals, there is no default to fall back to
+	if changeReq.Permission == pb.SpacePermission_SPACE_PERMISSION_UNSPECIFIED {
+		return nil, fmt.Errorf("%w: permission is required", anysync.ErrInvalidArgument)
+	}
+	permission, err := fromSpacePermission(changeReq.Permission)
+	if err != nil {
+		return nil, err
+	}
+
+	sm, err := b.spaces()
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := sm.ChangeMemberPermission(ctx, changeReq.SpaceId, changeReq.Identity, permission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to change member permission: %w", err)
+	}
+
+	return &pb.ChangeMemberPermissionResponse{Member: memberToProto(member)}, nil
+}
+
+// spaces returns the space manager of the running backend.
+func (b *Backend) spaces() (*anysync.SpaceManager, error) {
+	if err := b.ensureInitialized(); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.spaceManager == nil {
+		return nil, fmt.Errorf("space manager %w", ErrNotInitialized)
+	}
+	return b.spaceManager, nil
+}
+
+// memberToProto converts a member of a space.
+func memberToProto(member *anysync.Member) *pb.SpaceMember {
+	return &pb.SpaceMember{
+		Identity:   member.Identity,
+		Permission: toSpacePermission(member.Permission),
+		Status:     toMemberStatus(member.Status),
+		JoinedAt:   member.JoinedAt,
+		UpdatedAt:  member.UpdatedAt,
+	}
+}
+
+// toSpacePermission converts a space permission to protobuf, unspecified
+// for none.
+func toSpacePermission(permission anysync.SpacePermission) pb.SpacePermission {
+	switch permission {
+	case anysync.PermissionReader:
+		return pb.SpacePermission_SPACE_PERMISSION_READER
+	case anysync.PermissionWriter:
+		return pb.SpacePermission_SPACE_PERMISSION_WRITER
+	case anysync.PermissionAdmin:
+		return pb.SpacePermission_SPACE_PERMISSION_ADMIN
+	case anysync.PermissionOwner:
+		return pb.SpacePermission_SPACE_PERMISSION_OWNER
+	default:
+		return pb.SpacePermission_SPACE_PERMISSION_UNSPECIFIED
+	}
+}
+
+// toMemberStatus converts a member status to protobuf.
+func toMemberStatus(status anysync.MemberStatus) pb.MemberStatus {
+	switch status {
+	case anysync.MemberActive:
+		return pb.MemberStatus_MEMBER_STATUS_ACTIVE
+	case anysync.MemberJoinRequested:
+		return pb.MemberStatus_MEMBER_STATUS_JOIN_REQUESTED
+	case anysync.MemberLeaving:
+		return pb.MemberStatus_MEMBER_STATUS_LEAVING
+	default:
+		return pb.MemberStatus_MEMBER_STATUS_UNSPECIFIED
+	}
+}
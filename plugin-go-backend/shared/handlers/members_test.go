@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	pb "anysync-backend/shared/proto/syncspace/v1"
+)
+
+func TestUnit_Members_ListMembersNotInitialized(t *testing.T) {
+	b := NewBackend()
+
+	_, err := b.ListMembers(context.Background(), &pb.ListMembersRequest{SpaceId: "space1"})
+	if code := ErrorCodeOf(err); code != pb.ErrorCode_ERROR_CODE_NOT_INITIALIZED {
+		t.Errorf("Expected ERROR_CODE_NOT_INITIALIZED, got %v", code)
+	}
+}
+
+// TestUnit_Members tests approving a join request and managing the member
+// through the member commands.
+func TestUnit_Members(t *testing.T) {
+	owner, guest := newPeerDispatchers(t)
+
+	if err := dispatchMessage(owner, "CreateSpace", &pb.CreateSpaceRequest{SpaceId: "team", Name: "Team"}, &pb.CreateSpaceResponse{}); err != nil {
+		t.Fatalf("CreateSpace failed: %v", err)
+	}
+	var inviteResp pb.CreateInviteResponse
+	inviteReq := &pb.CreateInviteRequest{SpaceId: "team", RequireApproval: true}
+	if err := dispatchMessage(owner, "CreateInvite", inviteReq, &inviteResp); err != nil {
+		t.Fatalf("CreateInvite failed: %v", err)
+	}
+
+	var joinResp pb.JoinSpaceResponse
+	joinReq := &pb.JoinSpaceRequest{SpaceId: "shared", InviteToken: inviteResp.InviteToken}
+	if err := dispatchMessage(guest, "JoinSpace", joinReq, &joinResp); err != nil {
+		t.Fatalf("JoinSpace failed: %v", err)
+	}
+	if !joinResp.Pending {
+		t.Fatalf("Expected a pending join, got %+v", &joinResp)
+	}
+
+	var listResp pb.ListMembersResponse
+	if err := dispatchMessage(owner, "ListMembers", &pb.ListMembersRequest{SpaceId: "team"}, &listResp); err != nil {
+		t.Fatalf("ListMembers failed: %v", err)
+	}
+	if len(listResp.Members) != 2 {
+		t.Fatalf("Expected the owner and a join request, got %v", listResp.Members)
+	}
+	if m := listResp.Members[0]; m.Permission != pb.SpacePermission_SPACE_PERMISSION_OWNER || m.Status != pb.MemberStatus_MEMBER_STATUS_ACTIVE {
+		t.Errorf("Expected an active owner first, got %v", m)
+	}
+	request := listResp.Members[1]
+	if request.Status != pb.MemberStatus_MEMBER_STATUS_JOIN_REQUESTED || request.JoinedAt == 0 {
+		t.Fatalf("Expected a join request, got %v", request)
+	}
+
+	var approveResp pb.ApproveJoinRequestResponse
+	approveReq := &pb.ApproveJoinRequestRequest{SpaceId: "team", Identity: request.Identity, Permission: pb.SpacePermission_SPACE_PERMISSION_WRITER}
+	if err := dispatchMessage(owner, "ApproveJoinRequest", approveReq, &approveResp); err != nil {
+		t.Fatalf("ApproveJoinRequest failed: %v", err)
+	}
+	if approveResp.Member.Permission != pb.SpacePermission_SPACE_PERMISSION_WRITER {
+		t.Errorf("Expected a writer, got %v", approveResp.Member)
+	}
+
+	if err := dispatchMessage(guest, "JoinSpace", joinReq, &joinResp); err != nil {
+		t.Fatalf("JoinSpace failed: %v", err)
+	}
+	if joinResp.Pending || !joinResp.Success {
+		t.Fatalf("Expected to join once approved, got %+v", &joinResp)
+	}
+
+	var changeResp pb.ChangeMemberPermissionResponse
+	changeReq := &pb.ChangeMemberPermissionRequest{SpaceId: "team", Identity: request.Identity, Permission: pb.SpacePermission_SPACE_PERMISSION_READER}
+	if err := dispatchMessage(owner, "ChangeMemberPermission", changeReq, &changeResp); err != nil {
+		t.Fatalf("ChangeMemberPermission failed: %v", err)
+	}
+	if changeResp.Member.Permission != pb.SpacePermission_SPACE_PERMISSION_READER {
+		t.Errorf("Expected a reader, got %v", changeResp.Member)
+	}
+	changeReq.Permission = pb.SpacePermission_SPACE_PERMISSION_UNSPECIFIED
+	err := dispatchMessage(owner, "ChangeMemberPermission", changeReq, &changeResp)
+	if code := ErrorCodeOf(err); code != pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT {
+		t.Errorf("Expected ERROR_CODE_INVALID_ARGUMENT without a permission, got %v", code)
+	}
+
+	// Only members that manage the space remove others
+	removeReq := &pb.RemoveMemberRequest{SpaceId: "shared", Identity: listResp.Members[0].Identity}
+	err = dispatchMessage(guest, "RemoveMember", removeReq, &pb.RemoveMemberResponse{})
+	if code := ErrorCodeOf(err); code != pb.ErrorCode_ERROR_CODE_PERMISSION_DENIED {
+		t.Errorf("Expected ERROR_CODE_PERMISSION_DENIED, got %v", code)
+	}
+	removeReq = &pb.RemoveMemberRequest{SpaceId: "team", Identity: request.Identity}
+	if err := dispatchMessage(owner, "RemoveMember", removeReq, &pb.RemoveMemberResponse{}); err != nil {
+		t.Fatalf("RemoveMember failed: %v", err)
+	}
+	if err := dispatchMessage(owner, "ListMembers", &pb.ListMembersRequest{SpaceId: "team"}, &listResp); err != nil {
+		t.Fatalf("ListMembers failed: %v", err)
+	}
+	if len(listResp.Members) != 1 {
+		t.Errorf("Expected only the owner after the removal, got %v", listResp.Members)
+	}
+}
@@ -0,0 +1,574 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"anysync-backend/shared/anysync"
+	"anysync-backend/shared/dispatcher"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultProfileID is the profile opened by Init. It is stored directly in
+// the Init data directory, so single-profile installs keep their data.
+const DefaultProfileID = "default"
+
+const (
+	profilesDir     = "profiles"
+	profileMetaFile = "profile.json"
+)
+
+// profileIDPattern keeps profile IDs safe to use as directory names.
+var profileIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
+// profileMetadata is the profile.json stored in every profile directory.
+type profileMetadata struct {
+	Name      string `json:"name"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// Profiles hosts several backends, one per profile, behind one dispatcher so
+// an app can switch identities without restarting. Each profile has its own
+// data directory and account keys. Space, document and event commands run on
+// the active profile; OpenProfile switches it.
+type Profiles struct {
+	mu          sync.RWMutex
+	rootDir     string
+	initReq     *pb.InitRequest     // Settings every profile is opened with
+	backends    map[string]*Backend // Open profiles by ID
+	active      string              // Empty when no profile is active
+	keyStore    KeyStoreFunc
+	transport   anysync.Transport // Handed to every profile backend
+	initialized bool
+
+	// current is the backend of the active profile. It is read without p.mu,
+	// so commands (and batched commands in middleware) never wait on a
+	// profile operation that is draining a backend.
+	current atomic.Pointer[Backend]
+}
+
+// NewProfiles returns an uninitialized profile host; call Init to start it.
+func NewProfiles() *Profiles {
+	return &Profiles{}
+}
+
+// NewProfilesWithKeyStore returns an uninitialized profile host whose
+// profiles store their account keys in the key store keyStore returns for
+// the profile data directory.
+func NewProfilesWithKeyStore(keyStore KeyStoreFunc) *Profiles {
+	return &Profiles{keyStore: keyStore}
+}
+
+// SetTransport connects every profile, open or opened later, to the peers
+// of other accounts through transport; see Backend.SetTransport.
+func (p *Profiles) SetTransport(transport anysync.Transport) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.transport = transport
+	for _, b := range p.backends {
+		b.SetTransport(transport)
+	}
+}
+
+// newBackend returns an uninitialized backend with the key store and the
+// transport of the profiles. The caller must hold p.mu.
+func (p *Profiles) newBackend() *Backend {
+	b := NewBackendWithKeyStore(p.keyStore)
+	b.transport = p.transport
+	return b
+}
+
+// NewDispatcher creates a dispatcher bound to p. It serves the profile
+// commands and routes every other command to the active profile.
+func (p *Profiles) NewDispatcher() *dispatcher.Dispatcher {
+	d := dispatcher.New()
+	d.Use(dispatcher.Recover(p.reportPanic), dispatcher.Timeout(p.commandTimeout))
+	d.UseStream(dispatcher.RecoverStream(p.reportPanic))
+
+	// Lifecycle
+	d.Register("Init", p.Init, &pb.InitRequest{}, &pb.InitResponse{})
+	d.Register("Shutdown", outsideCommands(p.Shutdown), &pb.ShutdownRequest{}, &pb.ShutdownResponse{})
+	d.Register("ImportAccount", p.ImportAccount, &pb.ImportAccountRequest{}, &pb.ImportAccountResponse{})
+	d.Register("DeleteAccount", outsideCommands(p.DeleteAccount), &pb.DeleteAccountRequest{}, &pb.DeleteAccountResponse{})
+
+	// Profiles
+	d.Register("CreateProfile", p.CreateProfile, &pb.CreateProfileRequest{}, &pb.CreateProfileResponse{})
+	d.Register("ListProfiles", p.ListProfiles, &pb.ListProfilesRequest{}, &pb.ListProfilesResponse{})
+	d.Register("OpenProfile", p.OpenProfile, &pb.OpenProfileRequest{}, &pb.OpenProfileResponse{})
+	d.Register("CloseProfile", p.CloseProfile, &pb.CloseProfileRequest{}, &pb.CloseProfileResponse{})
+	d.Register("DeleteProfile", p.DeleteProfile, &pb.DeleteProfileRequest{}, &pb.DeleteProfileResponse{})
+
+	registerBackendCommands(d, p.activeBackend)
+	return d
+}
+
+// RegisterCommand adds a custom command to d, a dispatcher bound to p. The
+// handler receives the managers of the active profile. See
+// Backend.RegisterCommand for the naming rules.
+func (p *Profiles) RegisterCommand(d *dispatcher.Dispatcher, name string, handler CustomHandler, requestType, responseType proto.Message) error {
+	return registerCommand(d, p.activeBackend, name, handler, requestType, responseType)
+}
+
+// Init opens the default profile in req.DataDir and makes it active. The
+// other settings of req apply to every profile opened later.
+func (p *Profiles) Init(ctx context.Context, req proto.Message) (proto.Message, error) {
+	initReq := req.(*pb.InitRequest)
+
+	if initReq.DataDir == "" {
+		return nil, fmt.Errorf("%w: data_dir is required", anysync.ErrInvalidArgument)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.initialized {
+		return nil, fmt.Errorf("%w (dataDir: %s) - call Shutdown first", ErrAlreadyInitialized, p.rootDir)
+	}
+
+	b := p.newBackend()
+	resp, err := b.Init(ctx, initReq)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureProfileMetadata(initReq.DataDir, "Default"); err != nil {
+		_, _ = b.Shutdown(ctx, &pb.ShutdownRequest{})
+		return nil, err
+	}
+
+	p.rootDir = initReq.DataDir
+	p.initReq = proto.Clone(initReq).(*pb.InitRequest)
+	p.initReq.Passphrase = "" // Each profile has its own, given to OpenProfile
+	p.initReq.Mnemonic = ""
+	p.backends = map[string]*Backend{DefaultProfileID: b}
+	p.setActive(DefaultProfileID)
+	p.initialized = true
+
+	return resp, nil
+}
+
+// Shutdown closes every open profile, draining their in-flight work
+// concurrently within the request timeout. A profile that fails to close
+// stays open, and so does the host, so that Shutdown can be called again.
+func (p *Profiles) Shutdown(ctx context.Context, req proto.Message) (proto.Message, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.initialized {
+		return nil, ErrNotInitialized
+	}
+
+	// Drain the open profiles concurrently, so each gets the full timeout
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var forced atomic.Bool
+	var errs []error
+	closed := make(map[string]bool, len(p.backends))
+	for id, b := range p.backends {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := b.Shutdown(ctx, req)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case errors.Is(err, ErrNotInitialized):
+				closed[id] = true
+			case err != nil:
+				errs = append(errs, fmt.Errorf("failed to close profile %s: %w", id, err))
+			default:
+				closed[id] = true
+				if resp.(*pb.ShutdownResponse).Forced {
+					forced.Store(true)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for id := range closed {
+		delete(p.backends, id)
+		if p.active == id {
+			p.setActive("")
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	p.rootDir = ""
+	p.initReq = nil
+	p.backends = nil
+	p.initialized = false
+
+	return &pb.ShutdownResponse{Success: true, Forced: forced.Load()}, nil
+}
+
+// CreateProfile creates a new, closed profile.
+func (p *Profiles) CreateProfile(ctx context.Context, req proto.Message) (proto.Message, error) {
+	createReq := req.(*pb.CreateProfileRequest)
+
+	if err := validateProfileID(createReq.ProfileId); err != nil {
+		return nil, err
+	}
+	name := createReq.Name
+	if name == "" {
+		name = createReq.ProfileId
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.initialized {
+		return nil, fmt.Errorf("%w: call Init first", ErrNotInitialized)
+	}
+
+	dir := p.profileDir(createReq.ProfileId)
+	if _, err := os.Stat(dir); createReq.ProfileId == DefaultProfileID || err == nil {
+		return nil, &anysync.Error{
+			Kind:    anysync.ErrAlreadyExists,
+			Message: "profile already exists: " + createReq.ProfileId,
+			Details: map[string]string{"profile_id": createReq.ProfileId},
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create profile directory: %w", err)
+	}
+	if err := ensureProfileMetadata(dir, name); err != nil {
+		return nil, err
+	}
+
+	info, err := p.profileInfo(createReq.ProfileId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreateProfileResponse{Profile: info}, nil
+}
+
+// ListProfiles lists all profiles, open or not.
+func (p *Profiles) ListProfiles(ctx context.Context, req proto.Message) (proto.Message, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if !p.initialized {
+		return nil, fmt.Errorf("%w: call Init first", ErrNotInitialized)
+	}
+
+	ids := []string{DefaultProfileID}
+	entries, err := os.ReadDir(filepath.Join(p.rootDir, profilesDir))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+	var others []string
+	for _, entry := range entries {
+		if entry.IsDir() && profileIDPattern.MatchString(entry.Name()) {
+			others = append(others, entry.Name())
+		}
+	}
+	sort.Strings(others)
+	ids = append(ids, others...)
+
+	profiles := make([]*pb.ProfileInfo, 0, len(ids))
+	for _, id := range ids {
+		info, err := p.profileInfo(id)
+		if errors.Is(err, anysync.ErrNotFound) {
+			// Not a profile (e.g. a partially deleted directory)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, info)
+	}
+
+	return &pb.ListProfilesResponse{Profiles: profiles}, nil
+}
+
+// OpenProfile opens a profile if needed and makes it the active one.
+func (p *Profiles) OpenProfile(ctx context.Context, req proto.Message) (proto.Message, error) {
+	openReq := req.(*pb.OpenProfileRequest)
+
+	if err := validateProfileID(openReq.ProfileId); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.initialized {
+		return nil, fmt.Errorf("%w: call Init first", ErrNotInitialized)
+	}
+
+	if _, open := p.backends[openReq.ProfileId]; !open {
+		if _, err := p.profileInfo(openReq.ProfileId); err != nil {
+			return nil, err
+		}
+
+		initReq := proto.Clone(p.initReq).(*pb.InitRequest)
+		initReq.DataDir = p.profileDir(openReq.ProfileId)
+		initReq.Passphrase = openReq.Passphrase
+		initReq.Mnemonic = openReq.Mnemonic
+
+		b := p.newBackend()
+		if _, err := b.Init(ctx, initReq); err != nil {
+			return nil, fmt.Errorf("failed to open profile %s: %w", openReq.ProfileId, err)
+		}
+		p.backends[openReq.ProfileId] = b
+	}
+	p.setActive(openReq.ProfileId)
+
+	info, err := p.profileInfo(openReq.ProfileId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.OpenProfileResponse{Profile: info}, nil
+}
+
+// CloseProfile closes an open profile. Closing a closed profile is a no-op.
+func (p *Profiles) CloseProfile(ctx context.Context, req proto.Message) (proto.Message, error) {
+	closeReq := req.(*pb.CloseProfileRequest)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.initialized {
+		return nil, fmt.Errorf("%w: call Init first", ErrNotInitialized)
+	}
+	if _, err := p.profileInfo(closeReq.ProfileId); err != nil {
+		return nil, err
+	}
+
+	if err := p.closeProfile(ctx, closeReq.ProfileId); err != nil {
+		return &pb.CloseProfileResponse{Success: false}, err
+	}
+
+	return &pb.CloseProfileResponse{Success: true}, nil
+}
+
+// DeleteProfile closes a profile and removes its account keys and data
+// directory.
+func (p *Profiles) DeleteProfile(ctx context.Context, req proto.Message) (proto.Message, error) {
+	deleteReq := req.(*pb.DeleteProfileRequest)
+
+	if deleteReq.ProfileId == DefaultProfileID {
+		return nil, fmt.Errorf("%w: the default profile cannot be deleted", anysync.ErrInvalidArgument)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.initialized {
+		return nil, fmt.Errorf("%w: call Init first", ErrNotInitialized)
+	}
+	if _, err := p.profileInfo(deleteReq.ProfileId); err != nil {
+		return nil, err
+	}
+
+	if err := p.closeProfile(ctx, deleteReq.ProfileId); err != nil {
+		return &pb.DeleteProfileResponse{Success: false}, err
+	}
+	dir := p.profileDir(deleteReq.ProfileId)
+	if err := anysync.NewAccountManagerWithKeyStore(p.keyStore.open(dir)).DeleteKeys(); err != nil {
+		return &pb.DeleteProfileResponse{Success: false}, err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return &pb.DeleteProfileResponse{Success: false}, fmt.Errorf("failed to delete profile data: %w", err)
+	}
+
+	return &pb.DeleteProfileResponse{Success: true}, nil
+}
+
+// ImportAccount prepares a data directory from an account bundle: the
+// closed profile req.ProfileId, or req.DataDir before Init. OpenProfile (or
+// Init) then opens the imported account.
+func (p *Profiles) ImportAccount(ctx context.Context, req proto.Message) (proto.Message, error) {
+	importReq := req.(*pb.ImportAccountRequest)
+
+	if importReq.ProfileId == "" {
+		if importReq.DataDir == "" {
+			return nil, fmt.Errorf("%w: data_dir or profile_id is required", anysync.ErrInvalidArgument)
+		}
+		return importAccount(ctx, importReq.DataDir, p.keyStore.open(importReq.DataDir), importReq)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.initialized {
+		return nil, fmt.Errorf("%w: call Init first", ErrNotInitialized)
+	}
+	if _, err := p.profileInfo(importReq.ProfileId); err != nil {
+		return nil, err
+	}
+
+	dir := p.profileDir(importReq.ProfileId)
+	return importAccount(ctx, dir, p.keyStore.open(dir), importReq)
+}
+
+// DeleteAccount removes the account of the active profile, which is closed.
+// The profile itself remains; OpenProfile creates a new account in it.
+func (p *Profiles) DeleteAccount(ctx context.Context, req proto.Message) (proto.Message, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.initialized {
+		return nil, fmt.Errorf("%w: call Init first", ErrNotInitialized)
+	}
+	if p.active == "" {
+		return nil, fmt.Errorf("%w: no active profile, call OpenProfile", ErrNotInitialized)
+	}
+
+	profileID := p.active
+	b := p.backends[profileID]
+	resp, err := b.DeleteAccount(ctx, req)
+	if errors.Is(b.ensureInitialized(), ErrNotInitialized) {
+		// Shut down, even if the data could not all be removed
+		delete(p.backends, profileID)
+		p.setActive("")
+	}
+	return resp, err
+}
+
+// closeProfile shuts down the backend of an open profile. The caller must
+// hold p.mu for writing.
+func (p *Profiles) closeProfile(ctx context.Context, profileID string) error {
+	b, open := p.backends[profileID]
+	if !open {
+		return nil
+	}
+
+	if _, err := b.Shutdown(ctx, &pb.ShutdownRequest{}); err != nil {
+		return fmt.Errorf("failed to close profile %s: %w", profileID, err)
+	}
+	delete(p.backends, profileID)
+	if p.active == profileID {
+		p.setActive("")
+	}
+	return nil
+}
+
+// setActive makes profileID, which must be open, the active profile; an
+// empty ID leaves none active. The caller must hold p.mu for writing.
+func (p *Profiles) setActive(profileID string) {
+	p.active = profileID
+	p.current.Store(p.backends[profileID])
+}
+
+// activeBackend is the backendFunc of a dispatcher bound to p.
+func (p *Profiles) activeBackend() (*Backend, error) {
+	if b := p.current.Load(); b != nil {
+		return b, nil
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if !p.initialized {
+		return nil, fmt.Errorf("%w: call Init first", ErrNotInitialized)
+	}
+	if p.active == "" {
+		return nil, fmt.Errorf("%w: no active profile, call OpenProfile", ErrNotInitialized)
+	}
+	return p.backends[p.active], nil
+}
+
+// commandTimeout applies the timeouts of the active profile, or the default
+// before Init.
+func (p *Profiles) commandTimeout(command string) time.Duration {
+	if b := p.current.Load(); b != nil {
+		return b.commandTimeout(command)
+	}
+	if command == "Shutdown" {
+		return 0
+	}
+	return DefaultCommandTimeout
+}
+
+// reportPanic reports a panic through the active profile, if any.
+func (p *Profiles) reportPanic(err *dispatcher.PanicError) {
+	if b := p.current.Load(); b != nil {
+		b.reportPanic(err)
+		return
+	}
+	fmt.Printf("Error: %v\n%s\n", err, err.Stack)
+}
+
+// profileDir returns the data directory of a profile. The caller must hold p.mu.
+func (p *Profiles) profileDir(profileID string) string {
+	if profileID == DefaultProfileID {
+		return p.rootDir
+	}
+	return filepath.Join(p.rootDir, profilesDir, profileID)
+}
+
+// profileInfo describes a profile, or returns ErrNotFound if it does not
+// exist. The caller must hold p.mu.
+func (p *Profiles) profileInfo(profileID string) (*pb.ProfileInfo, error) {
+	notFound := &anysync.Error{
+		Kind:    anysync.ErrNotFound,
+		Message: "profile not found: " + profileID,
+		Details: map[string]string{"profile_id": profileID},
+	}
+	if !profileIDPattern.MatchString(profileID) {
+		return nil, notFound
+	}
+
+	data, err := os.ReadFile(filepath.Join(p.profileDir(profileID), profileMetaFile))
+	if os.IsNotExist(err) {
+		return nil, notFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile %s: %w", profileID, err)
+	}
+
+	var meta profileMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal profile %s: %w", profileID, err)
+	}
+
+	_, open := p.backends[profileID]
+	return &pb.ProfileInfo{
+		ProfileId: profileID,
+		Name:      meta.Name,
+		CreatedAt: meta.CreatedAt,
+		Open:      open,
+		Active:    p.active == profileID,
+	}, nil
+}
+
+// validateProfileID rejects IDs that are not safe directory names.
+func validateProfileID(profileID string) error {
+	if !profileIDPattern.MatchString(profileID) {
+		return fmt.Errorf("%w: profile_id %q must be 1-64 letters, digits, \"-\" or \"_\"", anysync.ErrInvalidArgument, profileID)
+	}
+	return nil
+}
+
+// ensureProfileMetadata writes profile.json to dir unless it already exists.
+func ensureProfileMetadata(dir, name string) error {
+	path := filepath.Join(dir, profileMetaFile)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(profileMetadata{Name: name, CreatedAt: time.Now().Unix()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal profile metadata: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write profile metadata: %w", err)
+	}
+	return nil
+}
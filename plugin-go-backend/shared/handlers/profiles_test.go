@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"anysync-backend/shared/dispatcher"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// setupProfiles initializes a profile host in a temporary data directory.
+func setupProfiles(t *testing.T) (*Profiles, string) {
+	t.Helper()
+
+	p := NewProfiles()
+	dataDir := t.TempDir()
+	_, err := p.Init(context.Background(), &pb.InitRequest{
+		DataDir:   dataDir,
+		NetworkId: "test-network",
+		DeviceId:  "test-device",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = p.Shutdown(context.Background(), &pb.ShutdownRequest{})
+	})
+
+	return p, dataDir
+}
+
+// createSpaceIn dispatches CreateSpace on the active profile.
+func createSpaceIn(t *testing.T, d *dispatcher.Dispatcher, name string) {
+	t.Helper()
+
+	payload, err := proto.Marshal(&pb.CreateSpaceRequest{Name: name})
+	require.NoError(t, err)
+	_, err = d.Dispatch(context.Background(), "CreateSpace", payload)
+	require.NoError(t, err)
+}
+
+// listSpaceNames dispatches ListSpaces on the active profile.
+func listSpaceNames(t *testing.T, d *dispatcher.Dispatcher) []string {
+	t.Helper()
+
+	respBytes, err := d.Dispatch(context.Background(), "ListSpaces", nil)
+	require.NoError(t, err)
+	var resp pb.ListSpacesResponse
+	require.NoError(t, proto.Unmarshal(respBytes, &resp))
+
+	names := make([]string, 0, len(resp.Spaces))
+	for _, space := range resp.Spaces {
+		names = append(names, space.Name)
+	}
+	return names
+}
+
+// TestIntegration_Profiles_Switch tests that commands run on the active profile.
+func TestIntegration_Profiles_Switch(t *testing.T) {
+	p, dataDir := setupProfiles(t)
+	d := p.NewDispatcher()
+	ctx := context.Background()
+
+	// Init opens the default profile
+	createSpaceIn(t, d, "Personal")
+
+	resp, err := p.CreateProfile(ctx, &pb.CreateProfileRequest{ProfileId: "work", Name: "Work"})
+	require.NoError(t, err)
+	created := resp.(*pb.CreateProfileResponse).Profile
+	assert.Equal(t, "Work", created.Name)
+	assert.False(t, created.Open)
+	assert.DirExists(t, filepath.Join(dataDir, "profiles", "work"))
+
+	// Switching gives the work profile its own keys and spaces
+	resp, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	opened := resp.(*pb.OpenProfileResponse).Profile
+	assert.True(t, opened.Open)
+	assert.True(t, opened.Active)
+	assert.FileExists(t, filepath.Join(dataDir, "profiles", "work", "account.key"))
+	assert.Empty(t, listSpaceNames(t, d))
+
+	createSpaceIn(t, d, "Work Space")
+	assert.Equal(t, []string{"Work Space"}, listSpaceNames(t, d))
+
+	// Switching back does not close the work profile
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: DefaultProfileID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Personal"}, listSpaceNames(t, d))
+
+	resp, err = p.ListProfiles(ctx, &pb.ListProfilesRequest{})
+	require.NoError(t, err)
+	profiles := resp.(*pb.ListProfilesResponse).Profiles
+	require.Len(t, profiles, 2)
+	assert.Equal(t, DefaultProfileID, profiles[0].ProfileId)
+	assert.True(t, profiles[0].Active)
+	assert.Equal(t, "work", profiles[1].ProfileId)
+	assert.True(t, profiles[1].Open)
+	assert.False(t, profiles[1].Active)
+}
+
+// TestIntegration_Profiles_CloseAndDelete tests closing and deleting profiles.
+func TestIntegration_Profiles_CloseAndDelete(t *testing.T) {
+	p, dataDir := setupProfiles(t)
+	d := p.NewDispatcher()
+	ctx := context.Background()
+
+	_, err := p.CreateProfile(ctx, &pb.CreateProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+
+	// Closing the active profile leaves none active
+	_, err = p.CloseProfile(ctx, &pb.CloseProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	_, err = d.Dispatch(ctx, "ListSpaces", nil)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_NOT_INITIALIZED, ErrorCodeOf(err))
+
+	// Closing again is a no-op
+	_, err = p.CloseProfile(ctx, &pb.CloseProfileRequest{ProfileId: "work"})
+	assert.NoError(t, err)
+
+	_, err = p.DeleteProfile(ctx, &pb.DeleteProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dataDir, "profiles", "work"))
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "work"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_NOT_FOUND, ErrorCodeOf(err))
+
+	// The default profile cannot be deleted, and can be made active again
+	_, err = p.DeleteProfile(ctx, &pb.DeleteProfileRequest{ProfileId: DefaultProfileID})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, ErrorCodeOf(err))
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: DefaultProfileID})
+	require.NoError(t, err)
+	assert.Empty(t, listSpaceNames(t, d))
+}
+
+// TestIntegration_Profiles_Shutdown tests that Shutdown cannot run inside a
+// batch, and that a profile that fails to close keeps the host open.
+func TestIntegration_Profiles_Shutdown(t *testing.T) {
+	p, _ := setupProfiles(t)
+	d := p.NewDispatcher()
+	ctx := context.Background()
+
+	_, err := p.CreateProfile(ctx, &pb.CreateProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	createSpaceIn(t, d, "Work")
+
+	var batchResp pb.BatchResponse
+	require.NoError(t, dispatchMessage(d, "Batch", &pb.BatchRequest{Commands: []*pb.Command{{Name: "Shutdown"}}}, &batchResp))
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, batchResp.Results[0].ErrorDetail.GetCode())
+	assert.Equal(t, []string{"Work"}, listSpaceNames(t, d))
+
+	// The work profile is already shutting down, so it fails to close
+	work := p.backends["work"]
+	work.mu.Lock()
+	work.closing = true
+	work.mu.Unlock()
+	_, err = d.Dispatch(ctx, "Shutdown", nil)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_UNAVAILABLE, ErrorCodeOf(err))
+	assert.Contains(t, err.Error(), "work")
+	assert.True(t, p.initialized)
+	assert.Equal(t, []string{"work"}, slices.Collect(maps.Keys(p.backends)), "the default profile is closed")
+
+	// Shutdown can be retried once the profile can close
+	work.mu.Lock()
+	work.closing = false
+	work.mu.Unlock()
+	var shutdownResp pb.ShutdownResponse
+	require.NoError(t, dispatchMessage(d, "Shutdown", &pb.ShutdownRequest{}, &shutdownResp))
+	assert.True(t, shutdownResp.Success)
+	assert.False(t, p.initialized)
+	assert.ErrorIs(t, work.ensureInitialized(), ErrNotInitialized)
+}
+
+// TestUnit_Profiles_Validation tests profile ID checks and lifecycle errors.
+func TestUnit_Profiles_Validation(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewProfiles().CreateProfile(ctx, &pb.CreateProfileRequest{ProfileId: "work"})
+	assert.ErrorIs(t, err, ErrNotInitialized)
+
+	p, _ := setupProfiles(t)
+	for _, id := range []string{"", "../escape", "a/b", ".hidden", "-dash"} {
+		_, err := p.CreateProfile(ctx, &pb.CreateProfileRequest{ProfileId: id})
+		assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, ErrorCodeOf(err), "profile_id %q", id)
+	}
+
+	_, err = p.CreateProfile(ctx, &pb.CreateProfileRequest{ProfileId: DefaultProfileID})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_ALREADY_EXISTS, ErrorCodeOf(err))
+	_, err = p.CreateProfile(ctx, &pb.CreateProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	_, err = p.CreateProfile(ctx, &pb.CreateProfileRequest{ProfileId: "work"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_ALREADY_EXISTS, ErrorCodeOf(err))
+
+	_, err = p.Init(ctx, &pb.InitRequest{DataDir: t.TempDir()})
+	assert.ErrorIs(t, err, ErrAlreadyInitialized)
+}
+
+// TestIntegration_Profiles_Persistence tests that profiles survive a restart.
+func TestIntegration_Profiles_Persistence(t *testing.T) {
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	initReq := &pb.InitRequest{DataDir: dataDir, NetworkId: "test-network"}
+
+	p := NewProfiles()
+	_, err := p.Init(ctx, initReq)
+	require.NoError(t, err)
+	_, err = p.CreateProfile(ctx, &pb.CreateProfileRequest{ProfileId: "work", Name: "Work"})
+	require.NoError(t, err)
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	_, err = p.Shutdown(ctx, &pb.ShutdownRequest{})
+	require.NoError(t, err)
+
+	_, err = p.Init(ctx, initReq)
+	require.NoError(t, err)
+	defer p.Shutdown(ctx, &pb.ShutdownRequest{})
+
+	resp, err := p.ListProfiles(ctx, &pb.ListProfilesRequest{})
+	require.NoError(t, err)
+	profiles := resp.(*pb.ListProfilesResponse).Profiles
+	require.Len(t, profiles, 2)
+	assert.Equal(t, "Default", profiles[0].Name)
+	assert.True(t, profiles[0].Active, "Init reopens the default profile")
+	assert.Equal(t, "Work", profiles[1].Name)
+	assert.False(t, profiles[1].Open)
+	assert.NotZero(t, profiles[1].CreatedAt)
+}
+
+// TestIntegration_Profiles_DeleteAccount tests that DeleteAccount wipes the active profile and leaves the others.
+func TestIntegration_Profiles_DeleteAccount(t *testing.T) {
+	p, dataDir := setupProfiles(t)
+	d := p.NewDispatcher()
+	ctx := context.Background()
+
+	_, err := p.CreateProfile(ctx, &pb.CreateProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	createSpaceIn(t, d, "Work Notes")
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: DefaultProfileID})
+	require.NoError(t, err)
+	createSpaceIn(t, d, "Personal Notes")
+
+	_, err = d.Dispatch(ctx, "DeleteAccount", nil)
+	require.NoError(t, err)
+	_, err = d.Dispatch(ctx, "ListSpaces", nil)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_NOT_INITIALIZED, ErrorCodeOf(err))
+	_, err = os.Stat(filepath.Join(dataDir, "profile.json"))
+	assert.NoError(t, err, "the profile remains")
+
+	// The default profile starts over, the work profile is untouched
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: DefaultProfileID})
+	require.NoError(t, err)
+	assert.Empty(t, listSpaceNames(t, d))
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "work"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Work Notes"}, listSpaceNames(t, d))
+}
@@ -1,41 +1,221 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
+	"anysync-backend/shared/anysync"
 	"anysync-backend/shared/dispatcher"
 
 	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"google.golang.org/protobuf/proto"
 )
 
-// RegisterAll registers all handlers with the dispatcher.
-func RegisterAll(d *dispatcher.Dispatcher) {
+// backendFunc resolves the backend a command runs on. It is called on every
+// command, so a Profiles host can route to whichever profile is active.
+type backendFunc func() (*Backend, error)
+
+// RegisterAll registers all handlers of b with the dispatcher.
+func (b *Backend) RegisterAll(d *dispatcher.Dispatcher) {
 	// Lifecycle - PascalCase to match protobuf service method names
-	d.Register("Init", Init, &pb.InitRequest{})
-	d.Register("Shutdown", Shutdown, &pb.ShutdownRequest{})
+	d.Register("Init", b.Init, &pb.InitRequest{}, &pb.InitResponse{})
+	d.Register("Shutdown", b.Shutdown, &pb.ShutdownRequest{}, &pb.ShutdownResponse{})
+	d.Register("ImportAccount", b.ImportAccount, &pb.ImportAccountRequest{}, &pb.ImportAccountResponse{})
+	d.Register("DeleteAccount", outsideCommands(b.DeleteAccount), &pb.DeleteAccountRequest{}, &pb.DeleteAccountResponse{})
+
+	registerBackendCommands(d, b.self)
+}
 
+// NewDispatcher creates a dispatcher bound to b, with all handlers registered.
+// Both the desktop gRPC server and the mobile bindings dispatch through it, so
+// middleware added with Use applies to every platform.
+func (b *Backend) NewDispatcher() *dispatcher.Dispatcher {
+	d := dispatcher.New()
+	d.Use(dispatcher.Recover(b.reportPanic), dispatcher.Timeout(b.commandTimeout))
+	d.UseStream(dispatcher.RecoverStream(b.reportPanic))
+	b.RegisterAll(d)
+	return d
+}
+
+// self is the backendFunc of a dispatcher bound to b.
+func (b *Backend) self() (*Backend, error) {
+	return b, nil
+}
+
+// registerBackendCommands registers every command that runs on a backend,
+// except the lifecycle commands, resolving the backend with backend.
+func registerBackendCommands(d *dispatcher.Dispatcher, backend backendFunc) {
 	// Spaces
-	d.Register("CreateSpace", CreateSpace, &pb.CreateSpaceRequest{})
-	d.Register("JoinSpace", JoinSpace, &pb.JoinSpaceRequest{})
-	d.Register("LeaveSpace", LeaveSpace, &pb.LeaveSpaceRequest{})
-	d.Register("ListSpaces", ListSpaces, &pb.ListSpacesRequest{})
-	d.Register("DeleteSpace", DeleteSpace, &pb.DeleteSpaceRequest{})
+	d.Register("CreateSpace", route(backend, (*Backend).CreateSpace), &pb.CreateSpaceRequest{}, &pb.CreateSpaceResponse{})
+	d.Register("CreateInvite", route(backend, (*Backend).CreateInvite), &pb.CreateInviteRequest{}, &pb.CreateInviteResponse{})
+	d.Register("JoinSpace", route(backend, (*Backend).JoinSpace), &pb.JoinSpaceRequest{}, &pb.JoinSpaceResponse{})
+	d.Register("ListMembers", route(backend, (*Backend).ListMembers), &pb.ListMembersRequest{}, &pb.ListMembersResponse{})
+	d.Register("ApproveJoinRequest", route(backend, (*Backend).ApproveJoinRequest), &pb.ApproveJoinRequestRequest{}, &pb.ApproveJoinRequestResponse{})
+	d.Register("RemoveMember", route(backend, (*Backend).RemoveMember), &pb.RemoveMemberRequest{}, &pb.RemoveMemberResponse{})
+	d.Register("ChangeMemberPermission", route(backend, (*Backend).ChangeMemberPermission), &pb.ChangeMemberPermissionRequest{}, &pb.ChangeMemberPermissionResponse{})
+	d.Register("LeaveSpace", route(backend, (*Backend).LeaveSpace), &pb.LeaveSpaceRequest{}, &pb.LeaveSpaceResponse{})
+	d.Register("ListSpaces", route(backend, (*Backend).ListSpaces), &pb.ListSpacesRequest{}, &pb.ListSpacesResponse{})
+	d.Register("UpdateSpace", route(backend, (*Backend).UpdateSpace), &pb.UpdateSpaceRequest{}, &pb.UpdateSpaceResponse{})
+	d.Register("DeleteSpace", route(backend, (*Backend).DeleteSpace), &pb.DeleteSpaceRequest{}, &pb.DeleteSpaceResponse{})
 
 	// Documents
-	d.Register("CreateDocument", CreateDocument, &pb.CreateDocumentRequest{})
-	d.Register("GetDocument", GetDocument, &pb.GetDocumentRequest{})
-	d.Register("UpdateDocument", UpdateDocument, &pb.UpdateDocumentRequest{})
-	d.Register("DeleteDocument", DeleteDocument, &pb.DeleteDocumentRequest{})
-	d.Register("ListDocuments", ListDocuments, &pb.ListDocumentsRequest{})
-	d.Register("QueryDocuments", QueryDocuments, &pb.QueryDocumentsRequest{})
+	d.Register("CreateDocument", route(backend, (*Backend).CreateDocument), &pb.CreateDocumentRequest{}, &pb.CreateDocumentResponse{})
+	d.Register("GetDocument", route(backend, (*Backend).GetDocument), &pb.GetDocumentRequest{}, &pb.GetDocumentResponse{})
+	d.Register("UpdateDocument", route(backend, (*Backend).UpdateDocument), &pb.UpdateDocumentRequest{}, &pb.UpdateDocumentResponse{})
+	d.Register("DeleteDocument", route(backend, (*Backend).DeleteDocument), &pb.DeleteDocumentRequest{}, &pb.DeleteDocumentResponse{})
+	d.Register("ListDocuments", route(backend, (*Backend).ListDocuments), &pb.ListDocumentsRequest{}, &pb.ListDocumentsResponse{})
+	d.Register("QueryDocuments", route(backend, (*Backend).QueryDocuments), &pb.QueryDocumentsRequest{}, &pb.QueryDocumentsResponse{})
 
 	// Sync
-	d.Register("StartSync", StartSync, &pb.StartSyncRequest{})
-	d.Register("PauseSync", PauseSync, &pb.PauseSyncRequest{})
-	d.Register("GetSyncStatus", GetSyncStatus, &pb.GetSyncStatusRequest{})
+	d.Register("StartSync", route(backend, (*Backend).StartSync), &pb.StartSyncRequest{}, &pb.StartSyncResponse{})
+	d.Register("PauseSync", route(backend, (*Backend).PauseSync), &pb.PauseSyncRequest{}, &pb.PauseSyncResponse{})
+	d.Register("GetSyncStatus", route(backend, (*Backend).GetSyncStatus), &pb.GetSyncStatusRequest{}, &pb.GetSyncStatusResponse{})
+
+	// Batch - dispatches its commands back through d
+	d.Register("Batch", newBatchHandler(d, backend), &pb.BatchRequest{}, &pb.BatchResponse{})
+
+	// Events - server-streaming
+	d.RegisterStream("Subscribe", routeStream(backend, (*Backend).SubscribeStream), &pb.SubscribeRequest{}, &pb.SubscribeResponse{})
+
+	// Configuration
+	d.Register("GetConfig", route(backend, (*Backend).GetConfig), &pb.GetConfigRequest{}, &pb.GetConfigResponse{})
+
+	// Account security
+	d.Register("SetPassphrase", route(backend, (*Backend).SetPassphrase), &pb.SetPassphraseRequest{}, &pb.SetPassphraseResponse{})
+	d.Register("ChangePassphrase", route(backend, (*Backend).ChangePassphrase), &pb.ChangePassphraseRequest{}, &pb.ChangePassphraseResponse{})
+	d.Register("RemovePassphrase", route(backend, (*Backend).RemovePassphrase), &pb.RemovePassphraseRequest{}, &pb.RemovePassphraseResponse{})
+	d.Register("ExportMnemonic", route(backend, (*Backend).ExportMnemonic), &pb.ExportMnemonicRequest{}, &pb.ExportMnemonicResponse{})
+	d.Register("Lock", routeSession(backend, (*Backend).Lock), &pb.LockRequest{}, &pb.LockResponse{})
+	d.Register("Unlock", routeSession(backend, (*Backend).Unlock), &pb.UnlockRequest{}, &pb.UnlockResponse{})
+
+	// Devices
+	d.Register("ListDevices", route(backend, (*Backend).ListDevices), &pb.ListDevicesRequest{}, &pb.ListDevicesResponse{})
+	d.Register("RotateDeviceKey", routeSession(backend, (*Backend).RotateDeviceKey), &pb.RotateDeviceKeyRequest{}, &pb.RotateDeviceKeyResponse{})
+	d.Register("RevokeDevice", route(backend, (*Backend).RevokeDevice), &pb.RevokeDeviceRequest{}, &pb.RevokeDeviceResponse{})
+
+	// Account transfer; ImportAccount runs before Init, see RegisterAll
+	d.Register("ExportAccount", route(backend, (*Backend).ExportAccount), &pb.ExportAccountRequest{}, &pb.ExportAccountResponse{})
+
+	// Diagnostics - reports an uninitialized backend instead of failing
+	d.Register("GetStatus", route(orUninitialized(backend), (*Backend).GetStatus), &pb.GetStatusRequest{}, &pb.GetStatusResponse{})
+
+	// Introspection - reports the commands registered on d
+	d.Register("DescribeCommands", NewDescribeCommandsHandler(d), &pb.DescribeCommandsRequest{}, &pb.DescribeCommandsResponse{})
 }
 
-// GetDispatcher creates and returns a dispatcher with all handlers registered.
-func GetDispatcher() *dispatcher.Dispatcher {
-	d := dispatcher.New()
-	RegisterAll(d)
-	return d
+// route returns a handler that runs handler on the backend resolved for each call.
+func route(backend backendFunc, handler func(*Backend, context.Context, proto.Message) (proto.Message, error)) dispatcher.Handler {
+	return func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		b, ctx, done, err := enter(ctx, backend, false)
+		if err != nil {
+			return nil, err
+		}
+		defer done()
+		return handler(b, ctx, req)
+	}
+}
+
+// routeStream is the streaming counterpart of route.
+func routeStream(backend backendFunc, handler func(*Backend, context.Context, proto.Message, func(proto.Message) error) error) dispatcher.StreamHandler {
+	return func(ctx context.Context, req proto.Message, send func(proto.Message) error) error {
+		b, ctx, done, err := enter(ctx, backend, true)
+		if err != nil {
+			return err
+		}
+		defer done()
+		return handler(b, ctx, req, send)
+	}
+}
+
+// routeSession is route for Lock, Unlock and RotateDeviceKey: the command
+// is admitted but does not hold the session, which it changes. It cannot run
+// within another command, such as a batch, as it would wait for that command.
+func routeSession(backend backendFunc, handler func(*Backend, context.Context, proto.Message) (proto.Message, error)) dispatcher.Handler {
+	return func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		if err := ensureOutsideCommands(ctx); err != nil {
+			return nil, err
+		}
+
+		b, err := backend()
+		if err != nil {
+			return nil, err
+		}
+		ctx, done, err := b.admit(ctx, &b.calls)
+		if err != nil {
+			return nil, err
+		}
+		defer done()
+		return handler(b, ctx, req)
+	}
+}
+
+// outsideCommands returns a handler that runs handler unless it is called
+// from within another command, such as a batch. It guards the lifecycle
+// commands that shut the backend down, which waits for the running commands.
+func outsideCommands(handler dispatcher.Handler) dispatcher.Handler {
+	return func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		if err := ensureOutsideCommands(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ensureOutsideCommands fails with ErrInvalidArgument if ctx is that of a
+// running command.
+func ensureOutsideCommands(ctx context.Context) error {
+	if _, running := ctx.Value(runningKey{}).(*Backend); running {
+		return fmt.Errorf("%w: the command cannot run within another command", anysync.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// orUninitialized resolves to an empty backend where backend fails with
+// ErrNotInitialized (a profile host before Init or with no active profile),
+// for commands that describe the backend state.
+func orUninitialized(backend backendFunc) backendFunc {
+	return func() (*Backend, error) {
+		b, err := backend()
+		if errors.Is(err, ErrNotInitialized) {
+			return NewBackend(), nil
+		}
+		return b, err
+	}
+}
+
+// enter resolves the backend of a command and admits the command to it (see
+// Backend.admit). Commands dispatched from within a running command, such as
+// batched commands, stay on its backend and are not admitted again.
+func enter(ctx context.Context, backend backendFunc, stream bool) (*Backend, context.Context, func(), error) {
+	if b, ok := ctx.Value(runningKey{}).(*Backend); ok {
+		return b, ctx, func() {}, nil
+	}
+
+	b, err := backend()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	wg := &b.calls
+	if stream {
+		wg = &b.streams
+	}
+	ctx, done, err := b.admit(ctx, wg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if stream {
+		return b, ctx, done, nil
+	}
+
+	// Commands run on a locked or unlocked session, never while it changes
+	b.session.RLock()
+	b.touch()
+	return b, ctx, func() {
+		b.touch()
+		b.session.RUnlock()
+		done()
+	}, nil
 }
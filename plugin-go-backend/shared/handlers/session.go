@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"anysync-backend/shared/anysync"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Reasons reported in the payload of account.locked events.
+const (
+	lockReasonRequested = "requested" // The Lock command
+	lockReasonIdle      = "idle"      // The auto-lock
+	lockReasonFailed    = "failed"    // Reopening the spaces failed, see RotateDeviceKey
+)
+
+// Lock clears the account keys from memory and closes the spaces, keeping
+// the backend and its event subscriptions running. Locking a locked session
+// is a no-op.
+func (b *Backend) Lock(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.lock(lockReasonRequested, 0); err != nil {
+		return nil, err
+	}
+	return &pb.LockResponse{Success: true}, nil
+}
+
+// Unlock opens the account keys again, with the passphrase if they are
+// protected, and reopens the managers. Unlocking an unlocked session is a
+// no-op.
+func (b *Backend) Unlock(ctx context.Context, req proto.Message) (proto.Message, error) {
+	unlockReq := req.(*pb.UnlockRequest)
+
+	b.session.Lock()
+	defer b.session.Unlock()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.initialized {
+		return nil, fmt.Errorf("%w: call Init first", ErrNotInitialized)
+	}
+	if b.closing {
+		return nil, ErrShuttingDown
+	}
+	if !b.locked {
+		return &pb.UnlockResponse{Success: true}, nil
+	}
+
+	if err := b.accountManager.LoadKeysWithPassphrase(unlockReq.Passphrase); err != nil {
+		b.accountManager.ClearKeys()
+		return nil, err
+	}
+	if err := b.openManagers(); err != nil {
+		b.accountManager.ClearKeys()
+		return nil, err
+	}
+	b.locked = false
+	b.touch()
+
+	if b.eventManager != nil {
+		b.eventManager.EmitEvent(anysync.EventAccountUnlocked, "", nil)
+	}
+
+	return &pb.UnlockResponse{Success: true}, nil
+}
+
+// lock locks the session once the running commands finish, unless idle is
+// positive and a command ran within the last idle.
+func (b *Backend) lock(reason string, idle time.Duration) error {
+	b.session.Lock()
+	defer b.session.Unlock()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.initialized {
+		return fmt.Errorf("%w: call Init first", ErrNotInitialized)
+	}
+	if b.closing {
+		return ErrShuttingDown
+	}
+	if b.locked || (idle > 0 && b.idleFor() < idle) {
+		return nil
+	}
+
+	b.closeManagers()
+	b.accountManager.ClearKeys()
+	b.locked = true
+
+	if b.eventManager != nil {
+		b.eventManager.EmitEvent(anysync.EventAccountLocked, "", map[string]string{"reason": reason})
+	}
+	return nil
+}
+
+// autoLock locks the session whenever no command ran for after, until ctx
+// is done.
+func (b *Backend) autoLock(ctx context.Context, after time.Duration) {
+	timer := time.NewTimer(after)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		if b.idleFor() >= after {
+			if err := b.lock(lockReasonIdle, after); err != nil {
+				return
+			}
+		}
+		timer.Reset(max(after-b.idleFor(), after/10))
+	}
+}
+
+// touch records that a command ran now.
+func (b *Backend) touch() {
+	b.lastActive.Store(time.Now().UnixNano())
+}
+
+// idleFor returns the time since the last command.
+func (b *Backend) idleFor() time.Duration {
+	return time.Since(time.Unix(0, b.lastActive.Load()))
+}
+
+// errSessionLocked returns the ErrLocked error of commands on a locked session.
+func errSessionLocked() error {
+	return &anysync.Error{
+		Kind:    anysync.ErrLocked,
+		Message: "the session is locked, call Unlock",
+		Details: map[string]string{"reason": "session_locked"},
+	}
+}
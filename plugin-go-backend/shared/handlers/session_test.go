@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"anysync-backend/shared/anysync"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_Session_LockUnlock tests that a locked session rejects data commands until it is unlocked.
+func TestIntegration_Session_LockUnlock(t *testing.T) {
+	ctx := context.Background()
+	b := NewBackend()
+	_, err := b.Init(ctx, &pb.InitRequest{DataDir: t.TempDir(), Passphrase: "correct horse"})
+	require.NoError(t, err)
+	defer b.Shutdown(ctx, &pb.ShutdownRequest{})
+	d := b.NewDispatcher()
+
+	createSpaceIn(t, d, "Notes")
+	subscriberID, events, err := b.Subscribe(ctx, &pb.SubscribeRequest{EventTypes: []string{"account.locked", "account.unlocked"}})
+	require.NoError(t, err)
+	defer b.Unsubscribe(subscriberID)
+
+	var lockResp pb.LockResponse
+	require.NoError(t, dispatchMessage(d, "Lock", &pb.LockRequest{}, &lockResp))
+	event := nextEvent(t, events)
+	assert.Equal(t, anysync.EventAccountLocked, event.Type)
+	assert.Equal(t, "requested", event.Payload["reason"])
+	assert.False(t, b.accountManager.HasKeys())
+
+	// Data commands fail, status and events keep working
+	_, err = d.Dispatch(ctx, "ListSpaces", nil)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_LOCKED, ErrorCodeOf(err))
+	assert.Equal(t, "session_locked", ToProtoError(err).Details["reason"])
+	var status pb.GetStatusResponse
+	require.NoError(t, dispatchMessage(d, "GetStatus", &pb.GetStatusRequest{}, &status))
+	assert.True(t, status.Locked)
+	require.NoError(t, dispatchMessage(d, "Lock", &pb.LockRequest{}, &lockResp), "locking again is a no-op")
+
+	var unlockResp pb.UnlockResponse
+	err = dispatchMessage(d, "Unlock", &pb.UnlockRequest{Passphrase: "wrong horse"}, &unlockResp)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_LOCKED, ErrorCodeOf(err))
+	assert.Equal(t, "wrong_passphrase", ToProtoError(err).Details["reason"])
+	require.NoError(t, dispatchMessage(d, "Unlock", &pb.UnlockRequest{Passphrase: "correct horse"}, &unlockResp))
+	assert.Equal(t, anysync.EventAccountUnlocked, nextEvent(t, events).Type)
+
+	assert.Equal(t, []string{"Notes"}, listSpaceNames(t, d))
+	require.NoError(t, dispatchMessage(d, "GetStatus", &pb.GetStatusRequest{}, &status))
+	assert.False(t, status.Locked)
+
+	// Lock cannot run inside a batch, which holds the session
+	var batchResp pb.BatchResponse
+	require.NoError(t, dispatchMessage(d, "Batch", &pb.BatchRequest{Commands: []*pb.Command{{Name: "Lock"}}}, &batchResp))
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, batchResp.Results[0].ErrorDetail.GetCode())
+}
+
+// TestIntegration_Session_AutoLock tests that an idle session locks itself.
+func TestIntegration_Session_AutoLock(t *testing.T) {
+	ctx := context.Background()
+	b := NewBackend()
+	_, err := b.Init(ctx, &pb.InitRequest{DataDir: t.TempDir(), Config: map[string]string{"auto_lock_sec": "1"}})
+	require.NoError(t, err)
+	defer b.Shutdown(ctx, &pb.ShutdownRequest{})
+	d := b.NewDispatcher()
+
+	subscriberID, events, err := b.Subscribe(ctx, &pb.SubscribeRequest{EventTypes: []string{"account.locked"}})
+	require.NoError(t, err)
+	defer b.Unsubscribe(subscriberID)
+
+	// Commands keep the session unlocked
+	for i := 0; i < 3; i++ {
+		time.Sleep(400 * time.Millisecond)
+		_, err := d.Dispatch(ctx, "ListSpaces", nil)
+		require.NoError(t, err)
+	}
+
+	event := nextEvent(t, events)
+	assert.Equal(t, "idle", event.Payload["reason"])
+	_, err = d.Dispatch(ctx, "ListSpaces", nil)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_LOCKED, ErrorCodeOf(err))
+
+	// An unprotected account unlocks without a passphrase
+	_, err = b.Unlock(ctx, &pb.UnlockRequest{})
+	require.NoError(t, err)
+	_, err = d.Dispatch(ctx, "ListSpaces", nil)
+	assert.NoError(t, err)
+}
+
+// nextEvent waits for the next event of events.
+func nextEvent(t *testing.T, events <-chan *anysync.Event) *anysync.Event {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(3 * time.Second):
+		t.Fatal("timeout waiting for event")
+		return nil
+	}
+}
@@ -3,90 +3,154 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"anysync-backend/shared/anysync"
 	pb "anysync-backend/shared/proto/syncspace/v1"
 
 	"google.golang.org/protobuf/proto"
 )
 
 // CreateSpace handles space creation.
-func CreateSpace(ctx context.Context, req proto.Message) (proto.Message, error) {
-	if err := ensureInitialized(); err != nil {
+func (b *Backend) CreateSpace(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
 		return nil, err
 	}
 
 	spaceReq := req.(*pb.CreateSpaceRequest)
 
 	// Create space using SpaceManager
-	globalState.mu.RLock()
-	sm := globalState.spaceManager
-	globalState.mu.RUnlock()
+	b.mu.RLock()
+	sm := b.spaceManager
+	b.mu.RUnlock()
 
 	if sm == nil {
-		return nil, fmt.Errorf("space manager not initialized")
+		return nil, fmt.Errorf("space manager %w", ErrNotInitialized)
 	}
 
-	// Note: spaceReq.SpaceId is used as a reference name, actual ID is generated
-	err := sm.CreateSpace(spaceReq.SpaceId, spaceReq.Name, spaceReq.Metadata)
+	// spaceReq.SpaceId is the client's alias, the Any-Sync ID is generated
+	spaceID, err := sm.CreateSpace(ctx, spaceReq.SpaceId, spaceReq.Name, spaceReq.Metadata)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create space: %w", err)
 	}
 
-	// Get the actual generated space ID
-	spaces := sm.ListSpaces()
-	var actualSpaceID string
-	for _, space := range spaces {
-		if space.Name == spaceReq.Name {
-			actualSpaceID = space.SpaceID
-			break
-		}
+	return &pb.CreateSpaceResponse{
+		SpaceId: spaceID,
+	}, nil
+}
+
+// CreateInvite handles inviting other accounts to a space.
+func (b *Backend) CreateInvite(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
+		return nil, err
 	}
 
-	return &pb.CreateSpaceResponse{
-		SpaceId: actualSpaceID,
+	inviteReq := req.(*pb.CreateInviteRequest)
+
+	if inviteReq.ExpiresInSec < 0 {
+		return nil, fmt.Errorf("%w: expires_in_sec must not be negative", anysync.ErrInvalidArgument)
+	}
+	permission, err := fromSpacePermission(inviteReq.Permission)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	sm := b.spaceManager
+	b.mu.RUnlock()
+
+	if sm == nil {
+		return nil, fmt.Errorf("space manager %w", ErrNotInitialized)
+	}
+
+	ttl := time.Duration(inviteReq.ExpiresInSec) * time.Second
+	var invite *anysync.Invite
+	if inviteReq.RequireApproval {
+		invite, err = sm.CreateApprovalInvite(ctx, inviteReq.SpaceId, ttl)
+	} else {
+		invite, err = sm.CreateInvite(ctx, inviteReq.SpaceId, permission, ttl)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invite: %w", err)
+	}
+
+	return &pb.CreateInviteResponse{
+		InviteToken: invite.Token,
+		InviteId:    invite.InviteID,
+		ExpiresAt:   invite.ExpiresAt,
 	}, nil
 }
 
-// JoinSpace handles joining a space.
-func JoinSpace(ctx context.Context, req proto.Message) (proto.Message, error) {
-	if err := ensureInitialized(); err != nil {
+// JoinSpace handles joining a space with an invite token.
+func (b *Backend) JoinSpace(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
 		return nil, err
 	}
 
 	joinReq := req.(*pb.JoinSpaceRequest)
 
-	// TODO: Implement with Any-Sync SpaceService
-	_ = joinReq
+	if joinReq.InviteToken == "" {
+		return nil, fmt.Errorf("%w: invite_token is required", anysync.ErrInvalidArgument)
+	}
+
+	b.mu.RLock()
+	sm := b.spaceManager
+	b.mu.RUnlock()
+
+	if sm == nil {
+		return nil, fmt.Errorf("space manager %w", ErrNotInitialized)
+	}
+
+	// joinReq.SpaceId is the client's alias, the Any-Sync ID is in the token
+	result, err := sm.JoinSpace(ctx, joinReq.SpaceId, joinReq.InviteToken)
+	if err != nil {
+		return &pb.JoinSpaceResponse{Success: false}, fmt.Errorf("failed to join space: %w", err)
+	}
 
-	return &pb.JoinSpaceResponse{Success: false}, fmt.Errorf("not implemented yet")
+	return &pb.JoinSpaceResponse{
+		Success: true,
+		SpaceId: result.SpaceID,
+		Pending: result.Pending,
+	}, nil
 }
 
-// LeaveSpace handles leaving a space.
-func LeaveSpace(ctx context.Context, req proto.Message) (proto.Message, error) {
-	if err := ensureInitialized(); err != nil {
+// LeaveSpace handles leaving a space, keeping a read-only copy or deleting
+// it.
+func (b *Backend) LeaveSpace(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
 		return nil, err
 	}
 
 	leaveReq := req.(*pb.LeaveSpaceRequest)
 
-	// TODO: Implement with Any-Sync SpaceService
-	_ = leaveReq
+	b.mu.RLock()
+	sm := b.spaceManager
+	b.mu.RUnlock()
+
+	if sm == nil {
+		return nil, fmt.Errorf("space manager %w", ErrNotInitialized)
+	}
+
+	result, err := sm.LeaveSpace(ctx, leaveReq.SpaceId, leaveReq.DeleteLocal)
+	if err != nil {
+		return &pb.LeaveSpaceResponse{Success: false}, fmt.Errorf("failed to leave space: %w", err)
+	}
 
-	return &pb.LeaveSpaceResponse{Success: false}, fmt.Errorf("not implemented yet")
+	return &pb.LeaveSpaceResponse{Success: true, AclError: result.AclError}, nil
 }
 
 // ListSpaces handles listing spaces.
-func ListSpaces(ctx context.Context, req proto.Message) (proto.Message, error) {
-	if err := ensureInitialized(); err != nil {
+func (b *Backend) ListSpaces(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
 		return nil, err
 	}
 
-	globalState.mu.RLock()
-	sm := globalState.spaceManager
-	globalState.mu.RUnlock()
+	b.mu.RLock()
+	sm := b.spaceManager
+	b.mu.RUnlock()
 
 	if sm == nil {
-		return nil, fmt.Errorf("space manager not initialized")
+		return nil, fmt.Errorf("space manager %w", ErrNotInitialized)
 	}
 
 	// Get spaces from SpaceManager
@@ -95,15 +159,7 @@ func ListSpaces(ctx context.Context, req proto.Message) (proto.Message, error) {
 	// Convert to protobuf format
 	pbSpaces := make([]*pb.SpaceInfo, len(spaces))
 	for i, space := range spaces {
-		pbSpaces[i] = &pb.SpaceInfo{
-			SpaceId:   space.SpaceID,
-			Name:      space.Name,
-			Metadata:  space.Metadata,
-			CreatedAt: space.CreatedAt,
-			UpdatedAt: space.UpdatedAt,
-			// SyncStatus: IDLE for local-only mode (network sync not yet implemented)
-			SyncStatus: pb.SyncStatus_SYNC_STATUS_IDLE,
-		}
+		pbSpaces[i] = toSpaceInfo(space)
 	}
 
 	return &pb.ListSpacesResponse{
@@ -111,27 +167,95 @@ func ListSpaces(ctx context.Context, req proto.Message) (proto.Message, error) {
 	}, nil
 }
 
+// UpdateSpace handles renaming a space and editing its metadata.
+func (b *Backend) UpdateSpace(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
+		return nil, err
+	}
+
+	updateReq := req.(*pb.UpdateSpaceRequest)
+
+	b.mu.RLock()
+	sm := b.spaceManager
+	b.mu.RUnlock()
+
+	if sm == nil {
+		return nil, fmt.Errorf("space manager %w", ErrNotInitialized)
+	}
+
+	space, err := sm.UpdateSpace(ctx, updateReq.SpaceId, anysync.SpaceUpdate{
+		Name:              updateReq.Name,
+		Metadata:          updateReq.Metadata,
+		ReplaceMetadata:   updateReq.ReplaceMetadata,
+		ExpectedUpdatedAt: updateReq.ExpectedUpdatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update space: %w", err)
+	}
+
+	return &pb.UpdateSpaceResponse{
+		Space: toSpaceInfo(space),
+	}, nil
+}
+
 // DeleteSpace handles space deletion.
-func DeleteSpace(ctx context.Context, req proto.Message) (proto.Message, error) {
-	if err := ensureInitialized(); err != nil {
+func (b *Backend) DeleteSpace(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
 		return nil, err
 	}
 
 	deleteReq := req.(*pb.DeleteSpaceRequest)
 
-	globalState.mu.RLock()
-	sm := globalState.spaceManager
-	globalState.mu.RUnlock()
+	b.mu.RLock()
+	sm := b.spaceManager
+	b.mu.RUnlock()
 
 	if sm == nil {
-		return nil, fmt.Errorf("space manager not initialized")
+		return nil, fmt.Errorf("space manager %w", ErrNotInitialized)
 	}
 
 	// Delete space using SpaceManager
-	err := sm.DeleteSpace(deleteReq.SpaceId)
+	err := sm.DeleteSpace(ctx, deleteReq.SpaceId)
 	if err != nil {
 		return &pb.DeleteSpaceResponse{Success: false}, fmt.Errorf("failed to delete space: %w", err)
 	}
 
 	return &pb.DeleteSpaceResponse{Success: true}, nil
 }
+
+// fromSpacePermission converts a protobuf space permission, reader when
+// unspecified.
+func fromSpacePermission(permission pb.SpacePermission) (anysync.SpacePermission, error) {
+	switch permission {
+	case pb.SpacePermission_SPACE_PERMISSION_UNSPECIFIED, pb.SpacePermission_SPACE_PERMISSION_READER:
+		return anysync.PermissionReader, nil
+	case pb.SpacePermission_SPACE_PERMISSION_WRITER:
+		return anysync.PermissionWriter, nil
+	case pb.SpacePermission_SPACE_PERMISSION_ADMIN:
+		return anysync.PermissionAdmin, nil
+	case pb.SpacePermission_SPACE_PERMISSION_OWNER:
+		return anysync.PermissionOwner, nil
+	default:
+		return "", fmt.Errorf("%w: unknown permission %v", anysync.ErrInvalidArgument, permission)
+	}
+}
+
+// toSpaceInfo converts space metadata to its protobuf representation.
+func toSpaceInfo(space *anysync.SpaceMetadata) *pb.SpaceInfo {
+	// SyncStatus: IDLE for local-only mode (network sync not yet implemented),
+	// PAUSED for good once the space is left
+	syncStatus := pb.SyncStatus_SYNC_STATUS_IDLE
+	if space.LeftAt != 0 {
+		syncStatus = pb.SyncStatus_SYNC_STATUS_PAUSED
+	}
+	return &pb.SpaceInfo{
+		SpaceId:    space.SpaceID,
+		Alias:      space.Alias,
+		Name:       space.Name,
+		Metadata:   space.Metadata,
+		CreatedAt:  space.CreatedAt,
+		UpdatedAt:  space.UpdatedAt,
+		SyncStatus: syncStatus,
+		LeftAt:     space.LeftAt,
+	}
+}
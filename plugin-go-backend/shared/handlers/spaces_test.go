@@ -4,18 +4,20 @@ import (
 	"context"
 	"testing"
 
+	"anysync-backend/shared/anysync"
+	"anysync-backend/shared/dispatcher"
 	pb "anysync-backend/shared/proto/syncspace/v1"
 )
 
 func TestUnit_Spaces_CreateSpaceNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.CreateSpaceRequest{
 		SpaceId: "space1",
 		Name:    "Test Space",
 	}
 
-	_, err := CreateSpace(context.Background(), req)
+	_, err := b.CreateSpace(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
@@ -23,26 +25,26 @@ func TestUnit_Spaces_CreateSpaceNotInitialized(t *testing.T) {
 
 func TestUnit_Spaces_CreateSpaceSuccess(t *testing.T) {
 	// Initialize first
-	resetGlobalState()
+	b := NewBackend()
 	initReq := &pb.InitRequest{
 		DataDir:   t.TempDir(),
 		NetworkId: "test-network",
 		DeviceId:  "test-device",
 	}
-	_, err := Init(context.Background(), initReq)
+	_, err := b.Init(context.Background(), initReq)
 	if err != nil {
 		t.Fatalf("Init failed: %v", err)
 	}
 
 	req := &pb.CreateSpaceRequest{
-		SpaceId: "ref1", // Used as reference name
+		SpaceId: "ref1", // Client alias
 		Name:    "Test Space",
 		Metadata: map[string]string{
 			"type": "personal",
 		},
 	}
 
-	resp, err := CreateSpace(context.Background(), req)
+	resp, err := b.CreateSpace(context.Background(), req)
 	if err != nil {
 		t.Fatalf("CreateSpace failed: %v", err)
 	}
@@ -53,58 +55,177 @@ func TestUnit_Spaces_CreateSpaceSuccess(t *testing.T) {
 	}
 }
 
+func TestUnit_Spaces_CreateSpaceAlias(t *testing.T) {
+	b := NewBackend()
+	initReq := &pb.InitRequest{
+		DataDir:   t.TempDir(),
+		NetworkId: "test-network",
+		DeviceId:  "test-device",
+	}
+	_, err := b.Init(context.Background(), initReq)
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	defer b.Shutdown(context.Background(), &pb.ShutdownRequest{})
+
+	create := func(alias string) string {
+		t.Helper()
+		resp, err := b.CreateSpace(context.Background(), &pb.CreateSpaceRequest{SpaceId: alias, Name: "Same Name"})
+		if err != nil {
+			t.Fatalf("CreateSpace failed: %v", err)
+		}
+		return resp.(*pb.CreateSpaceResponse).SpaceId
+	}
+
+	// Spaces with the same name get their own IDs
+	workID := create("work")
+	homeID := create("home")
+	if workID == homeID {
+		t.Fatal("Expected distinct space IDs for distinct aliases")
+	}
+
+	// Retrying with the alias returns the same space
+	if retryID := create("work"); retryID != workID {
+		t.Errorf("Expected retry to return %s, got %s", workID, retryID)
+	}
+
+	listResp, err := b.ListSpaces(context.Background(), &pb.ListSpacesRequest{})
+	if err != nil {
+		t.Fatalf("ListSpaces failed: %v", err)
+	}
+	spaces := listResp.(*pb.ListSpacesResponse).Spaces
+	if len(spaces) != 2 {
+		t.Fatalf("Expected 2 spaces, got %d", len(spaces))
+	}
+	for _, space := range spaces {
+		if space.SpaceId == workID && space.Alias != "work" {
+			t.Errorf("Expected alias work, got %q", space.Alias)
+		}
+	}
+
+	// The alias is accepted as a space ID
+	docResp, err := b.CreateDocument(context.Background(), &pb.CreateDocumentRequest{SpaceId: "work", Data: []byte("hello")})
+	if err != nil {
+		t.Fatalf("CreateDocument by alias failed: %v", err)
+	}
+	getResp, err := b.GetDocument(context.Background(), &pb.GetDocumentRequest{
+		SpaceId:    workID,
+		DocumentId: docResp.(*pb.CreateDocumentResponse).DocumentId,
+	})
+	if err != nil {
+		t.Fatalf("GetDocument by ID failed: %v", err)
+	}
+	if string(getResp.(*pb.GetDocumentResponse).Document.GetData()) != "hello" {
+		t.Error("Expected the document created by alias")
+	}
+
+	if _, err := b.DeleteSpace(context.Background(), &pb.DeleteSpaceRequest{SpaceId: "home"}); err != nil {
+		t.Fatalf("DeleteSpace by alias failed: %v", err)
+	}
+}
+
+func TestUnit_Spaces_UpdateSpace(t *testing.T) {
+	b := NewBackend()
+	initReq := &pb.InitRequest{
+		DataDir:   t.TempDir(),
+		NetworkId: "test-network",
+		DeviceId:  "test-device",
+	}
+	_, err := b.Init(context.Background(), initReq)
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	defer b.Shutdown(context.Background(), &pb.ShutdownRequest{})
+	d := b.NewDispatcher()
+
+	var createResp pb.CreateSpaceResponse
+	createReq := &pb.CreateSpaceRequest{SpaceId: "notes", Name: "Notes", Metadata: map[string]string{"color": "blue"}}
+	if err := dispatchMessage(d, "CreateSpace", createReq, &createResp); err != nil {
+		t.Fatalf("CreateSpace failed: %v", err)
+	}
+
+	// Without a name, only the metadata changes
+	var updateResp pb.UpdateSpaceResponse
+	updateReq := &pb.UpdateSpaceRequest{SpaceId: "notes", Metadata: map[string]string{"icon": "book"}}
+	if err := dispatchMessage(d, "UpdateSpace", updateReq, &updateResp); err != nil {
+		t.Fatalf("UpdateSpace failed: %v", err)
+	}
+	space := updateResp.Space
+	if space.SpaceId != createResp.SpaceId || space.Name != "Notes" {
+		t.Errorf("Expected space %s named Notes, got %s named %q", createResp.SpaceId, space.SpaceId, space.Name)
+	}
+	if space.Metadata["color"] != "blue" || space.Metadata["icon"] != "book" {
+		t.Errorf("Expected merged metadata, got %v", space.Metadata)
+	}
+
+	name := "Journal"
+	updateReq = &pb.UpdateSpaceRequest{SpaceId: "notes", Name: &name, ExpectedUpdatedAt: space.UpdatedAt}
+	if err := dispatchMessage(d, "UpdateSpace", updateReq, &updateResp); err != nil {
+		t.Fatalf("UpdateSpace failed: %v", err)
+	}
+	if updateResp.Space.Name != "Journal" {
+		t.Errorf("Expected name Journal, got %q", updateResp.Space.Name)
+	}
+
+	// The same expected_updated_at is stale now
+	err = dispatchMessage(d, "UpdateSpace", updateReq, &updateResp)
+	if code := ErrorCodeOf(err); code != pb.ErrorCode_ERROR_CODE_VERSION_CONFLICT {
+		t.Errorf("Expected ERROR_CODE_VERSION_CONFLICT, got %v", code)
+	}
+}
+
 func TestUnit_Spaces_JoinSpaceNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.JoinSpaceRequest{
 		SpaceId: "space1",
 	}
 
-	_, err := JoinSpace(context.Background(), req)
+	_, err := b.JoinSpace(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
 }
 
 func TestUnit_Spaces_LeaveSpaceNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.LeaveSpaceRequest{
 		SpaceId: "space1",
 	}
 
-	_, err := LeaveSpace(context.Background(), req)
+	_, err := b.LeaveSpace(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
 }
 
 func TestUnit_Spaces_ListSpacesNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.ListSpacesRequest{}
 
-	_, err := ListSpaces(context.Background(), req)
+	_, err := b.ListSpaces(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
 }
 
 func TestUnit_Spaces_ListSpacesEmpty(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 	initReq := &pb.InitRequest{
 		DataDir:   t.TempDir(),
 		NetworkId: "test-network",
 		DeviceId:  "test-device",
 	}
-	_, err := Init(context.Background(), initReq)
+	_, err := b.Init(context.Background(), initReq)
 	if err != nil {
 		t.Fatalf("Init failed: %v", err)
 	}
 
 	req := &pb.ListSpacesRequest{}
 
-	resp, err := ListSpaces(context.Background(), req)
+	resp, err := b.ListSpaces(context.Background(), req)
 	if err != nil {
 		t.Fatalf("ListSpaces failed: %v", err)
 	}
@@ -116,26 +237,26 @@ func TestUnit_Spaces_ListSpacesEmpty(t *testing.T) {
 }
 
 func TestUnit_Spaces_DeleteSpaceNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.DeleteSpaceRequest{
 		SpaceId: "space1",
 	}
 
-	_, err := DeleteSpace(context.Background(), req)
+	_, err := b.DeleteSpace(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
 }
 
 func TestUnit_Spaces_ListSpacesWithSpaces(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 	initReq := &pb.InitRequest{
 		DataDir:   t.TempDir(),
 		NetworkId: "test-network",
 		DeviceId:  "test-device",
 	}
-	_, err := Init(context.Background(), initReq)
+	_, err := b.Init(context.Background(), initReq)
 	if err != nil {
 		t.Fatalf("Init failed: %v", err)
 	}
@@ -146,7 +267,7 @@ func TestUnit_Spaces_ListSpacesWithSpaces(t *testing.T) {
 		Name:     "Space 1",
 		Metadata: map[string]string{"type": "work"},
 	}
-	_, err = CreateSpace(context.Background(), createReq1)
+	_, err = b.CreateSpace(context.Background(), createReq1)
 	if err != nil {
 		t.Fatalf("CreateSpace 1 failed: %v", err)
 	}
@@ -156,14 +277,14 @@ func TestUnit_Spaces_ListSpacesWithSpaces(t *testing.T) {
 		Name:     "Space 2",
 		Metadata: map[string]string{"type": "personal"},
 	}
-	_, err = CreateSpace(context.Background(), createReq2)
+	_, err = b.CreateSpace(context.Background(), createReq2)
 	if err != nil {
 		t.Fatalf("CreateSpace 2 failed: %v", err)
 	}
 
 	// List spaces
 	listReq := &pb.ListSpacesRequest{}
-	resp, err := ListSpaces(context.Background(), listReq)
+	resp, err := b.ListSpaces(context.Background(), listReq)
 	if err != nil {
 		t.Fatalf("ListSpaces failed: %v", err)
 	}
@@ -188,13 +309,13 @@ func TestUnit_Spaces_ListSpacesWithSpaces(t *testing.T) {
 }
 
 func TestUnit_Spaces_DeleteSpaceSuccess(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 	initReq := &pb.InitRequest{
 		DataDir:   t.TempDir(),
 		NetworkId: "test-network",
 		DeviceId:  "test-device",
 	}
-	_, err := Init(context.Background(), initReq)
+	_, err := b.Init(context.Background(), initReq)
 	if err != nil {
 		t.Fatalf("Init failed: %v", err)
 	}
@@ -204,7 +325,7 @@ func TestUnit_Spaces_DeleteSpaceSuccess(t *testing.T) {
 		SpaceId: "ref1",
 		Name:    "Test Space",
 	}
-	createResp, err := CreateSpace(context.Background(), createReq)
+	createResp, err := b.CreateSpace(context.Background(), createReq)
 	if err != nil {
 		t.Fatalf("CreateSpace failed: %v", err)
 	}
@@ -215,7 +336,7 @@ func TestUnit_Spaces_DeleteSpaceSuccess(t *testing.T) {
 	deleteReq := &pb.DeleteSpaceRequest{
 		SpaceId: spaceID,
 	}
-	deleteResp, err := DeleteSpace(context.Background(), deleteReq)
+	deleteResp, err := b.DeleteSpace(context.Background(), deleteReq)
 	if err != nil {
 		t.Fatalf("DeleteSpace failed: %v", err)
 	}
@@ -226,7 +347,7 @@ func TestUnit_Spaces_DeleteSpaceSuccess(t *testing.T) {
 
 	// Verify space is gone
 	listReq := &pb.ListSpacesRequest{}
-	listResp, err := ListSpaces(context.Background(), listReq)
+	listResp, err := b.ListSpaces(context.Background(), listReq)
 	if err != nil {
 		t.Fatalf("ListSpaces failed: %v", err)
 	}
@@ -237,13 +358,13 @@ func TestUnit_Spaces_DeleteSpaceSuccess(t *testing.T) {
 }
 
 func TestUnit_Spaces_DeleteSpaceNotFound(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 	initReq := &pb.InitRequest{
 		DataDir:   t.TempDir(),
 		NetworkId: "test-network",
 		DeviceId:  "test-device",
 	}
-	_, err := Init(context.Background(), initReq)
+	_, err := b.Init(context.Background(), initReq)
 	if err != nil {
 		t.Fatalf("Init failed: %v", err)
 	}
@@ -252,50 +373,206 @@ func TestUnit_Spaces_DeleteSpaceNotFound(t *testing.T) {
 	deleteReq := &pb.DeleteSpaceRequest{
 		SpaceId: "non-existent-space",
 	}
-	_, err = DeleteSpace(context.Background(), deleteReq)
+	_, err = b.DeleteSpace(context.Background(), deleteReq)
 	if err == nil {
 		t.Fatal("Expected error when deleting non-existent space")
 	}
 }
 
-func TestUnit_Spaces_JoinSpaceNotImplemented(t *testing.T) {
-	resetGlobalState()
+func TestUnit_Spaces_JoinSpaceInvalidToken(t *testing.T) {
+	b := NewBackend()
 	initReq := &pb.InitRequest{
 		DataDir:   t.TempDir(),
 		NetworkId: "test-network",
 		DeviceId:  "test-device",
 	}
-	_, err := Init(context.Background(), initReq)
+	_, err := b.Init(context.Background(), initReq)
 	if err != nil {
 		t.Fatalf("Init failed: %v", err)
 	}
+	defer b.Shutdown(context.Background(), &pb.ShutdownRequest{})
 
-	joinReq := &pb.JoinSpaceRequest{
-		SpaceId: "some-space",
+	for _, token := range []string{"", "not-a-token"} {
+		joinReq := &pb.JoinSpaceRequest{
+			SpaceId:     "some-space",
+			InviteToken: token,
+		}
+		_, err = b.JoinSpace(context.Background(), joinReq)
+		if code := ErrorCodeOf(err); code != pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT {
+			t.Errorf("token %q: expected ERROR_CODE_INVALID_ARGUMENT, got %v", token, code)
+		}
 	}
-	_, err = JoinSpace(context.Background(), joinReq)
-	if err == nil {
-		t.Fatal("Expected JoinSpace to return not implemented error")
+}
+
+// newPeerDispatchers starts two backends of different accounts, connected
+// by a memory transport, and returns their dispatchers.
+func newPeerDispatchers(t *testing.T) (owner, guest *dispatcher.Dispatcher) {
+	t.Helper()
+
+	transport := anysync.NewMemoryTransport()
+	var dispatchers []*dispatcher.Dispatcher
+	for _, deviceID := range []string{"owner-device", "guest-device"} {
+		b := NewBackend()
+		b.SetTransport(transport)
+		initReq := &pb.InitRequest{
+			DataDir:   t.TempDir(),
+			NetworkId: "test-network",
+			DeviceId:  deviceID,
+		}
+		if _, err := b.Init(context.Background(), initReq); err != nil {
+			t.Fatalf("Init failed: %v", err)
+		}
+		t.Cleanup(func() { b.Shutdown(context.Background(), &pb.ShutdownRequest{}) })
+		dispatchers = append(dispatchers, b.NewDispatcher())
 	}
+	return dispatchers[0], dispatchers[1]
 }
 
-func TestUnit_Spaces_LeaveSpaceNotImplemented(t *testing.T) {
-	resetGlobalState()
-	initReq := &pb.InitRequest{
-		DataDir:   t.TempDir(),
-		NetworkId: "test-network",
-		DeviceId:  "test-device",
+// TestUnit_Spaces_JoinSpace tests that the account of one backend joins a
+// space of another with an invite, over a transport between them.
+func TestUnit_Spaces_JoinSpace(t *testing.T) {
+	owner, guest := newPeerDispatchers(t)
+
+	var createResp pb.CreateSpaceResponse
+	if err := dispatchMessage(owner, "CreateSpace", &pb.CreateSpaceRequest{SpaceId: "team", Name: "Team"}, &createResp); err != nil {
+		t.Fatalf("CreateSpace failed: %v", err)
 	}
-	_, err := Init(context.Background(), initReq)
-	if err != nil {
-		t.Fatalf("Init failed: %v", err)
+	var inviteResp pb.CreateInviteResponse
+	inviteReq := &pb.CreateInviteRequest{
+		SpaceId:      "team",
+		Permission:   pb.SpacePermission_SPACE_PERMISSION_WRITER,
+		ExpiresInSec: 3600,
+	}
+	if err := dispatchMessage(owner, "CreateInvite", inviteReq, &inviteResp); err != nil {
+		t.Fatalf("CreateInvite failed: %v", err)
+	}
+	if inviteResp.InviteToken == "" || inviteResp.InviteId == "" || inviteResp.ExpiresAt == 0 {
+		t.Fatalf("Expected a token, ID and expiry, got %+v", &inviteResp)
 	}
 
-	leaveReq := &pb.LeaveSpaceRequest{
-		SpaceId: "some-space",
+	var joinResp pb.JoinSpaceResponse
+	joinReq := &pb.JoinSpaceRequest{SpaceId: "shared", InviteToken: inviteResp.InviteToken}
+	if err := dispatchMessage(guest, "JoinSpace", joinReq, &joinResp); err != nil {
+		t.Fatalf("JoinSpace failed: %v", err)
 	}
-	_, err = LeaveSpace(context.Background(), leaveReq)
-	if err == nil {
-		t.Fatal("Expected LeaveSpace to return not implemented error")
+	if !joinResp.Success || joinResp.SpaceId != createResp.SpaceId {
+		t.Fatalf("Expected to join %s, got %+v", createResp.SpaceId, &joinResp)
+	}
+
+	var listResp pb.ListSpacesResponse
+	if err := dispatchMessage(guest, "ListSpaces", &pb.ListSpacesRequest{}, &listResp); err != nil {
+		t.Fatalf("ListSpaces failed: %v", err)
+	}
+	if len(listResp.Spaces) != 1 || listResp.Spaces[0].Name != "Team" || listResp.Spaces[0].Alias != "shared" {
+		t.Fatalf("Expected the joined space Team as shared, got %v", listResp.Spaces)
+	}
+
+	// As a writer, the guest creates documents in the space
+	createDocReq := &pb.CreateDocumentRequest{SpaceId: "shared", DocumentId: "doc1", Collection: "notes", Data: []byte("hello")}
+	if err := dispatchMessage(guest, "CreateDocument", createDocReq, &pb.CreateDocumentResponse{}); err != nil {
+		t.Fatalf("CreateDocument failed: %v", err)
+	}
+
+	// Only members that manage the space invite others
+	inviteReq.SpaceId = "shared"
+	err := dispatchMessage(guest, "CreateInvite", inviteReq, &inviteResp)
+	if code := ErrorCodeOf(err); code != pb.ErrorCode_ERROR_CODE_PERMISSION_DENIED {
+		t.Errorf("guest invite: expected ERROR_CODE_PERMISSION_DENIED, got %v", code)
+	}
+	inviteReq.SpaceId = "team"
+	inviteReq.Permission = pb.SpacePermission_SPACE_PERMISSION_ADMIN
+	err = dispatchMessage(owner, "CreateInvite", inviteReq, &inviteResp)
+	if code := ErrorCodeOf(err); code != pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT {
+		t.Errorf("admin invite: expected ERROR_CODE_INVALID_ARGUMENT, got %v", code)
+	}
+}
+
+// TestUnit_Spaces_LeaveSpace tests leaving a joined space, first keeping a
+// read-only copy and then removing it.
+func TestUnit_Spaces_LeaveSpace(t *testing.T) {
+	owner, guest := newPeerDispatchers(t)
+
+	err := dispatchMessage(guest, "LeaveSpace", &pb.LeaveSpaceRequest{SpaceId: "missing"}, &pb.LeaveSpaceResponse{})
+	if code := ErrorCodeOf(err); code != pb.ErrorCode_ERROR_CODE_NOT_FOUND {
+		t.Errorf("missing space: expected ERROR_CODE_NOT_FOUND, got %v", code)
+	}
+
+	if err := dispatchMessage(owner, "CreateSpace", &pb.CreateSpaceRequest{SpaceId: "team", Name: "Team"}, &pb.CreateSpaceResponse{}); err != nil {
+		t.Fatalf("CreateSpace failed: %v", err)
+	}
+	var inviteResp pb.CreateInviteResponse
+	inviteReq := &pb.CreateInviteRequest{SpaceId: "team", Permission: pb.SpacePermission_SPACE_PERMISSION_WRITER}
+	if err := dispatchMessage(owner, "CreateInvite", inviteReq, &inviteResp); err != nil {
+		t.Fatalf("CreateInvite failed: %v", err)
+	}
+	joinReq := &pb.JoinSpaceRequest{SpaceId: "shared", InviteToken: inviteResp.InviteToken}
+	if err := dispatchMessage(guest, "JoinSpace", joinReq, &pb.JoinSpaceResponse{}); err != nil {
+		t.Fatalf("JoinSpace failed: %v", err)
+	}
+
+	var leaveResp pb.LeaveSpaceResponse
+	if err := dispatchMessage(guest, "LeaveSpace", &pb.LeaveSpaceRequest{SpaceId: "shared"}, &leaveResp); err != nil {
+		t.Fatalf("LeaveSpace failed: %v", err)
+	}
+	if !leaveResp.Success {
+		t.Fatal("Expected LeaveSpace to succeed")
+	}
+
+	// The owner no longer lists the guest
+	var membersResp pb.ListMembersResponse
+	if err := dispatchMessage(owner, "ListMembers", &pb.ListMembersRequest{SpaceId: "team"}, &membersResp); err != nil {
+		t.Fatalf("ListMembers failed: %v", err)
+	}
+	if len(membersResp.Members) != 1 {
+		t.Fatalf("Expected only the owner, got %v", membersResp.Members)
+	}
+
+	// The guest keeps a paused, read-only copy
+	var listResp pb.ListSpacesResponse
+	if err := dispatchMessage(guest, "ListSpaces", &pb.ListSpacesRequest{}, &listResp); err != nil {
+		t.Fatalf("ListSpaces failed: %v", err)
+	}
+	if len(listResp.Spaces) != 1 || listResp.Spaces[0].LeftAt == 0 {
+		t.Fatalf("Expected the left space, got %v", listResp.Spaces)
+	}
+	if status := listResp.Spaces[0].SyncStatus; status != pb.SyncStatus_SYNC_STATUS_PAUSED {
+		t.Errorf("Expected SYNC_STATUS_PAUSED, got %v", status)
+	}
+	createDocReq := &pb.CreateDocumentRequest{SpaceId: "shared", DocumentId: "doc1", Collection: "notes", Data: []byte("hello")}
+	err = dispatchMessage(guest, "CreateDocument", createDocReq, &pb.CreateDocumentResponse{})
+	if code := ErrorCodeOf(err); code != pb.ErrorCode_ERROR_CODE_PERMISSION_DENIED {
+		t.Errorf("left space write: expected ERROR_CODE_PERMISSION_DENIED, got %v", code)
+	}
+
+	leaveReq := &pb.LeaveSpaceRequest{SpaceId: "shared", DeleteLocal: true}
+	if err := dispatchMessage(guest, "LeaveSpace", leaveReq, &leaveResp); err != nil {
+		t.Fatalf("LeaveSpace failed: %v", err)
+	}
+	if err := dispatchMessage(guest, "ListSpaces", &pb.ListSpacesRequest{}, &listResp); err != nil {
+		t.Fatalf("ListSpaces failed: %v", err)
+	}
+	if len(listResp.Spaces) != 0 {
+		t.Fatalf("Expected no spaces, got %v", listResp.Spaces)
+	}
+
+	// Without the inviting peer, the space is still left, and the response
+	// reports that the ACL was not changed
+	if err := dispatchMessage(guest, "JoinSpace", joinReq, &pb.JoinSpaceResponse{}); err != nil {
+		t.Fatalf("JoinSpace again failed: %v", err)
+	}
+	if err := dispatchMessage(owner, "Shutdown", &pb.ShutdownRequest{}, &pb.ShutdownResponse{}); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if err := dispatchMessage(guest, "LeaveSpace", leaveReq, &leaveResp); err != nil {
+		t.Fatalf("LeaveSpace without the peer failed: %v", err)
+	}
+	if leaveResp.AclError == "" {
+		t.Error("Expected acl_error without the peer")
+	}
+	if err := dispatchMessage(guest, "ListSpaces", &pb.ListSpacesRequest{}, &listResp); err != nil {
+		t.Fatalf("ListSpaces failed: %v", err)
+	}
+	if len(listResp.Spaces) != 0 {
+		t.Fatalf("Expected no spaces, got %v", listResp.Spaces)
 	}
 }
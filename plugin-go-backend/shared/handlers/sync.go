@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"fmt"
 
 	pb "anysync-backend/shared/proto/syncspace/v1"
 
@@ -10,8 +9,8 @@ import (
 )
 
 // StartSync handles starting synchronization.
-func StartSync(ctx context.Context, req proto.Message) (proto.Message, error) {
-	if err := ensureInitialized(); err != nil {
+func (b *Backend) StartSync(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
 		return nil, err
 	}
 
@@ -20,12 +19,12 @@ func StartSync(ctx context.Context, req proto.Message) (proto.Message, error) {
 	// TODO: Implement with Any-Sync sync mechanisms
 	_ = syncReq
 
-	return &pb.StartSyncResponse{Success: false}, fmt.Errorf("not implemented yet")
+	return &pb.StartSyncResponse{Success: false}, ErrNotImplemented
 }
 
 // PauseSync handles pausing synchronization.
-func PauseSync(ctx context.Context, req proto.Message) (proto.Message, error) {
-	if err := ensureInitialized(); err != nil {
+func (b *Backend) PauseSync(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
 		return nil, err
 	}
 
@@ -34,12 +33,12 @@ func PauseSync(ctx context.Context, req proto.Message) (proto.Message, error) {
 	// TODO: Implement with Any-Sync sync mechanisms
 	_ = pauseReq
 
-	return &pb.PauseSyncResponse{Success: false}, fmt.Errorf("not implemented yet")
+	return &pb.PauseSyncResponse{Success: false}, ErrNotImplemented
 }
 
 // GetSyncStatus handles getting sync status.
-func GetSyncStatus(ctx context.Context, req proto.Message) (proto.Message, error) {
-	if err := ensureInitialized(); err != nil {
+func (b *Backend) GetSyncStatus(ctx context.Context, req proto.Message) (proto.Message, error) {
+	if err := b.ensureInitialized(); err != nil {
 		return nil, err
 	}
 
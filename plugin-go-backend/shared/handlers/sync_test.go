@@ -8,58 +8,58 @@ import (
 )
 
 func TestUnit_Sync_StartSyncNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.StartSyncRequest{
 		SpaceId: "space1",
 	}
 
-	_, err := StartSync(context.Background(), req)
+	_, err := b.StartSync(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
 }
 
 func TestUnit_Sync_PauseSyncNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.PauseSyncRequest{
 		SpaceId: "space1",
 	}
 
-	_, err := PauseSync(context.Background(), req)
+	_, err := b.PauseSync(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
 }
 
 func TestUnit_Sync_GetSyncStatusNotInitialized(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 
 	req := &pb.GetSyncStatusRequest{
 		SpaceId: "space1",
 	}
 
-	_, err := GetSyncStatus(context.Background(), req)
+	_, err := b.GetSyncStatus(context.Background(), req)
 	if err == nil {
 		t.Fatal("Expected error when not initialized")
 	}
 }
 
 func TestUnit_Sync_GetSyncStatusEmpty(t *testing.T) {
-	resetGlobalState()
+	b := NewBackend()
 	initReq := &pb.InitRequest{
 		DataDir:   t.TempDir(),
 		NetworkId: "test-network",
 		DeviceId:  "test-device",
 	}
-	Init(context.Background(), initReq)
+	b.Init(context.Background(), initReq)
 
 	req := &pb.GetSyncStatusRequest{
 		SpaceId: "",
 	}
 
-	resp, err := GetSyncStatus(context.Background(), req)
+	resp, err := b.GetSyncStatus(context.Background(), req)
 	if err != nil {
 		t.Fatalf("GetSyncStatus failed: %v", err)
 	}
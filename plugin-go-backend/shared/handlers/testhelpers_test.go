@@ -21,7 +21,7 @@ package handlers
 //       docID := tc.CreateDocument([]byte("test data"), nil)
 //       
 //       // Or use context for custom operations
-//       resp, err := GetDocument(tc.Context(), &pb.GetDocumentRequest{
+//       resp, err := tc.Backend().GetDocument(tc.Context(), &pb.GetDocumentRequest{
 //           SpaceId: tc.SpaceID(),
 //           DocumentId: docID,
 //       })
@@ -44,13 +44,14 @@ import (
 type TestContext struct {
 	t       *testing.T
 	ctx     context.Context
+	backend *Backend
 	dataDir string
 	spaceID string // Default test space
 }
 
 // SetupIntegrationTest creates a test context with initialized system.
 // Returns a TestContext with:
-// - Initialized global state
+// - An initialized backend of its own
 // - A default test space
 // - Cleanup registered via t.Cleanup()
 //
@@ -64,8 +65,8 @@ type TestContext struct {
 func SetupIntegrationTest(t *testing.T) *TestContext {
 	t.Helper()
 
-	// Ensure clean state before initialization
-	resetGlobalState()
+	// Each test gets its own backend, so tests do not share state
+	b := NewBackend()
 
 	tempDir := t.TempDir()
 	dataDir := filepath.Join(tempDir, "test_data")
@@ -81,7 +82,7 @@ func SetupIntegrationTest(t *testing.T) *TestContext {
 		NetworkId: "test-network",
 		DeviceId:  "test-device",
 	}
-	_, err := Init(ctx, initReq)
+	_, err := b.Init(ctx, initReq)
 	if err != nil {
 		t.Fatalf("Init failed: %v", err)
 	}
@@ -91,7 +92,7 @@ func SetupIntegrationTest(t *testing.T) *TestContext {
 		Name:     "Test Space",
 		Metadata: map[string]string{"purpose": "integration-testing"},
 	}
-	createSpaceResp, err := CreateSpace(ctx, createSpaceReq)
+	createSpaceResp, err := b.CreateSpace(ctx, createSpaceReq)
 	if err != nil {
 		t.Fatalf("CreateSpace failed: %v", err)
 	}
@@ -99,12 +100,13 @@ func SetupIntegrationTest(t *testing.T) *TestContext {
 
 	// Register cleanup
 	t.Cleanup(func() {
-		Shutdown(ctx, &pb.ShutdownRequest{})
+		b.Shutdown(ctx, &pb.ShutdownRequest{})
 	})
 
 	return &TestContext{
 		t:       t,
 		ctx:     ctx,
+		backend: b,
 		dataDir: dataDir,
 		spaceID: spaceID,
 	}
@@ -115,6 +117,11 @@ func (tc *TestContext) Context() context.Context {
 	return tc.ctx
 }
 
+// Backend returns the initialized backend under test.
+func (tc *TestContext) Backend() *Backend {
+	return tc.backend
+}
+
 // DataDir returns the test data directory.
 func (tc *TestContext) DataDir() string {
 	return tc.dataDir
@@ -133,7 +140,7 @@ func (tc *TestContext) CreateSpace(name string, metadata map[string]string) stri
 		Name:     name,
 		Metadata: metadata,
 	}
-	resp, err := CreateSpace(tc.ctx, req)
+	resp, err := tc.backend.CreateSpace(tc.ctx, req)
 	if err != nil {
 		tc.t.Fatalf("CreateSpace failed: %v", err)
 	}
@@ -149,7 +156,7 @@ func (tc *TestContext) CreateDocument(data []byte, metadata map[string]string) s
 		Data:     data,
 		Metadata: metadata,
 	}
-	resp, err := CreateDocument(tc.ctx, req)
+	resp, err := tc.backend.CreateDocument(tc.ctx, req)
 	if err != nil {
 		tc.t.Fatalf("CreateDocument failed: %v", err)
 	}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"anysync-backend/shared/anysync"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ExportAccount returns the account bundle: the account key, the device
+// registry and the key material of every space, sealed with the request
+// passphrase.
+func (b *Backend) ExportAccount(ctx context.Context, req proto.Message) (proto.Message, error) {
+	exportReq := req.(*pb.ExportAccountRequest)
+
+	if exportReq.Passphrase == "" {
+		return nil, fmt.Errorf("%w: passphrase is required", anysync.ErrInvalidArgument)
+	}
+
+	devices, err := b.deviceRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	sm := b.spaceManager
+	b.mu.RUnlock()
+
+	if sm == nil {
+		return nil, fmt.Errorf("space manager %w", ErrNotInitialized)
+	}
+
+	bundle := &anysync.AccountBundle{Devices: devices.List()}
+	err = b.withAccount(func(am *anysync.AccountManager, protected bool) error {
+		if protected && exportReq.AccountPassphrase == "" {
+			return fmt.Errorf("%w: account_passphrase is required", anysync.ErrInvalidArgument)
+		}
+		var err error
+		bundle.AccountKey, err = am.ExportAccountKey(exportReq.AccountPassphrase)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bundle.Spaces, err = sm.ExportSpaceKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := anysync.SealAccountBundle(bundle, exportReq.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ExportAccountResponse{Bundle: sealed}, nil
+}
+
+// ImportAccount prepares req.DataDir from an account bundle, before Init. It
+// does not touch the running backend, if any.
+func (b *Backend) ImportAccount(ctx context.Context, req proto.Message) (proto.Message, error) {
+	importReq := req.(*pb.ImportAccountRequest)
+
+	if importReq.ProfileId != "" {
+		return nil, fmt.Errorf("%w: profile_id requires a profile host", anysync.ErrInvalidArgument)
+	}
+	if importReq.DataDir == "" {
+		return nil, fmt.Errorf("%w: data_dir is required", anysync.ErrInvalidArgument)
+	}
+
+	return importAccount(ctx, importReq.DataDir, b.keyStore.open(importReq.DataDir), importReq)
+}
+
+// importAccount opens the bundle of importReq and writes its account into
+// dataDir, which must not hold an account, spaces or devices yet.
+func importAccount(ctx context.Context, dataDir string, keyStore anysync.KeyStore, importReq *pb.ImportAccountRequest) (*pb.ImportAccountResponse, error) {
+	bundle, err := anysync.OpenAccountBundle(importReq.Bundle, importReq.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	accountManager := anysync.NewAccountManagerWithKeyStore(keyStore)
+	if accountManager.KeysExist() {
+		return nil, fmt.Errorf("%w: data_dir already holds an account", anysync.ErrAlreadyExists)
+	}
+	devices, err := anysync.NewDeviceRegistry(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := accountManager.ImportKeys(bundle.AccountKey); err != nil {
+		return nil, err
+	}
+
+	spaceManager, err := anysync.NewSpaceManagerWithConfig(dataDir, accountManager.GetKeys(), anysync.NewEventManager(), anysync.DefaultSpaceConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize space manager: %w", err)
+	}
+	defer func() {
+		if err := spaceManager.Close(); err != nil {
+			fmt.Printf("Warning: failed to close space manager: %v\n", err)
+		}
+	}()
+	if len(spaceManager.ListSpaces()) != 0 {
+		return nil, fmt.Errorf("%w: data_dir already holds spaces", anysync.ErrAlreadyExists)
+	}
+
+	if err := devices.Import(bundle.Devices); err != nil {
+		return nil, err
+	}
+	if err := spaceManager.ImportSpaceKeys(ctx, bundle.Spaces); err != nil {
+		return nil, fmt.Errorf("failed to import spaces: %w", err)
+	}
+	// The keys go last, so a failed import leaves no account to open
+	if err := accountManager.StoreKeysWithPassphrase(importReq.AccountPassphrase); err != nil {
+		return nil, fmt.Errorf("failed to store keys: %w", err)
+	}
+
+	return &pb.ImportAccountResponse{
+		SpaceCount:  int32(len(bundle.Spaces)),
+		DeviceCount: int32(len(bundle.Devices)),
+		CreatedAt:   bundle.CreatedAt,
+	}, nil
+}
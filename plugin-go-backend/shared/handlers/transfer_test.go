@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"anysync-backend/shared/dispatcher"
+	pb "anysync-backend/shared/proto/syncspace/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestIntegration_AccountTransfer tests exporting an account with a space and importing it into a fresh data directory.
+func TestIntegration_AccountTransfer(t *testing.T) {
+	ctx := context.Background()
+
+	source := NewBackend()
+	_, err := source.Init(ctx, &pb.InitRequest{DataDir: t.TempDir(), Passphrase: "account secret", DeviceId: "laptop"})
+	require.NoError(t, err)
+	defer source.Shutdown(ctx, &pb.ShutdownRequest{})
+
+	_, err = source.CreateSpace(ctx, &pb.CreateSpaceRequest{SpaceId: "notes", Name: "Notes"})
+	require.NoError(t, err)
+	spaces, err := source.ListSpaces(ctx, &pb.ListSpacesRequest{})
+	require.NoError(t, err)
+	spaceID := spaces.(*pb.ListSpacesResponse).Spaces[0].SpaceId
+
+	_, err = source.ExportAccount(ctx, &pb.ExportAccountRequest{Passphrase: "bundle secret"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, ErrorCodeOf(err), "the protected account key needs its passphrase")
+	exportResp, err := source.ExportAccount(ctx, &pb.ExportAccountRequest{Passphrase: "bundle secret", AccountPassphrase: "account secret"})
+	require.NoError(t, err)
+	bundle := exportResp.(*pb.ExportAccountResponse).Bundle
+
+	// Import before Init
+	dataDir := t.TempDir()
+	b := NewBackend()
+	_, err = b.ImportAccount(ctx, &pb.ImportAccountRequest{DataDir: dataDir, Bundle: bundle, Passphrase: "wrong"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_LOCKED, ErrorCodeOf(err))
+	importResp, err := b.ImportAccount(ctx, &pb.ImportAccountRequest{DataDir: dataDir, Bundle: bundle, Passphrase: "bundle secret"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), importResp.(*pb.ImportAccountResponse).SpaceCount)
+	assert.Equal(t, int32(1), importResp.(*pb.ImportAccountResponse).DeviceCount)
+
+	// The data directory now holds the account
+	_, err = b.ImportAccount(ctx, &pb.ImportAccountRequest{DataDir: dataDir, Bundle: bundle, Passphrase: "bundle secret"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_ALREADY_EXISTS, ErrorCodeOf(err))
+
+	_, err = b.Init(ctx, &pb.InitRequest{DataDir: dataDir, DeviceId: "desktop"})
+	require.NoError(t, err)
+	defer b.Shutdown(ctx, &pb.ShutdownRequest{})
+
+	assert.Equal(t, source.accountManager.GetKeys().SignKey.GetPublic().Account(), b.accountManager.GetKeys().SignKey.GetPublic().Account())
+	assert.NotEqual(t, source.accountManager.GetKeys().PeerId, b.accountManager.GetKeys().PeerId, "the new device gets its own key")
+
+	spaces, err = b.ListSpaces(ctx, &pb.ListSpacesRequest{})
+	require.NoError(t, err)
+	require.Len(t, spaces.(*pb.ListSpacesResponse).Spaces, 1)
+	assert.Equal(t, spaceID, spaces.(*pb.ListSpacesResponse).Spaces[0].SpaceId)
+	assert.Equal(t, "Notes", spaces.(*pb.ListSpacesResponse).Spaces[0].Name)
+
+	// The space opens, so documents can be created in it
+	_, err = b.CreateDocument(ctx, &pb.CreateDocumentRequest{SpaceId: spaceID, Data: []byte("hello")})
+	require.NoError(t, err)
+
+	devices, err := b.ListDevices(ctx, &pb.ListDevicesRequest{})
+	require.NoError(t, err)
+	assert.Len(t, devices.(*pb.ListDevicesResponse).Devices, 2)
+}
+
+// TestIntegration_Profiles_ImportAccount tests importing an account bundle into a new profile.
+func TestIntegration_Profiles_ImportAccount(t *testing.T) {
+	p, _ := setupProfiles(t)
+	d := p.NewDispatcher()
+	ctx := context.Background()
+
+	createSpaceIn(t, d, "Notes")
+	var exportResp pb.ExportAccountResponse
+	require.NoError(t, dispatchMessage(d, "ExportAccount", &pb.ExportAccountRequest{Passphrase: "bundle secret"}, &exportResp))
+	bundle := exportResp.Bundle
+
+	var importResp pb.ImportAccountResponse
+	err := dispatchMessage(d, "ImportAccount", &pb.ImportAccountRequest{ProfileId: "copy", Bundle: bundle, Passphrase: "bundle secret"}, &importResp)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_NOT_FOUND, ErrorCodeOf(err))
+	err = dispatchMessage(d, "ImportAccount", &pb.ImportAccountRequest{ProfileId: DefaultProfileID, Bundle: bundle, Passphrase: "bundle secret"}, &importResp)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_ALREADY_EXISTS, ErrorCodeOf(err))
+
+	_, err = p.CreateProfile(ctx, &pb.CreateProfileRequest{ProfileId: "copy"})
+	require.NoError(t, err)
+	err = dispatchMessage(d, "ImportAccount", &pb.ImportAccountRequest{ProfileId: "copy", Bundle: bundle, Passphrase: "bundle secret", AccountPassphrase: "copy secret"}, &importResp)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), importResp.SpaceCount)
+
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "copy"})
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_LOCKED, ErrorCodeOf(err))
+	_, err = p.OpenProfile(ctx, &pb.OpenProfileRequest{ProfileId: "copy", Passphrase: "copy secret"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Notes"}, listSpaceNames(t, d))
+}
+
+// dispatchMessage dispatches command with req and unmarshals the response into resp.
+func dispatchMessage(d *dispatcher.Dispatcher, command string, req, resp proto.Message) error {
+	payload, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	respBytes, err := d.Dispatch(context.Background(), command, payload)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(respBytes, resp)
+}
@@ -21,6 +21,114 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SpacePermission is the access of an account to a space.
+type SpacePermission int32
+
+const (
+	SpacePermission_SPACE_PERMISSION_UNSPECIFIED SpacePermission = 0
+	SpacePermission_SPACE_PERMISSION_READER      SpacePermission = 1 // Reads documents
+	SpacePermission_SPACE_PERMISSION_WRITER      SpacePermission = 2 // Reads and writes documents
+	SpacePermission_SPACE_PERMISSION_ADMIN       SpacePermission = 3 // Also manages members and invites
+	SpacePermission_SPACE_PERMISSION_OWNER       SpacePermission = 4 // The creator of the space
+)
+
+// Enum value maps for SpacePermission.
+var (
+	SpacePermission_name = map[int32]string{
+		0: "SPACE_PERMISSION_UNSPECIFIED",
+		1: "SPACE_PERMISSION_READER",
+		2: "SPACE_PERMISSION_WRITER",
+		3: "SPACE_PERMISSION_ADMIN",
+		4: "SPACE_PERMISSION_OWNER",
+	}
+	SpacePermission_value = map[string]int32{
+		"SPACE_PERMISSION_UNSPECIFIED": 0,
+		"SPACE_PERMISSION_READER":      1,
+		"SPACE_PERMISSION_WRITER":      2,
+		"SPACE_PERMISSION_ADMIN":       3,
+		"SPACE_PERMISSION_OWNER":       4,
+	}
+)
+
+func (x SpacePermission) Enum() *SpacePermission {
+	p := new(SpacePermission)
+	*p = x
+	return p
+}
+
+func (x SpacePermission) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SpacePermission) Descriptor() protoreflect.EnumDescriptor {
+	return file_syncspace_v1_syncspace_proto_enumTypes[0].Descriptor()
+}
+
+func (SpacePermission) Type() protoreflect.EnumType {
+	return &file_syncspace_v1_syncspace_proto_enumTypes[0]
+}
+
+func (x SpacePermission) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SpacePermission.Descriptor instead.
+func (SpacePermission) EnumDescriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{0}
+}
+
+type MemberStatus int32
+
+const (
+	MemberStatus_MEMBER_STATUS_UNSPECIFIED    MemberStatus = 0
+	MemberStatus_MEMBER_STATUS_ACTIVE         MemberStatus = 1 // Has access to the space
+	MemberStatus_MEMBER_STATUS_JOIN_REQUESTED MemberStatus = 2 // Waits for ApproveJoinRequest
+	MemberStatus_MEMBER_STATUS_LEAVING        MemberStatus = 3 // Asked to be removed from the space
+)
+
+// Enum value maps for MemberStatus.
+var (
+	MemberStatus_name = map[int32]string{
+		0: "MEMBER_STATUS_UNSPECIFIED",
+		1: "MEMBER_STATUS_ACTIVE",
+		2: "MEMBER_STATUS_JOIN_REQUESTED",
+		3: "MEMBER_STATUS_LEAVING",
+	}
+	MemberStatus_value = map[string]int32{
+		"MEMBER_STATUS_UNSPECIFIED":    0,
+		"MEMBER_STATUS_ACTIVE":         1,
+		"MEMBER_STATUS_JOIN_REQUESTED": 2,
+		"MEMBER_STATUS_LEAVING":        3,
+	}
+)
+
+func (x MemberStatus) Enum() *MemberStatus {
+	p := new(MemberStatus)
+	*p = x
+	return p
+}
+
+func (x MemberStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MemberStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_syncspace_v1_syncspace_proto_enumTypes[1].Descriptor()
+}
+
+func (MemberStatus) Type() protoreflect.EnumType {
+	return &file_syncspace_v1_syncspace_proto_enumTypes[1]
+}
+
+func (x MemberStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MemberStatus.Descriptor instead.
+func (MemberStatus) EnumDescriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{1}
+}
+
 type SyncStatus int32
 
 const (
@@ -60,11 +168,11 @@ func (x SyncStatus) String() string {
 }
 
 func (SyncStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_syncspace_v1_syncspace_proto_enumTypes[0].Descriptor()
+	return file_syncspace_v1_syncspace_proto_enumTypes[2].Descriptor()
 }
 
 func (SyncStatus) Type() protoreflect.EnumType {
-	return &file_syncspace_v1_syncspace_proto_enumTypes[0]
+	return &file_syncspace_v1_syncspace_proto_enumTypes[2]
 }
 
 func (x SyncStatus) Number() protoreflect.EnumNumber {
@@ -73,7 +181,90 @@ func (x SyncStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SyncStatus.Descriptor instead.
 func (SyncStatus) EnumDescriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{0}
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{2}
+}
+
+// ErrorCode lets clients branch on failures without parsing messages
+type ErrorCode int32
+
+const (
+	ErrorCode_ERROR_CODE_UNSPECIFIED         ErrorCode = 0
+	ErrorCode_ERROR_CODE_INTERNAL            ErrorCode = 1  // Unexpected backend failure
+	ErrorCode_ERROR_CODE_INVALID_ARGUMENT    ErrorCode = 2  // Request is malformed or has invalid fields
+	ErrorCode_ERROR_CODE_NOT_FOUND           ErrorCode = 3  // Space or document does not exist
+	ErrorCode_ERROR_CODE_ALREADY_EXISTS      ErrorCode = 4  // Object with the same identity already exists
+	ErrorCode_ERROR_CODE_NOT_INITIALIZED     ErrorCode = 5  // Init has not been called
+	ErrorCode_ERROR_CODE_ALREADY_INITIALIZED ErrorCode = 6  // Init was called twice without Shutdown
+	ErrorCode_ERROR_CODE_VERSION_CONFLICT    ErrorCode = 7  // expected_version or expected_updated_at did not match
+	ErrorCode_ERROR_CODE_UNIMPLEMENTED       ErrorCode = 8  // Command is unknown or not implemented yet
+	ErrorCode_ERROR_CODE_DEADLINE_EXCEEDED   ErrorCode = 9  // Operation timed out
+	ErrorCode_ERROR_CODE_CANCELLED           ErrorCode = 10 // Operation was cancelled by the caller
+	ErrorCode_ERROR_CODE_UNAVAILABLE         ErrorCode = 11 // Backend is shutting down and accepts no new commands, or a peer cannot be reached
+	ErrorCode_ERROR_CODE_LOCKED              ErrorCode = 12 // Account passphrase is missing or wrong; details["reason"] says which
+	ErrorCode_ERROR_CODE_PERMISSION_DENIED   ErrorCode = 13 // The account or device may not do this, e.g. a revoked device
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0:  "ERROR_CODE_UNSPECIFIED",
+		1:  "ERROR_CODE_INTERNAL",
+		2:  "ERROR_CODE_INVALID_ARGUMENT",
+		3:  "ERROR_CODE_NOT_FOUND",
+		4:  "ERROR_CODE_ALREADY_EXISTS",
+		5:  "ERROR_CODE_NOT_INITIALIZED",
+		6:  "ERROR_CODE_ALREADY_INITIALIZED",
+		7:  "ERROR_CODE_VERSION_CONFLICT",
+		8:  "ERROR_CODE_UNIMPLEMENTED",
+		9:  "ERROR_CODE_DEADLINE_EXCEEDED",
+		10: "ERROR_CODE_CANCELLED",
+		11: "ERROR_CODE_UNAVAILABLE",
+		12: "ERROR_CODE_LOCKED",
+		13: "ERROR_CODE_PERMISSION_DENIED",
+	}
+	ErrorCode_value = map[string]int32{
+		"ERROR_CODE_UNSPECIFIED":         0,
+		"ERROR_CODE_INTERNAL":            1,
+		"ERROR_CODE_INVALID_ARGUMENT":    2,
+		"ERROR_CODE_NOT_FOUND":           3,
+		"ERROR_CODE_ALREADY_EXISTS":      4,
+		"ERROR_CODE_NOT_INITIALIZED":     5,
+		"ERROR_CODE_ALREADY_INITIALIZED": 6,
+		"ERROR_CODE_VERSION_CONFLICT":    7,
+		"ERROR_CODE_UNIMPLEMENTED":       8,
+		"ERROR_CODE_DEADLINE_EXCEEDED":   9,
+		"ERROR_CODE_CANCELLED":           10,
+		"ERROR_CODE_UNAVAILABLE":         11,
+		"ERROR_CODE_LOCKED":              12,
+		"ERROR_CODE_PERMISSION_DENIED":   13,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_syncspace_v1_syncspace_proto_enumTypes[3].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_syncspace_v1_syncspace_proto_enumTypes[3]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{3}
 }
 
 // Command represents a unified command for single-dispatch pattern
@@ -132,8 +323,9 @@ func (x *Command) GetPayload() []byte {
 // CommandResponse represents the unified response
 type CommandResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Payload       []byte                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"` // Serialized response message
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`     // Error message if operation failed (empty if success)
+	Payload       []byte                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`                            // Serialized response message
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`                                // Error message if operation failed (empty if success)
+	ErrorDetail   *CommandError          `protobuf:"bytes,3,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"` // Structured error (unset if success)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -182,12 +374,37 @@ func (x *CommandResponse) GetError() string {
 	return ""
 }
 
+func (x *CommandResponse) GetErrorDetail() *CommandError {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
 type InitRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DataDir       string                 `protobuf:"bytes,1,opt,name=data_dir,json=dataDir,proto3" json:"data_dir,omitempty"`                                                          // Directory for local storage
-	NetworkId     string                 `protobuf:"bytes,2,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`                                                    // Any-Sync network ID
-	DeviceId      string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`                                                       // Unique device identifier
-	Config        map[string]string      `protobuf:"bytes,4,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Additional configuration
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	DataDir   string                 `protobuf:"bytes,1,opt,name=data_dir,json=dataDir,proto3" json:"data_dir,omitempty"`                                                          // Directory for local storage
+	NetworkId string                 `protobuf:"bytes,2,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`                                                    // Any-Sync network ID
+	DeviceId  string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`                                                       // Unique device identifier; defaults to the one registered before, or the device peer ID
+	Config    map[string]string      `protobuf:"bytes,4,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Backend settings, keyed like the BackendConfig fields; unknown keys end up in BackendConfig.extra
+	// Default deadline for commands, in milliseconds. 0 uses the built-in
+	// default (30s), a negative value disables it. Deadlines set by the caller
+	// (gRPC deadline, mobile timeout argument) take precedence.
+	CommandTimeoutMs  int64            `protobuf:"varint,5,opt,name=command_timeout_ms,json=commandTimeoutMs,proto3" json:"command_timeout_ms,omitempty"`
+	CommandTimeoutsMs map[string]int64 `protobuf:"bytes,6,rep,name=command_timeouts_ms,json=commandTimeoutsMs,proto3" json:"command_timeouts_ms,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Per-command overrides, keyed by command name
+	// Backend settings as a JSON object with typed values, e.g.
+	// {"sync_period_sec": 10, "command_timeouts_ms": {"CreateSpace": 60000}}.
+	// Keys in config override it; the timeout fields above override both.
+	ConfigJson string `protobuf:"bytes,7,opt,name=config_json,json=configJson,proto3" json:"config_json,omitempty"`
+	// Opens a passphrase-protected account key; Init fails with
+	// ERROR_CODE_LOCKED if it is missing or wrong. A new account is created
+	// with this passphrase. Never stored or reported by GetConfig.
+	Passphrase string `protobuf:"bytes,8,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	// Recovery phrase (see ExportMnemonic) to restore the account from when
+	// data_dir has no account yet; a new device key is generated. If data_dir
+	// already holds an account, it must be the same one. Never stored.
+	Mnemonic      string `protobuf:"bytes,9,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
+	DeviceName    string `protobuf:"bytes,10,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"` // Display name of this device in the device registry, e.g. "Pixel 8"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -250,9 +467,52 @@ func (x *InitRequest) GetConfig() map[string]string {
 	return nil
 }
 
+func (x *InitRequest) GetCommandTimeoutMs() int64 {
+	if x != nil {
+		return x.CommandTimeoutMs
+	}
+	return 0
+}
+
+func (x *InitRequest) GetCommandTimeoutsMs() map[string]int64 {
+	if x != nil {
+		return x.CommandTimeoutsMs
+	}
+	return nil
+}
+
+func (x *InitRequest) GetConfigJson() string {
+	if x != nil {
+		return x.ConfigJson
+	}
+	return ""
+}
+
+func (x *InitRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+func (x *InitRequest) GetMnemonic() string {
+	if x != nil {
+		return x.Mnemonic
+	}
+	return ""
+}
+
+func (x *InitRequest) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
 type InitResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Restored      bool                   `protobuf:"varint,2,opt,name=restored,proto3" json:"restored,omitempty"` // The account was restored from InitRequest.mnemonic
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -294,8 +554,18 @@ func (x *InitResponse) GetSuccess() bool {
 	return false
 }
 
+func (x *InitResponse) GetRestored() bool {
+	if x != nil {
+		return x.Restored
+	}
+	return false
+}
+
 type ShutdownRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How long to wait for in-flight commands and streams before cancelling
+	// them; 0 uses the default (10s), negative waits without a limit
+	TimeoutMs     int64 `protobuf:"varint,1,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -330,9 +600,17 @@ func (*ShutdownRequest) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{4}
 }
 
+func (x *ShutdownRequest) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
 type ShutdownResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Forced        bool                   `protobuf:"varint,2,opt,name=forced,proto3" json:"forced,omitempty"` // In-flight work did not finish in time and was cancelled
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -374,9 +652,16 @@ func (x *ShutdownResponse) GetSuccess() bool {
 	return false
 }
 
+func (x *ShutdownResponse) GetForced() bool {
+	if x != nil {
+		return x.Forced
+	}
+	return false
+}
+
 type CreateSpaceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`                                                              // Unique space identifier
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`                                                              // Client-chosen alias, unique and accepted wherever a space ID is; creating with it again returns the same space
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                                                   // Human-readable space name
 	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Space metadata
 	unknownFields protoimpl.UnknownFields
@@ -436,7 +721,7 @@ func (x *CreateSpaceRequest) GetMetadata() map[string]string {
 
 type CreateSpaceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"` // Any-Sync space ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -478,28 +763,32 @@ func (x *CreateSpaceResponse) GetSpaceId() string {
 	return ""
 }
 
-type JoinSpaceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`             // Space identifier to join
-	InviteToken   string                 `protobuf:"bytes,2,opt,name=invite_token,json=inviteToken,proto3" json:"invite_token,omitempty"` // Invitation token (if required)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// CreateInviteRequest invites other accounts to a space. The response token
+// carries everything JoinSpace needs; anyone holding it may join.
+type CreateInviteRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId         string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`                           // Space ID or alias
+	Permission      SpacePermission        `protobuf:"varint,2,opt,name=permission,proto3,enum=syncspace.v1.SpacePermission" json:"permission,omitempty"` // Reader or writer; unspecified invites readers. Ignored with require_approval
+	ExpiresInSec    int64                  `protobuf:"varint,3,opt,name=expires_in_sec,json=expiresInSec,proto3" json:"expires_in_sec,omitempty"`         // Seconds until the invite expires (0 = never)
+	RequireApproval bool                   `protobuf:"varint,4,opt,name=require_approval,json=requireApproval,proto3" json:"require_approval,omitempty"`  // Joins wait for ApproveJoinRequest, which sets the permission
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *JoinSpaceRequest) Reset() {
-	*x = JoinSpaceRequest{}
+func (x *CreateInviteRequest) Reset() {
+	*x = CreateInviteRequest{}
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinSpaceRequest) String() string {
+func (x *CreateInviteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinSpaceRequest) ProtoMessage() {}
+func (*CreateInviteRequest) ProtoMessage() {}
 
-func (x *JoinSpaceRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateInviteRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -511,46 +800,62 @@ func (x *JoinSpaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinSpaceRequest.ProtoReflect.Descriptor instead.
-func (*JoinSpaceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateInviteRequest.ProtoReflect.Descriptor instead.
+func (*CreateInviteRequest) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *JoinSpaceRequest) GetSpaceId() string {
+func (x *CreateInviteRequest) GetSpaceId() string {
 	if x != nil {
 		return x.SpaceId
 	}
 	return ""
 }
 
-func (x *JoinSpaceRequest) GetInviteToken() string {
+func (x *CreateInviteRequest) GetPermission() SpacePermission {
 	if x != nil {
-		return x.InviteToken
+		return x.Permission
 	}
-	return ""
+	return SpacePermission_SPACE_PERMISSION_UNSPECIFIED
 }
 
-type JoinSpaceResponse struct {
+func (x *CreateInviteRequest) GetExpiresInSec() int64 {
+	if x != nil {
+		return x.ExpiresInSec
+	}
+	return 0
+}
+
+func (x *CreateInviteRequest) GetRequireApproval() bool {
+	if x != nil {
+		return x.RequireApproval
+	}
+	return false
+}
+
+type CreateInviteResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	InviteToken   string                 `protobuf:"bytes,1,opt,name=invite_token,json=inviteToken,proto3" json:"invite_token,omitempty"` // Self-contained token to hand to the invitee
+	InviteId      string                 `protobuf:"bytes,2,opt,name=invite_id,json=inviteId,proto3" json:"invite_id,omitempty"`          // ID of the invite in the space ACL
+	ExpiresAt     int64                  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`      // Unix timestamp, 0 if the invite does not expire
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JoinSpaceResponse) Reset() {
-	*x = JoinSpaceResponse{}
+func (x *CreateInviteResponse) Reset() {
+	*x = CreateInviteResponse{}
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinSpaceResponse) String() string {
+func (x *CreateInviteResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinSpaceResponse) ProtoMessage() {}
+func (*CreateInviteResponse) ProtoMessage() {}
 
-func (x *JoinSpaceResponse) ProtoReflect() protoreflect.Message {
+func (x *CreateInviteResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -562,39 +867,56 @@ func (x *JoinSpaceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinSpaceResponse.ProtoReflect.Descriptor instead.
-func (*JoinSpaceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateInviteResponse.ProtoReflect.Descriptor instead.
+func (*CreateInviteResponse) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *JoinSpaceResponse) GetSuccess() bool {
+func (x *CreateInviteResponse) GetInviteToken() string {
 	if x != nil {
-		return x.Success
+		return x.InviteToken
 	}
-	return false
+	return ""
 }
 
-type LeaveSpaceRequest struct {
+func (x *CreateInviteResponse) GetInviteId() string {
+	if x != nil {
+		return x.InviteId
+	}
+	return ""
+}
+
+func (x *CreateInviteResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// JoinSpaceRequest joins the space of another account with an invite token
+// from CreateInvite. The inviting device must be reachable.
+type JoinSpaceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"` // Space identifier to leave
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`             // Optional client-chosen alias for the joined space, as with CreateSpace
+	InviteToken   string                 `protobuf:"bytes,2,opt,name=invite_token,json=inviteToken,proto3" json:"invite_token,omitempty"` // Invite token from CreateInvite
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LeaveSpaceRequest) Reset() {
-	*x = LeaveSpaceRequest{}
+func (x *JoinSpaceRequest) Reset() {
+	*x = JoinSpaceRequest{}
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LeaveSpaceRequest) String() string {
+func (x *JoinSpaceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LeaveSpaceRequest) ProtoMessage() {}
+func (*JoinSpaceRequest) ProtoMessage() {}
 
-func (x *LeaveSpaceRequest) ProtoReflect() protoreflect.Message {
+func (x *JoinSpaceRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -606,39 +928,48 @@ func (x *LeaveSpaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LeaveSpaceRequest.ProtoReflect.Descriptor instead.
-func (*LeaveSpaceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use JoinSpaceRequest.ProtoReflect.Descriptor instead.
+func (*JoinSpaceRequest) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *LeaveSpaceRequest) GetSpaceId() string {
+func (x *JoinSpaceRequest) GetSpaceId() string {
 	if x != nil {
 		return x.SpaceId
 	}
 	return ""
 }
 
-type LeaveSpaceResponse struct {
+func (x *JoinSpaceRequest) GetInviteToken() string {
+	if x != nil {
+		return x.InviteToken
+	}
+	return ""
+}
+
+type JoinSpaceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	SpaceId       string                 `protobuf:"bytes,2,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"` // Any-Sync space ID of the joined space
+	Pending       bool                   `protobuf:"varint,3,opt,name=pending,proto3" json:"pending,omitempty"`               // The join request waits for approval; call JoinSpace again once approved
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LeaveSpaceResponse) Reset() {
-	*x = LeaveSpaceResponse{}
+func (x *JoinSpaceResponse) Reset() {
+	*x = JoinSpaceResponse{}
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LeaveSpaceResponse) String() string {
+func (x *JoinSpaceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LeaveSpaceResponse) ProtoMessage() {}
+func (*JoinSpaceResponse) ProtoMessage() {}
 
-func (x *LeaveSpaceResponse) ProtoReflect() protoreflect.Message {
+func (x *JoinSpaceResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -650,38 +981,53 @@ func (x *LeaveSpaceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LeaveSpaceResponse.ProtoReflect.Descriptor instead.
-func (*LeaveSpaceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use JoinSpaceResponse.ProtoReflect.Descriptor instead.
+func (*JoinSpaceResponse) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *LeaveSpaceResponse) GetSuccess() bool {
+func (x *JoinSpaceResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-type ListSpacesRequest struct {
+func (x *JoinSpaceResponse) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *JoinSpaceResponse) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+type ListMembersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"` // Space ID or alias
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSpacesRequest) Reset() {
-	*x = ListSpacesRequest{}
+func (x *ListMembersRequest) Reset() {
+	*x = ListMembersRequest{}
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSpacesRequest) String() string {
+func (x *ListMembersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSpacesRequest) ProtoMessage() {}
+func (*ListMembersRequest) ProtoMessage() {}
 
-func (x *ListSpacesRequest) ProtoReflect() protoreflect.Message {
+func (x *ListMembersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -693,32 +1039,39 @@ func (x *ListSpacesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSpacesRequest.ProtoReflect.Descriptor instead.
-func (*ListSpacesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListMembersRequest) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{12}
 }
 
-type ListSpacesResponse struct {
+func (x *ListMembersRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+type ListMembersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Spaces        []*SpaceInfo           `protobuf:"bytes,1,rep,name=spaces,proto3" json:"spaces,omitempty"`
+	Members       []*SpaceMember         `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"` // Members and pending join requests, by joined_at
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSpacesResponse) Reset() {
-	*x = ListSpacesResponse{}
+func (x *ListMembersResponse) Reset() {
+	*x = ListMembersResponse{}
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSpacesResponse) String() string {
+func (x *ListMembersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSpacesResponse) ProtoMessage() {}
+func (*ListMembersResponse) ProtoMessage() {}
 
-func (x *ListSpacesResponse) ProtoReflect() protoreflect.Message {
+func (x *ListMembersResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -730,44 +1083,41 @@ func (x *ListSpacesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSpacesResponse.ProtoReflect.Descriptor instead.
-func (*ListSpacesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListMembersResponse) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ListSpacesResponse) GetSpaces() []*SpaceInfo {
+func (x *ListMembersResponse) GetMembers() []*SpaceMember {
 	if x != nil {
-		return x.Spaces
+		return x.Members
 	}
 	return nil
 }
 
-type SpaceInfo struct {
+type ApproveJoinRequestRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix timestamp
-	UpdatedAt     int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // Unix timestamp
-	SyncStatus    SyncStatus             `protobuf:"varint,6,opt,name=sync_status,json=syncStatus,proto3,enum=syncspace.v1.SyncStatus" json:"sync_status,omitempty"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`                           // Space ID or alias
+	Identity      string                 `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`                                        // Account address of the requesting account
+	Permission    SpacePermission        `protobuf:"varint,3,opt,name=permission,proto3,enum=syncspace.v1.SpacePermission" json:"permission,omitempty"` // Reader, writer or admin; unspecified approves a reader
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SpaceInfo) Reset() {
-	*x = SpaceInfo{}
+func (x *ApproveJoinRequestRequest) Reset() {
+	*x = ApproveJoinRequestRequest{}
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SpaceInfo) String() string {
+func (x *ApproveJoinRequestRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SpaceInfo) ProtoMessage() {}
+func (*ApproveJoinRequestRequest) ProtoMessage() {}
 
-func (x *SpaceInfo) ProtoReflect() protoreflect.Message {
+func (x *ApproveJoinRequestRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -779,74 +1129,53 @@ func (x *SpaceInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SpaceInfo.ProtoReflect.Descriptor instead.
-func (*SpaceInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use ApproveJoinRequestRequest.ProtoReflect.Descriptor instead.
+func (*ApproveJoinRequestRequest) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *SpaceInfo) GetSpaceId() string {
+func (x *ApproveJoinRequestRequest) GetSpaceId() string {
 	if x != nil {
 		return x.SpaceId
 	}
 	return ""
 }
 
-func (x *SpaceInfo) GetName() string {
+func (x *ApproveJoinRequestRequest) GetIdentity() string {
 	if x != nil {
-		return x.Name
+		return x.Identity
 	}
 	return ""
 }
 
-func (x *SpaceInfo) GetMetadata() map[string]string {
-	if x != nil {
-		return x.Metadata
-	}
-	return nil
-}
-
-func (x *SpaceInfo) GetCreatedAt() int64 {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return 0
-}
-
-func (x *SpaceInfo) GetUpdatedAt() int64 {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return 0
-}
-
-func (x *SpaceInfo) GetSyncStatus() SyncStatus {
+func (x *ApproveJoinRequestRequest) GetPermission() SpacePermission {
 	if x != nil {
-		return x.SyncStatus
+		return x.Permission
 	}
-	return SyncStatus_SYNC_STATUS_UNSPECIFIED
+	return SpacePermission_SPACE_PERMISSION_UNSPECIFIED
 }
 
-type DeleteSpaceRequest struct {
+type ApproveJoinRequestResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	Member        *SpaceMember           `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteSpaceRequest) Reset() {
-	*x = DeleteSpaceRequest{}
+func (x *ApproveJoinRequestResponse) Reset() {
+	*x = ApproveJoinRequestResponse{}
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteSpaceRequest) String() string {
+func (x *ApproveJoinRequestResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSpaceRequest) ProtoMessage() {}
+func (*ApproveJoinRequestResponse) ProtoMessage() {}
 
-func (x *DeleteSpaceRequest) ProtoReflect() protoreflect.Message {
+func (x *ApproveJoinRequestResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -858,39 +1187,42 @@ func (x *DeleteSpaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSpaceRequest.ProtoReflect.Descriptor instead.
-func (*DeleteSpaceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ApproveJoinRequestResponse.ProtoReflect.Descriptor instead.
+func (*ApproveJoinRequestResponse) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *DeleteSpaceRequest) GetSpaceId() string {
+func (x *ApproveJoinRequestResponse) GetMember() *SpaceMember {
 	if x != nil {
-		return x.SpaceId
+		return x.Member
 	}
-	return ""
+	return nil
 }
 
-type DeleteSpaceResponse struct {
+// RemoveMemberRequest removes a member from a space and changes its read
+// key, or declines a pending join request.
+type RemoveMemberRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"` // Space ID or alias
+	Identity      string                 `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`              // Account address of the member
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteSpaceResponse) Reset() {
-	*x = DeleteSpaceResponse{}
+func (x *RemoveMemberRequest) Reset() {
+	*x = RemoveMemberRequest{}
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteSpaceResponse) String() string {
+func (x *RemoveMemberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSpaceResponse) ProtoMessage() {}
+func (*RemoveMemberRequest) ProtoMessage() {}
 
-func (x *DeleteSpaceResponse) ProtoReflect() protoreflect.Message {
+func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -902,43 +1234,46 @@ func (x *DeleteSpaceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSpaceResponse.ProtoReflect.Descriptor instead.
-func (*DeleteSpaceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RemoveMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *DeleteSpaceResponse) GetSuccess() bool {
+func (x *RemoveMemberRequest) GetSpaceId() string {
 	if x != nil {
-		return x.Success
+		return x.SpaceId
 	}
-	return false
+	return ""
 }
 
-type CreateDocumentRequest struct {
+func (x *RemoveMemberRequest) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+type RemoveMemberResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
-	DocumentId    string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`                                                     // Unique document identifier within space
-	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`                                                                       // Logical collection name (e.g., "notes", "tasks")
-	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`                                                                                   // Opaque document payload (application-defined format)
-	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Indexable metadata fields
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateDocumentRequest) Reset() {
-	*x = CreateDocumentRequest{}
+func (x *RemoveMemberResponse) Reset() {
+	*x = RemoveMemberResponse{}
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateDocumentRequest) String() string {
+func (x *RemoveMemberResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateDocumentRequest) ProtoMessage() {}
+func (*RemoveMemberResponse) ProtoMessage() {}
 
-func (x *CreateDocumentRequest) ProtoReflect() protoreflect.Message {
+func (x *RemoveMemberResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -950,68 +1285,41 @@ func (x *CreateDocumentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateDocumentRequest.ProtoReflect.Descriptor instead.
-func (*CreateDocumentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RemoveMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveMemberResponse) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *CreateDocumentRequest) GetSpaceId() string {
-	if x != nil {
-		return x.SpaceId
-	}
-	return ""
-}
-
-func (x *CreateDocumentRequest) GetDocumentId() string {
-	if x != nil {
-		return x.DocumentId
-	}
-	return ""
-}
-
-func (x *CreateDocumentRequest) GetCollection() string {
-	if x != nil {
-		return x.Collection
-	}
-	return ""
-}
-
-func (x *CreateDocumentRequest) GetData() []byte {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-func (x *CreateDocumentRequest) GetMetadata() map[string]string {
+func (x *RemoveMemberResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Metadata
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-type CreateDocumentResponse struct {
+type ChangeMemberPermissionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
-	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"` // Document version for optimistic locking
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`                           // Space ID or alias
+	Identity      string                 `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`                                        // Account address of the member
+	Permission    SpacePermission        `protobuf:"varint,3,opt,name=permission,proto3,enum=syncspace.v1.SpacePermission" json:"permission,omitempty"` // Reader, writer or admin
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateDocumentResponse) Reset() {
-	*x = CreateDocumentResponse{}
+func (x *ChangeMemberPermissionRequest) Reset() {
+	*x = ChangeMemberPermissionRequest{}
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateDocumentResponse) String() string {
+func (x *ChangeMemberPermissionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateDocumentResponse) ProtoMessage() {}
+func (*ChangeMemberPermissionRequest) ProtoMessage() {}
 
-func (x *CreateDocumentResponse) ProtoReflect() protoreflect.Message {
+func (x *ChangeMemberPermissionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1023,47 +1331,53 @@ func (x *CreateDocumentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateDocumentResponse.ProtoReflect.Descriptor instead.
-func (*CreateDocumentResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangeMemberPermissionRequest.ProtoReflect.Descriptor instead.
+func (*ChangeMemberPermissionRequest) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *CreateDocumentResponse) GetDocumentId() string {
+func (x *ChangeMemberPermissionRequest) GetSpaceId() string {
 	if x != nil {
-		return x.DocumentId
+		return x.SpaceId
 	}
 	return ""
 }
 
-func (x *CreateDocumentResponse) GetVersion() int64 {
+func (x *ChangeMemberPermissionRequest) GetIdentity() string {
 	if x != nil {
-		return x.Version
+		return x.Identity
 	}
-	return 0
+	return ""
 }
 
-type GetDocumentRequest struct {
+func (x *ChangeMemberPermissionRequest) GetPermission() SpacePermission {
+	if x != nil {
+		return x.Permission
+	}
+	return SpacePermission_SPACE_PERMISSION_UNSPECIFIED
+}
+
+type ChangeMemberPermissionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
-	DocumentId    string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Member        *SpaceMember           `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDocumentRequest) Reset() {
-	*x = GetDocumentRequest{}
+func (x *ChangeMemberPermissionResponse) Reset() {
+	*x = ChangeMemberPermissionResponse{}
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDocumentRequest) String() string {
+func (x *ChangeMemberPermissionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDocumentRequest) ProtoMessage() {}
+func (*ChangeMemberPermissionResponse) ProtoMessage() {}
 
-func (x *GetDocumentRequest) ProtoReflect() protoreflect.Message {
+func (x *ChangeMemberPermissionResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1075,47 +1389,43 @@ func (x *GetDocumentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDocumentRequest.ProtoReflect.Descriptor instead.
-func (*GetDocumentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangeMemberPermissionResponse.ProtoReflect.Descriptor instead.
+func (*ChangeMemberPermissionResponse) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GetDocumentRequest) GetSpaceId() string {
-	if x != nil {
-		return x.SpaceId
-	}
-	return ""
-}
-
-func (x *GetDocumentRequest) GetDocumentId() string {
+func (x *ChangeMemberPermissionResponse) GetMember() *SpaceMember {
 	if x != nil {
-		return x.DocumentId
+		return x.Member
 	}
-	return ""
+	return nil
 }
 
-type GetDocumentResponse struct {
+// LeaveSpaceRequest stops the account from taking part in a space. For a
+// joined space, the leave is also recorded in the space ACL through the
+// inviting device, when it can be reached.
+type LeaveSpaceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Document      *Document              `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
-	Found         bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`              // Space ID or alias
+	DeleteLocal   bool                   `protobuf:"varint,2,opt,name=delete_local,json=deleteLocal,proto3" json:"delete_local,omitempty"` // Delete the local copy, with its documents, instead of keeping it read-only
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDocumentResponse) Reset() {
-	*x = GetDocumentResponse{}
+func (x *LeaveSpaceRequest) Reset() {
+	*x = LeaveSpaceRequest{}
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDocumentResponse) String() string {
+func (x *LeaveSpaceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDocumentResponse) ProtoMessage() {}
+func (*LeaveSpaceRequest) ProtoMessage() {}
 
-func (x *GetDocumentResponse) ProtoReflect() protoreflect.Message {
+func (x *LeaveSpaceRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1127,53 +1437,47 @@ func (x *GetDocumentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDocumentResponse.ProtoReflect.Descriptor instead.
-func (*GetDocumentResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use LeaveSpaceRequest.ProtoReflect.Descriptor instead.
+func (*LeaveSpaceRequest) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *GetDocumentResponse) GetDocument() *Document {
+func (x *LeaveSpaceRequest) GetSpaceId() string {
 	if x != nil {
-		return x.Document
+		return x.SpaceId
 	}
-	return nil
+	return ""
 }
 
-func (x *GetDocumentResponse) GetFound() bool {
+func (x *LeaveSpaceRequest) GetDeleteLocal() bool {
 	if x != nil {
-		return x.Found
+		return x.DeleteLocal
 	}
 	return false
 }
 
-type Document struct {
+type LeaveSpaceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
-	SpaceId       string                 `protobuf:"bytes,2,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
-	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
-	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"` // Opaque payload
-	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Version       int64                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     int64                  `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	AclError      string                 `protobuf:"bytes,2,opt,name=acl_error,json=aclError,proto3" json:"acl_error,omitempty"` // Why the leave was not recorded in the ACL; empty if it was or there was nothing to record
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Document) Reset() {
-	*x = Document{}
+func (x *LeaveSpaceResponse) Reset() {
+	*x = LeaveSpaceResponse{}
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Document) String() string {
+func (x *LeaveSpaceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Document) ProtoMessage() {}
+func (*LeaveSpaceResponse) ProtoMessage() {}
 
-func (x *Document) ProtoReflect() protoreflect.Message {
+func (x *LeaveSpaceResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_syncspace_v1_syncspace_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1185,93 +1489,232 @@ func (x *Document) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Document.ProtoReflect.Descriptor instead.
-func (*Document) Descriptor() ([]byte, []int) {
+// Deprecated: Use LeaveSpaceResponse.ProtoReflect.Descriptor instead.
+func (*LeaveSpaceResponse) Descriptor() ([]byte, []int) {
 	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *Document) GetDocumentId() string {
+func (x *LeaveSpaceResponse) GetSuccess() bool {
 	if x != nil {
-		return x.DocumentId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *Document) GetSpaceId() string {
+func (x *LeaveSpaceResponse) GetAclError() string {
 	if x != nil {
-		return x.SpaceId
+		return x.AclError
 	}
 	return ""
 }
 
-func (x *Document) GetCollection() string {
-	if x != nil {
-		return x.Collection
-	}
-	return ""
+type ListSpacesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Document) GetData() []byte {
-	if x != nil {
-		return x.Data
-	}
-	return nil
+func (x *ListSpacesRequest) Reset() {
+	*x = ListSpacesRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Document) GetMetadata() map[string]string {
-	if x != nil {
-		return x.Metadata
-	}
-	return nil
+func (x *ListSpacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Document) GetVersion() int64 {
+func (*ListSpacesRequest) ProtoMessage() {}
+
+func (x *ListSpacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[22]
 	if x != nil {
-		return x.Version
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Document) GetCreatedAt() int64 {
+// Deprecated: Use ListSpacesRequest.ProtoReflect.Descriptor instead.
+func (*ListSpacesRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{22}
+}
+
+type ListSpacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Spaces        []*SpaceInfo           `protobuf:"bytes,1,rep,name=spaces,proto3" json:"spaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSpacesResponse) Reset() {
+	*x = ListSpacesResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSpacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSpacesResponse) ProtoMessage() {}
+
+func (x *ListSpacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSpacesResponse.ProtoReflect.Descriptor instead.
+func (*ListSpacesResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListSpacesResponse) GetSpaces() []*SpaceInfo {
+	if x != nil {
+		return x.Spaces
+	}
+	return nil
+}
+
+type SpaceInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix timestamp
+	UpdatedAt     int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // Unix timestamp
+	SyncStatus    SyncStatus             `protobuf:"varint,6,opt,name=sync_status,json=syncStatus,proto3,enum=syncspace.v1.SyncStatus" json:"sync_status,omitempty"`
+	Alias         string                 `protobuf:"bytes,7,opt,name=alias,proto3" json:"alias,omitempty"`                  // Client-chosen alias from CreateSpace or JoinSpace, if any
+	LeftAt        int64                  `protobuf:"varint,8,opt,name=left_at,json=leftAt,proto3" json:"left_at,omitempty"` // Unix timestamp the account left the space, 0 while it takes part; a left space is read-only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpaceInfo) Reset() {
+	*x = SpaceInfo{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpaceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpaceInfo) ProtoMessage() {}
+
+func (x *SpaceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpaceInfo.ProtoReflect.Descriptor instead.
+func (*SpaceInfo) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SpaceInfo) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *SpaceInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SpaceInfo) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *SpaceInfo) GetCreatedAt() int64 {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return 0
 }
 
-func (x *Document) GetUpdatedAt() int64 {
+func (x *SpaceInfo) GetUpdatedAt() int64 {
 	if x != nil {
 		return x.UpdatedAt
 	}
 	return 0
 }
 
-type UpdateDocumentRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId         string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
-	DocumentId      string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
-	Data            []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
-	Metadata        map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	ExpectedVersion int64                  `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // For optimistic locking (0 = skip check)
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *SpaceInfo) GetSyncStatus() SyncStatus {
+	if x != nil {
+		return x.SyncStatus
+	}
+	return SyncStatus_SYNC_STATUS_UNSPECIFIED
 }
 
-func (x *UpdateDocumentRequest) Reset() {
-	*x = UpdateDocumentRequest{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[22]
+func (x *SpaceInfo) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *SpaceInfo) GetLeftAt() int64 {
+	if x != nil {
+		return x.LeftAt
+	}
+	return 0
+}
+
+// SpaceMember is an account in the ACL of a space.
+type SpaceMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identity      string                 `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`                                        // Account address
+	Permission    SpacePermission        `protobuf:"varint,2,opt,name=permission,proto3,enum=syncspace.v1.SpacePermission" json:"permission,omitempty"` // Unspecified while the join request waits
+	Status        MemberStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=syncspace.v1.MemberStatus" json:"status,omitempty"`
+	JoinedAt      int64                  `protobuf:"varint,4,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`    // Unix timestamp the member joined, or requested to
+	UpdatedAt     int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // Unix timestamp of the last change of the membership
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpaceMember) Reset() {
+	*x = SpaceMember{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateDocumentRequest) String() string {
+func (x *SpaceMember) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateDocumentRequest) ProtoMessage() {}
+func (*SpaceMember) ProtoMessage() {}
 
-func (x *UpdateDocumentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[22]
+func (x *SpaceMember) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1282,68 +1725,3395 @@ func (x *UpdateDocumentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateDocumentRequest.ProtoReflect.Descriptor instead.
-func (*UpdateDocumentRequest) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use SpaceMember.ProtoReflect.Descriptor instead.
+func (*SpaceMember) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *UpdateDocumentRequest) GetSpaceId() string {
+func (x *SpaceMember) GetIdentity() string {
 	if x != nil {
-		return x.SpaceId
+		return x.Identity
 	}
 	return ""
 }
 
-func (x *UpdateDocumentRequest) GetDocumentId() string {
+func (x *SpaceMember) GetPermission() SpacePermission {
 	if x != nil {
-		return x.DocumentId
+		return x.Permission
+	}
+	return SpacePermission_SPACE_PERMISSION_UNSPECIFIED
+}
+
+func (x *SpaceMember) GetStatus() MemberStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MemberStatus_MEMBER_STATUS_UNSPECIFIED
+}
+
+func (x *SpaceMember) GetJoinedAt() int64 {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return 0
+}
+
+func (x *SpaceMember) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// UpdateSpaceRequest changes the name and metadata of a space. Unset fields
+// keep their current value.
+type UpdateSpaceRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId           string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`                                                              // Space ID or alias
+	Name              *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`                                                                             // New name
+	Metadata          map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Entries to merge, an empty value removes its key; or the new metadata with replace_metadata
+	ReplaceMetadata   bool                   `protobuf:"varint,4,opt,name=replace_metadata,json=replaceMetadata,proto3" json:"replace_metadata,omitempty"`                                     // Replace the metadata instead of merging into it
+	ExpectedUpdatedAt int64                  `protobuf:"varint,5,opt,name=expected_updated_at,json=expectedUpdatedAt,proto3" json:"expected_updated_at,omitempty"`                             // For optimistic locking, the updated_at the client last saw (0 = skip check)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateSpaceRequest) Reset() {
+	*x = UpdateSpaceRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSpaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSpaceRequest) ProtoMessage() {}
+
+func (x *UpdateSpaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSpaceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSpaceRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateSpaceRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
 	}
 	return ""
 }
 
-func (x *UpdateDocumentRequest) GetData() []byte {
+func (x *UpdateSpaceRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateSpaceRequest) GetMetadata() map[string]string {
 	if x != nil {
-		return x.Data
+		return x.Metadata
 	}
 	return nil
 }
 
-func (x *UpdateDocumentRequest) GetMetadata() map[string]string {
+func (x *UpdateSpaceRequest) GetReplaceMetadata() bool {
+	if x != nil {
+		return x.ReplaceMetadata
+	}
+	return false
+}
+
+func (x *UpdateSpaceRequest) GetExpectedUpdatedAt() int64 {
+	if x != nil {
+		return x.ExpectedUpdatedAt
+	}
+	return 0
+}
+
+type UpdateSpaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Space         *SpaceInfo             `protobuf:"bytes,1,opt,name=space,proto3" json:"space,omitempty"` // The space after the update
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSpaceResponse) Reset() {
+	*x = UpdateSpaceResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSpaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSpaceResponse) ProtoMessage() {}
+
+func (x *UpdateSpaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSpaceResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSpaceResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateSpaceResponse) GetSpace() *SpaceInfo {
+	if x != nil {
+		return x.Space
+	}
+	return nil
+}
+
+type DeleteSpaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSpaceRequest) Reset() {
+	*x = DeleteSpaceRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSpaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSpaceRequest) ProtoMessage() {}
+
+func (x *DeleteSpaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSpaceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSpaceRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteSpaceRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+type DeleteSpaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSpaceResponse) Reset() {
+	*x = DeleteSpaceResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSpaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSpaceResponse) ProtoMessage() {}
+
+func (x *DeleteSpaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSpaceResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSpaceResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteSpaceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type CreateDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	DocumentId    string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`                                                     // Unique document identifier within space
+	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`                                                                       // Logical collection name (e.g., "notes", "tasks")
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`                                                                                   // Opaque document payload (application-defined format)
+	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Indexable metadata fields
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDocumentRequest) Reset() {
+	*x = CreateDocumentRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDocumentRequest) ProtoMessage() {}
+
+func (x *CreateDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDocumentRequest.ProtoReflect.Descriptor instead.
+func (*CreateDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateDocumentRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *CreateDocumentRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *CreateDocumentRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *CreateDocumentRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CreateDocumentRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type CreateDocumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"` // Document version for optimistic locking
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDocumentResponse) Reset() {
+	*x = CreateDocumentResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDocumentResponse) ProtoMessage() {}
+
+func (x *CreateDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDocumentResponse.ProtoReflect.Descriptor instead.
+func (*CreateDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreateDocumentResponse) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *CreateDocumentResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type GetDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	DocumentId    string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentRequest) Reset() {
+	*x = GetDocumentRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentRequest) ProtoMessage() {}
+
+func (x *GetDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentRequest.ProtoReflect.Descriptor instead.
+func (*GetDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetDocumentRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *GetDocumentRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+type GetDocumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Document      *Document              `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	Found         bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentResponse) Reset() {
+	*x = GetDocumentResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentResponse) ProtoMessage() {}
+
+func (x *GetDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentResponse.ProtoReflect.Descriptor instead.
+func (*GetDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetDocumentResponse) GetDocument() *Document {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *GetDocumentResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+type Document struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	SpaceId       string                 `protobuf:"bytes,2,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"` // Opaque payload
+	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Version       int64                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Document) Reset() {
+	*x = Document{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Document) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Document) ProtoMessage() {}
+
+func (x *Document) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Document.ProtoReflect.Descriptor instead.
+func (*Document) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Document) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *Document) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *Document) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *Document) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Document) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Document) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Document) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Document) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type UpdateDocumentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId         string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	DocumentId      string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Data            []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata        map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ExpectedVersion int64                  `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // For optimistic locking (0 = skip check)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateDocumentRequest) Reset() {
+	*x = UpdateDocumentRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDocumentRequest) ProtoMessage() {}
+
+func (x *UpdateDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDocumentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpdateDocumentRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *UpdateDocumentRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *UpdateDocumentRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UpdateDocumentRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpdateDocumentRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdateDocumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"` // New version after update
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDocumentResponse) Reset() {
+	*x = UpdateDocumentResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDocumentResponse) ProtoMessage() {}
+
+func (x *UpdateDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDocumentResponse.ProtoReflect.Descriptor instead.
+func (*UpdateDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UpdateDocumentResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	DocumentId    string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDocumentRequest) Reset() {
+	*x = DeleteDocumentRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDocumentRequest) ProtoMessage() {}
+
+func (x *DeleteDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDocumentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeleteDocumentRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *DeleteDocumentRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+type DeleteDocumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Existed       bool                   `protobuf:"varint,1,opt,name=existed,proto3" json:"existed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDocumentResponse) Reset() {
+	*x = DeleteDocumentResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDocumentResponse) ProtoMessage() {}
+
+func (x *DeleteDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDocumentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteDocumentResponse) GetExisted() bool {
+	if x != nil {
+		return x.Existed
+	}
+	return false
+}
+
+type ListDocumentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	Collection    string                 `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"` // Filter by collection (empty = all)
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`          // Max results (0 = no limit)
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`         // Pagination cursor (empty = first page)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDocumentsRequest) Reset() {
+	*x = ListDocumentsRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsRequest) ProtoMessage() {}
+
+func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsRequest.ProtoReflect.Descriptor instead.
+func (*ListDocumentsRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListDocumentsRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDocumentsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListDocumentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Documents     []*DocumentInfo        `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Cursor for next page (empty = no more pages)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDocumentsResponse) Reset() {
+	*x = ListDocumentsResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsResponse) ProtoMessage() {}
+
+func (x *ListDocumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsResponse.ProtoReflect.Descriptor instead.
+func (*ListDocumentsResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListDocumentsResponse) GetDocuments() []*DocumentInfo {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+func (x *ListDocumentsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type DocumentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Collection    string                 `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Version       int64                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentInfo) Reset() {
+	*x = DocumentInfo{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentInfo) ProtoMessage() {}
+
+func (x *DocumentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentInfo.ProtoReflect.Descriptor instead.
+func (*DocumentInfo) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DocumentInfo) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *DocumentInfo) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *DocumentInfo) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *DocumentInfo) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DocumentInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *DocumentInfo) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type QueryDocumentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	Collection    string                 `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	Filters       []*QueryFilter         `protobuf:"bytes,3,rep,name=filters,proto3" json:"filters,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryDocumentsRequest) Reset() {
+	*x = QueryDocumentsRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryDocumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryDocumentsRequest) ProtoMessage() {}
+
+func (x *QueryDocumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryDocumentsRequest.ProtoReflect.Descriptor instead.
+func (*QueryDocumentsRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *QueryDocumentsRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *QueryDocumentsRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *QueryDocumentsRequest) GetFilters() []*QueryFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *QueryDocumentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *QueryDocumentsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type QueryFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`       // Metadata field name
+	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"` // "eq", "ne", "gt", "gte", "lt", "lte", "contains"
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`       // Filter value
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryFilter) Reset() {
+	*x = QueryFilter{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryFilter) ProtoMessage() {}
+
+func (x *QueryFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryFilter.ProtoReflect.Descriptor instead.
+func (*QueryFilter) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *QueryFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *QueryFilter) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *QueryFilter) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type QueryDocumentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Documents     []*DocumentInfo        `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryDocumentsResponse) Reset() {
+	*x = QueryDocumentsResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryDocumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryDocumentsResponse) ProtoMessage() {}
+
+func (x *QueryDocumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryDocumentsResponse.ProtoReflect.Descriptor instead.
+func (*QueryDocumentsResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *QueryDocumentsResponse) GetDocuments() []*DocumentInfo {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+func (x *QueryDocumentsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type StartSyncRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"` // Space to sync (empty = all spaces)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartSyncRequest) Reset() {
+	*x = StartSyncRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartSyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSyncRequest) ProtoMessage() {}
+
+func (x *StartSyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSyncRequest.ProtoReflect.Descriptor instead.
+func (*StartSyncRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *StartSyncRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+type StartSyncResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartSyncResponse) Reset() {
+	*x = StartSyncResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartSyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSyncResponse) ProtoMessage() {}
+
+func (x *StartSyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSyncResponse.ProtoReflect.Descriptor instead.
+func (*StartSyncResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *StartSyncResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type PauseSyncRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"` // Space to pause (empty = all spaces)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseSyncRequest) Reset() {
+	*x = PauseSyncRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseSyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseSyncRequest) ProtoMessage() {}
+
+func (x *PauseSyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseSyncRequest.ProtoReflect.Descriptor instead.
+func (*PauseSyncRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *PauseSyncRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+type PauseSyncResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseSyncResponse) Reset() {
+	*x = PauseSyncResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseSyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseSyncResponse) ProtoMessage() {}
+
+func (x *PauseSyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseSyncResponse.ProtoReflect.Descriptor instead.
+func (*PauseSyncResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *PauseSyncResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetSyncStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"` // Space to query (empty = all spaces)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncStatusRequest) Reset() {
+	*x = GetSyncStatusRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncStatusRequest) ProtoMessage() {}
+
+func (x *GetSyncStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetSyncStatusRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetSyncStatusRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+type GetSyncStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statuses      []*SpaceSyncStatus     `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncStatusResponse) Reset() {
+	*x = GetSyncStatusResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncStatusResponse) ProtoMessage() {}
+
+func (x *GetSyncStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetSyncStatusResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetSyncStatusResponse) GetStatuses() []*SpaceSyncStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type SpaceSyncStatus struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId        string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	Status         SyncStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=syncspace.v1.SyncStatus" json:"status,omitempty"`
+	LastSyncAt     int64                  `protobuf:"varint,3,opt,name=last_sync_at,json=lastSyncAt,proto3" json:"last_sync_at,omitempty"`           // Unix timestamp
+	PendingChanges int32                  `protobuf:"varint,4,opt,name=pending_changes,json=pendingChanges,proto3" json:"pending_changes,omitempty"` // Number of local changes not yet synced
+	Error          string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                                          // Last sync error (empty if no error)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SpaceSyncStatus) Reset() {
+	*x = SpaceSyncStatus{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpaceSyncStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpaceSyncStatus) ProtoMessage() {}
+
+func (x *SpaceSyncStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpaceSyncStatus.ProtoReflect.Descriptor instead.
+func (*SpaceSyncStatus) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SpaceSyncStatus) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *SpaceSyncStatus) GetStatus() SyncStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SyncStatus_SYNC_STATUS_UNSPECIFIED
+}
+
+func (x *SpaceSyncStatus) GetLastSyncAt() int64 {
+	if x != nil {
+		return x.LastSyncAt
+	}
+	return 0
+}
+
+func (x *SpaceSyncStatus) GetPendingChanges() int32 {
+	if x != nil {
+		return x.PendingChanges
+	}
+	return 0
+}
+
+func (x *SpaceSyncStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventTypes    []string               `protobuf:"bytes,1,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"` // Event types to subscribe to (empty = all)
+	SpaceIds      []string               `protobuf:"bytes,2,rep,name=space_ids,json=spaceIds,proto3" json:"space_ids,omitempty"`       // Space IDs to filter by (empty = all)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SubscribeRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetSpaceIds() []string {
+	if x != nil {
+		return x.SpaceIds
+	}
+	return nil
+}
+
+type SubscribeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // "document.created", "document.updated", "sync.status_changed", etc.
+	SpaceId       string                 `protobuf:"bytes,3,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp
+	Payload       []byte                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`      // Event-specific data
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeResponse) Reset() {
+	*x = SubscribeResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeResponse) ProtoMessage() {}
+
+func (x *SubscribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SubscribeResponse) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *SubscribeResponse) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *SubscribeResponse) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *SubscribeResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *SubscribeResponse) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type DocumentCreatedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Collection    string                 `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentCreatedEvent) Reset() {
+	*x = DocumentCreatedEvent{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentCreatedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentCreatedEvent) ProtoMessage() {}
+
+func (x *DocumentCreatedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentCreatedEvent.ProtoReflect.Descriptor instead.
+func (*DocumentCreatedEvent) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DocumentCreatedEvent) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *DocumentCreatedEvent) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+type DocumentUpdatedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	OldVersion    int64                  `protobuf:"varint,2,opt,name=old_version,json=oldVersion,proto3" json:"old_version,omitempty"`
+	NewVersion    int64                  `protobuf:"varint,3,opt,name=new_version,json=newVersion,proto3" json:"new_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentUpdatedEvent) Reset() {
+	*x = DocumentUpdatedEvent{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentUpdatedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentUpdatedEvent) ProtoMessage() {}
+
+func (x *DocumentUpdatedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentUpdatedEvent.ProtoReflect.Descriptor instead.
+func (*DocumentUpdatedEvent) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DocumentUpdatedEvent) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *DocumentUpdatedEvent) GetOldVersion() int64 {
+	if x != nil {
+		return x.OldVersion
+	}
+	return 0
+}
+
+func (x *DocumentUpdatedEvent) GetNewVersion() int64 {
+	if x != nil {
+		return x.NewVersion
+	}
+	return 0
+}
+
+type DocumentDeletedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentDeletedEvent) Reset() {
+	*x = DocumentDeletedEvent{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentDeletedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentDeletedEvent) ProtoMessage() {}
+
+func (x *DocumentDeletedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentDeletedEvent.ProtoReflect.Descriptor instead.
+func (*DocumentDeletedEvent) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *DocumentDeletedEvent) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+type SpaceUpdatedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldName       string                 `protobuf:"bytes,1,opt,name=old_name,json=oldName,proto3" json:"old_name,omitempty"`
+	NewName       string                 `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	OldMetadata   map[string]string      `protobuf:"bytes,3,rep,name=old_metadata,json=oldMetadata,proto3" json:"old_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	NewMetadata   map[string]string      `protobuf:"bytes,4,rep,name=new_metadata,json=newMetadata,proto3" json:"new_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	OldUpdatedAt  int64                  `protobuf:"varint,5,opt,name=old_updated_at,json=oldUpdatedAt,proto3" json:"old_updated_at,omitempty"`
+	NewUpdatedAt  int64                  `protobuf:"varint,6,opt,name=new_updated_at,json=newUpdatedAt,proto3" json:"new_updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpaceUpdatedEvent) Reset() {
+	*x = SpaceUpdatedEvent{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpaceUpdatedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpaceUpdatedEvent) ProtoMessage() {}
+
+func (x *SpaceUpdatedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpaceUpdatedEvent.ProtoReflect.Descriptor instead.
+func (*SpaceUpdatedEvent) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SpaceUpdatedEvent) GetOldName() string {
+	if x != nil {
+		return x.OldName
+	}
+	return ""
+}
+
+func (x *SpaceUpdatedEvent) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+func (x *SpaceUpdatedEvent) GetOldMetadata() map[string]string {
+	if x != nil {
+		return x.OldMetadata
+	}
+	return nil
+}
+
+func (x *SpaceUpdatedEvent) GetNewMetadata() map[string]string {
+	if x != nil {
+		return x.NewMetadata
+	}
+	return nil
+}
+
+func (x *SpaceUpdatedEvent) GetOldUpdatedAt() int64 {
+	if x != nil {
+		return x.OldUpdatedAt
+	}
+	return 0
+}
+
+func (x *SpaceUpdatedEvent) GetNewUpdatedAt() int64 {
+	if x != nil {
+		return x.NewUpdatedAt
+	}
+	return 0
+}
+
+// SpaceMemberEvent is the payload of the space.member_joined,
+// space.member_join_requested, space.member_removed and
+// space.member_permission_changed events.
+type SpaceMemberEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identity      string                 `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`                                // Account address of the member
+	Permission    string                 `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"`                            // reader, writer or admin; before the removal for space.member_removed
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                                    // active, join_requested or leaving
+	OldPermission string                 `protobuf:"bytes,4,opt,name=old_permission,json=oldPermission,proto3" json:"old_permission,omitempty"` // Only for space.member_permission_changed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpaceMemberEvent) Reset() {
+	*x = SpaceMemberEvent{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpaceMemberEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpaceMemberEvent) ProtoMessage() {}
+
+func (x *SpaceMemberEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpaceMemberEvent.ProtoReflect.Descriptor instead.
+func (*SpaceMemberEvent) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SpaceMemberEvent) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *SpaceMemberEvent) GetPermission() string {
+	if x != nil {
+		return x.Permission
+	}
+	return ""
+}
+
+func (x *SpaceMemberEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SpaceMemberEvent) GetOldPermission() string {
+	if x != nil {
+		return x.OldPermission
+	}
+	return ""
+}
+
+type SyncStatusChangedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldStatus     SyncStatus             `protobuf:"varint,1,opt,name=old_status,json=oldStatus,proto3,enum=syncspace.v1.SyncStatus" json:"old_status,omitempty"`
+	NewStatus     SyncStatus             `protobuf:"varint,2,opt,name=new_status,json=newStatus,proto3,enum=syncspace.v1.SyncStatus" json:"new_status,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncStatusChangedEvent) Reset() {
+	*x = SyncStatusChangedEvent{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncStatusChangedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncStatusChangedEvent) ProtoMessage() {}
+
+func (x *SyncStatusChangedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncStatusChangedEvent.ProtoReflect.Descriptor instead.
+func (*SyncStatusChangedEvent) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *SyncStatusChangedEvent) GetOldStatus() SyncStatus {
+	if x != nil {
+		return x.OldStatus
+	}
+	return SyncStatus_SYNC_STATUS_UNSPECIFIED
+}
+
+func (x *SyncStatusChangedEvent) GetNewStatus() SyncStatus {
+	if x != nil {
+		return x.NewStatus
+	}
+	return SyncStatus_SYNC_STATUS_UNSPECIFIED
+}
+
+func (x *SyncStatusChangedEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// BatchRequest executes several commands in one call, in order.
+// With atomic set, only document commands are allowed: if any command fails,
+// document changes made by earlier commands are rolled back and their events
+// are never emitted. Without it, every command runs and reports its own result.
+type BatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Commands      []*Command             `protobuf:"bytes,1,rep,name=commands,proto3" json:"commands,omitempty"` // Commands to execute, in order
+	Atomic        bool                   `protobuf:"varint,2,opt,name=atomic,proto3" json:"atomic,omitempty"`    // All-or-nothing execution
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRequest) Reset() {
+	*x = BatchRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRequest) ProtoMessage() {}
+
+func (x *BatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRequest.ProtoReflect.Descriptor instead.
+func (*BatchRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *BatchRequest) GetCommands() []*Command {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *BatchRequest) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+type BatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*CommandResponse     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // One result per command, in request order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchResponse) Reset() {
+	*x = BatchResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResponse) ProtoMessage() {}
+
+func (x *BatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResponse.ProtoReflect.Descriptor instead.
+func (*BatchResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *BatchResponse) GetResults() []*CommandResponse {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// DescribeCommandsRequest lists the commands registered in the backend.
+// It can be called before Init.
+type DescribeCommandsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DescribeCommandsRequest) Reset() {
+	*x = DescribeCommandsRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeCommandsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeCommandsRequest) ProtoMessage() {}
+
+func (x *DescribeCommandsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeCommandsRequest.ProtoReflect.Descriptor instead.
+func (*DescribeCommandsRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{62}
+}
+
+type DescribeCommandsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Commands          []*CommandInfo         `protobuf:"bytes,1,rep,name=commands,proto3" json:"commands,omitempty"`                                              // Registered commands, sorted by name
+	FileDescriptorSet []byte                 `protobuf:"bytes,2,opt,name=file_descriptor_set,json=fileDescriptorSet,proto3" json:"file_descriptor_set,omitempty"` // Serialized google.protobuf.FileDescriptorSet of all request/response messages
+	SchemaDigest      string                 `protobuf:"bytes,3,opt,name=schema_digest,json=schemaDigest,proto3" json:"schema_digest,omitempty"`                  // Hex SHA-256 of file_descriptor_set, for quick version skew checks
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DescribeCommandsResponse) Reset() {
+	*x = DescribeCommandsResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeCommandsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeCommandsResponse) ProtoMessage() {}
+
+func (x *DescribeCommandsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeCommandsResponse.ProtoReflect.Descriptor instead.
+func (*DescribeCommandsResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *DescribeCommandsResponse) GetCommands() []*CommandInfo {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *DescribeCommandsResponse) GetFileDescriptorSet() []byte {
+	if x != nil {
+		return x.FileDescriptorSet
+	}
+	return nil
+}
+
+func (x *DescribeCommandsResponse) GetSchemaDigest() string {
+	if x != nil {
+		return x.SchemaDigest
+	}
+	return ""
+}
+
+type CommandInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                     // Command name (e.g., "CreateDocument")
+	RequestType   string                 `protobuf:"bytes,2,opt,name=request_type,json=requestType,proto3" json:"request_type,omitempty"`    // Fully-qualified request message name (e.g., "syncspace.v1.CreateDocumentRequest")
+	ResponseType  string                 `protobuf:"bytes,3,opt,name=response_type,json=responseType,proto3" json:"response_type,omitempty"` // Fully-qualified response message name (for streams, the streamed message)
+	Streaming     bool                   `protobuf:"varint,4,opt,name=streaming,proto3" json:"streaming,omitempty"`                          // Server-streaming command; see StreamMessage
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandInfo) Reset() {
+	*x = CommandInfo{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandInfo) ProtoMessage() {}
+
+func (x *CommandInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandInfo.ProtoReflect.Descriptor instead.
+func (*CommandInfo) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *CommandInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CommandInfo) GetRequestType() string {
+	if x != nil {
+		return x.RequestType
+	}
+	return ""
+}
+
+func (x *CommandInfo) GetResponseType() string {
+	if x != nil {
+		return x.ResponseType
+	}
+	return ""
+}
+
+func (x *CommandInfo) GetStreaming() bool {
+	if x != nil {
+		return x.Streaming
+	}
+	return false
+}
+
+// CommandError is the structured error returned across the dispatch boundary.
+// Desktop attaches it to the gRPC status details; mobile returns it as the
+// JSON-encoded message of the thrown error.
+type CommandError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          ErrorCode              `protobuf:"varint,1,opt,name=code,proto3,enum=syncspace.v1.ErrorCode" json:"code,omitempty"`                                                    // Machine-readable error category
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                                                                           // Human-readable description
+	Details       map[string]string      `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Additional context (e.g. "space_id", "document_id")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandError) Reset() {
+	*x = CommandError{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandError) ProtoMessage() {}
+
+func (x *CommandError) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandError.ProtoReflect.Descriptor instead.
+func (*CommandError) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *CommandError) GetCode() ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+func (x *CommandError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CommandError) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// StreamMessage carries one message of a streaming command (e.g. Subscribe)
+// to hosts that receive all streams through a single callback, such as the
+// mobile event handler. Desktop streams over gRPC and does not use it.
+type StreamMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StreamId      string                 `protobuf:"bytes,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"` // Stream ID returned when the stream was started
+	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`                   // Streaming command name
+	Payload       []byte                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`                   // Serialized streamed message; empty when done is set
+	Done          bool                   `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`                        // Last message of the stream; no more messages follow
+	Error         *CommandError          `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                       // Why the stream ended, if it ended with an error
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamMessage) Reset() {
+	*x = StreamMessage{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamMessage) ProtoMessage() {}
+
+func (x *StreamMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamMessage.ProtoReflect.Descriptor instead.
+func (*StreamMessage) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *StreamMessage) GetStreamId() string {
+	if x != nil {
+		return x.StreamId
+	}
+	return ""
+}
+
+func (x *StreamMessage) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *StreamMessage) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *StreamMessage) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *StreamMessage) GetError() *CommandError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type ProfileInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProfileId     string                 `protobuf:"bytes,1,opt,name=profile_id,json=profileId,proto3" json:"profile_id,omitempty"`  // Directory-safe identifier: letters, digits, "-" and "_"
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                             // Display name
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix timestamp
+	Open          bool                   `protobuf:"varint,4,opt,name=open,proto3" json:"open,omitempty"`                            // Profile is loaded; its keys are in memory
+	Active        bool                   `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`                        // Commands currently run on this profile
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileInfo) Reset() {
+	*x = ProfileInfo{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileInfo) ProtoMessage() {}
+
+func (x *ProfileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileInfo.ProtoReflect.Descriptor instead.
+func (*ProfileInfo) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ProfileInfo) GetProfileId() string {
+	if x != nil {
+		return x.ProfileId
+	}
+	return ""
+}
+
+func (x *ProfileInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProfileInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ProfileInfo) GetOpen() bool {
+	if x != nil {
+		return x.Open
+	}
+	return false
+}
+
+func (x *ProfileInfo) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type CreateProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProfileId     string                 `protobuf:"bytes,1,opt,name=profile_id,json=profileId,proto3" json:"profile_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // Defaults to profile_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProfileRequest) Reset() {
+	*x = CreateProfileRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProfileRequest) ProtoMessage() {}
+
+func (x *CreateProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProfileRequest.ProtoReflect.Descriptor instead.
+func (*CreateProfileRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *CreateProfileRequest) GetProfileId() string {
+	if x != nil {
+		return x.ProfileId
+	}
+	return ""
+}
+
+func (x *CreateProfileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       *ProfileInfo           `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProfileResponse) Reset() {
+	*x = CreateProfileResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProfileResponse) ProtoMessage() {}
+
+func (x *CreateProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProfileResponse.ProtoReflect.Descriptor instead.
+func (*CreateProfileResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CreateProfileResponse) GetProfile() *ProfileInfo {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type ListProfilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProfilesRequest) Reset() {
+	*x = ListProfilesRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProfilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProfilesRequest) ProtoMessage() {}
+
+func (x *ListProfilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProfilesRequest.ProtoReflect.Descriptor instead.
+func (*ListProfilesRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{70}
+}
+
+type ListProfilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profiles      []*ProfileInfo         `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty"` // "default" first, then sorted by profile_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProfilesResponse) Reset() {
+	*x = ListProfilesResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProfilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProfilesResponse) ProtoMessage() {}
+
+func (x *ListProfilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProfilesResponse.ProtoReflect.Descriptor instead.
+func (*ListProfilesResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ListProfilesResponse) GetProfiles() []*ProfileInfo {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+// OpenProfileRequest loads a profile if needed and makes it the active one.
+type OpenProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProfileId     string                 `protobuf:"bytes,1,opt,name=profile_id,json=profileId,proto3" json:"profile_id,omitempty"`
+	Passphrase    string                 `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"` // For a passphrase-protected profile, see InitRequest.passphrase
+	Mnemonic      string                 `protobuf:"bytes,3,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`     // Restores the account of a new profile, see InitRequest.mnemonic
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenProfileRequest) Reset() {
+	*x = OpenProfileRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenProfileRequest) ProtoMessage() {}
+
+func (x *OpenProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenProfileRequest.ProtoReflect.Descriptor instead.
+func (*OpenProfileRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *OpenProfileRequest) GetProfileId() string {
+	if x != nil {
+		return x.ProfileId
+	}
+	return ""
+}
+
+func (x *OpenProfileRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+func (x *OpenProfileRequest) GetMnemonic() string {
+	if x != nil {
+		return x.Mnemonic
+	}
+	return ""
+}
+
+type OpenProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       *ProfileInfo           `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenProfileResponse) Reset() {
+	*x = OpenProfileResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenProfileResponse) ProtoMessage() {}
+
+func (x *OpenProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenProfileResponse.ProtoReflect.Descriptor instead.
+func (*OpenProfileResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *OpenProfileResponse) GetProfile() *ProfileInfo {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+// CloseProfileRequest unloads a profile. Closing the active profile leaves no
+// profile active until the next OpenProfile.
+type CloseProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProfileId     string                 `protobuf:"bytes,1,opt,name=profile_id,json=profileId,proto3" json:"profile_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseProfileRequest) Reset() {
+	*x = CloseProfileRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseProfileRequest) ProtoMessage() {}
+
+func (x *CloseProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseProfileRequest.ProtoReflect.Descriptor instead.
+func (*CloseProfileRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *CloseProfileRequest) GetProfileId() string {
+	if x != nil {
+		return x.ProfileId
+	}
+	return ""
+}
+
+type CloseProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseProfileResponse) Reset() {
+	*x = CloseProfileResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseProfileResponse) ProtoMessage() {}
+
+func (x *CloseProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseProfileResponse.ProtoReflect.Descriptor instead.
+func (*CloseProfileResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *CloseProfileResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// DeleteProfileRequest closes a profile and removes all of its data.
+// The default profile cannot be deleted.
+type DeleteProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProfileId     string                 `protobuf:"bytes,1,opt,name=profile_id,json=profileId,proto3" json:"profile_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProfileRequest) Reset() {
+	*x = DeleteProfileRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProfileRequest) ProtoMessage() {}
+
+func (x *DeleteProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProfileRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProfileRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *DeleteProfileRequest) GetProfileId() string {
+	if x != nil {
+		return x.ProfileId
+	}
+	return ""
+}
+
+type DeleteProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProfileResponse) Reset() {
+	*x = DeleteProfileResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProfileResponse) ProtoMessage() {}
+
+func (x *DeleteProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProfileResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProfileResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *DeleteProfileResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// GetConfigRequest returns the configuration the backend runs with, after
+// defaults are applied.
+type GetConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{78}
+}
+
+type GetConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *BackendConfig         `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigResponse) Reset() {
+	*x = GetConfigResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigResponse) ProtoMessage() {}
+
+func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *GetConfigResponse) GetConfig() *BackendConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// BackendConfig is the validated configuration parsed from InitRequest.
+// InitRequest.config and config_json accept every field except data_dir,
+// network_id, device_id and extra, under the same names.
+type BackendConfig struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	DataDir              string                 `protobuf:"bytes,1,opt,name=data_dir,json=dataDir,proto3" json:"data_dir,omitempty"`
+	NetworkId            string                 `protobuf:"bytes,2,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	DeviceId             string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	NetworkMode          string                 `protobuf:"bytes,4,opt,name=network_mode,json=networkMode,proto3" json:"network_mode,omitempty"`                                                                                                // "local" (default); network sync is not available yet
+	LogLevel             string                 `protobuf:"bytes,5,opt,name=log_level,json=logLevel,proto3" json:"log_level,omitempty"`                                                                                                         // "debug", "info" (default), "warn" or "error"
+	CommandTimeoutMs     int64                  `protobuf:"varint,6,opt,name=command_timeout_ms,json=commandTimeoutMs,proto3" json:"command_timeout_ms,omitempty"`                                                                              // Default command deadline; negative means none
+	CommandTimeoutsMs    map[string]int64       `protobuf:"bytes,7,rep,name=command_timeouts_ms,json=commandTimeoutsMs,proto3" json:"command_timeouts_ms,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Per-command overrides
+	SyncPeriodSec        int32                  `protobuf:"varint,8,opt,name=sync_period_sec,json=syncPeriodSec,proto3" json:"sync_period_sec,omitempty"`                                                                                       // any-sync head sync period (default 5)
+	GcTtlSec             int32                  `protobuf:"varint,9,opt,name=gc_ttl_sec,json=gcTtlSec,proto3" json:"gc_ttl_sec,omitempty"`                                                                                                      // any-sync tree garbage collection TTL (default 60)
+	KeepTreeDataInMemory bool                   `protobuf:"varint,10,opt,name=keep_tree_data_in_memory,json=keepTreeDataInMemory,proto3" json:"keep_tree_data_in_memory,omitempty"`                                                             // any-sync tree cache (default true)
+	AutoLockSec          int32                  `protobuf:"varint,12,opt,name=auto_lock_sec,json=autoLockSec,proto3" json:"auto_lock_sec,omitempty"`                                                                                            // Idle time before the session locks itself; 0 (default) never
+	SpaceCacheSize       int32                  `protobuf:"varint,13,opt,name=space_cache_size,json=spaceCacheSize,proto3" json:"space_cache_size,omitempty"`                                                                                   // Space objects kept open at once; 0 (default) no limit
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *BackendConfig) Reset() {
+	*x = BackendConfig{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackendConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackendConfig) ProtoMessage() {}
+
+func (x *BackendConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackendConfig.ProtoReflect.Descriptor instead.
+func (*BackendConfig) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *BackendConfig) GetDataDir() string {
+	if x != nil {
+		return x.DataDir
+	}
+	return ""
+}
+
+func (x *BackendConfig) GetNetworkId() string {
+	if x != nil {
+		return x.NetworkId
+	}
+	return ""
+}
+
+func (x *BackendConfig) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *BackendConfig) GetNetworkMode() string {
+	if x != nil {
+		return x.NetworkMode
+	}
+	return ""
+}
+
+func (x *BackendConfig) GetLogLevel() string {
+	if x != nil {
+		return x.LogLevel
+	}
+	return ""
+}
+
+func (x *BackendConfig) GetCommandTimeoutMs() int64 {
+	if x != nil {
+		return x.CommandTimeoutMs
+	}
+	return 0
+}
+
+func (x *BackendConfig) GetCommandTimeoutsMs() map[string]int64 {
+	if x != nil {
+		return x.CommandTimeoutsMs
+	}
+	return nil
+}
+
+func (x *BackendConfig) GetSyncPeriodSec() int32 {
+	if x != nil {
+		return x.SyncPeriodSec
+	}
+	return 0
+}
+
+func (x *BackendConfig) GetGcTtlSec() int32 {
+	if x != nil {
+		return x.GcTtlSec
+	}
+	return 0
+}
+
+func (x *BackendConfig) GetKeepTreeDataInMemory() bool {
+	if x != nil {
+		return x.KeepTreeDataInMemory
+	}
+	return false
+}
+
+func (x *BackendConfig) GetAutoLockSec() int32 {
+	if x != nil {
+		return x.AutoLockSec
+	}
+	return 0
+}
+
+func (x *BackendConfig) GetSpaceCacheSize() int32 {
+	if x != nil {
+		return x.SpaceCacheSize
+	}
+	return 0
+}
+
+// GetStatusRequest reports the state of the backend, for health checks and
+// bug reports. It succeeds before Init, reporting initialized = false.
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{81}
+}
+
+type GetStatusResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Initialized         bool                   `protobuf:"varint,1,opt,name=initialized,proto3" json:"initialized,omitempty"`
+	DataDir             string                 `protobuf:"bytes,2,opt,name=data_dir,json=dataDir,proto3" json:"data_dir,omitempty"`
+	StartedAt           int64                  `protobuf:"varint,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`                                // Unix timestamp (seconds) of Init; 0 before Init
+	UptimeMs            int64                  `protobuf:"varint,4,opt,name=uptime_ms,json=uptimeMs,proto3" json:"uptime_ms,omitempty"`                                   // Time since Init
+	OpenSpaceCount      int32                  `protobuf:"varint,5,opt,name=open_space_count,json=openSpaceCount,proto3" json:"open_space_count,omitempty"`               // Any-Sync space objects loaded in memory
+	SubscriberCount     int32                  `protobuf:"varint,6,opt,name=subscriber_count,json=subscriberCount,proto3" json:"subscriber_count,omitempty"`              // Active event subscriptions
+	StorageBytes        int64                  `protobuf:"varint,7,opt,name=storage_bytes,json=storageBytes,proto3" json:"storage_bytes,omitempty"`                       // Total on-disk size of all spaces
+	Spaces              []*SpaceDiagnostics    `protobuf:"bytes,8,rep,name=spaces,proto3" json:"spaces,omitempty"`                                                        // Sorted by space_id
+	GoVersion           string                 `protobuf:"bytes,9,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`                                 // Go runtime of the backend
+	Platform            string                 `protobuf:"bytes,10,opt,name=platform,proto3" json:"platform,omitempty"`                                                   // GOOS/GOARCH, e.g. "darwin/arm64"
+	PassphraseProtected bool                   `protobuf:"varint,11,opt,name=passphrase_protected,json=passphraseProtected,proto3" json:"passphrase_protected,omitempty"` // The account key is sealed with a passphrase
+	Locked              bool                   `protobuf:"varint,12,opt,name=locked,proto3" json:"locked,omitempty"`                                                      // The session is locked, see Lock
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusResponse) ProtoMessage() {}
+
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetStatusResponse) GetInitialized() bool {
+	if x != nil {
+		return x.Initialized
+	}
+	return false
+}
+
+func (x *GetStatusResponse) GetDataDir() string {
+	if x != nil {
+		return x.DataDir
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetUptimeMs() int64 {
+	if x != nil {
+		return x.UptimeMs
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetOpenSpaceCount() int32 {
+	if x != nil {
+		return x.OpenSpaceCount
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetSubscriberCount() int32 {
+	if x != nil {
+		return x.SubscriberCount
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetStorageBytes() int64 {
+	if x != nil {
+		return x.StorageBytes
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetSpaces() []*SpaceDiagnostics {
+	if x != nil {
+		return x.Spaces
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetPassphraseProtected() bool {
 	if x != nil {
-		return x.Metadata
+		return x.PassphraseProtected
 	}
-	return nil
+	return false
 }
 
-func (x *UpdateDocumentRequest) GetExpectedVersion() int64 {
+func (x *GetStatusResponse) GetLocked() bool {
 	if x != nil {
-		return x.ExpectedVersion
+		return x.Locked
 	}
-	return 0
+	return false
 }
 
-type UpdateDocumentResponse struct {
+// SpaceDiagnostics describes the local state of one space.
+type SpaceDiagnostics struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"` // New version after update
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Open          bool                   `protobuf:"varint,3,opt,name=open,proto3" json:"open,omitempty"` // The space object is loaded in memory
+	DocumentCount int32                  `protobuf:"varint,4,opt,name=document_count,json=documentCount,proto3" json:"document_count,omitempty"`
+	StorageBytes  int64                  `protobuf:"varint,5,opt,name=storage_bytes,json=storageBytes,proto3" json:"storage_bytes,omitempty"` // On-disk size of the space storage
+	LoadError     string                 `protobuf:"bytes,6,opt,name=load_error,json=loadError,proto3" json:"load_error,omitempty"`           // Why the space last failed to load; empty if it did not
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateDocumentResponse) Reset() {
-	*x = UpdateDocumentResponse{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[23]
+func (x *SpaceDiagnostics) Reset() {
+	*x = SpaceDiagnostics{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateDocumentResponse) String() string {
+func (x *SpaceDiagnostics) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateDocumentResponse) ProtoMessage() {}
+func (*SpaceDiagnostics) ProtoMessage() {}
 
-func (x *UpdateDocumentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[23]
+func (x *SpaceDiagnostics) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1354,92 +5124,76 @@ func (x *UpdateDocumentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateDocumentResponse.ProtoReflect.Descriptor instead.
-func (*UpdateDocumentResponse) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use SpaceDiagnostics.ProtoReflect.Descriptor instead.
+func (*SpaceDiagnostics) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *UpdateDocumentResponse) GetVersion() int64 {
+func (x *SpaceDiagnostics) GetSpaceId() string {
 	if x != nil {
-		return x.Version
+		return x.SpaceId
 	}
-	return 0
-}
-
-type DeleteDocumentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
-	DocumentId    string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *DeleteDocumentRequest) Reset() {
-	*x = DeleteDocumentRequest{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[24]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return ""
 }
 
-func (x *DeleteDocumentRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SpaceDiagnostics) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (*DeleteDocumentRequest) ProtoMessage() {}
-
-func (x *DeleteDocumentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[24]
+func (x *SpaceDiagnostics) GetOpen() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Open
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use DeleteDocumentRequest.ProtoReflect.Descriptor instead.
-func (*DeleteDocumentRequest) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{24}
+func (x *SpaceDiagnostics) GetDocumentCount() int32 {
+	if x != nil {
+		return x.DocumentCount
+	}
+	return 0
 }
 
-func (x *DeleteDocumentRequest) GetSpaceId() string {
+func (x *SpaceDiagnostics) GetStorageBytes() int64 {
 	if x != nil {
-		return x.SpaceId
+		return x.StorageBytes
 	}
-	return ""
+	return 0
 }
 
-func (x *DeleteDocumentRequest) GetDocumentId() string {
+func (x *SpaceDiagnostics) GetLoadError() string {
 	if x != nil {
-		return x.DocumentId
+		return x.LoadError
 	}
 	return ""
 }
 
-type DeleteDocumentResponse struct {
+// SetPassphraseRequest protects an unprotected account key.
+type SetPassphraseRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Existed       bool                   `protobuf:"varint,1,opt,name=existed,proto3" json:"existed,omitempty"`
+	Passphrase    string                 `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteDocumentResponse) Reset() {
-	*x = DeleteDocumentResponse{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[25]
+func (x *SetPassphraseRequest) Reset() {
+	*x = SetPassphraseRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteDocumentResponse) String() string {
+func (x *SetPassphraseRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteDocumentResponse) ProtoMessage() {}
+func (*SetPassphraseRequest) ProtoMessage() {}
 
-func (x *DeleteDocumentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[25]
+func (x *SetPassphraseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1450,43 +5204,40 @@ func (x *DeleteDocumentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteDocumentResponse.ProtoReflect.Descriptor instead.
-func (*DeleteDocumentResponse) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use SetPassphraseRequest.ProtoReflect.Descriptor instead.
+func (*SetPassphraseRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *DeleteDocumentResponse) GetExisted() bool {
+func (x *SetPassphraseRequest) GetPassphrase() string {
 	if x != nil {
-		return x.Existed
+		return x.Passphrase
 	}
-	return false
+	return ""
 }
 
-type ListDocumentsRequest struct {
+type SetPassphraseResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
-	Collection    string                 `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"` // Filter by collection (empty = all)
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`          // Max results (0 = no limit)
-	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`         // Pagination cursor (empty = first page)
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListDocumentsRequest) Reset() {
-	*x = ListDocumentsRequest{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[26]
+func (x *SetPassphraseResponse) Reset() {
+	*x = SetPassphraseResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListDocumentsRequest) String() string {
+func (x *SetPassphraseResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDocumentsRequest) ProtoMessage() {}
+func (*SetPassphraseResponse) ProtoMessage() {}
 
-func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[26]
+func (x *SetPassphraseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1497,62 +5248,42 @@ func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDocumentsRequest.ProtoReflect.Descriptor instead.
-func (*ListDocumentsRequest) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{26}
-}
-
-func (x *ListDocumentsRequest) GetSpaceId() string {
-	if x != nil {
-		return x.SpaceId
-	}
-	return ""
-}
-
-func (x *ListDocumentsRequest) GetCollection() string {
-	if x != nil {
-		return x.Collection
-	}
-	return ""
-}
-
-func (x *ListDocumentsRequest) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
+// Deprecated: Use SetPassphraseResponse.ProtoReflect.Descriptor instead.
+func (*SetPassphraseResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *ListDocumentsRequest) GetCursor() string {
+func (x *SetPassphraseResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Cursor
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-type ListDocumentsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Documents     []*DocumentInfo        `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
-	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Cursor for next page (empty = no more pages)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// ChangePassphraseRequest re-seals the account key with a new passphrase.
+type ChangePassphraseRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPassphrase string                 `protobuf:"bytes,1,opt,name=current_passphrase,json=currentPassphrase,proto3" json:"current_passphrase,omitempty"`
+	NewPassphrase     string                 `protobuf:"bytes,2,opt,name=new_passphrase,json=newPassphrase,proto3" json:"new_passphrase,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *ListDocumentsResponse) Reset() {
-	*x = ListDocumentsResponse{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[27]
+func (x *ChangePassphraseRequest) Reset() {
+	*x = ChangePassphraseRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListDocumentsResponse) String() string {
+func (x *ChangePassphraseRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDocumentsResponse) ProtoMessage() {}
+func (*ChangePassphraseRequest) ProtoMessage() {}
 
-func (x *ListDocumentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[27]
+func (x *ChangePassphraseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1563,52 +5294,47 @@ func (x *ListDocumentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDocumentsResponse.ProtoReflect.Descriptor instead.
-func (*ListDocumentsResponse) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use ChangePassphraseRequest.ProtoReflect.Descriptor instead.
+func (*ChangePassphraseRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *ListDocumentsResponse) GetDocuments() []*DocumentInfo {
+func (x *ChangePassphraseRequest) GetCurrentPassphrase() string {
 	if x != nil {
-		return x.Documents
+		return x.CurrentPassphrase
 	}
-	return nil
+	return ""
 }
 
-func (x *ListDocumentsResponse) GetNextCursor() string {
+func (x *ChangePassphraseRequest) GetNewPassphrase() string {
 	if x != nil {
-		return x.NextCursor
+		return x.NewPassphrase
 	}
 	return ""
 }
 
-type DocumentInfo struct {
+type ChangePassphraseResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
-	Collection    string                 `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Version       int64                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DocumentInfo) Reset() {
-	*x = DocumentInfo{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[28]
+func (x *ChangePassphraseResponse) Reset() {
+	*x = ChangePassphraseResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DocumentInfo) String() string {
+func (x *ChangePassphraseResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DocumentInfo) ProtoMessage() {}
+func (*ChangePassphraseResponse) ProtoMessage() {}
 
-func (x *DocumentInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[28]
+func (x *ChangePassphraseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1619,79 +5345,85 @@ func (x *DocumentInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DocumentInfo.ProtoReflect.Descriptor instead.
-func (*DocumentInfo) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use ChangePassphraseResponse.ProtoReflect.Descriptor instead.
+func (*ChangePassphraseResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *DocumentInfo) GetDocumentId() string {
+func (x *ChangePassphraseResponse) GetSuccess() bool {
 	if x != nil {
-		return x.DocumentId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *DocumentInfo) GetCollection() string {
-	if x != nil {
-		return x.Collection
-	}
-	return ""
+// RemovePassphraseRequest stores the account key without a passphrase again.
+type RemovePassphraseRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPassphrase string                 `protobuf:"bytes,1,opt,name=current_passphrase,json=currentPassphrase,proto3" json:"current_passphrase,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *DocumentInfo) GetMetadata() map[string]string {
-	if x != nil {
-		return x.Metadata
-	}
-	return nil
+func (x *RemovePassphraseRequest) Reset() {
+	*x = RemovePassphraseRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *DocumentInfo) GetVersion() int64 {
-	if x != nil {
-		return x.Version
-	}
-	return 0
+func (x *RemovePassphraseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *DocumentInfo) GetCreatedAt() int64 {
+func (*RemovePassphraseRequest) ProtoMessage() {}
+
+func (x *RemovePassphraseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[88]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *DocumentInfo) GetUpdatedAt() int64 {
+// Deprecated: Use RemovePassphraseRequest.ProtoReflect.Descriptor instead.
+func (*RemovePassphraseRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *RemovePassphraseRequest) GetCurrentPassphrase() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.CurrentPassphrase
 	}
-	return 0
+	return ""
 }
 
-type QueryDocumentsRequest struct {
+type RemovePassphraseResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
-	Collection    string                 `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
-	Filters       []*QueryFilter         `protobuf:"bytes,3,rep,name=filters,proto3" json:"filters,omitempty"`
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
-	Cursor        string                 `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *QueryDocumentsRequest) Reset() {
-	*x = QueryDocumentsRequest{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[29]
+func (x *RemovePassphraseResponse) Reset() {
+	*x = RemovePassphraseResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueryDocumentsRequest) String() string {
+func (x *RemovePassphraseResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryDocumentsRequest) ProtoMessage() {}
+func (*RemovePassphraseResponse) ProtoMessage() {}
 
-func (x *QueryDocumentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[29]
+func (x *RemovePassphraseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1702,70 +5434,43 @@ func (x *QueryDocumentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryDocumentsRequest.ProtoReflect.Descriptor instead.
-func (*QueryDocumentsRequest) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{29}
-}
-
-func (x *QueryDocumentsRequest) GetSpaceId() string {
-	if x != nil {
-		return x.SpaceId
-	}
-	return ""
-}
-
-func (x *QueryDocumentsRequest) GetCollection() string {
-	if x != nil {
-		return x.Collection
-	}
-	return ""
-}
-
-func (x *QueryDocumentsRequest) GetFilters() []*QueryFilter {
-	if x != nil {
-		return x.Filters
-	}
-	return nil
-}
-
-func (x *QueryDocumentsRequest) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-func (x *QueryDocumentsRequest) GetCursor() string {
+// Deprecated: Use RemovePassphraseResponse.ProtoReflect.Descriptor instead.
+func (*RemovePassphraseResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *RemovePassphraseResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Cursor
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-type QueryFilter struct {
+// ExportMnemonicRequest asks for the recovery phrase of the account: 24 BIP39
+// words encoding the account key. Init restores the account from it on a new
+// device (see InitRequest.mnemonic), so it must be kept secret.
+type ExportMnemonicRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`       // Metadata field name
-	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"` // "eq", "ne", "gt", "gte", "lt", "lte", "contains"
-	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`       // Filter value
+	Passphrase    string                 `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"` // Required if the account key is passphrase-protected
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *QueryFilter) Reset() {
-	*x = QueryFilter{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[30]
+func (x *ExportMnemonicRequest) Reset() {
+	*x = ExportMnemonicRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueryFilter) String() string {
+func (x *ExportMnemonicRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryFilter) ProtoMessage() {}
+func (*ExportMnemonicRequest) ProtoMessage() {}
 
-func (x *QueryFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[30]
+func (x *ExportMnemonicRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1776,55 +5481,40 @@ func (x *QueryFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryFilter.ProtoReflect.Descriptor instead.
-func (*QueryFilter) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{30}
-}
-
-func (x *QueryFilter) GetField() string {
-	if x != nil {
-		return x.Field
-	}
-	return ""
-}
-
-func (x *QueryFilter) GetOperator() string {
-	if x != nil {
-		return x.Operator
-	}
-	return ""
+// Deprecated: Use ExportMnemonicRequest.ProtoReflect.Descriptor instead.
+func (*ExportMnemonicRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *QueryFilter) GetValue() string {
+func (x *ExportMnemonicRequest) GetPassphrase() string {
 	if x != nil {
-		return x.Value
+		return x.Passphrase
 	}
 	return ""
 }
 
-type QueryDocumentsResponse struct {
+type ExportMnemonicResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Documents     []*DocumentInfo        `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
-	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	Mnemonic      string                 `protobuf:"bytes,1,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *QueryDocumentsResponse) Reset() {
-	*x = QueryDocumentsResponse{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[31]
+func (x *ExportMnemonicResponse) Reset() {
+	*x = ExportMnemonicResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueryDocumentsResponse) String() string {
+func (x *ExportMnemonicResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryDocumentsResponse) ProtoMessage() {}
+func (*ExportMnemonicResponse) ProtoMessage() {}
 
-func (x *QueryDocumentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[31]
+func (x *ExportMnemonicResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1835,47 +5525,44 @@ func (x *QueryDocumentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryDocumentsResponse.ProtoReflect.Descriptor instead.
-func (*QueryDocumentsResponse) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{31}
-}
-
-func (x *QueryDocumentsResponse) GetDocuments() []*DocumentInfo {
-	if x != nil {
-		return x.Documents
-	}
-	return nil
+// Deprecated: Use ExportMnemonicResponse.ProtoReflect.Descriptor instead.
+func (*ExportMnemonicResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{91}
 }
 
-func (x *QueryDocumentsResponse) GetNextCursor() string {
+func (x *ExportMnemonicResponse) GetMnemonic() string {
 	if x != nil {
-		return x.NextCursor
+		return x.Mnemonic
 	}
 	return ""
 }
 
-type StartSyncRequest struct {
+// LockRequest locks the session: the account keys are cleared from memory
+// and the spaces are closed, after the running commands finish. Until
+// Unlock, other data commands fail with ERROR_CODE_LOCKED. Event
+// subscriptions stay open and receive account.locked. The session also locks
+// itself after BackendConfig.auto_lock_sec without commands.
+type LockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"` // Space to sync (empty = all spaces)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartSyncRequest) Reset() {
-	*x = StartSyncRequest{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[32]
+func (x *LockRequest) Reset() {
+	*x = LockRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartSyncRequest) String() string {
+func (x *LockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartSyncRequest) ProtoMessage() {}
+func (*LockRequest) ProtoMessage() {}
 
-func (x *StartSyncRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[32]
+func (x *LockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1886,40 +5573,33 @@ func (x *StartSyncRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartSyncRequest.ProtoReflect.Descriptor instead.
-func (*StartSyncRequest) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{32}
-}
-
-func (x *StartSyncRequest) GetSpaceId() string {
-	if x != nil {
-		return x.SpaceId
-	}
-	return ""
+// Deprecated: Use LockRequest.ProtoReflect.Descriptor instead.
+func (*LockRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{92}
 }
 
-type StartSyncResponse struct {
+type LockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartSyncResponse) Reset() {
-	*x = StartSyncResponse{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[33]
+func (x *LockResponse) Reset() {
+	*x = LockResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartSyncResponse) String() string {
+func (x *LockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartSyncResponse) ProtoMessage() {}
+func (*LockResponse) ProtoMessage() {}
 
-func (x *StartSyncResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[33]
+func (x *LockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1930,40 +5610,41 @@ func (x *StartSyncResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartSyncResponse.ProtoReflect.Descriptor instead.
-func (*StartSyncResponse) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use LockResponse.ProtoReflect.Descriptor instead.
+func (*LockResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *StartSyncResponse) GetSuccess() bool {
+func (x *LockResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-type PauseSyncRequest struct {
+// UnlockRequest opens the account keys again and emits account.unlocked.
+type UnlockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"` // Space to pause (empty = all spaces)
+	Passphrase    string                 `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"` // Required if the account key is passphrase-protected
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PauseSyncRequest) Reset() {
-	*x = PauseSyncRequest{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[34]
+func (x *UnlockRequest) Reset() {
+	*x = UnlockRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PauseSyncRequest) String() string {
+func (x *UnlockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PauseSyncRequest) ProtoMessage() {}
+func (*UnlockRequest) ProtoMessage() {}
 
-func (x *PauseSyncRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[34]
+func (x *UnlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1974,40 +5655,40 @@ func (x *PauseSyncRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PauseSyncRequest.ProtoReflect.Descriptor instead.
-func (*PauseSyncRequest) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use UnlockRequest.ProtoReflect.Descriptor instead.
+func (*UnlockRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *PauseSyncRequest) GetSpaceId() string {
+func (x *UnlockRequest) GetPassphrase() string {
 	if x != nil {
-		return x.SpaceId
+		return x.Passphrase
 	}
 	return ""
 }
 
-type PauseSyncResponse struct {
+type UnlockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PauseSyncResponse) Reset() {
-	*x = PauseSyncResponse{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[35]
+func (x *UnlockResponse) Reset() {
+	*x = UnlockResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PauseSyncResponse) String() string {
+func (x *UnlockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PauseSyncResponse) ProtoMessage() {}
+func (*UnlockResponse) ProtoMessage() {}
 
-func (x *PauseSyncResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[35]
+func (x *UnlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2018,40 +5699,46 @@ func (x *PauseSyncResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PauseSyncResponse.ProtoReflect.Descriptor instead.
-func (*PauseSyncResponse) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use UnlockResponse.ProtoReflect.Descriptor instead.
+func (*UnlockResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *PauseSyncResponse) GetSuccess() bool {
+func (x *UnlockResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-type GetSyncStatusRequest struct {
+// DeleteAccountRequest removes the account from this device. The backend
+// shuts down first, like Shutdown. Then the keys are deleted from the key
+// store, and the space storages, the document and space metadata and the
+// device registry are removed from the data directory. Files are overwritten
+// before removal, on a best-effort basis. Init afterwards creates a new
+// account. With profiles, the active profile is wiped but not deleted.
+type DeleteAccountRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"` // Space to query (empty = all spaces)
+	Passphrase    string                 `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"` // Required if the account key is passphrase-protected
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSyncStatusRequest) Reset() {
-	*x = GetSyncStatusRequest{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[36]
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSyncStatusRequest) String() string {
+func (x *DeleteAccountRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSyncStatusRequest) ProtoMessage() {}
+func (*DeleteAccountRequest) ProtoMessage() {}
 
-func (x *GetSyncStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[36]
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2062,40 +5749,42 @@ func (x *GetSyncStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSyncStatusRequest.ProtoReflect.Descriptor instead.
-func (*GetSyncStatusRequest) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *GetSyncStatusRequest) GetSpaceId() string {
+func (x *DeleteAccountRequest) GetPassphrase() string {
 	if x != nil {
-		return x.SpaceId
+		return x.Passphrase
 	}
 	return ""
 }
 
-type GetSyncStatusResponse struct {
+type DeleteAccountResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Statuses      []*SpaceSyncStatus     `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	RemovedFiles  []string               `protobuf:"bytes,1,rep,name=removed_files,json=removedFiles,proto3" json:"removed_files,omitempty"`  // Relative to the data directory, sorted
+	RemovedKeys   []string               `protobuf:"bytes,2,rep,name=removed_keys,json=removedKeys,proto3" json:"removed_keys,omitempty"`     // Names removed from the key store, e.g. "account.key"
+	RemovedBytes  int64                  `protobuf:"varint,3,opt,name=removed_bytes,json=removedBytes,proto3" json:"removed_bytes,omitempty"` // Total size of the removed files
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSyncStatusResponse) Reset() {
-	*x = GetSyncStatusResponse{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[37]
+func (x *DeleteAccountResponse) Reset() {
+	*x = DeleteAccountResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSyncStatusResponse) String() string {
+func (x *DeleteAccountResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSyncStatusResponse) ProtoMessage() {}
+func (*DeleteAccountResponse) ProtoMessage() {}
 
-func (x *GetSyncStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[37]
+func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2106,44 +5795,61 @@ func (x *GetSyncStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSyncStatusResponse.ProtoReflect.Descriptor instead.
-func (*GetSyncStatusResponse) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use DeleteAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAccountResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *GetSyncStatusResponse) GetStatuses() []*SpaceSyncStatus {
+func (x *DeleteAccountResponse) GetRemovedFiles() []string {
 	if x != nil {
-		return x.Statuses
+		return x.RemovedFiles
 	}
 	return nil
 }
 
-type SpaceSyncStatus struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	SpaceId        string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
-	Status         SyncStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=syncspace.v1.SyncStatus" json:"status,omitempty"`
-	LastSyncAt     int64                  `protobuf:"varint,3,opt,name=last_sync_at,json=lastSyncAt,proto3" json:"last_sync_at,omitempty"`           // Unix timestamp
-	PendingChanges int32                  `protobuf:"varint,4,opt,name=pending_changes,json=pendingChanges,proto3" json:"pending_changes,omitempty"` // Number of local changes not yet synced
-	Error          string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                                          // Last sync error (empty if no error)
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *DeleteAccountResponse) GetRemovedKeys() []string {
+	if x != nil {
+		return x.RemovedKeys
+	}
+	return nil
 }
 
-func (x *SpaceSyncStatus) Reset() {
-	*x = SpaceSyncStatus{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[38]
+func (x *DeleteAccountResponse) GetRemovedBytes() int64 {
+	if x != nil {
+		return x.RemovedBytes
+	}
+	return 0
+}
+
+type DeviceInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PeerId        string                 `protobuf:"bytes,3,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`                // Peer ID of the current device key
+	PeerKey       []byte                 `protobuf:"bytes,4,opt,name=peer_key,json=peerKey,proto3" json:"peer_key,omitempty"`             // Marshalled public device key
+	AddedAt       int64                  `protobuf:"varint,5,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`            // Unix timestamp
+	LastSeenAt    int64                  `protobuf:"varint,6,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"` // Unix timestamp of the last Init on the device
+	RevokedAt     int64                  `protobuf:"varint,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`      // Unix timestamp; 0 while the device is active
+	Current       bool                   `protobuf:"varint,8,opt,name=current,proto3" json:"current,omitempty"`                           // This is the device the backend runs on
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceInfo) Reset() {
+	*x = DeviceInfo{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SpaceSyncStatus) String() string {
+func (x *DeviceInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SpaceSyncStatus) ProtoMessage() {}
+func (*DeviceInfo) ProtoMessage() {}
 
-func (x *SpaceSyncStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[38]
+func (x *DeviceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2154,69 +5860,169 @@ func (x *SpaceSyncStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SpaceSyncStatus.ProtoReflect.Descriptor instead.
-func (*SpaceSyncStatus) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use DeviceInfo.ProtoReflect.Descriptor instead.
+func (*DeviceInfo) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *SpaceSyncStatus) GetSpaceId() string {
+func (x *DeviceInfo) GetDeviceId() string {
 	if x != nil {
-		return x.SpaceId
+		return x.DeviceId
 	}
 	return ""
 }
 
-func (x *SpaceSyncStatus) GetStatus() SyncStatus {
+func (x *DeviceInfo) GetName() string {
 	if x != nil {
-		return x.Status
+		return x.Name
 	}
-	return SyncStatus_SYNC_STATUS_UNSPECIFIED
+	return ""
 }
 
-func (x *SpaceSyncStatus) GetLastSyncAt() int64 {
+func (x *DeviceInfo) GetPeerId() string {
 	if x != nil {
-		return x.LastSyncAt
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *DeviceInfo) GetPeerKey() []byte {
+	if x != nil {
+		return x.PeerKey
+	}
+	return nil
+}
+
+func (x *DeviceInfo) GetAddedAt() int64 {
+	if x != nil {
+		return x.AddedAt
 	}
 	return 0
 }
 
-func (x *SpaceSyncStatus) GetPendingChanges() int32 {
+func (x *DeviceInfo) GetLastSeenAt() int64 {
 	if x != nil {
-		return x.PendingChanges
+		return x.LastSeenAt
+	}
+	return 0
+}
+
+func (x *DeviceInfo) GetRevokedAt() int64 {
+	if x != nil {
+		return x.RevokedAt
 	}
 	return 0
 }
 
-func (x *SpaceSyncStatus) GetError() string {
+func (x *DeviceInfo) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{99}
+}
+
+type ListDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*DeviceInfo          `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"` // By date added
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*DeviceInfo {
 	if x != nil {
-		return x.Error
+		return x.Devices
 	}
-	return ""
+	return nil
 }
 
-type SubscribeRequest struct {
+// RotateDeviceKeyRequest replaces the key of this device with a new one.
+type RotateDeviceKeyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	EventTypes    []string               `protobuf:"bytes,1,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"` // Event types to subscribe to (empty = all)
-	SpaceIds      []string               `protobuf:"bytes,2,rep,name=space_ids,json=spaceIds,proto3" json:"space_ids,omitempty"`       // Space IDs to filter by (empty = all)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubscribeRequest) Reset() {
-	*x = SubscribeRequest{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[39]
+func (x *RotateDeviceKeyRequest) Reset() {
+	*x = RotateDeviceKeyRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscribeRequest) String() string {
+func (x *RotateDeviceKeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeRequest) ProtoMessage() {}
+func (*RotateDeviceKeyRequest) ProtoMessage() {}
 
-func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[39]
+func (x *RotateDeviceKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2227,51 +6033,79 @@ func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
-func (*SubscribeRequest) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use RotateDeviceKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateDeviceKeyRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{101}
 }
 
-func (x *SubscribeRequest) GetEventTypes() []string {
+type RotateDeviceKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *DeviceInfo            `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateDeviceKeyResponse) Reset() {
+	*x = RotateDeviceKeyResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateDeviceKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateDeviceKeyResponse) ProtoMessage() {}
+
+func (x *RotateDeviceKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[102]
 	if x != nil {
-		return x.EventTypes
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *SubscribeRequest) GetSpaceIds() []string {
+// Deprecated: Use RotateDeviceKeyResponse.ProtoReflect.Descriptor instead.
+func (*RotateDeviceKeyResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *RotateDeviceKeyResponse) GetDevice() *DeviceInfo {
 	if x != nil {
-		return x.SpaceIds
+		return x.Device
 	}
 	return nil
 }
 
-type SubscribeResponse struct {
+// RevokeDeviceRequest revokes another device: Init fails on it with
+// ERROR_CODE_PERMISSION_DENIED. This device cannot be revoked.
+type RevokeDeviceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
-	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // "document.created", "document.updated", "sync.status_changed", etc.
-	SpaceId       string                 `protobuf:"bytes,3,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp
-	Payload       []byte                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`      // Event-specific data
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubscribeResponse) Reset() {
-	*x = SubscribeResponse{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[40]
+func (x *RevokeDeviceRequest) Reset() {
+	*x = RevokeDeviceRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscribeResponse) String() string {
+func (x *RevokeDeviceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeResponse) ProtoMessage() {}
+func (*RevokeDeviceRequest) ProtoMessage() {}
 
-func (x *SubscribeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[40]
+func (x *RevokeDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2282,69 +6116,86 @@ func (x *SubscribeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeResponse.ProtoReflect.Descriptor instead.
-func (*SubscribeResponse) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use RevokeDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RevokeDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{103}
 }
 
-func (x *SubscribeResponse) GetEventId() string {
+func (x *RevokeDeviceRequest) GetDeviceId() string {
 	if x != nil {
-		return x.EventId
+		return x.DeviceId
 	}
 	return ""
 }
 
-func (x *SubscribeResponse) GetEventType() string {
-	if x != nil {
-		return x.EventType
-	}
-	return ""
+type RevokeDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *DeviceInfo            `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubscribeResponse) GetSpaceId() string {
-	if x != nil {
-		return x.SpaceId
-	}
-	return ""
+func (x *RevokeDeviceResponse) Reset() {
+	*x = RevokeDeviceResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscribeResponse) GetTimestamp() int64 {
+func (x *RevokeDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeDeviceResponse) ProtoMessage() {}
+
+func (x *RevokeDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[104]
 	if x != nil {
-		return x.Timestamp
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *SubscribeResponse) GetPayload() []byte {
+// Deprecated: Use RevokeDeviceResponse.ProtoReflect.Descriptor instead.
+func (*RevokeDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *RevokeDeviceResponse) GetDevice() *DeviceInfo {
 	if x != nil {
-		return x.Payload
+		return x.Device
 	}
 	return nil
 }
 
-type DocumentCreatedEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
-	Collection    string                 `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// ExportAccountRequest asks for the account bundle.
+type ExportAccountRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Passphrase        string                 `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`                                        // Encrypts the bundle; required
+	AccountPassphrase string                 `protobuf:"bytes,2,opt,name=account_passphrase,json=accountPassphrase,proto3" json:"account_passphrase,omitempty"` // Required if the account key is passphrase-protected
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *DocumentCreatedEvent) Reset() {
-	*x = DocumentCreatedEvent{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[41]
+func (x *ExportAccountRequest) Reset() {
+	*x = ExportAccountRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DocumentCreatedEvent) String() string {
+func (x *ExportAccountRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DocumentCreatedEvent) ProtoMessage() {}
+func (*ExportAccountRequest) ProtoMessage() {}
 
-func (x *DocumentCreatedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[41]
+func (x *ExportAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2355,49 +6206,47 @@ func (x *DocumentCreatedEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DocumentCreatedEvent.ProtoReflect.Descriptor instead.
-func (*DocumentCreatedEvent) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use ExportAccountRequest.ProtoReflect.Descriptor instead.
+func (*ExportAccountRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{105}
 }
 
-func (x *DocumentCreatedEvent) GetDocumentId() string {
+func (x *ExportAccountRequest) GetPassphrase() string {
 	if x != nil {
-		return x.DocumentId
+		return x.Passphrase
 	}
 	return ""
 }
 
-func (x *DocumentCreatedEvent) GetCollection() string {
+func (x *ExportAccountRequest) GetAccountPassphrase() string {
 	if x != nil {
-		return x.Collection
+		return x.AccountPassphrase
 	}
 	return ""
 }
 
-type DocumentUpdatedEvent struct {
+type ExportAccountResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
-	OldVersion    int64                  `protobuf:"varint,2,opt,name=old_version,json=oldVersion,proto3" json:"old_version,omitempty"`
-	NewVersion    int64                  `protobuf:"varint,3,opt,name=new_version,json=newVersion,proto3" json:"new_version,omitempty"`
+	Bundle        []byte                 `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"` // Versioned and encrypted; keep it secret
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DocumentUpdatedEvent) Reset() {
-	*x = DocumentUpdatedEvent{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[42]
+func (x *ExportAccountResponse) Reset() {
+	*x = ExportAccountResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DocumentUpdatedEvent) String() string {
+func (x *ExportAccountResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DocumentUpdatedEvent) ProtoMessage() {}
+func (*ExportAccountResponse) ProtoMessage() {}
 
-func (x *DocumentUpdatedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[42]
+func (x *ExportAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2408,54 +6257,47 @@ func (x *DocumentUpdatedEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DocumentUpdatedEvent.ProtoReflect.Descriptor instead.
-func (*DocumentUpdatedEvent) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{42}
-}
-
-func (x *DocumentUpdatedEvent) GetDocumentId() string {
-	if x != nil {
-		return x.DocumentId
-	}
-	return ""
-}
-
-func (x *DocumentUpdatedEvent) GetOldVersion() int64 {
-	if x != nil {
-		return x.OldVersion
-	}
-	return 0
+// Deprecated: Use ExportAccountResponse.ProtoReflect.Descriptor instead.
+func (*ExportAccountResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{106}
 }
 
-func (x *DocumentUpdatedEvent) GetNewVersion() int64 {
+func (x *ExportAccountResponse) GetBundle() []byte {
 	if x != nil {
-		return x.NewVersion
+		return x.Bundle
 	}
-	return 0
+	return nil
 }
 
-type DocumentDeletedEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// ImportAccountRequest prepares a data directory from an account bundle. It
+// runs before Init, which then opens the account with a new device key. The
+// data directory must not hold an account yet.
+type ImportAccountRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	DataDir           string                 `protobuf:"bytes,1,opt,name=data_dir,json=dataDir,proto3" json:"data_dir,omitempty"`
+	Bundle            []byte                 `protobuf:"bytes,2,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	Passphrase        string                 `protobuf:"bytes,3,opt,name=passphrase,proto3" json:"passphrase,omitempty"`                                        // The bundle passphrase; ERROR_CODE_LOCKED if wrong
+	AccountPassphrase string                 `protobuf:"bytes,4,opt,name=account_passphrase,json=accountPassphrase,proto3" json:"account_passphrase,omitempty"` // Protects the imported account key, like InitRequest.passphrase
+	ProfileId         string                 `protobuf:"bytes,5,opt,name=profile_id,json=profileId,proto3" json:"profile_id,omitempty"`                         // With profiles, imports into this closed profile instead of data_dir
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *DocumentDeletedEvent) Reset() {
-	*x = DocumentDeletedEvent{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[43]
+func (x *ImportAccountRequest) Reset() {
+	*x = ImportAccountRequest{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DocumentDeletedEvent) String() string {
+func (x *ImportAccountRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DocumentDeletedEvent) ProtoMessage() {}
+func (*ImportAccountRequest) ProtoMessage() {}
 
-func (x *DocumentDeletedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[43]
+func (x *ImportAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2466,42 +6308,70 @@ func (x *DocumentDeletedEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DocumentDeletedEvent.ProtoReflect.Descriptor instead.
-func (*DocumentDeletedEvent) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use ImportAccountRequest.ProtoReflect.Descriptor instead.
+func (*ImportAccountRequest) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{107}
 }
 
-func (x *DocumentDeletedEvent) GetDocumentId() string {
+func (x *ImportAccountRequest) GetDataDir() string {
 	if x != nil {
-		return x.DocumentId
+		return x.DataDir
 	}
 	return ""
 }
 
-type SyncStatusChangedEvent struct {
+func (x *ImportAccountRequest) GetBundle() []byte {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+func (x *ImportAccountRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+func (x *ImportAccountRequest) GetAccountPassphrase() string {
+	if x != nil {
+		return x.AccountPassphrase
+	}
+	return ""
+}
+
+func (x *ImportAccountRequest) GetProfileId() string {
+	if x != nil {
+		return x.ProfileId
+	}
+	return ""
+}
+
+type ImportAccountResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OldStatus     SyncStatus             `protobuf:"varint,1,opt,name=old_status,json=oldStatus,proto3,enum=syncspace.v1.SyncStatus" json:"old_status,omitempty"`
-	NewStatus     SyncStatus             `protobuf:"varint,2,opt,name=new_status,json=newStatus,proto3,enum=syncspace.v1.SyncStatus" json:"new_status,omitempty"`
-	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	SpaceCount    int32                  `protobuf:"varint,1,opt,name=space_count,json=spaceCount,proto3" json:"space_count,omitempty"`    // Spaces imported
+	DeviceCount   int32                  `protobuf:"varint,2,opt,name=device_count,json=deviceCount,proto3" json:"device_count,omitempty"` // Devices in the imported registry
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`       // Unix timestamp the bundle was exported at
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SyncStatusChangedEvent) Reset() {
-	*x = SyncStatusChangedEvent{}
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[44]
+func (x *ImportAccountResponse) Reset() {
+	*x = ImportAccountResponse{}
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SyncStatusChangedEvent) String() string {
+func (x *ImportAccountResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SyncStatusChangedEvent) ProtoMessage() {}
+func (*ImportAccountResponse) ProtoMessage() {}
 
-func (x *SyncStatusChangedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_syncspace_v1_syncspace_proto_msgTypes[44]
+func (x *ImportAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_syncspace_v1_syncspace_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2512,30 +6382,30 @@ func (x *SyncStatusChangedEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SyncStatusChangedEvent.ProtoReflect.Descriptor instead.
-func (*SyncStatusChangedEvent) Descriptor() ([]byte, []int) {
-	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{44}
+// Deprecated: Use ImportAccountResponse.ProtoReflect.Descriptor instead.
+func (*ImportAccountResponse) Descriptor() ([]byte, []int) {
+	return file_syncspace_v1_syncspace_proto_rawDescGZIP(), []int{108}
 }
 
-func (x *SyncStatusChangedEvent) GetOldStatus() SyncStatus {
+func (x *ImportAccountResponse) GetSpaceCount() int32 {
 	if x != nil {
-		return x.OldStatus
+		return x.SpaceCount
 	}
-	return SyncStatus_SYNC_STATUS_UNSPECIFIED
+	return 0
 }
 
-func (x *SyncStatusChangedEvent) GetNewStatus() SyncStatus {
+func (x *ImportAccountResponse) GetDeviceCount() int32 {
 	if x != nil {
-		return x.NewStatus
+		return x.DeviceCount
 	}
-	return SyncStatus_SYNC_STATUS_UNSPECIFIED
+	return 0
 }
 
-func (x *SyncStatusChangedEvent) GetError() string {
+func (x *ImportAccountResponse) GetCreatedAt() int64 {
 	if x != nil {
-		return x.Error
+		return x.CreatedAt
 	}
-	return ""
+	return 0
 }
 
 var File_syncspace_v1_syncspace_proto protoreflect.FileDescriptor
@@ -2545,24 +6415,43 @@ const file_syncspace_v1_syncspace_proto_rawDesc = "" +
 	"\x1csyncspace/v1/syncspace.proto\x12\fsyncspace.v1\"7\n" +
 	"\aCommand\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
-	"\apayload\x18\x02 \x01(\fR\apayload\"A\n" +
+	"\apayload\x18\x02 \x01(\fR\apayload\"\x80\x01\n" +
 	"\x0fCommandResponse\x12\x18\n" +
 	"\apayload\x18\x01 \x01(\fR\apayload\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"\xde\x01\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12=\n" +
+	"\ferror_detail\x18\x03 \x01(\v2\x1a.syncspace.v1.CommandErrorR\verrorDetail\"\xb2\x04\n" +
 	"\vInitRequest\x12\x19\n" +
 	"\bdata_dir\x18\x01 \x01(\tR\adataDir\x12\x1d\n" +
 	"\n" +
 	"network_id\x18\x02 \x01(\tR\tnetworkId\x12\x1b\n" +
 	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\x12=\n" +
-	"\x06config\x18\x04 \x03(\v2%.syncspace.v1.InitRequest.ConfigEntryR\x06config\x1a9\n" +
+	"\x06config\x18\x04 \x03(\v2%.syncspace.v1.InitRequest.ConfigEntryR\x06config\x12,\n" +
+	"\x12command_timeout_ms\x18\x05 \x01(\x03R\x10commandTimeoutMs\x12`\n" +
+	"\x13command_timeouts_ms\x18\x06 \x03(\v20.syncspace.v1.InitRequest.CommandTimeoutsMsEntryR\x11commandTimeoutsMs\x12\x1f\n" +
+	"\vconfig_json\x18\a \x01(\tR\n" +
+	"configJson\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\b \x01(\tR\n" +
+	"passphrase\x12\x1a\n" +
+	"\bmnemonic\x18\t \x01(\tR\bmnemonic\x12\x1f\n" +
+	"\vdevice_name\x18\n" +
+	" \x01(\tR\n" +
+	"deviceName\x1a9\n" +
 	"\vConfigEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"(\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aD\n" +
+	"\x16CommandTimeoutsMsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"D\n" +
 	"\fInitResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x11\n" +
-	"\x0fShutdownRequest\",\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1a\n" +
+	"\brestored\x18\x02 \x01(\bR\brestored\"0\n" +
+	"\x0fShutdownRequest\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x01 \x01(\x03R\ttimeoutMs\"D\n" +
 	"\x10ShutdownResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xcc\x01\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06forced\x18\x02 \x01(\bR\x06forced\"\xcc\x01\n" +
 	"\x12CreateSpaceRequest\x12\x19\n" +
 	"\bspace_id\x18\x01 \x01(\tR\aspaceId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12J\n" +
@@ -2571,19 +6460,60 @@ const file_syncspace_v1_syncspace_proto_rawDesc = "" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"0\n" +
 	"\x13CreateSpaceResponse\x12\x19\n" +
-	"\bspace_id\x18\x01 \x01(\tR\aspaceId\"P\n" +
+	"\bspace_id\x18\x01 \x01(\tR\aspaceId\"\xc0\x01\n" +
+	"\x13CreateInviteRequest\x12\x19\n" +
+	"\bspace_id\x18\x01 \x01(\tR\aspaceId\x12=\n" +
+	"\n" +
+	"permission\x18\x02 \x01(\x0e2\x1d.syncspace.v1.SpacePermissionR\n" +
+	"permission\x12$\n" +
+	"\x0eexpires_in_sec\x18\x03 \x01(\x03R\fexpiresInSec\x12)\n" +
+	"\x10require_approval\x18\x04 \x01(\bR\x0frequireApproval\"u\n" +
+	"\x14CreateInviteResponse\x12!\n" +
+	"\finvite_token\x18\x01 \x01(\tR\vinviteToken\x12\x1b\n" +
+	"\tinvite_id\x18\x02 \x01(\tR\binviteId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\x03R\texpiresAt\"P\n" +
 	"\x10JoinSpaceRequest\x12\x19\n" +
 	"\bspace_id\x18\x01 \x01(\tR\aspaceId\x12!\n" +
-	"\finvite_token\x18\x02 \x01(\tR\vinviteToken\"-\n" +
+	"\finvite_token\x18\x02 \x01(\tR\vinviteToken\"b\n" +
 	"\x11JoinSpaceResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\".\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x19\n" +
+	"\bspace_id\x18\x02 \x01(\tR\aspaceId\x12\x18\n" +
+	"\apending\x18\x03 \x01(\bR\apending\"/\n" +
+	"\x12ListMembersRequest\x12\x19\n" +
+	"\bspace_id\x18\x01 \x01(\tR\aspaceId\"J\n" +
+	"\x13ListMembersResponse\x123\n" +
+	"\amembers\x18\x01 \x03(\v2\x19.syncspace.v1.SpaceMemberR\amembers\"\x91\x01\n" +
+	"\x19ApproveJoinRequestRequest\x12\x19\n" +
+	"\bspace_id\x18\x01 \x01(\tR\aspaceId\x12\x1a\n" +
+	"\bidentity\x18\x02 \x01(\tR\bidentity\x12=\n" +
+	"\n" +
+	"permission\x18\x03 \x01(\x0e2\x1d.syncspace.v1.SpacePermissionR\n" +
+	"permission\"O\n" +
+	"\x1aApproveJoinRequestResponse\x121\n" +
+	"\x06member\x18\x01 \x01(\v2\x19.syncspace.v1.SpaceMemberR\x06member\"L\n" +
+	"\x13RemoveMemberRequest\x12\x19\n" +
+	"\bspace_id\x18\x01 \x01(\tR\aspaceId\x12\x1a\n" +
+	"\bidentity\x18\x02 \x01(\tR\bidentity\"0\n" +
+	"\x14RemoveMemberResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x95\x01\n" +
+	"\x1dChangeMemberPermissionRequest\x12\x19\n" +
+	"\bspace_id\x18\x01 \x01(\tR\aspaceId\x12\x1a\n" +
+	"\bidentity\x18\x02 \x01(\tR\bidentity\x12=\n" +
+	"\n" +
+	"permission\x18\x03 \x01(\x0e2\x1d.syncspace.v1.SpacePermissionR\n" +
+	"permission\"S\n" +
+	"\x1eChangeMemberPermissionResponse\x121\n" +
+	"\x06member\x18\x01 \x01(\v2\x19.syncspace.v1.SpaceMemberR\x06member\"Q\n" +
 	"\x11LeaveSpaceRequest\x12\x19\n" +
-	"\bspace_id\x18\x01 \x01(\tR\aspaceId\".\n" +
+	"\bspace_id\x18\x01 \x01(\tR\aspaceId\x12!\n" +
+	"\fdelete_local\x18\x02 \x01(\bR\vdeleteLocal\"K\n" +
 	"\x12LeaveSpaceResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x13\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1b\n" +
+	"\tacl_error\x18\x02 \x01(\tR\baclError\"\x13\n" +
 	"\x11ListSpacesRequest\"E\n" +
 	"\x12ListSpacesResponse\x12/\n" +
-	"\x06spaces\x18\x01 \x03(\v2\x17.syncspace.v1.SpaceInfoR\x06spaces\"\xb3\x02\n" +
+	"\x06spaces\x18\x01 \x03(\v2\x17.syncspace.v1.SpaceInfoR\x06spaces\"\xe2\x02\n" +
 	"\tSpaceInfo\x12\x19\n" +
 	"\bspace_id\x18\x01 \x01(\tR\aspaceId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12A\n" +
@@ -2593,10 +6523,33 @@ const file_syncspace_v1_syncspace_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\x129\n" +
 	"\vsync_status\x18\x06 \x01(\x0e2\x18.syncspace.v1.SyncStatusR\n" +
-	"syncStatus\x1a;\n" +
+	"syncStatus\x12\x14\n" +
+	"\x05alias\x18\a \x01(\tR\x05alias\x12\x17\n" +
+	"\aleft_at\x18\b \x01(\x03R\x06leftAt\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd8\x01\n" +
+	"\vSpaceMember\x12\x1a\n" +
+	"\bidentity\x18\x01 \x01(\tR\bidentity\x12=\n" +
+	"\n" +
+	"permission\x18\x02 \x01(\x0e2\x1d.syncspace.v1.SpacePermissionR\n" +
+	"permission\x122\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1a.syncspace.v1.MemberStatusR\x06status\x12\x1b\n" +
+	"\tjoined_at\x18\x04 \x01(\x03R\bjoinedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\"\xb5\x02\n" +
+	"\x12UpdateSpaceRequest\x12\x19\n" +
+	"\bspace_id\x18\x01 \x01(\tR\aspaceId\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12J\n" +
+	"\bmetadata\x18\x03 \x03(\v2..syncspace.v1.UpdateSpaceRequest.MetadataEntryR\bmetadata\x12)\n" +
+	"\x10replace_metadata\x18\x04 \x01(\bR\x0freplaceMetadata\x12.\n" +
+	"\x13expected_updated_at\x18\x05 \x01(\x03R\x11expectedUpdatedAt\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"/\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
+	"\x05_name\"D\n" +
+	"\x13UpdateSpaceResponse\x12-\n" +
+	"\x05space\x18\x01 \x01(\v2\x17.syncspace.v1.SpaceInfoR\x05space\"/\n" +
 	"\x12DeleteSpaceRequest\x12\x19\n" +
 	"\bspace_id\x18\x01 \x01(\tR\aspaceId\"/\n" +
 	"\x13DeleteSpaceResponse\x12\x18\n" +
@@ -2746,29 +6699,275 @@ const file_syncspace_v1_syncspace_proto_rawDesc = "" +
 	"newVersion\"7\n" +
 	"\x14DocumentDeletedEvent\x12\x1f\n" +
 	"\vdocument_id\x18\x01 \x01(\tR\n" +
-	"documentId\"\xa0\x01\n" +
+	"documentId\"\xbf\x03\n" +
+	"\x11SpaceUpdatedEvent\x12\x19\n" +
+	"\bold_name\x18\x01 \x01(\tR\aoldName\x12\x19\n" +
+	"\bnew_name\x18\x02 \x01(\tR\anewName\x12S\n" +
+	"\fold_metadata\x18\x03 \x03(\v20.syncspace.v1.SpaceUpdatedEvent.OldMetadataEntryR\voldMetadata\x12S\n" +
+	"\fnew_metadata\x18\x04 \x03(\v20.syncspace.v1.SpaceUpdatedEvent.NewMetadataEntryR\vnewMetadata\x12$\n" +
+	"\x0eold_updated_at\x18\x05 \x01(\x03R\foldUpdatedAt\x12$\n" +
+	"\x0enew_updated_at\x18\x06 \x01(\x03R\fnewUpdatedAt\x1a>\n" +
+	"\x10OldMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10NewMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8d\x01\n" +
+	"\x10SpaceMemberEvent\x12\x1a\n" +
+	"\bidentity\x18\x01 \x01(\tR\bidentity\x12\x1e\n" +
+	"\n" +
+	"permission\x18\x02 \x01(\tR\n" +
+	"permission\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12%\n" +
+	"\x0eold_permission\x18\x04 \x01(\tR\roldPermission\"\xa0\x01\n" +
 	"\x16SyncStatusChangedEvent\x127\n" +
 	"\n" +
 	"old_status\x18\x01 \x01(\x0e2\x18.syncspace.v1.SyncStatusR\toldStatus\x127\n" +
 	"\n" +
 	"new_status\x18\x02 \x01(\x0e2\x18.syncspace.v1.SyncStatusR\tnewStatus\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error*\x87\x01\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"Y\n" +
+	"\fBatchRequest\x121\n" +
+	"\bcommands\x18\x01 \x03(\v2\x15.syncspace.v1.CommandR\bcommands\x12\x16\n" +
+	"\x06atomic\x18\x02 \x01(\bR\x06atomic\"H\n" +
+	"\rBatchResponse\x127\n" +
+	"\aresults\x18\x01 \x03(\v2\x1d.syncspace.v1.CommandResponseR\aresults\"\x19\n" +
+	"\x17DescribeCommandsRequest\"\xa6\x01\n" +
+	"\x18DescribeCommandsResponse\x125\n" +
+	"\bcommands\x18\x01 \x03(\v2\x19.syncspace.v1.CommandInfoR\bcommands\x12.\n" +
+	"\x13file_descriptor_set\x18\x02 \x01(\fR\x11fileDescriptorSet\x12#\n" +
+	"\rschema_digest\x18\x03 \x01(\tR\fschemaDigest\"\x87\x01\n" +
+	"\vCommandInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\frequest_type\x18\x02 \x01(\tR\vrequestType\x12#\n" +
+	"\rresponse_type\x18\x03 \x01(\tR\fresponseType\x12\x1c\n" +
+	"\tstreaming\x18\x04 \x01(\bR\tstreaming\"\xd4\x01\n" +
+	"\fCommandError\x12+\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x17.syncspace.v1.ErrorCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12A\n" +
+	"\adetails\x18\x03 \x03(\v2'.syncspace.v1.CommandError.DetailsEntryR\adetails\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa6\x01\n" +
+	"\rStreamMessage\x12\x1b\n" +
+	"\tstream_id\x18\x01 \x01(\tR\bstreamId\x12\x18\n" +
+	"\acommand\x18\x02 \x01(\tR\acommand\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\fR\apayload\x12\x12\n" +
+	"\x04done\x18\x04 \x01(\bR\x04done\x120\n" +
+	"\x05error\x18\x05 \x01(\v2\x1a.syncspace.v1.CommandErrorR\x05error\"\x8b\x01\n" +
+	"\vProfileInfo\x12\x1d\n" +
+	"\n" +
+	"profile_id\x18\x01 \x01(\tR\tprofileId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\x12\x12\n" +
+	"\x04open\x18\x04 \x01(\bR\x04open\x12\x16\n" +
+	"\x06active\x18\x05 \x01(\bR\x06active\"I\n" +
+	"\x14CreateProfileRequest\x12\x1d\n" +
+	"\n" +
+	"profile_id\x18\x01 \x01(\tR\tprofileId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"L\n" +
+	"\x15CreateProfileResponse\x123\n" +
+	"\aprofile\x18\x01 \x01(\v2\x19.syncspace.v1.ProfileInfoR\aprofile\"\x15\n" +
+	"\x13ListProfilesRequest\"M\n" +
+	"\x14ListProfilesResponse\x125\n" +
+	"\bprofiles\x18\x01 \x03(\v2\x19.syncspace.v1.ProfileInfoR\bprofiles\"o\n" +
+	"\x12OpenProfileRequest\x12\x1d\n" +
+	"\n" +
+	"profile_id\x18\x01 \x01(\tR\tprofileId\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x02 \x01(\tR\n" +
+	"passphrase\x12\x1a\n" +
+	"\bmnemonic\x18\x03 \x01(\tR\bmnemonic\"J\n" +
+	"\x13OpenProfileResponse\x123\n" +
+	"\aprofile\x18\x01 \x01(\v2\x19.syncspace.v1.ProfileInfoR\aprofile\"4\n" +
+	"\x13CloseProfileRequest\x12\x1d\n" +
+	"\n" +
+	"profile_id\x18\x01 \x01(\tR\tprofileId\"0\n" +
+	"\x14CloseProfileResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"5\n" +
+	"\x14DeleteProfileRequest\x12\x1d\n" +
+	"\n" +
+	"profile_id\x18\x01 \x01(\tR\tprofileId\"1\n" +
+	"\x15DeleteProfileResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x12\n" +
+	"\x10GetConfigRequest\"H\n" +
+	"\x11GetConfigResponse\x123\n" +
+	"\x06config\x18\x01 \x01(\v2\x1b.syncspace.v1.BackendConfigR\x06config\"\xd7\x04\n" +
+	"\rBackendConfig\x12\x19\n" +
+	"\bdata_dir\x18\x01 \x01(\tR\adataDir\x12\x1d\n" +
+	"\n" +
+	"network_id\x18\x02 \x01(\tR\tnetworkId\x12\x1b\n" +
+	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\x12!\n" +
+	"\fnetwork_mode\x18\x04 \x01(\tR\vnetworkMode\x12\x1b\n" +
+	"\tlog_level\x18\x05 \x01(\tR\blogLevel\x12,\n" +
+	"\x12command_timeout_ms\x18\x06 \x01(\x03R\x10commandTimeoutMs\x12b\n" +
+	"\x13command_timeouts_ms\x18\a \x03(\v22.syncspace.v1.BackendConfig.CommandTimeoutsMsEntryR\x11commandTimeoutsMs\x12&\n" +
+	"\x0fsync_period_sec\x18\b \x01(\x05R\rsyncPeriodSec\x12\x1c\n" +
+	"\n" +
+	"gc_ttl_sec\x18\t \x01(\x05R\bgcTtlSec\x126\n" +
+	"\x18keep_tree_data_in_memory\x18\n" +
+	" \x01(\bR\x14keepTreeDataInMemory\x12\"\n" +
+	"\rauto_lock_sec\x18\f \x01(\x05R\vautoLockSec\x12(\n" +
+	"\x10space_cache_size\x18\r \x01(\x05R\x0espaceCacheSize\x1aD\n" +
+	"\x16CommandTimeoutsMsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01J\x04\b\v\x10\fR\x05extra\"\x12\n" +
+	"\x10GetStatusRequest\"\xc4\x03\n" +
+	"\x11GetStatusResponse\x12 \n" +
+	"\vinitialized\x18\x01 \x01(\bR\vinitialized\x12\x19\n" +
+	"\bdata_dir\x18\x02 \x01(\tR\adataDir\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\x03R\tstartedAt\x12\x1b\n" +
+	"\tuptime_ms\x18\x04 \x01(\x03R\buptimeMs\x12(\n" +
+	"\x10open_space_count\x18\x05 \x01(\x05R\x0eopenSpaceCount\x12)\n" +
+	"\x10subscriber_count\x18\x06 \x01(\x05R\x0fsubscriberCount\x12#\n" +
+	"\rstorage_bytes\x18\a \x01(\x03R\fstorageBytes\x126\n" +
+	"\x06spaces\x18\b \x03(\v2\x1e.syncspace.v1.SpaceDiagnosticsR\x06spaces\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\t \x01(\tR\tgoVersion\x12\x1a\n" +
+	"\bplatform\x18\n" +
+	" \x01(\tR\bplatform\x121\n" +
+	"\x14passphrase_protected\x18\v \x01(\bR\x13passphraseProtected\x12\x16\n" +
+	"\x06locked\x18\f \x01(\bR\x06locked\"\xc0\x01\n" +
+	"\x10SpaceDiagnostics\x12\x19\n" +
+	"\bspace_id\x18\x01 \x01(\tR\aspaceId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04open\x18\x03 \x01(\bR\x04open\x12%\n" +
+	"\x0edocument_count\x18\x04 \x01(\x05R\rdocumentCount\x12#\n" +
+	"\rstorage_bytes\x18\x05 \x01(\x03R\fstorageBytes\x12\x1d\n" +
+	"\n" +
+	"load_error\x18\x06 \x01(\tR\tloadError\"6\n" +
+	"\x14SetPassphraseRequest\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x01 \x01(\tR\n" +
+	"passphrase\"1\n" +
+	"\x15SetPassphraseResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"o\n" +
+	"\x17ChangePassphraseRequest\x12-\n" +
+	"\x12current_passphrase\x18\x01 \x01(\tR\x11currentPassphrase\x12%\n" +
+	"\x0enew_passphrase\x18\x02 \x01(\tR\rnewPassphrase\"4\n" +
+	"\x18ChangePassphraseResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"H\n" +
+	"\x17RemovePassphraseRequest\x12-\n" +
+	"\x12current_passphrase\x18\x01 \x01(\tR\x11currentPassphrase\"4\n" +
+	"\x18RemovePassphraseResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"7\n" +
+	"\x15ExportMnemonicRequest\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x01 \x01(\tR\n" +
+	"passphrase\"4\n" +
+	"\x16ExportMnemonicResponse\x12\x1a\n" +
+	"\bmnemonic\x18\x01 \x01(\tR\bmnemonic\"\r\n" +
+	"\vLockRequest\"(\n" +
+	"\fLockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"/\n" +
+	"\rUnlockRequest\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x01 \x01(\tR\n" +
+	"passphrase\"*\n" +
+	"\x0eUnlockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"6\n" +
+	"\x14DeleteAccountRequest\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x01 \x01(\tR\n" +
+	"passphrase\"\x84\x01\n" +
+	"\x15DeleteAccountResponse\x12#\n" +
+	"\rremoved_files\x18\x01 \x03(\tR\fremovedFiles\x12!\n" +
+	"\fremoved_keys\x18\x02 \x03(\tR\vremovedKeys\x12#\n" +
+	"\rremoved_bytes\x18\x03 \x01(\x03R\fremovedBytes\"\xe7\x01\n" +
+	"\n" +
+	"DeviceInfo\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x17\n" +
+	"\apeer_id\x18\x03 \x01(\tR\x06peerId\x12\x19\n" +
+	"\bpeer_key\x18\x04 \x01(\fR\apeerKey\x12\x19\n" +
+	"\badded_at\x18\x05 \x01(\x03R\aaddedAt\x12 \n" +
+	"\flast_seen_at\x18\x06 \x01(\x03R\n" +
+	"lastSeenAt\x12\x1d\n" +
+	"\n" +
+	"revoked_at\x18\a \x01(\x03R\trevokedAt\x12\x18\n" +
+	"\acurrent\x18\b \x01(\bR\acurrent\"\x14\n" +
+	"\x12ListDevicesRequest\"I\n" +
+	"\x13ListDevicesResponse\x122\n" +
+	"\adevices\x18\x01 \x03(\v2\x18.syncspace.v1.DeviceInfoR\adevices\"\x18\n" +
+	"\x16RotateDeviceKeyRequest\"K\n" +
+	"\x17RotateDeviceKeyResponse\x120\n" +
+	"\x06device\x18\x01 \x01(\v2\x18.syncspace.v1.DeviceInfoR\x06device\"2\n" +
+	"\x13RevokeDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"H\n" +
+	"\x14RevokeDeviceResponse\x120\n" +
+	"\x06device\x18\x01 \x01(\v2\x18.syncspace.v1.DeviceInfoR\x06device\"e\n" +
+	"\x14ExportAccountRequest\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x01 \x01(\tR\n" +
+	"passphrase\x12-\n" +
+	"\x12account_passphrase\x18\x02 \x01(\tR\x11accountPassphrase\"/\n" +
+	"\x15ExportAccountResponse\x12\x16\n" +
+	"\x06bundle\x18\x01 \x01(\fR\x06bundle\"\xb7\x01\n" +
+	"\x14ImportAccountRequest\x12\x19\n" +
+	"\bdata_dir\x18\x01 \x01(\tR\adataDir\x12\x16\n" +
+	"\x06bundle\x18\x02 \x01(\fR\x06bundle\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x03 \x01(\tR\n" +
+	"passphrase\x12-\n" +
+	"\x12account_passphrase\x18\x04 \x01(\tR\x11accountPassphrase\x12\x1d\n" +
+	"\n" +
+	"profile_id\x18\x05 \x01(\tR\tprofileId\"z\n" +
+	"\x15ImportAccountResponse\x12\x1f\n" +
+	"\vspace_count\x18\x01 \x01(\x05R\n" +
+	"spaceCount\x12!\n" +
+	"\fdevice_count\x18\x02 \x01(\x05R\vdeviceCount\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt*\xa5\x01\n" +
+	"\x0fSpacePermission\x12 \n" +
+	"\x1cSPACE_PERMISSION_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17SPACE_PERMISSION_READER\x10\x01\x12\x1b\n" +
+	"\x17SPACE_PERMISSION_WRITER\x10\x02\x12\x1a\n" +
+	"\x16SPACE_PERMISSION_ADMIN\x10\x03\x12\x1a\n" +
+	"\x16SPACE_PERMISSION_OWNER\x10\x04*\x84\x01\n" +
+	"\fMemberStatus\x12\x1d\n" +
+	"\x19MEMBER_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14MEMBER_STATUS_ACTIVE\x10\x01\x12 \n" +
+	"\x1cMEMBER_STATUS_JOIN_REQUESTED\x10\x02\x12\x19\n" +
+	"\x15MEMBER_STATUS_LEAVING\x10\x03*\x87\x01\n" +
 	"\n" +
 	"SyncStatus\x12\x1b\n" +
 	"\x17SYNC_STATUS_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10SYNC_STATUS_IDLE\x10\x01\x12\x17\n" +
 	"\x13SYNC_STATUS_SYNCING\x10\x02\x12\x16\n" +
 	"\x12SYNC_STATUS_PAUSED\x10\x03\x12\x15\n" +
-	"\x11SYNC_STATUS_ERROR\x10\x042\x9c\v\n" +
+	"\x11SYNC_STATUS_ERROR\x10\x04*\xae\x03\n" +
+	"\tErrorCode\x12\x1a\n" +
+	"\x16ERROR_CODE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13ERROR_CODE_INTERNAL\x10\x01\x12\x1f\n" +
+	"\x1bERROR_CODE_INVALID_ARGUMENT\x10\x02\x12\x18\n" +
+	"\x14ERROR_CODE_NOT_FOUND\x10\x03\x12\x1d\n" +
+	"\x19ERROR_CODE_ALREADY_EXISTS\x10\x04\x12\x1e\n" +
+	"\x1aERROR_CODE_NOT_INITIALIZED\x10\x05\x12\"\n" +
+	"\x1eERROR_CODE_ALREADY_INITIALIZED\x10\x06\x12\x1f\n" +
+	"\x1bERROR_CODE_VERSION_CONFLICT\x10\a\x12\x1c\n" +
+	"\x18ERROR_CODE_UNIMPLEMENTED\x10\b\x12 \n" +
+	"\x1cERROR_CODE_DEADLINE_EXCEEDED\x10\t\x12\x18\n" +
+	"\x14ERROR_CODE_CANCELLED\x10\n" +
+	"\x12\x1a\n" +
+	"\x16ERROR_CODE_UNAVAILABLE\x10\v\x12\x15\n" +
+	"\x11ERROR_CODE_LOCKED\x10\f\x12 \n" +
+	"\x1cERROR_CODE_PERMISSION_DENIED\x10\r2\xe1\x1d\n" +
 	"\x10SyncSpaceService\x12=\n" +
 	"\x04Init\x12\x19.syncspace.v1.InitRequest\x1a\x1a.syncspace.v1.InitResponse\x12I\n" +
 	"\bShutdown\x12\x1d.syncspace.v1.ShutdownRequest\x1a\x1e.syncspace.v1.ShutdownResponse\x12R\n" +
-	"\vCreateSpace\x12 .syncspace.v1.CreateSpaceRequest\x1a!.syncspace.v1.CreateSpaceResponse\x12L\n" +
-	"\tJoinSpace\x12\x1e.syncspace.v1.JoinSpaceRequest\x1a\x1f.syncspace.v1.JoinSpaceResponse\x12O\n" +
+	"\vCreateSpace\x12 .syncspace.v1.CreateSpaceRequest\x1a!.syncspace.v1.CreateSpaceResponse\x12U\n" +
+	"\fCreateInvite\x12!.syncspace.v1.CreateInviteRequest\x1a\".syncspace.v1.CreateInviteResponse\x12L\n" +
+	"\tJoinSpace\x12\x1e.syncspace.v1.JoinSpaceRequest\x1a\x1f.syncspace.v1.JoinSpaceResponse\x12R\n" +
+	"\vListMembers\x12 .syncspace.v1.ListMembersRequest\x1a!.syncspace.v1.ListMembersResponse\x12g\n" +
+	"\x12ApproveJoinRequest\x12'.syncspace.v1.ApproveJoinRequestRequest\x1a(.syncspace.v1.ApproveJoinRequestResponse\x12U\n" +
+	"\fRemoveMember\x12!.syncspace.v1.RemoveMemberRequest\x1a\".syncspace.v1.RemoveMemberResponse\x12s\n" +
+	"\x16ChangeMemberPermission\x12+.syncspace.v1.ChangeMemberPermissionRequest\x1a,.syncspace.v1.ChangeMemberPermissionResponse\x12O\n" +
 	"\n" +
 	"LeaveSpace\x12\x1f.syncspace.v1.LeaveSpaceRequest\x1a .syncspace.v1.LeaveSpaceResponse\x12O\n" +
 	"\n" +
 	"ListSpaces\x12\x1f.syncspace.v1.ListSpacesRequest\x1a .syncspace.v1.ListSpacesResponse\x12R\n" +
+	"\vUpdateSpace\x12 .syncspace.v1.UpdateSpaceRequest\x1a!.syncspace.v1.UpdateSpaceResponse\x12R\n" +
 	"\vDeleteSpace\x12 .syncspace.v1.DeleteSpaceRequest\x1a!.syncspace.v1.DeleteSpaceResponse\x12[\n" +
 	"\x0eCreateDocument\x12#.syncspace.v1.CreateDocumentRequest\x1a$.syncspace.v1.CreateDocumentResponse\x12R\n" +
 	"\vGetDocument\x12 .syncspace.v1.GetDocumentRequest\x1a!.syncspace.v1.GetDocumentResponse\x12[\n" +
@@ -2778,8 +6977,29 @@ const file_syncspace_v1_syncspace_proto_rawDesc = "" +
 	"\x0eQueryDocuments\x12#.syncspace.v1.QueryDocumentsRequest\x1a$.syncspace.v1.QueryDocumentsResponse\x12L\n" +
 	"\tStartSync\x12\x1e.syncspace.v1.StartSyncRequest\x1a\x1f.syncspace.v1.StartSyncResponse\x12L\n" +
 	"\tPauseSync\x12\x1e.syncspace.v1.PauseSyncRequest\x1a\x1f.syncspace.v1.PauseSyncResponse\x12X\n" +
-	"\rGetSyncStatus\x12\".syncspace.v1.GetSyncStatusRequest\x1a#.syncspace.v1.GetSyncStatusResponse\x12N\n" +
-	"\tSubscribe\x12\x1e.syncspace.v1.SubscribeRequest\x1a\x1f.syncspace.v1.SubscribeResponse0\x01B\xa8\x01\n" +
+	"\rGetSyncStatus\x12\".syncspace.v1.GetSyncStatusRequest\x1a#.syncspace.v1.GetSyncStatusResponse\x12@\n" +
+	"\x05Batch\x12\x1a.syncspace.v1.BatchRequest\x1a\x1b.syncspace.v1.BatchResponse\x12a\n" +
+	"\x10DescribeCommands\x12%.syncspace.v1.DescribeCommandsRequest\x1a&.syncspace.v1.DescribeCommandsResponse\x12L\n" +
+	"\tGetConfig\x12\x1e.syncspace.v1.GetConfigRequest\x1a\x1f.syncspace.v1.GetConfigResponse\x12L\n" +
+	"\tGetStatus\x12\x1e.syncspace.v1.GetStatusRequest\x1a\x1f.syncspace.v1.GetStatusResponse\x12X\n" +
+	"\rSetPassphrase\x12\".syncspace.v1.SetPassphraseRequest\x1a#.syncspace.v1.SetPassphraseResponse\x12a\n" +
+	"\x10ChangePassphrase\x12%.syncspace.v1.ChangePassphraseRequest\x1a&.syncspace.v1.ChangePassphraseResponse\x12a\n" +
+	"\x10RemovePassphrase\x12%.syncspace.v1.RemovePassphraseRequest\x1a&.syncspace.v1.RemovePassphraseResponse\x12[\n" +
+	"\x0eExportMnemonic\x12#.syncspace.v1.ExportMnemonicRequest\x1a$.syncspace.v1.ExportMnemonicResponse\x12=\n" +
+	"\x04Lock\x12\x19.syncspace.v1.LockRequest\x1a\x1a.syncspace.v1.LockResponse\x12C\n" +
+	"\x06Unlock\x12\x1b.syncspace.v1.UnlockRequest\x1a\x1c.syncspace.v1.UnlockResponse\x12X\n" +
+	"\rDeleteAccount\x12\".syncspace.v1.DeleteAccountRequest\x1a#.syncspace.v1.DeleteAccountResponse\x12R\n" +
+	"\vListDevices\x12 .syncspace.v1.ListDevicesRequest\x1a!.syncspace.v1.ListDevicesResponse\x12^\n" +
+	"\x0fRotateDeviceKey\x12$.syncspace.v1.RotateDeviceKeyRequest\x1a%.syncspace.v1.RotateDeviceKeyResponse\x12U\n" +
+	"\fRevokeDevice\x12!.syncspace.v1.RevokeDeviceRequest\x1a\".syncspace.v1.RevokeDeviceResponse\x12X\n" +
+	"\rExportAccount\x12\".syncspace.v1.ExportAccountRequest\x1a#.syncspace.v1.ExportAccountResponse\x12X\n" +
+	"\rImportAccount\x12\".syncspace.v1.ImportAccountRequest\x1a#.syncspace.v1.ImportAccountResponse\x12N\n" +
+	"\tSubscribe\x12\x1e.syncspace.v1.SubscribeRequest\x1a\x1f.syncspace.v1.SubscribeResponse0\x01\x12X\n" +
+	"\rCreateProfile\x12\".syncspace.v1.CreateProfileRequest\x1a#.syncspace.v1.CreateProfileResponse\x12U\n" +
+	"\fListProfiles\x12!.syncspace.v1.ListProfilesRequest\x1a\".syncspace.v1.ListProfilesResponse\x12R\n" +
+	"\vOpenProfile\x12 .syncspace.v1.OpenProfileRequest\x1a!.syncspace.v1.OpenProfileResponse\x12U\n" +
+	"\fCloseProfile\x12!.syncspace.v1.CloseProfileRequest\x1a\".syncspace.v1.CloseProfileResponse\x12X\n" +
+	"\rDeleteProfile\x12\".syncspace.v1.DeleteProfileRequest\x1a#.syncspace.v1.DeleteProfileResponseB\xa8\x01\n" +
 	"\x10com.syncspace.v1B\x0eSyncspaceProtoP\x01Z3anysync-backend/shared/proto/syncspace/v1;syncspace\xa2\x02\x03SXX\xaa\x02\fSyncspace.V1\xca\x02\fSyncspace\\V1\xe2\x02\x18Syncspace\\V1\\GPBMetadata\xea\x02\rSyncspace::V1b\x06proto3"
 
 var (
@@ -2794,120 +7014,276 @@ func file_syncspace_v1_syncspace_proto_rawDescGZIP() []byte {
 	return file_syncspace_v1_syncspace_proto_rawDescData
 }
 
-var file_syncspace_v1_syncspace_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_syncspace_v1_syncspace_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
+var file_syncspace_v1_syncspace_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_syncspace_v1_syncspace_proto_msgTypes = make([]protoimpl.MessageInfo, 122)
 var file_syncspace_v1_syncspace_proto_goTypes = []any{
-	(SyncStatus)(0),                // 0: syncspace.v1.SyncStatus
-	(*Command)(nil),                // 1: syncspace.v1.Command
-	(*CommandResponse)(nil),        // 2: syncspace.v1.CommandResponse
-	(*InitRequest)(nil),            // 3: syncspace.v1.InitRequest
-	(*InitResponse)(nil),           // 4: syncspace.v1.InitResponse
-	(*ShutdownRequest)(nil),        // 5: syncspace.v1.ShutdownRequest
-	(*ShutdownResponse)(nil),       // 6: syncspace.v1.ShutdownResponse
-	(*CreateSpaceRequest)(nil),     // 7: syncspace.v1.CreateSpaceRequest
-	(*CreateSpaceResponse)(nil),    // 8: syncspace.v1.CreateSpaceResponse
-	(*JoinSpaceRequest)(nil),       // 9: syncspace.v1.JoinSpaceRequest
-	(*JoinSpaceResponse)(nil),      // 10: syncspace.v1.JoinSpaceResponse
-	(*LeaveSpaceRequest)(nil),      // 11: syncspace.v1.LeaveSpaceRequest
-	(*LeaveSpaceResponse)(nil),     // 12: syncspace.v1.LeaveSpaceResponse
-	(*ListSpacesRequest)(nil),      // 13: syncspace.v1.ListSpacesRequest
-	(*ListSpacesResponse)(nil),     // 14: syncspace.v1.ListSpacesResponse
-	(*SpaceInfo)(nil),              // 15: syncspace.v1.SpaceInfo
-	(*DeleteSpaceRequest)(nil),     // 16: syncspace.v1.DeleteSpaceRequest
-	(*DeleteSpaceResponse)(nil),    // 17: syncspace.v1.DeleteSpaceResponse
-	(*CreateDocumentRequest)(nil),  // 18: syncspace.v1.CreateDocumentRequest
-	(*CreateDocumentResponse)(nil), // 19: syncspace.v1.CreateDocumentResponse
-	(*GetDocumentRequest)(nil),     // 20: syncspace.v1.GetDocumentRequest
-	(*GetDocumentResponse)(nil),    // 21: syncspace.v1.GetDocumentResponse
-	(*Document)(nil),               // 22: syncspace.v1.Document
-	(*UpdateDocumentRequest)(nil),  // 23: syncspace.v1.UpdateDocumentRequest
-	(*UpdateDocumentResponse)(nil), // 24: syncspace.v1.UpdateDocumentResponse
-	(*DeleteDocumentRequest)(nil),  // 25: syncspace.v1.DeleteDocumentRequest
-	(*DeleteDocumentResponse)(nil), // 26: syncspace.v1.DeleteDocumentResponse
-	(*ListDocumentsRequest)(nil),   // 27: syncspace.v1.ListDocumentsRequest
-	(*ListDocumentsResponse)(nil),  // 28: syncspace.v1.ListDocumentsResponse
-	(*DocumentInfo)(nil),           // 29: syncspace.v1.DocumentInfo
-	(*QueryDocumentsRequest)(nil),  // 30: syncspace.v1.QueryDocumentsRequest
-	(*QueryFilter)(nil),            // 31: syncspace.v1.QueryFilter
-	(*QueryDocumentsResponse)(nil), // 32: syncspace.v1.QueryDocumentsResponse
-	(*StartSyncRequest)(nil),       // 33: syncspace.v1.StartSyncRequest
-	(*StartSyncResponse)(nil),      // 34: syncspace.v1.StartSyncResponse
-	(*PauseSyncRequest)(nil),       // 35: syncspace.v1.PauseSyncRequest
-	(*PauseSyncResponse)(nil),      // 36: syncspace.v1.PauseSyncResponse
-	(*GetSyncStatusRequest)(nil),   // 37: syncspace.v1.GetSyncStatusRequest
-	(*GetSyncStatusResponse)(nil),  // 38: syncspace.v1.GetSyncStatusResponse
-	(*SpaceSyncStatus)(nil),        // 39: syncspace.v1.SpaceSyncStatus
-	(*SubscribeRequest)(nil),       // 40: syncspace.v1.SubscribeRequest
-	(*SubscribeResponse)(nil),      // 41: syncspace.v1.SubscribeResponse
-	(*DocumentCreatedEvent)(nil),   // 42: syncspace.v1.DocumentCreatedEvent
-	(*DocumentUpdatedEvent)(nil),   // 43: syncspace.v1.DocumentUpdatedEvent
-	(*DocumentDeletedEvent)(nil),   // 44: syncspace.v1.DocumentDeletedEvent
-	(*SyncStatusChangedEvent)(nil), // 45: syncspace.v1.SyncStatusChangedEvent
-	nil,                            // 46: syncspace.v1.InitRequest.ConfigEntry
-	nil,                            // 47: syncspace.v1.CreateSpaceRequest.MetadataEntry
-	nil,                            // 48: syncspace.v1.SpaceInfo.MetadataEntry
-	nil,                            // 49: syncspace.v1.CreateDocumentRequest.MetadataEntry
-	nil,                            // 50: syncspace.v1.Document.MetadataEntry
-	nil,                            // 51: syncspace.v1.UpdateDocumentRequest.MetadataEntry
-	nil,                            // 52: syncspace.v1.DocumentInfo.MetadataEntry
+	(SpacePermission)(0),                   // 0: syncspace.v1.SpacePermission
+	(MemberStatus)(0),                      // 1: syncspace.v1.MemberStatus
+	(SyncStatus)(0),                        // 2: syncspace.v1.SyncStatus
+	(ErrorCode)(0),                         // 3: syncspace.v1.ErrorCode
+	(*Command)(nil),                        // 4: syncspace.v1.Command
+	(*CommandResponse)(nil),                // 5: syncspace.v1.CommandResponse
+	(*InitRequest)(nil),                    // 6: syncspace.v1.InitRequest
+	(*InitResponse)(nil),                   // 7: syncspace.v1.InitResponse
+	(*ShutdownRequest)(nil),                // 8: syncspace.v1.ShutdownRequest
+	(*ShutdownResponse)(nil),               // 9: syncspace.v1.ShutdownResponse
+	(*CreateSpaceRequest)(nil),             // 10: syncspace.v1.CreateSpaceRequest
+	(*CreateSpaceResponse)(nil),            // 11: syncspace.v1.CreateSpaceResponse
+	(*CreateInviteRequest)(nil),            // 12: syncspace.v1.CreateInviteRequest
+	(*CreateInviteResponse)(nil),           // 13: syncspace.v1.CreateInviteResponse
+	(*JoinSpaceRequest)(nil),               // 14: syncspace.v1.JoinSpaceRequest
+	(*JoinSpaceResponse)(nil),              // 15: syncspace.v1.JoinSpaceResponse
+	(*ListMembersRequest)(nil),             // 16: syncspace.v1.ListMembersRequest
+	(*ListMembersResponse)(nil),            // 17: syncspace.v1.ListMembersResponse
+	(*ApproveJoinRequestRequest)(nil),      // 18: syncspace.v1.ApproveJoinRequestRequest
+	(*ApproveJoinRequestResponse)(nil),     // 19: syncspace.v1.ApproveJoinRequestResponse
+	(*RemoveMemberRequest)(nil),            // 20: syncspace.v1.RemoveMemberRequest
+	(*RemoveMemberResponse)(nil),           // 21: syncspace.v1.RemoveMemberResponse
+	(*ChangeMemberPermissionRequest)(nil),  // 22: syncspace.v1.ChangeMemberPermissionRequest
+	(*ChangeMemberPermissionResponse)(nil), // 23: syncspace.v1.ChangeMemberPermissionResponse
+	(*LeaveSpaceRequest)(nil),              // 24: syncspace.v1.LeaveSpaceRequest
+	(*LeaveSpaceResponse)(nil),             // 25: syncspace.v1.LeaveSpaceResponse
+	(*ListSpacesRequest)(nil),              // 26: syncspace.v1.ListSpacesRequest
+	(*ListSpacesResponse)(nil),             // 27: syncspace.v1.ListSpacesResponse
+	(*SpaceInfo)(nil),                      // 28: syncspace.v1.SpaceInfo
+	(*SpaceMember)(nil),                    // 29: syncspace.v1.SpaceMember
+	(*UpdateSpaceRequest)(nil),             // 30: syncspace.v1.UpdateSpaceRequest
+	(*UpdateSpaceResponse)(nil),            // 31: syncspace.v1.UpdateSpaceResponse
+	(*DeleteSpaceRequest)(nil),             // 32: syncspace.v1.DeleteSpaceRequest
+	(*DeleteSpaceResponse)(nil),            // 33: syncspace.v1.DeleteSpaceResponse
+	(*CreateDocumentRequest)(nil),          // 34: syncspace.v1.CreateDocumentRequest
+	(*CreateDocumentResponse)(nil),         // 35: syncspace.v1.CreateDocumentResponse
+	(*GetDocumentRequest)(nil),             // 36: syncspace.v1.GetDocumentRequest
+	(*GetDocumentResponse)(nil),            // 37: syncspace.v1.GetDocumentResponse
+	(*Document)(nil),                       // 38: syncspace.v1.Document
+	(*UpdateDocumentRequest)(nil),          // 39: syncspace.v1.UpdateDocumentRequest
+	(*UpdateDocumentResponse)(nil),         // 40: syncspace.v1.UpdateDocumentResponse
+	(*DeleteDocumentRequest)(nil),          // 41: syncspace.v1.DeleteDocumentRequest
+	(*DeleteDocumentResponse)(nil),         // 42: syncspace.v1.DeleteDocumentResponse
+	(*ListDocumentsRequest)(nil),           // 43: syncspace.v1.ListDocumentsRequest
+	(*ListDocumentsResponse)(nil),          // 44: syncspace.v1.ListDocumentsResponse
+	(*DocumentInfo)(nil),                   // 45: syncspace.v1.DocumentInfo
+	(*QueryDocumentsRequest)(nil),          // 46: syncspace.v1.QueryDocumentsRequest
+	(*QueryFilter)(nil),                    // 47: syncspace.v1.QueryFilter
+	(*QueryDocumentsResponse)(nil),         // 48: syncspace.v1.QueryDocumentsResponse
+	(*StartSyncRequest)(nil),               // 49: syncspace.v1.StartSyncRequest
+	(*StartSyncResponse)(nil),              // 50: syncspace.v1.StartSyncResponse
+	(*PauseSyncRequest)(nil),               // 51: syncspace.v1.PauseSyncRequest
+	(*PauseSyncResponse)(nil),              // 52: syncspace.v1.PauseSyncResponse
+	(*GetSyncStatusRequest)(nil),           // 53: syncspace.v1.GetSyncStatusRequest
+	(*GetSyncStatusResponse)(nil),          // 54: syncspace.v1.GetSyncStatusResponse
+	(*SpaceSyncStatus)(nil),                // 55: syncspace.v1.SpaceSyncStatus
+	(*SubscribeRequest)(nil),               // 56: syncspace.v1.SubscribeRequest
+	(*SubscribeResponse)(nil),              // 57: syncspace.v1.SubscribeResponse
+	(*DocumentCreatedEvent)(nil),           // 58: syncspace.v1.DocumentCreatedEvent
+	(*DocumentUpdatedEvent)(nil),           // 59: syncspace.v1.DocumentUpdatedEvent
+	(*DocumentDeletedEvent)(nil),           // 60: syncspace.v1.DocumentDeletedEvent
+	(*SpaceUpdatedEvent)(nil),              // 61: syncspace.v1.SpaceUpdatedEvent
+	(*SpaceMemberEvent)(nil),               // 62: syncspace.v1.SpaceMemberEvent
+	(*SyncStatusChangedEvent)(nil),         // 63: syncspace.v1.SyncStatusChangedEvent
+	(*BatchRequest)(nil),                   // 64: syncspace.v1.BatchRequest
+	(*BatchResponse)(nil),                  // 65: syncspace.v1.BatchResponse
+	(*DescribeCommandsRequest)(nil),        // 66: syncspace.v1.DescribeCommandsRequest
+	(*DescribeCommandsResponse)(nil),       // 67: syncspace.v1.DescribeCommandsResponse
+	(*CommandInfo)(nil),                    // 68: syncspace.v1.CommandInfo
+	(*CommandError)(nil),                   // 69: syncspace.v1.CommandError
+	(*StreamMessage)(nil),                  // 70: syncspace.v1.StreamMessage
+	(*ProfileInfo)(nil),                    // 71: syncspace.v1.ProfileInfo
+	(*CreateProfileRequest)(nil),           // 72: syncspace.v1.CreateProfileRequest
+	(*CreateProfileResponse)(nil),          // 73: syncspace.v1.CreateProfileResponse
+	(*ListProfilesRequest)(nil),            // 74: syncspace.v1.ListProfilesRequest
+	(*ListProfilesResponse)(nil),           // 75: syncspace.v1.ListProfilesResponse
+	(*OpenProfileRequest)(nil),             // 76: syncspace.v1.OpenProfileRequest
+	(*OpenProfileResponse)(nil),            // 77: syncspace.v1.OpenProfileResponse
+	(*CloseProfileRequest)(nil),            // 78: syncspace.v1.CloseProfileRequest
+	(*CloseProfileResponse)(nil),           // 79: syncspace.v1.CloseProfileResponse
+	(*DeleteProfileRequest)(nil),           // 80: syncspace.v1.DeleteProfileRequest
+	(*DeleteProfileResponse)(nil),          // 81: syncspace.v1.DeleteProfileResponse
+	(*GetConfigRequest)(nil),               // 82: syncspace.v1.GetConfigRequest
+	(*GetConfigResponse)(nil),              // 83: syncspace.v1.GetConfigResponse
+	(*BackendConfig)(nil),                  // 84: syncspace.v1.BackendConfig
+	(*GetStatusRequest)(nil),               // 85: syncspace.v1.GetStatusRequest
+	(*GetStatusResponse)(nil),              // 86: syncspace.v1.GetStatusResponse
+	(*SpaceDiagnostics)(nil),               // 87: syncspace.v1.SpaceDiagnostics
+	(*SetPassphraseRequest)(nil),           // 88: syncspace.v1.SetPassphraseRequest
+	(*SetPassphraseResponse)(nil),          // 89: syncspace.v1.SetPassphraseResponse
+	(*ChangePassphraseRequest)(nil),        // 90: syncspace.v1.ChangePassphraseRequest
+	(*ChangePassphraseResponse)(nil),       // 91: syncspace.v1.ChangePassphraseResponse
+	(*RemovePassphraseRequest)(nil),        // 92: syncspace.v1.RemovePassphraseRequest
+	(*RemovePassphraseResponse)(nil),       // 93: syncspace.v1.RemovePassphraseResponse
+	(*ExportMnemonicRequest)(nil),          // 94: syncspace.v1.ExportMnemonicRequest
+	(*ExportMnemonicResponse)(nil),         // 95: syncspace.v1.ExportMnemonicResponse
+	(*LockRequest)(nil),                    // 96: syncspace.v1.LockRequest
+	(*LockResponse)(nil),                   // 97: syncspace.v1.LockResponse
+	(*UnlockRequest)(nil),                  // 98: syncspace.v1.UnlockRequest
+	(*UnlockResponse)(nil),                 // 99: syncspace.v1.UnlockResponse
+	(*DeleteAccountRequest)(nil),           // 100: syncspace.v1.DeleteAccountRequest
+	(*DeleteAccountResponse)(nil),          // 101: syncspace.v1.DeleteAccountResponse
+	(*DeviceInfo)(nil),                     // 102: syncspace.v1.DeviceInfo
+	(*ListDevicesRequest)(nil),             // 103: syncspace.v1.ListDevicesRequest
+	(*ListDevicesResponse)(nil),            // 104: syncspace.v1.ListDevicesResponse
+	(*RotateDeviceKeyRequest)(nil),         // 105: syncspace.v1.RotateDeviceKeyRequest
+	(*RotateDeviceKeyResponse)(nil),        // 106: syncspace.v1.RotateDeviceKeyResponse
+	(*RevokeDeviceRequest)(nil),            // 107: syncspace.v1.RevokeDeviceRequest
+	(*RevokeDeviceResponse)(nil),           // 108: syncspace.v1.RevokeDeviceResponse
+	(*ExportAccountRequest)(nil),           // 109: syncspace.v1.ExportAccountRequest
+	(*ExportAccountResponse)(nil),          // 110: syncspace.v1.ExportAccountResponse
+	(*ImportAccountRequest)(nil),           // 111: syncspace.v1.ImportAccountRequest
+	(*ImportAccountResponse)(nil),          // 112: syncspace.v1.ImportAccountResponse
+	nil,                                    // 113: syncspace.v1.InitRequest.ConfigEntry
+	nil,                                    // 114: syncspace.v1.InitRequest.CommandTimeoutsMsEntry
+	nil,                                    // 115: syncspace.v1.CreateSpaceRequest.MetadataEntry
+	nil,                                    // 116: syncspace.v1.SpaceInfo.MetadataEntry
+	nil,                                    // 117: syncspace.v1.UpdateSpaceRequest.MetadataEntry
+	nil,                                    // 118: syncspace.v1.CreateDocumentRequest.MetadataEntry
+	nil,                                    // 119: syncspace.v1.Document.MetadataEntry
+	nil,                                    // 120: syncspace.v1.UpdateDocumentRequest.MetadataEntry
+	nil,                                    // 121: syncspace.v1.DocumentInfo.MetadataEntry
+	nil,                                    // 122: syncspace.v1.SpaceUpdatedEvent.OldMetadataEntry
+	nil,                                    // 123: syncspace.v1.SpaceUpdatedEvent.NewMetadataEntry
+	nil,                                    // 124: syncspace.v1.CommandError.DetailsEntry
+	nil,                                    // 125: syncspace.v1.BackendConfig.CommandTimeoutsMsEntry
 }
 var file_syncspace_v1_syncspace_proto_depIdxs = []int32{
-	46, // 0: syncspace.v1.InitRequest.config:type_name -> syncspace.v1.InitRequest.ConfigEntry
-	47, // 1: syncspace.v1.CreateSpaceRequest.metadata:type_name -> syncspace.v1.CreateSpaceRequest.MetadataEntry
-	15, // 2: syncspace.v1.ListSpacesResponse.spaces:type_name -> syncspace.v1.SpaceInfo
-	48, // 3: syncspace.v1.SpaceInfo.metadata:type_name -> syncspace.v1.SpaceInfo.MetadataEntry
-	0,  // 4: syncspace.v1.SpaceInfo.sync_status:type_name -> syncspace.v1.SyncStatus
-	49, // 5: syncspace.v1.CreateDocumentRequest.metadata:type_name -> syncspace.v1.CreateDocumentRequest.MetadataEntry
-	22, // 6: syncspace.v1.GetDocumentResponse.document:type_name -> syncspace.v1.Document
-	50, // 7: syncspace.v1.Document.metadata:type_name -> syncspace.v1.Document.MetadataEntry
-	51, // 8: syncspace.v1.UpdateDocumentRequest.metadata:type_name -> syncspace.v1.UpdateDocumentRequest.MetadataEntry
-	29, // 9: syncspace.v1.ListDocumentsResponse.documents:type_name -> syncspace.v1.DocumentInfo
-	52, // 10: syncspace.v1.DocumentInfo.metadata:type_name -> syncspace.v1.DocumentInfo.MetadataEntry
-	31, // 11: syncspace.v1.QueryDocumentsRequest.filters:type_name -> syncspace.v1.QueryFilter
-	29, // 12: syncspace.v1.QueryDocumentsResponse.documents:type_name -> syncspace.v1.DocumentInfo
-	39, // 13: syncspace.v1.GetSyncStatusResponse.statuses:type_name -> syncspace.v1.SpaceSyncStatus
-	0,  // 14: syncspace.v1.SpaceSyncStatus.status:type_name -> syncspace.v1.SyncStatus
-	0,  // 15: syncspace.v1.SyncStatusChangedEvent.old_status:type_name -> syncspace.v1.SyncStatus
-	0,  // 16: syncspace.v1.SyncStatusChangedEvent.new_status:type_name -> syncspace.v1.SyncStatus
-	3,  // 17: syncspace.v1.SyncSpaceService.Init:input_type -> syncspace.v1.InitRequest
-	5,  // 18: syncspace.v1.SyncSpaceService.Shutdown:input_type -> syncspace.v1.ShutdownRequest
-	7,  // 19: syncspace.v1.SyncSpaceService.CreateSpace:input_type -> syncspace.v1.CreateSpaceRequest
-	9,  // 20: syncspace.v1.SyncSpaceService.JoinSpace:input_type -> syncspace.v1.JoinSpaceRequest
-	11, // 21: syncspace.v1.SyncSpaceService.LeaveSpace:input_type -> syncspace.v1.LeaveSpaceRequest
-	13, // 22: syncspace.v1.SyncSpaceService.ListSpaces:input_type -> syncspace.v1.ListSpacesRequest
-	16, // 23: syncspace.v1.SyncSpaceService.DeleteSpace:input_type -> syncspace.v1.DeleteSpaceRequest
-	18, // 24: syncspace.v1.SyncSpaceService.CreateDocument:input_type -> syncspace.v1.CreateDocumentRequest
-	20, // 25: syncspace.v1.SyncSpaceService.GetDocument:input_type -> syncspace.v1.GetDocumentRequest
-	23, // 26: syncspace.v1.SyncSpaceService.UpdateDocument:input_type -> syncspace.v1.UpdateDocumentRequest
-	25, // 27: syncspace.v1.SyncSpaceService.DeleteDocument:input_type -> syncspace.v1.DeleteDocumentRequest
-	27, // 28: syncspace.v1.SyncSpaceService.ListDocuments:input_type -> syncspace.v1.ListDocumentsRequest
-	30, // 29: syncspace.v1.SyncSpaceService.QueryDocuments:input_type -> syncspace.v1.QueryDocumentsRequest
-	33, // 30: syncspace.v1.SyncSpaceService.StartSync:input_type -> syncspace.v1.StartSyncRequest
-	35, // 31: syncspace.v1.SyncSpaceService.PauseSync:input_type -> syncspace.v1.PauseSyncRequest
-	37, // 32: syncspace.v1.SyncSpaceService.GetSyncStatus:input_type -> syncspace.v1.GetSyncStatusRequest
-	40, // 33: syncspace.v1.SyncSpaceService.Subscribe:input_type -> syncspace.v1.SubscribeRequest
-	4,  // 34: syncspace.v1.SyncSpaceService.Init:output_type -> syncspace.v1.InitResponse
-	6,  // 35: syncspace.v1.SyncSpaceService.Shutdown:output_type -> syncspace.v1.ShutdownResponse
-	8,  // 36: syncspace.v1.SyncSpaceService.CreateSpace:output_type -> syncspace.v1.CreateSpaceResponse
-	10, // 37: syncspace.v1.SyncSpaceService.JoinSpace:output_type -> syncspace.v1.JoinSpaceResponse
-	12, // 38: syncspace.v1.SyncSpaceService.LeaveSpace:output_type -> syncspace.v1.LeaveSpaceResponse
-	14, // 39: syncspace.v1.SyncSpaceService.ListSpaces:output_type -> syncspace.v1.ListSpacesResponse
-	17, // 40: syncspace.v1.SyncSpaceService.DeleteSpace:output_type -> syncspace.v1.DeleteSpaceResponse
-	19, // 41: syncspace.v1.SyncSpaceService.CreateDocument:output_type -> syncspace.v1.CreateDocumentResponse
-	21, // 42: syncspace.v1.SyncSpaceService.GetDocument:output_type -> syncspace.v1.GetDocumentResponse
-	24, // 43: syncspace.v1.SyncSpaceService.UpdateDocument:output_type -> syncspace.v1.UpdateDocumentResponse
-	26, // 44: syncspace.v1.SyncSpaceService.DeleteDocument:output_type -> syncspace.v1.DeleteDocumentResponse
-	28, // 45: syncspace.v1.SyncSpaceService.ListDocuments:output_type -> syncspace.v1.ListDocumentsResponse
-	32, // 46: syncspace.v1.SyncSpaceService.QueryDocuments:output_type -> syncspace.v1.QueryDocumentsResponse
-	34, // 47: syncspace.v1.SyncSpaceService.StartSync:output_type -> syncspace.v1.StartSyncResponse
-	36, // 48: syncspace.v1.SyncSpaceService.PauseSync:output_type -> syncspace.v1.PauseSyncResponse
-	38, // 49: syncspace.v1.SyncSpaceService.GetSyncStatus:output_type -> syncspace.v1.GetSyncStatusResponse
-	41, // 50: syncspace.v1.SyncSpaceService.Subscribe:output_type -> syncspace.v1.SubscribeResponse
-	34, // [34:51] is the sub-list for method output_type
-	17, // [17:34] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	69,  // 0: syncspace.v1.CommandResponse.error_detail:type_name -> syncspace.v1.CommandError
+	113, // 1: syncspace.v1.InitRequest.config:type_name -> syncspace.v1.InitRequest.ConfigEntry
+	114, // 2: syncspace.v1.InitRequest.command_timeouts_ms:type_name -> syncspace.v1.InitRequest.CommandTimeoutsMsEntry
+	115, // 3: syncspace.v1.CreateSpaceRequest.metadata:type_name -> syncspace.v1.CreateSpaceRequest.MetadataEntry
+	0,   // 4: syncspace.v1.CreateInviteRequest.permission:type_name -> syncspace.v1.SpacePermission
+	29,  // 5: syncspace.v1.ListMembersResponse.members:type_name -> syncspace.v1.SpaceMember
+	0,   // 6: syncspace.v1.ApproveJoinRequestRequest.permission:type_name -> syncspace.v1.SpacePermission
+	29,  // 7: syncspace.v1.ApproveJoinRequestResponse.member:type_name -> syncspace.v1.SpaceMember
+	0,   // 8: syncspace.v1.ChangeMemberPermissionRequest.permission:type_name -> syncspace.v1.SpacePermission
+	29,  // 9: syncspace.v1.ChangeMemberPermissionResponse.member:type_name -> syncspace.v1.SpaceMember
+	28,  // 10: syncspace.v1.ListSpacesResponse.spaces:type_name -> syncspace.v1.SpaceInfo
+	116, // 11: syncspace.v1.SpaceInfo.metadata:type_name -> syncspace.v1.SpaceInfo.MetadataEntry
+	2,   // 12: syncspace.v1.SpaceInfo.sync_status:type_name -> syncspace.v1.SyncStatus
+	0,   // 13: syncspace.v1.SpaceMember.permission:type_name -> syncspace.v1.SpacePermission
+	1,   // 14: syncspace.v1.SpaceMember.status:type_name -> syncspace.v1.MemberStatus
+	117, // 15: syncspace.v1.UpdateSpaceRequest.metadata:type_name -> syncspace.v1.UpdateSpaceRequest.MetadataEntry
+	28,  // 16: syncspace.v1.UpdateSpaceResponse.space:type_name -> syncspace.v1.SpaceInfo
+	118, // 17: syncspace.v1.CreateDocumentRequest.metadata:type_name -> syncspace.v1.CreateDocumentRequest.MetadataEntry
+	38,  // 18: syncspace.v1.GetDocumentResponse.document:type_name -> syncspace.v1.Document
+	119, // 19: syncspace.v1.Document.metadata:type_name -> syncspace.v1.Document.MetadataEntry
+	120, // 20: syncspace.v1.UpdateDocumentRequest.metadata:type_name -> syncspace.v1.UpdateDocumentRequest.MetadataEntry
+	45,  // 21: syncspace.v1.ListDocumentsResponse.documents:type_name -> syncspace.v1.DocumentInfo
+	121, // 22: syncspace.v1.DocumentInfo.metadata:type_name -> syncspace.v1.DocumentInfo.MetadataEntry
+	47,  // 23: syncspace.v1.QueryDocumentsRequest.filters:type_name -> syncspace.v1.QueryFilter
+	45,  // 24: syncspace.v1.QueryDocumentsResponse.documents:type_name -> syncspace.v1.DocumentInfo
+	55,  // 25: syncspace.v1.GetSyncStatusResponse.statuses:type_name -> syncspace.v1.SpaceSyncStatus
+	2,   // 26: syncspace.v1.SpaceSyncStatus.status:type_name -> syncspace.v1.SyncStatus
+	122, // 27: syncspace.v1.SpaceUpdatedEvent.old_metadata:type_name -> syncspace.v1.SpaceUpdatedEvent.OldMetadataEntry
+	123, // 28: syncspace.v1.SpaceUpdatedEvent.new_metadata:type_name -> syncspace.v1.SpaceUpdatedEvent.NewMetadataEntry
+	2,   // 29: syncspace.v1.SyncStatusChangedEvent.old_status:type_name -> syncspace.v1.SyncStatus
+	2,   // 30: syncspace.v1.SyncStatusChangedEvent.new_status:type_name -> syncspace.v1.SyncStatus
+	4,   // 31: syncspace.v1.BatchRequest.commands:type_name -> syncspace.v1.Command
+	5,   // 32: syncspace.v1.BatchResponse.results:type_name -> syncspace.v1.CommandResponse
+	68,  // 33: syncspace.v1.DescribeCommandsResponse.commands:type_name -> syncspace.v1.CommandInfo
+	3,   // 34: syncspace.v1.CommandError.code:type_name -> syncspace.v1.ErrorCode
+	124, // 35: syncspace.v1.CommandError.details:type_name -> syncspace.v1.CommandError.DetailsEntry
+	69,  // 36: syncspace.v1.StreamMessage.error:type_name -> syncspace.v1.CommandError
+	71,  // 37: syncspace.v1.CreateProfileResponse.profile:type_name -> syncspace.v1.ProfileInfo
+	71,  // 38: syncspace.v1.ListProfilesResponse.profiles:type_name -> syncspace.v1.ProfileInfo
+	71,  // 39: syncspace.v1.OpenProfileResponse.profile:type_name -> syncspace.v1.ProfileInfo
+	84,  // 40: syncspace.v1.GetConfigResponse.config:type_name -> syncspace.v1.BackendConfig
+	125, // 41: syncspace.v1.BackendConfig.command_timeouts_ms:type_name -> syncspace.v1.BackendConfig.CommandTimeoutsMsEntry
+	87,  // 42: syncspace.v1.GetStatusResponse.spaces:type_name -> syncspace.v1.SpaceDiagnostics
+	102, // 43: syncspace.v1.ListDevicesResponse.devices:type_name -> syncspace.v1.DeviceInfo
+	102, // 44: syncspace.v1.RotateDeviceKeyResponse.device:type_name -> syncspace.v1.DeviceInfo
+	102, // 45: syncspace.v1.RevokeDeviceResponse.device:type_name -> syncspace.v1.DeviceInfo
+	6,   // 46: syncspace.v1.SyncSpaceService.Init:input_type -> syncspace.v1.InitRequest
+	8,   // 47: syncspace.v1.SyncSpaceService.Shutdown:input_type -> syncspace.v1.ShutdownRequest
+	10,  // 48: syncspace.v1.SyncSpaceService.CreateSpace:input_type -> syncspace.v1.CreateSpaceRequest
+	12,  // 49: syncspace.v1.SyncSpaceService.CreateInvite:input_type -> syncspace.v1.CreateInviteRequest
+	14,  // 50: syncspace.v1.SyncSpaceService.JoinSpace:input_type -> syncspace.v1.JoinSpaceRequest
+	16,  // 51: syncspace.v1.SyncSpaceService.ListMembers:input_type -> syncspace.v1.ListMembersRequest
+	18,  // 52: syncspace.v1.SyncSpaceService.ApproveJoinRequest:input_type -> syncspace.v1.ApproveJoinRequestRequest
+	20,  // 53: syncspace.v1.SyncSpaceService.RemoveMember:input_type -> syncspace.v1.RemoveMemberRequest
+	22,  // 54: syncspace.v1.SyncSpaceService.ChangeMemberPermission:input_type -> syncspace.v1.ChangeMemberPermissionRequest
+	24,  // 55: syncspace.v1.SyncSpaceService.LeaveSpace:input_type -> syncspace.v1.LeaveSpaceRequest
+	26,  // 56: syncspace.v1.SyncSpaceService.ListSpaces:input_type -> syncspace.v1.ListSpacesRequest
+	30,  // 57: syncspace.v1.SyncSpaceService.UpdateSpace:input_type -> syncspace.v1.UpdateSpaceRequest
+	32,  // 58: syncspace.v1.SyncSpaceService.DeleteSpace:input_type -> syncspace.v1.DeleteSpaceRequest
+	34,  // 59: syncspace.v1.SyncSpaceService.CreateDocument:input_type -> syncspace.v1.CreateDocumentRequest
+	36,  // 60: syncspace.v1.SyncSpaceService.GetDocument:input_type -> syncspace.v1.GetDocumentRequest
+	39,  // 61: syncspace.v1.SyncSpaceService.UpdateDocument:input_type -> syncspace.v1.UpdateDocumentRequest
+	41,  // 62: syncspace.v1.SyncSpaceService.DeleteDocument:input_type -> syncspace.v1.DeleteDocumentRequest
+	43,  // 63: syncspace.v1.SyncSpaceService.ListDocuments:input_type -> syncspace.v1.ListDocumentsRequest
+	46,  // 64: syncspace.v1.SyncSpaceService.QueryDocuments:input_type -> syncspace.v1.QueryDocumentsRequest
+	49,  // 65: syncspace.v1.SyncSpaceService.StartSync:input_type -> syncspace.v1.StartSyncRequest
+	51,  // 66: syncspace.v1.SyncSpaceService.PauseSync:input_type -> syncspace.v1.PauseSyncRequest
+	53,  // 67: syncspace.v1.SyncSpaceService.GetSyncStatus:input_type -> syncspace.v1.GetSyncStatusRequest
+	64,  // 68: syncspace.v1.SyncSpaceService.Batch:input_type -> syncspace.v1.BatchRequest
+	66,  // 69: syncspace.v1.SyncSpaceService.DescribeCommands:input_type -> syncspace.v1.DescribeCommandsRequest
+	82,  // 70: syncspace.v1.SyncSpaceService.GetConfig:input_type -> syncspace.v1.GetConfigRequest
+	85,  // 71: syncspace.v1.SyncSpaceService.GetStatus:input_type -> syncspace.v1.GetStatusRequest
+	88,  // 72: syncspace.v1.SyncSpaceService.SetPassphrase:input_type -> syncspace.v1.SetPassphraseRequest
+	90,  // 73: syncspace.v1.SyncSpaceService.ChangePassphrase:input_type -> syncspace.v1.ChangePassphraseRequest
+	92,  // 74: syncspace.v1.SyncSpaceService.RemovePassphrase:input_type -> syncspace.v1.RemovePassphraseRequest
+	94,  // 75: syncspace.v1.SyncSpaceService.ExportMnemonic:input_type -> syncspace.v1.ExportMnemonicRequest
+	96,  // 76: syncspace.v1.SyncSpaceService.Lock:input_type -> syncspace.v1.LockRequest
+	98,  // 77: syncspace.v1.SyncSpaceService.Unlock:input_type -> syncspace.v1.UnlockRequest
+	100, // 78: syncspace.v1.SyncSpaceService.DeleteAccount:input_type -> syncspace.v1.DeleteAccountRequest
+	103, // 79: syncspace.v1.SyncSpaceService.ListDevices:input_type -> syncspace.v1.ListDevicesRequest
+	105, // 80: syncspace.v1.SyncSpaceService.RotateDeviceKey:input_type -> syncspace.v1.RotateDeviceKeyRequest
+	107, // 81: syncspace.v1.SyncSpaceService.RevokeDevice:input_type -> syncspace.v1.RevokeDeviceRequest
+	109, // 82: syncspace.v1.SyncSpaceService.ExportAccount:input_type -> syncspace.v1.ExportAccountRequest
+	111, // 83: syncspace.v1.SyncSpaceService.ImportAccount:input_type -> syncspace.v1.ImportAccountRequest
+	56,  // 84: syncspace.v1.SyncSpaceService.Subscribe:input_type -> syncspace.v1.SubscribeRequest
+	72,  // 85: syncspace.v1.SyncSpaceService.CreateProfile:input_type -> syncspace.v1.CreateProfileRequest
+	74,  // 86: syncspace.v1.SyncSpaceService.ListProfiles:input_type -> syncspace.v1.ListProfilesRequest
+	76,  // 87: syncspace.v1.SyncSpaceService.OpenProfile:input_type -> syncspace.v1.OpenProfileRequest
+	78,  // 88: syncspace.v1.SyncSpaceService.CloseProfile:input_type -> syncspace.v1.CloseProfileRequest
+	80,  // 89: syncspace.v1.SyncSpaceService.DeleteProfile:input_type -> syncspace.v1.DeleteProfileRequest
+	7,   // 90: syncspace.v1.SyncSpaceService.Init:output_type -> syncspace.v1.InitResponse
+	9,   // 91: syncspace.v1.SyncSpaceService.Shutdown:output_type -> syncspace.v1.ShutdownResponse
+	11,  // 92: syncspace.v1.SyncSpaceService.CreateSpace:output_type -> syncspace.v1.CreateSpaceResponse
+	13,  // 93: syncspace.v1.SyncSpaceService.CreateInvite:output_type -> syncspace.v1.CreateInviteResponse
+	15,  // 94: syncspace.v1.SyncSpaceService.JoinSpace:output_type -> syncspace.v1.JoinSpaceResponse
+	17,  // 95: syncspace.v1.SyncSpaceService.ListMembers:output_type -> syncspace.v1.ListMembersResponse
+	19,  // 96: syncspace.v1.SyncSpaceService.ApproveJoinRequest:output_type -> syncspace.v1.ApproveJoinRequestResponse
+	21,  // 97: syncspace.v1.SyncSpaceService.RemoveMember:output_type -> syncspace.v1.RemoveMemberResponse
+	23,  // 98: syncspace.v1.SyncSpaceService.ChangeMemberPermission:output_type -> syncspace.v1.ChangeMemberPermissionResponse
+	25,  // 99: syncspace.v1.SyncSpaceService.LeaveSpace:output_type -> syncspace.v1.LeaveSpaceResponse
+	27,  // 100: syncspace.v1.SyncSpaceService.ListSpaces:output_type -> syncspace.v1.ListSpacesResponse
+	31,  // 101: syncspace.v1.SyncSpaceService.UpdateSpace:output_type -> syncspace.v1.UpdateSpaceResponse
+	33,  // 102: syncspace.v1.SyncSpaceService.DeleteSpace:output_type -> syncspace.v1.DeleteSpaceResponse
+	35,  // 103: syncspace.v1.SyncSpaceService.CreateDocument:output_type -> syncspace.v1.CreateDocumentResponse
+	37,  // 104: syncspace.v1.SyncSpaceService.GetDocument:output_type -> syncspace.v1.GetDocumentResponse
+	40,  // 105: syncspace.v1.SyncSpaceService.UpdateDocument:output_type -> syncspace.v1.UpdateDocumentResponse
+	42,  // 106: syncspace.v1.SyncSpaceService.DeleteDocument:output_type -> syncspace.v1.DeleteDocumentResponse
+	44,  // 107: syncspace.v1.SyncSpaceService.ListDocuments:output_type -> syncspace.v1.ListDocumentsResponse
+	48,  // 108: syncspace.v1.SyncSpaceService.QueryDocuments:output_type -> syncspace.v1.QueryDocumentsResponse
+	50,  // 109: syncspace.v1.SyncSpaceService.StartSync:output_type -> syncspace.v1.StartSyncResponse
+	52,  // 110: syncspace.v1.SyncSpaceService.PauseSync:output_type -> syncspace.v1.PauseSyncResponse
+	54,  // 111: syncspace.v1.SyncSpaceService.GetSyncStatus:output_type -> syncspace.v1.GetSyncStatusResponse
+	65,  // 112: syncspace.v1.SyncSpaceService.Batch:output_type -> syncspace.v1.BatchResponse
+	67,  // 113: syncspace.v1.SyncSpaceService.DescribeCommands:output_type -> syncspace.v1.DescribeCommandsResponse
+	83,  // 114: syncspace.v1.SyncSpaceService.GetConfig:output_type -> syncspace.v1.GetConfigResponse
+	86,  // 115: syncspace.v1.SyncSpaceService.GetStatus:output_type -> syncspace.v1.GetStatusResponse
+	89,  // 116: syncspace.v1.SyncSpaceService.SetPassphrase:output_type -> syncspace.v1.SetPassphraseResponse
+	91,  // 117: syncspace.v1.SyncSpaceService.ChangePassphrase:output_type -> syncspace.v1.ChangePassphraseResponse
+	93,  // 118: syncspace.v1.SyncSpaceService.RemovePassphrase:output_type -> syncspace.v1.RemovePassphraseResponse
+	95,  // 119: syncspace.v1.SyncSpaceService.ExportMnemonic:output_type -> syncspace.v1.ExportMnemonicResponse
+	97,  // 120: syncspace.v1.SyncSpaceService.Lock:output_type -> syncspace.v1.LockResponse
+	99,  // 121: syncspace.v1.SyncSpaceService.Unlock:output_type -> syncspace.v1.UnlockResponse
+	101, // 122: syncspace.v1.SyncSpaceService.DeleteAccount:output_type -> syncspace.v1.DeleteAccountResponse
+	104, // 123: syncspace.v1.SyncSpaceService.ListDevices:output_type -> syncspace.v1.ListDevicesResponse
+	106, // 124: syncspace.v1.SyncSpaceService.RotateDeviceKey:output_type -> syncspace.v1.RotateDeviceKeyResponse
+	108, // 125: syncspace.v1.SyncSpaceService.RevokeDevice:output_type -> syncspace.v1.RevokeDeviceResponse
+	110, // 126: syncspace.v1.SyncSpaceService.ExportAccount:output_type -> syncspace.v1.ExportAccountResponse
+	112, // 127: syncspace.v1.SyncSpaceService.ImportAccount:output_type -> syncspace.v1.ImportAccountResponse
+	57,  // 128: syncspace.v1.SyncSpaceService.Subscribe:output_type -> syncspace.v1.SubscribeResponse
+	73,  // 129: syncspace.v1.SyncSpaceService.CreateProfile:output_type -> syncspace.v1.CreateProfileResponse
+	75,  // 130: syncspace.v1.SyncSpaceService.ListProfiles:output_type -> syncspace.v1.ListProfilesResponse
+	77,  // 131: syncspace.v1.SyncSpaceService.OpenProfile:output_type -> syncspace.v1.OpenProfileResponse
+	79,  // 132: syncspace.v1.SyncSpaceService.CloseProfile:output_type -> syncspace.v1.CloseProfileResponse
+	81,  // 133: syncspace.v1.SyncSpaceService.DeleteProfile:output_type -> syncspace.v1.DeleteProfileResponse
+	90,  // [90:134] is the sub-list for method output_type
+	46,  // [46:90] is the sub-list for method input_type
+	46,  // [46:46] is the sub-list for extension type_name
+	46,  // [46:46] is the sub-list for extension extendee
+	0,   // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_syncspace_v1_syncspace_proto_init() }
@@ -2915,13 +7291,14 @@ func file_syncspace_v1_syncspace_proto_init() {
 	if File_syncspace_v1_syncspace_proto != nil {
 		return
 	}
+	file_syncspace_v1_syncspace_proto_msgTypes[26].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_syncspace_v1_syncspace_proto_rawDesc), len(file_syncspace_v1_syncspace_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   52,
+			NumEnums:      4,
+			NumMessages:   122,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
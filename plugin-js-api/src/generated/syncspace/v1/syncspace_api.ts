@@ -33,6 +33,14 @@ export type CreateSpaceResponse = Expand<
   Omit<pb.CreateSpaceResponse, keyof Message<"syncspace.v1.CreateSpaceResponse">>
 >;
 
+export type CreateInviteRequest = Expand<
+  Omit<pb.CreateInviteRequest, keyof Message<"syncspace.v1.CreateInviteRequest">>
+>;
+
+export type CreateInviteResponse = Expand<
+  Omit<pb.CreateInviteResponse, keyof Message<"syncspace.v1.CreateInviteResponse">>
+>;
+
 export type JoinSpaceRequest = Expand<
   Omit<pb.JoinSpaceRequest, keyof Message<"syncspace.v1.JoinSpaceRequest">>
 >;
@@ -41,6 +49,38 @@ export type JoinSpaceResponse = Expand<
   Omit<pb.JoinSpaceResponse, keyof Message<"syncspace.v1.JoinSpaceResponse">>
 >;
 
+export type ListMembersRequest = Expand<
+  Omit<pb.ListMembersRequest, keyof Message<"syncspace.v1.ListMembersRequest">>
+>;
+
+export type ListMembersResponse = Expand<
+  Omit<pb.ListMembersResponse, keyof Message<"syncspace.v1.ListMembersResponse">>
+>;
+
+export type ApproveJoinRequestRequest = Expand<
+  Omit<pb.ApproveJoinRequestRequest, keyof Message<"syncspace.v1.ApproveJoinRequestRequest">>
+>;
+
+export type ApproveJoinRequestResponse = Expand<
+  Omit<pb.ApproveJoinRequestResponse, keyof Message<"syncspace.v1.ApproveJoinRequestResponse">>
+>;
+
+export type RemoveMemberRequest = Expand<
+  Omit<pb.RemoveMemberRequest, keyof Message<"syncspace.v1.RemoveMemberRequest">>
+>;
+
+export type RemoveMemberResponse = Expand<
+  Omit<pb.RemoveMemberResponse, keyof Message<"syncspace.v1.RemoveMemberResponse">>
+>;
+
+export type ChangeMemberPermissionRequest = Expand<
+  Omit<pb.ChangeMemberPermissionRequest, keyof Message<"syncspace.v1.ChangeMemberPermissionRequest">>
+>;
+
+export type ChangeMemberPermissionResponse = Expand<
+  Omit<pb.ChangeMemberPermissionResponse, keyof Message<"syncspace.v1.ChangeMemberPermissionResponse">>
+>;
+
 export type LeaveSpaceRequest = Expand<
   Omit<pb.LeaveSpaceRequest, keyof Message<"syncspace.v1.LeaveSpaceRequest">>
 >;
@@ -59,6 +99,16 @@ export type ListSpacesResponse = Expand<
 
 export type SpaceInfo = Expand<Omit<pb.SpaceInfo, keyof Message<"syncspace.v1.SpaceInfo">>>;
 
+export type SpaceMember = Expand<Omit<pb.SpaceMember, keyof Message<"syncspace.v1.SpaceMember">>>;
+
+export type UpdateSpaceRequest = Expand<
+  Omit<pb.UpdateSpaceRequest, keyof Message<"syncspace.v1.UpdateSpaceRequest">>
+>;
+
+export type UpdateSpaceResponse = Expand<
+  Omit<pb.UpdateSpaceResponse, keyof Message<"syncspace.v1.UpdateSpaceResponse">>
+>;
+
 export type DeleteSpaceRequest = Expand<
   Omit<pb.DeleteSpaceRequest, keyof Message<"syncspace.v1.DeleteSpaceRequest">>
 >;
@@ -171,10 +221,206 @@ export type DocumentDeletedEvent = Expand<
   Omit<pb.DocumentDeletedEvent, keyof Message<"syncspace.v1.DocumentDeletedEvent">>
 >;
 
+export type SpaceUpdatedEvent = Expand<
+  Omit<pb.SpaceUpdatedEvent, keyof Message<"syncspace.v1.SpaceUpdatedEvent">>
+>;
+
+export type SpaceMemberEvent = Expand<
+  Omit<pb.SpaceMemberEvent, keyof Message<"syncspace.v1.SpaceMemberEvent">>
+>;
+
 export type SyncStatusChangedEvent = Expand<
   Omit<pb.SyncStatusChangedEvent, keyof Message<"syncspace.v1.SyncStatusChangedEvent">>
 >;
 
+export type BatchRequest = Expand<
+  Omit<pb.BatchRequest, keyof Message<"syncspace.v1.BatchRequest">>
+>;
+
+export type BatchResponse = Expand<
+  Omit<pb.BatchResponse, keyof Message<"syncspace.v1.BatchResponse">>
+>;
+
+export type DescribeCommandsRequest = Expand<
+  Omit<pb.DescribeCommandsRequest, keyof Message<"syncspace.v1.DescribeCommandsRequest">>
+>;
+
+export type DescribeCommandsResponse = Expand<
+  Omit<pb.DescribeCommandsResponse, keyof Message<"syncspace.v1.DescribeCommandsResponse">>
+>;
+
+export type CommandInfo = Expand<Omit<pb.CommandInfo, keyof Message<"syncspace.v1.CommandInfo">>>;
+
+export type CommandError = Expand<
+  Omit<pb.CommandError, keyof Message<"syncspace.v1.CommandError">>
+>;
+
+export type StreamMessage = Expand<
+  Omit<pb.StreamMessage, keyof Message<"syncspace.v1.StreamMessage">>
+>;
+
+export type ProfileInfo = Expand<Omit<pb.ProfileInfo, keyof Message<"syncspace.v1.ProfileInfo">>>;
+
+export type CreateProfileRequest = Expand<
+  Omit<pb.CreateProfileRequest, keyof Message<"syncspace.v1.CreateProfileRequest">>
+>;
+
+export type CreateProfileResponse = Expand<
+  Omit<pb.CreateProfileResponse, keyof Message<"syncspace.v1.CreateProfileResponse">>
+>;
+
+export type ListProfilesRequest = Expand<
+  Omit<pb.ListProfilesRequest, keyof Message<"syncspace.v1.ListProfilesRequest">>
+>;
+
+export type ListProfilesResponse = Expand<
+  Omit<pb.ListProfilesResponse, keyof Message<"syncspace.v1.ListProfilesResponse">>
+>;
+
+export type OpenProfileRequest = Expand<
+  Omit<pb.OpenProfileRequest, keyof Message<"syncspace.v1.OpenProfileRequest">>
+>;
+
+export type OpenProfileResponse = Expand<
+  Omit<pb.OpenProfileResponse, keyof Message<"syncspace.v1.OpenProfileResponse">>
+>;
+
+export type CloseProfileRequest = Expand<
+  Omit<pb.CloseProfileRequest, keyof Message<"syncspace.v1.CloseProfileRequest">>
+>;
+
+export type CloseProfileResponse = Expand<
+  Omit<pb.CloseProfileResponse, keyof Message<"syncspace.v1.CloseProfileResponse">>
+>;
+
+export type DeleteProfileRequest = Expand<
+  Omit<pb.DeleteProfileRequest, keyof Message<"syncspace.v1.DeleteProfileRequest">>
+>;
+
+export type DeleteProfileResponse = Expand<
+  Omit<pb.DeleteProfileResponse, keyof Message<"syncspace.v1.DeleteProfileResponse">>
+>;
+
+export type GetConfigRequest = Expand<
+  Omit<pb.GetConfigRequest, keyof Message<"syncspace.v1.GetConfigRequest">>
+>;
+
+export type GetConfigResponse = Expand<
+  Omit<pb.GetConfigResponse, keyof Message<"syncspace.v1.GetConfigResponse">>
+>;
+
+export type BackendConfig = Expand<
+  Omit<pb.BackendConfig, keyof Message<"syncspace.v1.BackendConfig">>
+>;
+
+export type GetStatusRequest = Expand<
+  Omit<pb.GetStatusRequest, keyof Message<"syncspace.v1.GetStatusRequest">>
+>;
+
+export type GetStatusResponse = Expand<
+  Omit<pb.GetStatusResponse, keyof Message<"syncspace.v1.GetStatusResponse">>
+>;
+
+export type SpaceDiagnostics = Expand<
+  Omit<pb.SpaceDiagnostics, keyof Message<"syncspace.v1.SpaceDiagnostics">>
+>;
+
+export type SetPassphraseRequest = Expand<
+  Omit<pb.SetPassphraseRequest, keyof Message<"syncspace.v1.SetPassphraseRequest">>
+>;
+
+export type SetPassphraseResponse = Expand<
+  Omit<pb.SetPassphraseResponse, keyof Message<"syncspace.v1.SetPassphraseResponse">>
+>;
+
+export type ChangePassphraseRequest = Expand<
+  Omit<pb.ChangePassphraseRequest, keyof Message<"syncspace.v1.ChangePassphraseRequest">>
+>;
+
+export type ChangePassphraseResponse = Expand<
+  Omit<pb.ChangePassphraseResponse, keyof Message<"syncspace.v1.ChangePassphraseResponse">>
+>;
+
+export type RemovePassphraseRequest = Expand<
+  Omit<pb.RemovePassphraseRequest, keyof Message<"syncspace.v1.RemovePassphraseRequest">>
+>;
+
+export type RemovePassphraseResponse = Expand<
+  Omit<pb.RemovePassphraseResponse, keyof Message<"syncspace.v1.RemovePassphraseResponse">>
+>;
+
+export type ExportMnemonicRequest = Expand<
+  Omit<pb.ExportMnemonicRequest, keyof Message<"syncspace.v1.ExportMnemonicRequest">>
+>;
+
+export type ExportMnemonicResponse = Expand<
+  Omit<pb.ExportMnemonicResponse, keyof Message<"syncspace.v1.ExportMnemonicResponse">>
+>;
+
+export type LockRequest = Expand<Omit<pb.LockRequest, keyof Message<"syncspace.v1.LockRequest">>>;
+
+export type LockResponse = Expand<
+  Omit<pb.LockResponse, keyof Message<"syncspace.v1.LockResponse">>
+>;
+
+export type UnlockRequest = Expand<
+  Omit<pb.UnlockRequest, keyof Message<"syncspace.v1.UnlockRequest">>
+>;
+
+export type UnlockResponse = Expand<
+  Omit<pb.UnlockResponse, keyof Message<"syncspace.v1.UnlockResponse">>
+>;
+
+export type DeleteAccountRequest = Expand<
+  Omit<pb.DeleteAccountRequest, keyof Message<"syncspace.v1.DeleteAccountRequest">>
+>;
+
+export type DeleteAccountResponse = Expand<
+  Omit<pb.DeleteAccountResponse, keyof Message<"syncspace.v1.DeleteAccountResponse">>
+>;
+
+export type DeviceInfo = Expand<Omit<pb.DeviceInfo, keyof Message<"syncspace.v1.DeviceInfo">>>;
+
+export type ListDevicesRequest = Expand<
+  Omit<pb.ListDevicesRequest, keyof Message<"syncspace.v1.ListDevicesRequest">>
+>;
+
+export type ListDevicesResponse = Expand<
+  Omit<pb.ListDevicesResponse, keyof Message<"syncspace.v1.ListDevicesResponse">>
+>;
+
+export type RotateDeviceKeyRequest = Expand<
+  Omit<pb.RotateDeviceKeyRequest, keyof Message<"syncspace.v1.RotateDeviceKeyRequest">>
+>;
+
+export type RotateDeviceKeyResponse = Expand<
+  Omit<pb.RotateDeviceKeyResponse, keyof Message<"syncspace.v1.RotateDeviceKeyResponse">>
+>;
+
+export type RevokeDeviceRequest = Expand<
+  Omit<pb.RevokeDeviceRequest, keyof Message<"syncspace.v1.RevokeDeviceRequest">>
+>;
+
+export type RevokeDeviceResponse = Expand<
+  Omit<pb.RevokeDeviceResponse, keyof Message<"syncspace.v1.RevokeDeviceResponse">>
+>;
+
+export type ExportAccountRequest = Expand<
+  Omit<pb.ExportAccountRequest, keyof Message<"syncspace.v1.ExportAccountRequest">>
+>;
+
+export type ExportAccountResponse = Expand<
+  Omit<pb.ExportAccountResponse, keyof Message<"syncspace.v1.ExportAccountResponse">>
+>;
+
+export type ImportAccountRequest = Expand<
+  Omit<pb.ImportAccountRequest, keyof Message<"syncspace.v1.ImportAccountRequest">>
+>;
+
+export type ImportAccountResponse = Expand<
+  Omit<pb.ImportAccountResponse, keyof Message<"syncspace.v1.ImportAccountResponse">>
+>;
+
 /**
  * SyncSpaceService provides the complete SyncSpace API for spaces, documents, and synchronization
  * Note: This service definition is for documentation and TypeScript client generation.
@@ -221,8 +467,13 @@ export class SyncSpaceClient {
   /**
    * @generated from rpc syncspace.v1.SyncSpaceService.Shutdown
    */
-  public async shutdown(): Promise<ShutdownResponse> {
-    return await this.dispatch("Shutdown", pb.ShutdownRequestSchema, pb.ShutdownResponseSchema, {});
+  public async shutdown(request: ShutdownRequest): Promise<ShutdownResponse> {
+    return await this.dispatch(
+      "Shutdown",
+      pb.ShutdownRequestSchema,
+      pb.ShutdownResponseSchema,
+      request,
+    );
   }
 
   /**
@@ -239,6 +490,18 @@ export class SyncSpaceClient {
     );
   }
 
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.CreateInvite
+   */
+  public async createInvite(request: CreateInviteRequest): Promise<CreateInviteResponse> {
+    return await this.dispatch(
+      "CreateInvite",
+      pb.CreateInviteRequestSchema,
+      pb.CreateInviteResponseSchema,
+      request,
+    );
+  }
+
   /**
    * @generated from rpc syncspace.v1.SyncSpaceService.JoinSpace
    */
@@ -251,6 +514,58 @@ export class SyncSpaceClient {
     );
   }
 
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.ListMembers
+   */
+  public async listMembers(request: ListMembersRequest): Promise<ListMembersResponse> {
+    return await this.dispatch(
+      "ListMembers",
+      pb.ListMembersRequestSchema,
+      pb.ListMembersResponseSchema,
+      request,
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.ApproveJoinRequest
+   */
+  public async approveJoinRequest(
+    request: ApproveJoinRequestRequest,
+  ): Promise<ApproveJoinRequestResponse> {
+    return await this.dispatch(
+      "ApproveJoinRequest",
+      pb.ApproveJoinRequestRequestSchema,
+      pb.ApproveJoinRequestResponseSchema,
+      request,
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.RemoveMember
+   */
+  public async removeMember(request: RemoveMemberRequest): Promise<RemoveMemberResponse> {
+    return await this.dispatch(
+      "RemoveMember",
+      pb.RemoveMemberRequestSchema,
+      pb.RemoveMemberResponseSchema,
+      request,
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.ChangeMemberPermission
+   */
+  public async changeMemberPermission(
+    request: ChangeMemberPermissionRequest,
+  ): Promise<ChangeMemberPermissionResponse> {
+    return await this.dispatch(
+      "ChangeMemberPermission",
+      pb.ChangeMemberPermissionRequestSchema,
+      pb.ChangeMemberPermissionResponseSchema,
+      request,
+    );
+  }
+
   /**
    * @generated from rpc syncspace.v1.SyncSpaceService.LeaveSpace
    */
@@ -275,6 +590,18 @@ export class SyncSpaceClient {
     );
   }
 
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.UpdateSpace
+   */
+  public async updateSpace(request: UpdateSpaceRequest): Promise<UpdateSpaceResponse> {
+    return await this.dispatch(
+      "UpdateSpace",
+      pb.UpdateSpaceRequestSchema,
+      pb.UpdateSpaceResponseSchema,
+      request,
+    );
+  }
+
   /**
    * @generated from rpc syncspace.v1.SyncSpaceService.DeleteSpace
    */
@@ -399,6 +726,197 @@ export class SyncSpaceClient {
     );
   }
 
+  /**
+   * Batch operations
+   *
+   * @generated from rpc syncspace.v1.SyncSpaceService.Batch
+   */
+  public async batch(request: BatchRequest): Promise<BatchResponse> {
+    return await this.dispatch("Batch", pb.BatchRequestSchema, pb.BatchResponseSchema, request);
+  }
+
+  /**
+   * Introspection
+   *
+   * @generated from rpc syncspace.v1.SyncSpaceService.DescribeCommands
+   */
+  public async describeCommands(): Promise<DescribeCommandsResponse> {
+    return await this.dispatch(
+      "DescribeCommands",
+      pb.DescribeCommandsRequestSchema,
+      pb.DescribeCommandsResponseSchema,
+      {},
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.GetConfig
+   */
+  public async getConfig(): Promise<GetConfigResponse> {
+    return await this.dispatch(
+      "GetConfig",
+      pb.GetConfigRequestSchema,
+      pb.GetConfigResponseSchema,
+      {},
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.GetStatus
+   */
+  public async getStatus(): Promise<GetStatusResponse> {
+    return await this.dispatch(
+      "GetStatus",
+      pb.GetStatusRequestSchema,
+      pb.GetStatusResponseSchema,
+      {},
+    );
+  }
+
+  /**
+   * Account security
+   *
+   * @generated from rpc syncspace.v1.SyncSpaceService.SetPassphrase
+   */
+  public async setPassphrase(request: SetPassphraseRequest): Promise<SetPassphraseResponse> {
+    return await this.dispatch(
+      "SetPassphrase",
+      pb.SetPassphraseRequestSchema,
+      pb.SetPassphraseResponseSchema,
+      request,
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.ChangePassphrase
+   */
+  public async changePassphrase(
+    request: ChangePassphraseRequest,
+  ): Promise<ChangePassphraseResponse> {
+    return await this.dispatch(
+      "ChangePassphrase",
+      pb.ChangePassphraseRequestSchema,
+      pb.ChangePassphraseResponseSchema,
+      request,
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.RemovePassphrase
+   */
+  public async removePassphrase(
+    request: RemovePassphraseRequest,
+  ): Promise<RemovePassphraseResponse> {
+    return await this.dispatch(
+      "RemovePassphrase",
+      pb.RemovePassphraseRequestSchema,
+      pb.RemovePassphraseResponseSchema,
+      request,
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.ExportMnemonic
+   */
+  public async exportMnemonic(request: ExportMnemonicRequest): Promise<ExportMnemonicResponse> {
+    return await this.dispatch(
+      "ExportMnemonic",
+      pb.ExportMnemonicRequestSchema,
+      pb.ExportMnemonicResponseSchema,
+      request,
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.Lock
+   */
+  public async lock(): Promise<LockResponse> {
+    return await this.dispatch("Lock", pb.LockRequestSchema, pb.LockResponseSchema, {});
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.Unlock
+   */
+  public async unlock(request: UnlockRequest): Promise<UnlockResponse> {
+    return await this.dispatch("Unlock", pb.UnlockRequestSchema, pb.UnlockResponseSchema, request);
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.DeleteAccount
+   */
+  public async deleteAccount(request: DeleteAccountRequest): Promise<DeleteAccountResponse> {
+    return await this.dispatch(
+      "DeleteAccount",
+      pb.DeleteAccountRequestSchema,
+      pb.DeleteAccountResponseSchema,
+      request,
+    );
+  }
+
+  /**
+   * Devices
+   *
+   * @generated from rpc syncspace.v1.SyncSpaceService.ListDevices
+   */
+  public async listDevices(): Promise<ListDevicesResponse> {
+    return await this.dispatch(
+      "ListDevices",
+      pb.ListDevicesRequestSchema,
+      pb.ListDevicesResponseSchema,
+      {},
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.RotateDeviceKey
+   */
+  public async rotateDeviceKey(): Promise<RotateDeviceKeyResponse> {
+    return await this.dispatch(
+      "RotateDeviceKey",
+      pb.RotateDeviceKeyRequestSchema,
+      pb.RotateDeviceKeyResponseSchema,
+      {},
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.RevokeDevice
+   */
+  public async revokeDevice(request: RevokeDeviceRequest): Promise<RevokeDeviceResponse> {
+    return await this.dispatch(
+      "RevokeDevice",
+      pb.RevokeDeviceRequestSchema,
+      pb.RevokeDeviceResponseSchema,
+      request,
+    );
+  }
+
+  /**
+   * Account transfer
+   *
+   * @generated from rpc syncspace.v1.SyncSpaceService.ExportAccount
+   */
+  public async exportAccount(request: ExportAccountRequest): Promise<ExportAccountResponse> {
+    return await this.dispatch(
+      "ExportAccount",
+      pb.ExportAccountRequestSchema,
+      pb.ExportAccountResponseSchema,
+      request,
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.ImportAccount
+   */
+  public async importAccount(request: ImportAccountRequest): Promise<ImportAccountResponse> {
+    return await this.dispatch(
+      "ImportAccount",
+      pb.ImportAccountRequestSchema,
+      pb.ImportAccountResponseSchema,
+      request,
+    );
+  }
+
   /**
    * Event streaming
    *
@@ -412,6 +930,68 @@ export class SyncSpaceClient {
       request,
     );
   }
+
+  /**
+   * Profile operations
+   *
+   * @generated from rpc syncspace.v1.SyncSpaceService.CreateProfile
+   */
+  public async createProfile(request: CreateProfileRequest): Promise<CreateProfileResponse> {
+    return await this.dispatch(
+      "CreateProfile",
+      pb.CreateProfileRequestSchema,
+      pb.CreateProfileResponseSchema,
+      request,
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.ListProfiles
+   */
+  public async listProfiles(): Promise<ListProfilesResponse> {
+    return await this.dispatch(
+      "ListProfiles",
+      pb.ListProfilesRequestSchema,
+      pb.ListProfilesResponseSchema,
+      {},
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.OpenProfile
+   */
+  public async openProfile(request: OpenProfileRequest): Promise<OpenProfileResponse> {
+    return await this.dispatch(
+      "OpenProfile",
+      pb.OpenProfileRequestSchema,
+      pb.OpenProfileResponseSchema,
+      request,
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.CloseProfile
+   */
+  public async closeProfile(request: CloseProfileRequest): Promise<CloseProfileResponse> {
+    return await this.dispatch(
+      "CloseProfile",
+      pb.CloseProfileRequestSchema,
+      pb.CloseProfileResponseSchema,
+      request,
+    );
+  }
+
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.DeleteProfile
+   */
+  public async deleteProfile(request: DeleteProfileRequest): Promise<DeleteProfileResponse> {
+    return await this.dispatch(
+      "DeleteProfile",
+      pb.DeleteProfileRequestSchema,
+      pb.DeleteProfileResponseSchema,
+      request,
+    );
+  }
 }
 
 export const syncspace = new SyncSpaceClient();
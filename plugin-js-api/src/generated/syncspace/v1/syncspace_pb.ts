@@ -12,7 +12,7 @@ import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf
 export const file_syncspace_v1_syncspace: GenFile =
   /*@__PURE__*/
   fileDesc(
-    "ChxzeW5jc3BhY2UvdjEvc3luY3NwYWNlLnByb3RvEgxzeW5jc3BhY2UudjEiKAoHQ29tbWFuZBIMCgRuYW1lGAEgASgJEg8KB3BheWxvYWQYAiABKAwiMQoPQ29tbWFuZFJlc3BvbnNlEg8KB3BheWxvYWQYASABKAwSDQoFZXJyb3IYAiABKAkirAEKC0luaXRSZXF1ZXN0EhAKCGRhdGFfZGlyGAEgASgJEhIKCm5ldHdvcmtfaWQYAiABKAkSEQoJZGV2aWNlX2lkGAMgASgJEjUKBmNvbmZpZxgEIAMoCzIlLnN5bmNzcGFjZS52MS5Jbml0UmVxdWVzdC5Db25maWdFbnRyeRotCgtDb25maWdFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIh8KDEluaXRSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIIhEKD1NodXRkb3duUmVxdWVzdCIjChBTaHV0ZG93blJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgipwEKEkNyZWF0ZVNwYWNlUmVxdWVzdBIQCghzcGFjZV9pZBgBIAEoCRIMCgRuYW1lGAIgASgJEkAKCG1ldGFkYXRhGAMgAygLMi4uc3luY3NwYWNlLnYxLkNyZWF0ZVNwYWNlUmVxdWVzdC5NZXRhZGF0YUVudHJ5Gi8KDU1ldGFkYXRhRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASInChNDcmVhdGVTcGFjZVJlc3BvbnNlEhAKCHNwYWNlX2lkGAEgASgJIjoKEEpvaW5TcGFjZVJlcXVlc3QSEAoIc3BhY2VfaWQYASABKAkSFAoMaW52aXRlX3Rva2VuGAIgASgJIiQKEUpvaW5TcGFjZVJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgiJQoRTGVhdmVTcGFjZVJlcXVlc3QSEAoIc3BhY2VfaWQYASABKAkiJQoSTGVhdmVTcGFjZVJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgiEwoRTGlzdFNwYWNlc1JlcXVlc3QiPQoSTGlzdFNwYWNlc1Jlc3BvbnNlEicKBnNwYWNlcxgBIAMoCzIXLnN5bmNzcGFjZS52MS5TcGFjZUluZm8i7AEKCVNwYWNlSW5mbxIQCghzcGFjZV9pZBgBIAEoCRIMCgRuYW1lGAIgASgJEjcKCG1ldGFkYXRhGAMgAygLMiUuc3luY3NwYWNlLnYxLlNwYWNlSW5mby5NZXRhZGF0YUVudHJ5EhIKCmNyZWF0ZWRfYXQYBCABKAMSEgoKdXBkYXRlZF9hdBgFIAEoAxItCgtzeW5jX3N0YXR1cxgGIAEoDjIYLnN5bmNzcGFjZS52MS5TeW5jU3RhdHVzGi8KDU1ldGFkYXRhRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASImChJEZWxldGVTcGFjZVJlcXVlc3QSEAoIc3BhY2VfaWQYASABKAkiJgoTRGVsZXRlU3BhY2VSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIItYBChVDcmVhdGVEb2N1bWVudFJlcXVlc3QSEAoIc3BhY2VfaWQYASABKAkSEwoLZG9jdW1lbnRfaWQYAiABKAkSEgoKY29sbGVjdGlvbhgDIAEoCRIMCgRkYXRhGAQgASgMEkMKCG1ldGFkYXRhGAUgAygLMjEuc3luY3NwYWNlLnYxLkNyZWF0ZURvY3VtZW50UmVxdWVzdC5NZXRhZGF0YUVudHJ5Gi8KDU1ldGFkYXRhRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASI+ChZDcmVhdGVEb2N1bWVudFJlc3BvbnNlEhMKC2RvY3VtZW50X2lkGAEgASgJEg8KB3ZlcnNpb24YAiABKAMiOwoSR2V0RG9jdW1lbnRSZXF1ZXN0EhAKCHNwYWNlX2lkGAEgASgJEhMKC2RvY3VtZW50X2lkGAIgASgJIk4KE0dldERvY3VtZW50UmVzcG9uc2USKAoIZG9jdW1lbnQYASABKAsyFi5zeW5jc3BhY2UudjEuRG9jdW1lbnQSDQoFZm91bmQYAiABKAgi9QEKCERvY3VtZW50EhMKC2RvY3VtZW50X2lkGAEgASgJEhAKCHNwYWNlX2lkGAIgASgJEhIKCmNvbGxlY3Rpb24YAyABKAkSDAoEZGF0YRgEIAEoDBI2CghtZXRhZGF0YRgFIAMoCzIkLnN5bmNzcGFjZS52MS5Eb2N1bWVudC5NZXRhZGF0YUVudHJ5Eg8KB3ZlcnNpb24YBiABKAMSEgoKY3JlYXRlZF9hdBgHIAEoAxISCgp1cGRhdGVkX2F0GAggASgDGi8KDU1ldGFkYXRhRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASLcAQoVVXBkYXRlRG9jdW1lbnRSZXF1ZXN0EhAKCHNwYWNlX2lkGAEgASgJEhMKC2RvY3VtZW50X2lkGAIgASgJEgwKBGRhdGEYAyABKAwSQwoIbWV0YWRhdGEYBCADKAsyMS5zeW5jc3BhY2UudjEuVXBkYXRlRG9jdW1lbnRSZXF1ZXN0Lk1ldGFkYXRhRW50cnkSGAoQZXhwZWN0ZWRfdmVyc2lvbhgFIAEoAxovCg1NZXRhZGF0YUVudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEiKQoWVXBkYXRlRG9jdW1lbnRSZXNwb25zZRIPCgd2ZXJzaW9uGAEgASgDIj4KFURlbGV0ZURvY3VtZW50UmVxdWVzdBIQCghzcGFjZV9pZBgBIAEoCRITCgtkb2N1bWVudF9pZBgCIAEoCSIpChZEZWxldGVEb2N1bWVudFJlc3BvbnNlEg8KB2V4aXN0ZWQYASABKAgiWwoUTGlzdERvY3VtZW50c1JlcXVlc3QSEAoIc3BhY2VfaWQYASABKAkSEgoKY29sbGVjdGlvbhgCIAEoCRINCgVsaW1pdBgDIAEoBRIOCgZjdXJzb3IYBCABKAkiWwoVTGlzdERvY3VtZW50c1Jlc3BvbnNlEi0KCWRvY3VtZW50cxgBIAMoCzIaLnN5bmNzcGFjZS52MS5Eb2N1bWVudEluZm8SEwoLbmV4dF9jdXJzb3IYAiABKAki3QEKDERvY3VtZW50SW5mbxITCgtkb2N1bWVudF9pZBgBIAEoCRISCgpjb2xsZWN0aW9uGAIgASgJEjoKCG1ldGFkYXRhGAMgAygLMiguc3luY3NwYWNlLnYxLkRvY3VtZW50SW5mby5NZXRhZGF0YUVudHJ5Eg8KB3ZlcnNpb24YBCABKAMSEgoKY3JlYXRlZF9hdBgFIAEoAxISCgp1cGRhdGVkX2F0GAYgASgDGi8KDU1ldGFkYXRhRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASKIAQoVUXVlcnlEb2N1bWVudHNSZXF1ZXN0EhAKCHNwYWNlX2lkGAEgASgJEhIKCmNvbGxlY3Rpb24YAiABKAkSKgoHZmlsdGVycxgDIAMoCzIZLnN5bmNzcGFjZS52MS5RdWVyeUZpbHRlchINCgVsaW1pdBgEIAEoBRIOCgZjdXJzb3IYBSABKAkiPQoLUXVlcnlGaWx0ZXISDQoFZmllbGQYASABKAkSEAoIb3BlcmF0b3IYAiABKAkSDQoFdmFsdWUYAyABKAkiXAoWUXVlcnlEb2N1bWVudHNSZXNwb25zZRItCglkb2N1bWVudHMYASADKAsyGi5zeW5jc3BhY2UudjEuRG9jdW1lbnRJbmZvEhMKC25leHRfY3Vyc29yGAIgASgJIiQKEFN0YXJ0U3luY1JlcXVlc3QSEAoIc3BhY2VfaWQYASABKAkiJAoRU3RhcnRTeW5jUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCIkChBQYXVzZVN5bmNSZXF1ZXN0EhAKCHNwYWNlX2lkGAEgASgJIiQKEVBhdXNlU3luY1Jlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgiKAoUR2V0U3luY1N0YXR1c1JlcXVlc3QSEAoIc3BhY2VfaWQYASABKAkiSAoVR2V0U3luY1N0YXR1c1Jlc3BvbnNlEi8KCHN0YXR1c2VzGAEgAygLMh0uc3luY3NwYWNlLnYxLlNwYWNlU3luY1N0YXR1cyKLAQoPU3BhY2VTeW5jU3RhdHVzEhAKCHNwYWNlX2lkGAEgASgJEigKBnN0YXR1cxgCIAEoDjIYLnN5bmNzcGFjZS52MS5TeW5jU3RhdHVzEhQKDGxhc3Rfc3luY19hdBgDIAEoAxIXCg9wZW5kaW5nX2NoYW5nZXMYBCABKAUSDQoFZXJyb3IYBSABKAkiOgoQU3Vic2NyaWJlUmVxdWVzdBITCgtldmVudF90eXBlcxgBIAMoCRIRCglzcGFjZV9pZHMYAiADKAkibwoRU3Vic2NyaWJlUmVzcG9uc2USEAoIZXZlbnRfaWQYASABKAkSEgoKZXZlbnRfdHlwZRgCIAEoCRIQCghzcGFjZV9pZBgDIAEoCRIRCgl0aW1lc3RhbXAYBCABKAMSDwoHcGF5bG9hZBgFIAEoDCI/ChREb2N1bWVudENyZWF0ZWRFdmVudBITCgtkb2N1bWVudF9pZBgBIAEoCRISCgpjb2xsZWN0aW9uGAIgASgJIlUKFERvY3VtZW50VXBkYXRlZEV2ZW50EhMKC2RvY3VtZW50X2lkGAEgASgJEhMKC29sZF92ZXJzaW9uGAIgASgDEhMKC25ld192ZXJzaW9uGAMgASgDIisKFERvY3VtZW50RGVsZXRlZEV2ZW50EhMKC2RvY3VtZW50X2lkGAEgASgJIoMBChZTeW5jU3RhdHVzQ2hhbmdlZEV2ZW50EiwKCm9sZF9zdGF0dXMYASABKA4yGC5zeW5jc3BhY2UudjEuU3luY1N0YXR1cxIsCgpuZXdfc3RhdHVzGAIgASgOMhguc3luY3NwYWNlLnYxLlN5bmNTdGF0dXMSDQoFZXJyb3IYAyABKAkqhwEKClN5bmNTdGF0dXMSGwoXU1lOQ19TVEFUVVNfVU5TUEVDSUZJRUQQABIUChBTWU5DX1NUQVRVU19JRExFEAESFwoTU1lOQ19TVEFUVVNfU1lOQ0lORxACEhYKElNZTkNfU1RBVFVTX1BBVVNFRBADEhUKEVNZTkNfU1RBVFVTX0VSUk9SEAQynAsKEFN5bmNTcGFjZVNlcnZpY2USPQoESW5pdBIZLnN5bmNzcGFjZS52MS5Jbml0UmVxdWVzdBoaLnN5bmNzcGFjZS52MS5Jbml0UmVzcG9uc2USSQoIU2h1dGRvd24SHS5zeW5jc3BhY2UudjEuU2h1dGRvd25SZXF1ZXN0Gh4uc3luY3NwYWNlLnYxLlNodXRkb3duUmVzcG9uc2USUgoLQ3JlYXRlU3BhY2USIC5zeW5jc3BhY2UudjEuQ3JlYXRlU3BhY2VSZXF1ZXN0GiEuc3luY3NwYWNlLnYxLkNyZWF0ZVNwYWNlUmVzcG9uc2USTAoJSm9pblNwYWNlEh4uc3luY3NwYWNlLnYxLkpvaW5TcGFjZVJlcXVlc3QaHy5zeW5jc3BhY2UudjEuSm9pblNwYWNlUmVzcG9uc2USTwoKTGVhdmVTcGFjZRIfLnN5bmNzcGFjZS52MS5MZWF2ZVNwYWNlUmVxdWVzdBogLnN5bmNzcGFjZS52MS5MZWF2ZVNwYWNlUmVzcG9uc2USTwoKTGlzdFNwYWNlcxIfLnN5bmNzcGFjZS52MS5MaXN0U3BhY2VzUmVxdWVzdBogLnN5bmNzcGFjZS52MS5MaXN0U3BhY2VzUmVzcG9uc2USUgoLRGVsZXRlU3BhY2USIC5zeW5jc3BhY2UudjEuRGVsZXRlU3BhY2VSZXF1ZXN0GiEuc3luY3NwYWNlLnYxLkRlbGV0ZVNwYWNlUmVzcG9uc2USWwoOQ3JlYXRlRG9jdW1lbnQSIy5zeW5jc3BhY2UudjEuQ3JlYXRlRG9jdW1lbnRSZXF1ZXN0GiQuc3luY3NwYWNlLnYxLkNyZWF0ZURvY3VtZW50UmVzcG9uc2USUgoLR2V0RG9jdW1lbnQSIC5zeW5jc3BhY2UudjEuR2V0RG9jdW1lbnRSZXF1ZXN0GiEuc3luY3NwYWNlLnYxLkdldERvY3VtZW50UmVzcG9uc2USWwoOVXBkYXRlRG9jdW1lbnQSIy5zeW5jc3BhY2UudjEuVXBkYXRlRG9jdW1lbnRSZXF1ZXN0GiQuc3luY3NwYWNlLnYxLlVwZGF0ZURvY3VtZW50UmVzcG9uc2USWwoORGVsZXRlRG9jdW1lbnQSIy5zeW5jc3BhY2UudjEuRGVsZXRlRG9jdW1lbnRSZXF1ZXN0GiQuc3luY3NwYWNlLnYxLkRlbGV0ZURvY3VtZW50UmVzcG9uc2USWAoNTGlzdERvY3VtZW50cxIiLnN5bmNzcGFjZS52MS5MaXN0RG9jdW1lbnRzUmVxdWVzdBojLnN5bmNzcGFjZS52MS5MaXN0RG9jdW1lbnRzUmVzcG9uc2USWwoOUXVlcnlEb2N1bWVudHMSIy5zeW5jc3BhY2UudjEuUXVlcnlEb2N1bWVudHNSZXF1ZXN0GiQuc3luY3NwYWNlLnYxLlF1ZXJ5RG9jdW1lbnRzUmVzcG9uc2USTAoJU3RhcnRTeW5jEh4uc3luY3NwYWNlLnYxLlN0YXJ0U3luY1JlcXVlc3QaHy5zeW5jc3BhY2UudjEuU3RhcnRTeW5jUmVzcG9uc2USTAoJUGF1c2VTeW5jEh4uc3luY3NwYWNlLnYxLlBhdXNlU3luY1JlcXVlc3QaHy5zeW5jc3BhY2UudjEuUGF1c2VTeW5jUmVzcG9uc2USWAoNR2V0U3luY1N0YXR1cxIiLnN5bmNzcGFjZS52MS5HZXRTeW5jU3RhdHVzUmVxdWVzdBojLnN5bmNzcGFjZS52MS5HZXRTeW5jU3RhdHVzUmVzcG9uc2USTgoJU3Vic2NyaWJlEh4uc3luY3NwYWNlLnYxLlN1YnNjcmliZVJlcXVlc3QaHy5zeW5jc3BhY2UudjEuU3Vic2NyaWJlUmVzcG9uc2UwAUKoAQoQY29tLnN5bmNzcGFjZS52MUIOU3luY3NwYWNlUHJvdG9QAVozYW55c3luYy1iYWNrZW5kL3NoYXJlZC9wcm90by9zeW5jc3BhY2UvdjE7c3luY3NwYWNlogIDU1hYqgIMU3luY3NwYWNlLlYxygIMU3luY3NwYWNlXFYx4gIYU3luY3NwYWNlXFYxXEdQQk1ldGFkYXRh6gINU3luY3NwYWNlOjpWMWIGcHJvdG8z",
+    "ChxzeW5jc3BhY2UvdjEvc3luY3NwYWNlLnByb3RvEgxzeW5jc3BhY2UudjEiKAoHQ29tbWFuZBIMCgRuYW1lGAEgASgJEg8KB3BheWxvYWQYAiABKAwiYwoPQ29tbWFuZFJlc3BvbnNlEg8KB3BheWxvYWQYASABKAwSDQoFZXJyb3IYAiABKAkSMAoMZXJyb3JfZGV0YWlsGAMgASgLMhouc3luY3NwYWNlLnYxLkNvbW1hbmRFcnJvciKhAwoLSW5pdFJlcXVlc3QSEAoIZGF0YV9kaXIYASABKAkSEgoKbmV0d29ya19pZBgCIAEoCRIRCglkZXZpY2VfaWQYAyABKAkSNQoGY29uZmlnGAQgAygLMiUuc3luY3NwYWNlLnYxLkluaXRSZXF1ZXN0LkNvbmZpZ0VudHJ5EhoKEmNvbW1hbmRfdGltZW91dF9tcxgFIAEoAxJNChNjb21tYW5kX3RpbWVvdXRzX21zGAYgAygLMjAuc3luY3NwYWNlLnYxLkluaXRSZXF1ZXN0LkNvbW1hbmRUaW1lb3V0c01zRW50cnkSEwoLY29uZmlnX2pzb24YByABKAkSEgoKcGFzc3BocmFzZRgIIAEoCRIQCghtbmVtb25pYxgJIAEoCRITCgtkZXZpY2VfbmFtZRgKIAEoCRotCgtDb25maWdFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBGjgKFkNvbW1hbmRUaW1lb3V0c01zRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgDOgI4ASIxCgxJbml0UmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCBIQCghyZXN0b3JlZBgCIAEoCCIlCg9TaHV0ZG93blJlcXVlc3QSEgoKdGltZW91dF9tcxgBIAEoAyIzChBTaHV0ZG93blJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgSDgoGZm9yY2VkGAIgASgIIqcBChJDcmVhdGVTcGFjZVJlcXVlc3QSEAoIc3BhY2VfaWQYASABKAkSDAoEbmFtZRgCIAEoCRJACghtZXRhZGF0YRgDIAMoCzIuLnN5bmNzcGFjZS52MS5DcmVhdGVTcGFjZVJlcXVlc3QuTWV0YWRhdGFFbnRyeRovCg1NZXRhZGF0YUVudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEiJwoTQ3JlYXRlU3BhY2VSZXNwb25zZRIQCghzcGFjZV9pZBgBIAEoCSKMAQoTQ3JlYXRlSW52aXRlUmVxdWVzdBIQCghzcGFjZV9pZBgBIAEoCRIxCgpwZXJtaXNzaW9uGAIgASgOMh0uc3luY3NwYWNlLnYxLlNwYWNlUGVybWlzc2lvbhIWCg5leHBpcmVzX2luX3NlYxgDIAEoAxIYChByZXF1aXJlX2FwcHJvdmFsGAQgASgIIlMKFENyZWF0ZUludml0ZVJlc3BvbnNlEhQKDGludml0ZV90b2tlbhgBIAEoCRIRCglpbnZpdGVfaWQYAiABKAkSEgoKZXhwaXJlc19hdBgDIAEoAyI6ChBKb2luU3BhY2VSZXF1ZXN0EhAKCHNwYWNlX2lkGAEgASgJEhQKDGludml0ZV90b2tlbhgCIAEoCSJHChFKb2luU3BhY2VSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIEhAKCHNwYWNlX2lkGAIgASgJEg8KB3BlbmRpbmcYAyABKAgiJgoSTGlzdE1lbWJlcnNSZXF1ZXN0EhAKCHNwYWNlX2lkGAEgASgJIkEKE0xpc3RNZW1iZXJzUmVzcG9uc2USKgoHbWVtYmVycxgBIAMoCzIZLnN5bmNzcGFjZS52MS5TcGFjZU1lbWJlciJyChlBcHByb3ZlSm9pblJlcXVlc3RSZXF1ZXN0EhAKCHNwYWNlX2lkGAEgASgJEhAKCGlkZW50aXR5GAIgASgJEjEKCnBlcm1pc3Npb24YAyABKA4yHS5zeW5jc3BhY2UudjEuU3BhY2VQZXJtaXNzaW9uIkcKGkFwcHJvdmVKb2luUmVxdWVzdFJlc3BvbnNlEikKBm1lbWJlchgBIAEoCzIZLnN5bmNzcGFjZS52MS5TcGFjZU1lbWJlciI5ChNSZW1vdmVNZW1iZXJSZXF1ZXN0EhAKCHNwYWNlX2lkGAEgASgJEhAKCGlkZW50aXR5GAIgASgJIicKFFJlbW92ZU1lbWJlclJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgidgodQ2hhbmdlTWVtYmVyUGVybWlzc2lvblJlcXVlc3QSEAoIc3BhY2VfaWQYASABKAkSEAoIaWRlbnRpdHkYAiABKAkSMQoKcGVybWlzc2lvbhgDIAEoDjIdLnN5bmNzcGFjZS52MS5TcGFjZVBlcm1pc3Npb24iSwoeQ2hhbmdlTWVtYmVyUGVybWlzc2lvblJlc3BvbnNlEikKBm1lbWJlchgBIAEoCzIZLnN5bmNzcGFjZS52MS5TcGFjZU1lbWJlciI7ChFMZWF2ZVNwYWNlUmVxdWVzdBIQCghzcGFjZV9pZBgBIAEoCRIUCgxkZWxldGVfbG9jYWwYAiABKAgiOAoSTGVhdmVTcGFjZVJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgSEQoJYWNsX2Vycm9yGAIgASgJIhMKEUxpc3RTcGFjZXNSZXF1ZXN0Ij0KEkxpc3RTcGFjZXNSZXNwb25zZRInCgZzcGFjZXMYASADKAsyFy5zeW5jc3BhY2UudjEuU3BhY2VJbmZvIowCCglTcGFjZUluZm8SEAoIc3BhY2VfaWQYASABKAkSDAoEbmFtZRgCIAEoCRI3CghtZXRhZGF0YRgDIAMoCzIlLnN5bmNzcGFjZS52MS5TcGFjZUluZm8uTWV0YWRhdGFFbnRyeRISCgpjcmVhdGVkX2F0GAQgASgDEhIKCnVwZGF0ZWRfYXQYBSABKAMSLQoLc3luY19zdGF0dXMYBiABKA4yGC5zeW5jc3BhY2UudjEuU3luY1N0YXR1cxINCgVhbGlhcxgHIAEoCRIPCgdsZWZ0X2F0GAggASgDGi8KDU1ldGFkYXRhRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASKlAQoLU3BhY2VNZW1iZXISEAoIaWRlbnRpdHkYASABKAkSMQoKcGVybWlzc2lvbhgCIAEoDjIdLnN5bmNzcGFjZS52MS5TcGFjZVBlcm1pc3Npb24SKgoGc3RhdHVzGAMgASgOMhouc3luY3NwYWNlLnYxLk1lbWJlclN0YXR1cxIRCglqb2luZWRfYXQYBCABKAMSEgoKdXBkYXRlZF9hdBgFIAEoAyLsAQoSVXBkYXRlU3BhY2VSZXF1ZXN0EhAKCHNwYWNlX2lkGAEgASgJEhEKBG5hbWUYAiABKAlIAIgBARJACghtZXRhZGF0YRgDIAMoCzIuLnN5bmNzcGFjZS52MS5VcGRhdGVTcGFjZVJlcXVlc3QuTWV0YWRhdGFFbnRyeRIYChByZXBsYWNlX21ldGFkYXRhGAQgASgIEhsKE2V4cGVjdGVkX3VwZGF0ZWRfYXQYBSABKAMaLwoNTWV0YWRhdGFFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBQgcKBV9uYW1lIj0KE1VwZGF0ZVNwYWNlUmVzcG9uc2USJgoFc3BhY2UYASABKAsyFy5zeW5jc3BhY2UudjEuU3BhY2VJbmZvIiYKEkRlbGV0ZVNwYWNlUmVxdWVzdBIQCghzcGFjZV9pZBgBIAEoCSImChNEZWxldGVTcGFjZVJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgi1gEKFUNyZWF0ZURvY3VtZW50UmVxdWVzdBIQCghzcGFjZV9pZBgBIAEoCRITCgtkb2N1bWVudF9pZBgCIAEoCRISCgpjb2xsZWN0aW9uGAMgASgJEgwKBGRhdGEYBCABKAwSQwoIbWV0YWRhdGEYBSADKAsyMS5zeW5jc3BhY2UudjEuQ3JlYXRlRG9jdW1lbnRSZXF1ZXN0Lk1ldGFkYXRhRW50cnkaLwoNTWV0YWRhdGFFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIj4KFkNyZWF0ZURvY3VtZW50UmVzcG9uc2USEwoLZG9jdW1lbnRfaWQYASABKAkSDwoHdmVyc2lvbhgCIAEoAyI7ChJHZXREb2N1bWVudFJlcXVlc3QSEAoIc3BhY2VfaWQYASABKAkSEwoLZG9jdW1lbnRfaWQYAiABKAkiTgoTR2V0RG9jdW1lbnRSZXNwb25zZRIoCghkb2N1bWVudBgBIAEoCzIWLnN5bmNzcGFjZS52MS5Eb2N1bWVudBINCgVmb3VuZBgCIAEoCCL1AQoIRG9jdW1lbnQSEwoLZG9jdW1lbnRfaWQYASABKAkSEAoIc3BhY2VfaWQYAiABKAkSEgoKY29sbGVjdGlvbhgDIAEoCRIMCgRkYXRhGAQgASgMEjYKCG1ldGFkYXRhGAUgAygLMiQuc3luY3NwYWNlLnYxLkRvY3VtZW50Lk1ldGFkYXRhRW50cnkSDwoHdmVyc2lvbhgGIAEoAxISCgpjcmVhdGVkX2F0GAcgASgDEhIKCnVwZGF0ZWRfYXQYCCABKAMaLwoNTWV0YWRhdGFFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBItwBChVVcGRhdGVEb2N1bWVudFJlcXVlc3QSEAoIc3BhY2VfaWQYASABKAkSEwoLZG9jdW1lbnRfaWQYAiABKAkSDAoEZGF0YRgDIAEoDBJDCghtZXRhZGF0YRgEIAMoCzIxLnN5bmNzcGFjZS52MS5VcGRhdGVEb2N1bWVudFJlcXVlc3QuTWV0YWRhdGFFbnRyeRIYChBleHBlY3RlZF92ZXJzaW9uGAUgASgDGi8KDU1ldGFkYXRhRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASIpChZVcGRhdGVEb2N1bWVudFJlc3BvbnNlEg8KB3ZlcnNpb24YASABKAMiPgoVRGVsZXRlRG9jdW1lbnRSZXF1ZXN0EhAKCHNwYWNlX2lkGAEgASgJEhMKC2RvY3VtZW50X2lkGAIgASgJIikKFkRlbGV0ZURvY3VtZW50UmVzcG9uc2USDwoHZXhpc3RlZBgBIAEoCCJbChRMaXN0RG9jdW1lbnRzUmVxdWVzdBIQCghzcGFjZV9pZBgBIAEoCRISCgpjb2xsZWN0aW9uGAIgASgJEg0KBWxpbWl0GAMgASgFEg4KBmN1cnNvchgEIAEoCSJbChVMaXN0RG9jdW1lbnRzUmVzcG9uc2USLQoJZG9jdW1lbnRzGAEgAygLMhouc3luY3NwYWNlLnYxLkRvY3VtZW50SW5mbxITCgtuZXh0X2N1cnNvchgCIAEoCSLdAQoMRG9jdW1lbnRJbmZvEhMKC2RvY3VtZW50X2lkGAEgASgJEhIKCmNvbGxlY3Rpb24YAiABKAkSOgoIbWV0YWRhdGEYAyADKAsyKC5zeW5jc3BhY2UudjEuRG9jdW1lbnRJbmZvLk1ldGFkYXRhRW50cnkSDwoHdmVyc2lvbhgEIAEoAxISCgpjcmVhdGVkX2F0GAUgASgDEhIKCnVwZGF0ZWRfYXQYBiABKAMaLwoNTWV0YWRhdGFFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIogBChVRdWVyeURvY3VtZW50c1JlcXVlc3QSEAoIc3BhY2VfaWQYASABKAkSEgoKY29sbGVjdGlvbhgCIAEoCRIqCgdmaWx0ZXJzGAMgAygLMhkuc3luY3NwYWNlLnYxLlF1ZXJ5RmlsdGVyEg0KBWxpbWl0GAQgASgFEg4KBmN1cnNvchgFIAEoCSI9CgtRdWVyeUZpbHRlchINCgVmaWVsZBgBIAEoCRIQCghvcGVyYXRvchgCIAEoCRINCgV2YWx1ZRgDIAEoCSJcChZRdWVyeURvY3VtZW50c1Jlc3BvbnNlEi0KCWRvY3VtZW50cxgBIAMoCzIaLnN5bmNzcGFjZS52MS5Eb2N1bWVudEluZm8SEwoLbmV4dF9jdXJzb3IYAiABKAkiJAoQU3RhcnRTeW5jUmVxdWVzdBIQCghzcGFjZV9pZBgBIAEoCSIkChFTdGFydFN5bmNSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIIiQKEFBhdXNlU3luY1JlcXVlc3QSEAoIc3BhY2VfaWQYASABKAkiJAoRUGF1c2VTeW5jUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCIoChRHZXRTeW5jU3RhdHVzUmVxdWVzdBIQCghzcGFjZV9pZBgBIAEoCSJIChVHZXRTeW5jU3RhdHVzUmVzcG9uc2USLwoIc3RhdHVzZXMYASADKAsyHS5zeW5jc3BhY2UudjEuU3BhY2VTeW5jU3RhdHVzIosBCg9TcGFjZVN5bmNTdGF0dXMSEAoIc3BhY2VfaWQYASABKAkSKAoGc3RhdHVzGAIgASgOMhguc3luY3NwYWNlLnYxLlN5bmNTdGF0dXMSFAoMbGFzdF9zeW5jX2F0GAMgASgDEhcKD3BlbmRpbmdfY2hhbmdlcxgEIAEoBRINCgVlcnJvchgFIAEoCSI6ChBTdWJzY3JpYmVSZXF1ZXN0EhMKC2V2ZW50X3R5cGVzGAEgAygJEhEKCXNwYWNlX2lkcxgCIAMoCSJvChFTdWJzY3JpYmVSZXNwb25zZRIQCghldmVudF9pZBgBIAEoCRISCgpldmVudF90eXBlGAIgASgJEhAKCHNwYWNlX2lkGAMgASgJEhEKCXRpbWVzdGFtcBgEIAEoAxIPCgdwYXlsb2FkGAUgASgMIj8KFERvY3VtZW50Q3JlYXRlZEV2ZW50EhMKC2RvY3VtZW50X2lkGAEgASgJEhIKCmNvbGxlY3Rpb24YAiABKAkiVQoURG9jdW1lbnRVcGRhdGVkRXZlbnQSEwoLZG9jdW1lbnRfaWQYASABKAkSEwoLb2xkX3ZlcnNpb24YAiABKAMSEwoLbmV3X3ZlcnNpb24YAyABKAMiKwoURG9jdW1lbnREZWxldGVkRXZlbnQSEwoLZG9jdW1lbnRfaWQYASABKAki3wIKEVNwYWNlVXBkYXRlZEV2ZW50EhAKCG9sZF9uYW1lGAEgASgJEhAKCG5ld19uYW1lGAIgASgJEkYKDG9sZF9tZXRhZGF0YRgDIAMoCzIwLnN5bmNzcGFjZS52MS5TcGFjZVVwZGF0ZWRFdmVudC5PbGRNZXRhZGF0YUVudHJ5EkYKDG5ld19tZXRhZGF0YRgEIAMoCzIwLnN5bmNzcGFjZS52MS5TcGFjZVVwZGF0ZWRFdmVudC5OZXdNZXRhZGF0YUVudHJ5EhYKDm9sZF91cGRhdGVkX2F0GAUgASgDEhYKDm5ld191cGRhdGVkX2F0GAYgASgDGjIKEE9sZE1ldGFkYXRhRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ARoyChBOZXdNZXRhZGF0YUVudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEiYAoQU3BhY2VNZW1iZXJFdmVudBIQCghpZGVudGl0eRgBIAEoCRISCgpwZXJtaXNzaW9uGAIgASgJEg4KBnN0YXR1cxgDIAEoCRIWCg5vbGRfcGVybWlzc2lvbhgEIAEoCSKDAQoWU3luY1N0YXR1c0NoYW5nZWRFdmVudBIsCgpvbGRfc3RhdHVzGAEgASgOMhguc3luY3NwYWNlLnYxLlN5bmNTdGF0dXMSLAoKbmV3X3N0YXR1cxgCIAEoDjIYLnN5bmNzcGFjZS52MS5TeW5jU3RhdHVzEg0KBWVycm9yGAMgASgJIkcKDEJhdGNoUmVxdWVzdBInCghjb21tYW5kcxgBIAMoCzIVLnN5bmNzcGFjZS52MS5Db21tYW5kEg4KBmF0b21pYxgCIAEoCCI/Cg1CYXRjaFJlc3BvbnNlEi4KB3Jlc3VsdHMYASADKAsyHS5zeW5jc3BhY2UudjEuQ29tbWFuZFJlc3BvbnNlIhkKF0Rlc2NyaWJlQ29tbWFuZHNSZXF1ZXN0InsKGERlc2NyaWJlQ29tbWFuZHNSZXNwb25zZRIrCghjb21tYW5kcxgBIAMoCzIZLnN5bmNzcGFjZS52MS5Db21tYW5kSW5mbxIbChNmaWxlX2Rlc2NyaXB0b3Jfc2V0GAIgASgMEhUKDXNjaGVtYV9kaWdlc3QYAyABKAkiWwoLQ29tbWFuZEluZm8SDAoEbmFtZRgBIAEoCRIUCgxyZXF1ZXN0X3R5cGUYAiABKAkSFQoNcmVzcG9uc2VfdHlwZRgDIAEoCRIRCglzdHJlYW1pbmcYBCABKAgisAEKDENvbW1hbmRFcnJvchIlCgRjb2RlGAEgASgOMhcuc3luY3NwYWNlLnYxLkVycm9yQ29kZRIPCgdtZXNzYWdlGAIgASgJEjgKB2RldGFpbHMYAyADKAsyJy5zeW5jc3BhY2UudjEuQ29tbWFuZEVycm9yLkRldGFpbHNFbnRyeRouCgxEZXRhaWxzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASJ9Cg1TdHJlYW1NZXNzYWdlEhEKCXN0cmVhbV9pZBgBIAEoCRIPCgdjb21tYW5kGAIgASgJEg8KB3BheWxvYWQYAyABKAwSDAoEZG9uZRgEIAEoCBIpCgVlcnJvchgFIAEoCzIaLnN5bmNzcGFjZS52MS5Db21tYW5kRXJyb3IiYQoLUHJvZmlsZUluZm8SEgoKcHJvZmlsZV9pZBgBIAEoCRIMCgRuYW1lGAIgASgJEhIKCmNyZWF0ZWRfYXQYAyABKAMSDAoEb3BlbhgEIAEoCBIOCgZhY3RpdmUYBSABKAgiOAoUQ3JlYXRlUHJvZmlsZVJlcXVlc3QSEgoKcHJvZmlsZV9pZBgBIAEoCRIMCgRuYW1lGAIgASgJIkMKFUNyZWF0ZVByb2ZpbGVSZXNwb25zZRIqCgdwcm9maWxlGAEgASgLMhkuc3luY3NwYWNlLnYxLlByb2ZpbGVJbmZvIhUKE0xpc3RQcm9maWxlc1JlcXVlc3QiQwoUTGlzdFByb2ZpbGVzUmVzcG9uc2USKwoIcHJvZmlsZXMYASADKAsyGS5zeW5jc3BhY2UudjEuUHJvZmlsZUluZm8iTgoST3BlblByb2ZpbGVSZXF1ZXN0EhIKCnByb2ZpbGVfaWQYASABKAkSEgoKcGFzc3BocmFzZRgCIAEoCRIQCghtbmVtb25pYxgDIAEoCSJBChNPcGVuUHJvZmlsZVJlc3BvbnNlEioKB3Byb2ZpbGUYASABKAsyGS5zeW5jc3BhY2UudjEuUHJvZmlsZUluZm8iKQoTQ2xvc2VQcm9maWxlUmVxdWVzdBISCgpwcm9maWxlX2lkGAEgASgJIicKFENsb3NlUHJvZmlsZVJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgiKgoURGVsZXRlUHJvZmlsZVJlcXVlc3QSEgoKcHJvZmlsZV9pZBgBIAEoCSIoChVEZWxldGVQcm9maWxlUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCISChBHZXRDb25maWdSZXF1ZXN0IkAKEUdldENvbmZpZ1Jlc3BvbnNlEisKBmNvbmZpZxgBIAEoCzIbLnN5bmNzcGFjZS52MS5CYWNrZW5kQ29uZmlnIqUDCg1CYWNrZW5kQ29uZmlnEhAKCGRhdGFfZGlyGAEgASgJEhIKCm5ldHdvcmtfaWQYAiABKAkSEQoJZGV2aWNlX2lkGAMgASgJEhQKDG5ldHdvcmtfbW9kZRgEIAEoCRIRCglsb2dfbGV2ZWwYBSABKAkSGgoSY29tbWFuZF90aW1lb3V0X21zGAYgASgDEk8KE2NvbW1hbmRfdGltZW91dHNfbXMYByADKAsyMi5zeW5jc3BhY2UudjEuQmFja2VuZENvbmZpZy5Db21tYW5kVGltZW91dHNNc0VudHJ5EhcKD3N5bmNfcGVyaW9kX3NlYxgIIAEoBRISCgpnY190dGxfc2VjGAkgASgFEiAKGGtlZXBfdHJlZV9kYXRhX2luX21lbW9yeRgKIAEoCBIVCg1hdXRvX2xvY2tfc2VjGAwgASgFEhgKEHNwYWNlX2NhY2hlX3NpemUYDSABKAUaOAoWQ29tbWFuZFRpbWVvdXRzTXNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAM6AjgBSgQICxAMUgVleHRyYSISChBHZXRTdGF0dXNSZXF1ZXN0IrACChFHZXRTdGF0dXNSZXNwb25zZRITCgtpbml0aWFsaXplZBgBIAEoCBIQCghkYXRhX2RpchgCIAEoCRISCgpzdGFydGVkX2F0GAMgASgDEhEKCXVwdGltZV9tcxgEIAEoAxIYChBvcGVuX3NwYWNlX2NvdW50GAUgASgFEhgKEHN1YnNjcmliZXJfY291bnQYBiABKAUSFQoNc3RvcmFnZV9ieXRlcxgHIAEoAxIuCgZzcGFjZXMYCCADKAsyHi5zeW5jc3BhY2UudjEuU3BhY2VEaWFnbm9zdGljcxISCgpnb192ZXJzaW9uGAkgASgJEhAKCHBsYXRmb3JtGAogASgJEhwKFHBhc3NwaHJhc2VfcHJvdGVjdGVkGAsgASgIEg4KBmxvY2tlZBgMIAEoCCKDAQoQU3BhY2VEaWFnbm9zdGljcxIQCghzcGFjZV9pZBgBIAEoCRIMCgRuYW1lGAIgASgJEgwKBG9wZW4YAyABKAgSFgoOZG9jdW1lbnRfY291bnQYBCABKAUSFQoNc3RvcmFnZV9ieXRlcxgFIAEoAxISCgpsb2FkX2Vycm9yGAYgASgJIioKFFNldFBhc3NwaHJhc2VSZXF1ZXN0EhIKCnBhc3NwaHJhc2UYASABKAkiKAoVU2V0UGFzc3BocmFzZVJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgiTQoXQ2hhbmdlUGFzc3BocmFzZVJlcXVlc3QSGgoSY3VycmVudF9wYXNzcGhyYXNlGAEgASgJEhYKDm5ld19wYXNzcGhyYXNlGAIgASgJIisKGENoYW5nZVBhc3NwaHJhc2VSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIIjUKF1JlbW92ZVBhc3NwaHJhc2VSZXF1ZXN0EhoKEmN1cnJlbnRfcGFzc3BocmFzZRgBIAEoCSIrChhSZW1vdmVQYXNzcGhyYXNlUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCIrChVFeHBvcnRNbmVtb25pY1JlcXVlc3QSEgoKcGFzc3BocmFzZRgBIAEoCSIqChZFeHBvcnRNbmVtb25pY1Jlc3BvbnNlEhAKCG1uZW1vbmljGAEgASgJIg0KC0xvY2tSZXF1ZXN0Ih8KDExvY2tSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIIiMKDVVubG9ja1JlcXVlc3QSEgoKcGFzc3BocmFzZRgBIAEoCSIhCg5VbmxvY2tSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIIioKFERlbGV0ZUFjY291bnRSZXF1ZXN0EhIKCnBhc3NwaHJhc2UYASABKAkiWwoVRGVsZXRlQWNjb3VudFJlc3BvbnNlEhUKDXJlbW92ZWRfZmlsZXMYASADKAkSFAoMcmVtb3ZlZF9rZXlzGAIgAygJEhUKDXJlbW92ZWRfYnl0ZXMYAyABKAMinQEKCkRldmljZUluZm8SEQoJZGV2aWNlX2lkGAEgASgJEgwKBG5hbWUYAiABKAkSDwoHcGVlcl9pZBgDIAEoCRIQCghwZWVyX2tleRgEIAEoDBIQCghhZGRlZF9hdBgFIAEoAxIUCgxsYXN0X3NlZW5fYXQYBiABKAMSEgoKcmV2b2tlZF9hdBgHIAEoAxIPCgdjdXJyZW50GAggASgIIhQKEkxpc3REZXZpY2VzUmVxdWVzdCJAChNMaXN0RGV2aWNlc1Jlc3BvbnNlEikKB2RldmljZXMYASADKAsyGC5zeW5jc3BhY2UudjEuRGV2aWNlSW5mbyIYChZSb3RhdGVEZXZpY2VLZXlSZXF1ZXN0IkMKF1JvdGF0ZURldmljZUtleVJlc3BvbnNlEigKBmRldmljZRgBIAEoCzIYLnN5bmNzcGFjZS52MS5EZXZpY2VJbmZvIigKE1Jldm9rZURldmljZVJlcXVlc3QSEQoJZGV2aWNlX2lkGAEgASgJIkAKFFJldm9rZURldmljZVJlc3BvbnNlEigKBmRldmljZRgBIAEoCzIYLnN5bmNzcGFjZS52MS5EZXZpY2VJbmZvIkYKFEV4cG9ydEFjY291bnRSZXF1ZXN0EhIKCnBhc3NwaHJhc2UYASABKAkSGgoSYWNjb3VudF9wYXNzcGhyYXNlGAIgASgJIicKFUV4cG9ydEFjY291bnRSZXNwb25zZRIOCgZidW5kbGUYASABKAwifAoUSW1wb3J0QWNjb3VudFJlcXVlc3QSEAoIZGF0YV9kaXIYASABKAkSDgoGYnVuZGxlGAIgASgMEhIKCnBhc3NwaHJhc2UYAyABKAkSGgoSYWNjb3VudF9wYXNzcGhyYXNlGAQgASgJEhIKCnByb2ZpbGVfaWQYBSABKAkiVgoVSW1wb3J0QWNjb3VudFJlc3BvbnNlEhMKC3NwYWNlX2NvdW50GAEgASgFEhQKDGRldmljZV9jb3VudBgCIAEoBRISCgpjcmVhdGVkX2F0GAMgASgDKqUBCg9TcGFjZVBlcm1pc3Npb24SIAocU1BBQ0VfUEVSTUlTU0lPTl9VTlNQRUNJRklFRBAAEhsKF1NQQUNFX1BFUk1JU1NJT05fUkVBREVSEAESGwoXU1BBQ0VfUEVSTUlTU0lPTl9XUklURVIQAhIaChZTUEFDRV9QRVJNSVNTSU9OX0FETUlOEAMSGgoWU1BBQ0VfUEVSTUlTU0lPTl9PV05FUhAEKoQBCgxNZW1iZXJTdGF0dXMSHQoZTUVNQkVSX1NUQVRVU19VTlNQRUNJRklFRBAAEhgKFE1FTUJFUl9TVEFUVVNfQUNUSVZFEAESIAocTUVNQkVSX1NUQVRVU19KT0lOX1JFUVVFU1RFRBACEhkKFU1FTUJFUl9TVEFUVVNfTEVBVklORxADKocBCgpTeW5jU3RhdHVzEhsKF1NZTkNfU1RBVFVTX1VOU1BFQ0lGSUVEEAASFAoQU1lOQ19TVEFUVVNfSURMRRABEhcKE1NZTkNfU1RBVFVTX1NZTkNJTkcQAhIWChJTWU5DX1NUQVRVU19QQVVTRUQQAxIVChFTWU5DX1NUQVRVU19FUlJPUhAEKq4DCglFcnJvckNvZGUSGgoWRVJST1JfQ09ERV9VTlNQRUNJRklFRBAAEhcKE0VSUk9SX0NPREVfSU5URVJOQUwQARIfChtFUlJPUl9DT0RFX0lOVkFMSURfQVJHVU1FTlQQAhIYChRFUlJPUl9DT0RFX05PVF9GT1VORBADEh0KGUVSUk9SX0NPREVfQUxSRUFEWV9FWElTVFMQBBIeChpFUlJPUl9DT0RFX05PVF9JTklUSUFMSVpFRBAFEiIKHkVSUk9SX0NPREVfQUxSRUFEWV9JTklUSUFMSVpFRBAGEh8KG0VSUk9SX0NPREVfVkVSU0lPTl9DT05GTElDVBAHEhwKGEVSUk9SX0NPREVfVU5JTVBMRU1FTlRFRBAIEiAKHEVSUk9SX0NPREVfREVBRExJTkVfRVhDRUVERUQQCRIYChRFUlJPUl9DT0RFX0NBTkNFTExFRBAKEhoKFkVSUk9SX0NPREVfVU5BVkFJTEFCTEUQCxIVChFFUlJPUl9DT0RFX0xPQ0tFRBAMEiAKHEVSUk9SX0NPREVfUEVSTUlTU0lPTl9ERU5JRUQQDTLhHQoQU3luY1NwYWNlU2VydmljZRI9CgRJbml0Ehkuc3luY3NwYWNlLnYxLkluaXRSZXF1ZXN0Ghouc3luY3NwYWNlLnYxLkluaXRSZXNwb25zZRJJCghTaHV0ZG93bhIdLnN5bmNzcGFjZS52MS5TaHV0ZG93blJlcXVlc3QaHi5zeW5jc3BhY2UudjEuU2h1dGRvd25SZXNwb25zZRJSCgtDcmVhdGVTcGFjZRIgLnN5bmNzcGFjZS52MS5DcmVhdGVTcGFjZVJlcXVlc3QaIS5zeW5jc3BhY2UudjEuQ3JlYXRlU3BhY2VSZXNwb25zZRJVCgxDcmVhdGVJbnZpdGUSIS5zeW5jc3BhY2UudjEuQ3JlYXRlSW52aXRlUmVxdWVzdBoiLnN5bmNzcGFjZS52MS5DcmVhdGVJbnZpdGVSZXNwb25zZRJMCglKb2luU3BhY2USHi5zeW5jc3BhY2UudjEuSm9pblNwYWNlUmVxdWVzdBofLnN5bmNzcGFjZS52MS5Kb2luU3BhY2VSZXNwb25zZRJSCgtMaXN0TWVtYmVycxIgLnN5bmNzcGFjZS52MS5MaXN0TWVtYmVyc1JlcXVlc3QaIS5zeW5jc3BhY2UudjEuTGlzdE1lbWJlcnNSZXNwb25zZRJnChJBcHByb3ZlSm9pblJlcXVlc3QSJy5zeW5jc3BhY2UudjEuQXBwcm92ZUpvaW5SZXF1ZXN0UmVxdWVzdBooLnN5bmNzcGFjZS52MS5BcHByb3ZlSm9pblJlcXVlc3RSZXNwb25zZRJVCgxSZW1vdmVNZW1iZXISIS5zeW5jc3BhY2UudjEuUmVtb3ZlTWVtYmVyUmVxdWVzdBoiLnN5bmNzcGFjZS52MS5SZW1vdmVNZW1iZXJSZXNwb25zZRJzChZDaGFuZ2VNZW1iZXJQZXJtaXNzaW9uEisuc3luY3NwYWNlLnYxLkNoYW5nZU1lbWJlclBlcm1pc3Npb25SZXF1ZXN0Giwuc3luY3NwYWNlLnYxLkNoYW5nZU1lbWJlclBlcm1pc3Npb25SZXNwb25zZRJPCgpMZWF2ZVNwYWNlEh8uc3luY3NwYWNlLnYxLkxlYXZlU3BhY2VSZXF1ZXN0GiAuc3luY3NwYWNlLnYxLkxlYXZlU3BhY2VSZXNwb25zZRJPCgpMaXN0U3BhY2VzEh8uc3luY3NwYWNlLnYxLkxpc3RTcGFjZXNSZXF1ZXN0GiAuc3luY3NwYWNlLnYxLkxpc3RTcGFjZXNSZXNwb25zZRJSCgtVcGRhdGVTcGFjZRIgLnN5bmNzcGFjZS52MS5VcGRhdGVTcGFjZVJlcXVlc3QaIS5zeW5jc3BhY2UudjEuVXBkYXRlU3BhY2VSZXNwb25zZRJSCgtEZWxldGVTcGFjZRIgLnN5bmNzcGFjZS52MS5EZWxldGVTcGFjZVJlcXVlc3QaIS5zeW5jc3BhY2UudjEuRGVsZXRlU3BhY2VSZXNwb25zZRJbCg5DcmVhdGVEb2N1bWVudBIjLnN5bmNzcGFjZS52MS5DcmVhdGVEb2N1bWVudFJlcXVlc3QaJC5zeW5jc3BhY2UudjEuQ3JlYXRlRG9jdW1lbnRSZXNwb25zZRJSCgtHZXREb2N1bWVudBIgLnN5bmNzcGFjZS52MS5HZXREb2N1bWVudFJlcXVlc3QaIS5zeW5jc3BhY2UudjEuR2V0RG9jdW1lbnRSZXNwb25zZRJbCg5VcGRhdGVEb2N1bWVudBIjLnN5bmNzcGFjZS52MS5VcGRhdGVEb2N1bWVudFJlcXVlc3QaJC5zeW5jc3BhY2UudjEuVXBkYXRlRG9jdW1lbnRSZXNwb25zZRJbCg5EZWxldGVEb2N1bWVudBIjLnN5bmNzcGFjZS52MS5EZWxldGVEb2N1bWVudFJlcXVlc3QaJC5zeW5jc3BhY2UudjEuRGVsZXRlRG9jdW1lbnRSZXNwb25zZRJYCg1MaXN0RG9jdW1lbnRzEiIuc3luY3NwYWNlLnYxLkxpc3REb2N1bWVudHNSZXF1ZXN0GiMuc3luY3NwYWNlLnYxLkxpc3REb2N1bWVudHNSZXNwb25zZRJbCg5RdWVyeURvY3VtZW50cxIjLnN5bmNzcGFjZS52MS5RdWVyeURvY3VtZW50c1JlcXVlc3QaJC5zeW5jc3BhY2UudjEuUXVlcnlEb2N1bWVudHNSZXNwb25zZRJMCglTdGFydFN5bmMSHi5zeW5jc3BhY2UudjEuU3RhcnRTeW5jUmVxdWVzdBofLnN5bmNzcGFjZS52MS5TdGFydFN5bmNSZXNwb25zZRJMCglQYXVzZVN5bmMSHi5zeW5jc3BhY2UudjEuUGF1c2VTeW5jUmVxdWVzdBofLnN5bmNzcGFjZS52MS5QYXVzZVN5bmNSZXNwb25zZRJYCg1HZXRTeW5jU3RhdHVzEiIuc3luY3NwYWNlLnYxLkdldFN5bmNTdGF0dXNSZXF1ZXN0GiMuc3luY3NwYWNlLnYxLkdldFN5bmNTdGF0dXNSZXNwb25zZRJACgVCYXRjaBIaLnN5bmNzcGFjZS52MS5CYXRjaFJlcXVlc3QaGy5zeW5jc3BhY2UudjEuQmF0Y2hSZXNwb25zZRJhChBEZXNjcmliZUNvbW1hbmRzEiUuc3luY3NwYWNlLnYxLkRlc2NyaWJlQ29tbWFuZHNSZXF1ZXN0GiYuc3luY3NwYWNlLnYxLkRlc2NyaWJlQ29tbWFuZHNSZXNwb25zZRJMCglHZXRDb25maWcSHi5zeW5jc3BhY2UudjEuR2V0Q29uZmlnUmVxdWVzdBofLnN5bmNzcGFjZS52MS5HZXRDb25maWdSZXNwb25zZRJMCglHZXRTdGF0dXMSHi5zeW5jc3BhY2UudjEuR2V0U3RhdHVzUmVxdWVzdBofLnN5bmNzcGFjZS52MS5HZXRTdGF0dXNSZXNwb25zZRJYCg1TZXRQYXNzcGhyYXNlEiIuc3luY3NwYWNlLnYxLlNldFBhc3NwaHJhc2VSZXF1ZXN0GiMuc3luY3NwYWNlLnYxLlNldFBhc3NwaHJhc2VSZXNwb25zZRJhChBDaGFuZ2VQYXNzcGhyYXNlEiUuc3luY3NwYWNlLnYxLkNoYW5nZVBhc3NwaHJhc2VSZXF1ZXN0GiYuc3luY3NwYWNlLnYxLkNoYW5nZVBhc3NwaHJhc2VSZXNwb25zZRJhChBSZW1vdmVQYXNzcGhyYXNlEiUuc3luY3NwYWNlLnYxLlJlbW92ZVBhc3NwaHJhc2VSZXF1ZXN0GiYuc3luY3NwYWNlLnYxLlJlbW92ZVBhc3NwaHJhc2VSZXNwb25zZRJbCg5FeHBvcnRNbmVtb25pYxIjLnN5bmNzcGFjZS52MS5FeHBvcnRNbmVtb25pY1JlcXVlc3QaJC5zeW5jc3BhY2UudjEuRXhwb3J0TW5lbW9uaWNSZXNwb25zZRI9CgRMb2NrEhkuc3luY3NwYWNlLnYxLkxvY2tSZXF1ZXN0Ghouc3luY3NwYWNlLnYxLkxvY2tSZXNwb25zZRJDCgZVbmxvY2sSGy5zeW5jc3BhY2UudjEuVW5sb2NrUmVxdWVzdBocLnN5bmNzcGFjZS52MS5VbmxvY2tSZXNwb25zZRJYCg1EZWxldGVBY2NvdW50EiIuc3luY3NwYWNlLnYxLkRlbGV0ZUFjY291bnRSZXF1ZXN0GiMuc3luY3NwYWNlLnYxLkRlbGV0ZUFjY291bnRSZXNwb25zZRJSCgtMaXN0RGV2aWNlcxIgLnN5bmNzcGFjZS52MS5MaXN0RGV2aWNlc1JlcXVlc3QaIS5zeW5jc3BhY2UudjEuTGlzdERldmljZXNSZXNwb25zZRJeCg9Sb3RhdGVEZXZpY2VLZXkSJC5zeW5jc3BhY2UudjEuUm90YXRlRGV2aWNlS2V5UmVxdWVzdBolLnN5bmNzcGFjZS52MS5Sb3RhdGVEZXZpY2VLZXlSZXNwb25zZRJVCgxSZXZva2VEZXZpY2USIS5zeW5jc3BhY2UudjEuUmV2b2tlRGV2aWNlUmVxdWVzdBoiLnN5bmNzcGFjZS52MS5SZXZva2VEZXZpY2VSZXNwb25zZRJYCg1FeHBvcnRBY2NvdW50EiIuc3luY3NwYWNlLnYxLkV4cG9ydEFjY291bnRSZXF1ZXN0GiMuc3luY3NwYWNlLnYxLkV4cG9ydEFjY291bnRSZXNwb25zZRJYCg1JbXBvcnRBY2NvdW50EiIuc3luY3NwYWNlLnYxLkltcG9ydEFjY291bnRSZXF1ZXN0GiMuc3luY3NwYWNlLnYxLkltcG9ydEFjY291bnRSZXNwb25zZRJOCglTdWJzY3JpYmUSHi5zeW5jc3BhY2UudjEuU3Vic2NyaWJlUmVxdWVzdBofLnN5bmNzcGFjZS52MS5TdWJzY3JpYmVSZXNwb25zZTABElgKDUNyZWF0ZVByb2ZpbGUSIi5zeW5jc3BhY2UudjEuQ3JlYXRlUHJvZmlsZVJlcXVlc3QaIy5zeW5jc3BhY2UudjEuQ3JlYXRlUHJvZmlsZVJlc3BvbnNlElUKDExpc3RQcm9maWxlcxIhLnN5bmNzcGFjZS52MS5MaXN0UHJvZmlsZXNSZXF1ZXN0GiIuc3luY3NwYWNlLnYxLkxpc3RQcm9maWxlc1Jlc3BvbnNlElIKC09wZW5Qcm9maWxlEiAuc3luY3NwYWNlLnYxLk9wZW5Qcm9maWxlUmVxdWVzdBohLnN5bmNzcGFjZS52MS5PcGVuUHJvZmlsZVJlc3BvbnNlElUKDENsb3NlUHJvZmlsZRIhLnN5bmNzcGFjZS52MS5DbG9zZVByb2ZpbGVSZXF1ZXN0GiIuc3luY3NwYWNlLnYxLkNsb3NlUHJvZmlsZVJlc3BvbnNlElgKDURlbGV0ZVByb2ZpbGUSIi5zeW5jc3BhY2UudjEuRGVsZXRlUHJvZmlsZVJlcXVlc3QaIy5zeW5jc3BhY2UudjEuRGVsZXRlUHJvZmlsZVJlc3BvbnNlQqgBChBjb20uc3luY3NwYWNlLnYxQg5TeW5jc3BhY2VQcm90b1ABWjNhbnlzeW5jLWJhY2tlbmQvc2hhcmVkL3Byb3RvL3N5bmNzcGFjZS92MTtzeW5jc3BhY2WiAgNTWFiqAgxTeW5jc3BhY2UuVjHKAgxTeW5jc3BhY2VcVjHiAhhTeW5jc3BhY2VcVjFcR1BCTWV0YWRhdGHqAg1TeW5jc3BhY2U6OlYxYgZwcm90bzM=",
   );
 
 /**
@@ -63,6 +63,13 @@ export type CommandResponse = Message<"syncspace.v1.CommandResponse"> & {
    * @generated from field: string error = 2;
    */
   error: string;
+
+  /**
+   * Structured error (unset if success)
+   *
+   * @generated from field: syncspace.v1.CommandError error_detail = 3;
+   */
+  errorDetail?: CommandError;
 };
 
 /**
@@ -92,18 +99,68 @@ export type InitRequest = Message<"syncspace.v1.InitRequest"> & {
   networkId: string;
 
   /**
-   * Unique device identifier
+   * Unique device identifier; defaults to the one registered before, or the device peer ID
    *
    * @generated from field: string device_id = 3;
    */
   deviceId: string;
 
   /**
-   * Additional configuration
+   * Backend settings, keyed like the BackendConfig fields; unknown keys end up in BackendConfig.extra
    *
    * @generated from field: map<string, string> config = 4;
    */
   config: { [key: string]: string };
+
+  /**
+   * Default deadline for commands, in milliseconds. 0 uses the built-in
+   * default (30s), a negative value disables it. Deadlines set by the caller
+   * (gRPC deadline, mobile timeout argument) take precedence.
+   *
+   * @generated from field: int64 command_timeout_ms = 5;
+   */
+  commandTimeoutMs: bigint;
+
+  /**
+   * Per-command overrides, keyed by command name
+   *
+   * @generated from field: map<string, int64> command_timeouts_ms = 6;
+   */
+  commandTimeoutsMs: { [key: string]: bigint };
+
+  /**
+   * Backend settings as a JSON object with typed values, e.g.
+   * {"sync_period_sec": 10, "command_timeouts_ms": {"CreateSpace": 60000}}.
+   * Keys in config override it; the timeout fields above override both.
+   *
+   * @generated from field: string config_json = 7;
+   */
+  configJson: string;
+
+  /**
+   * Opens a passphrase-protected account key; Init fails with
+   * ERROR_CODE_LOCKED if it is missing or wrong. A new account is created
+   * with this passphrase. Never stored or reported by GetConfig.
+   *
+   * @generated from field: string passphrase = 8;
+   */
+  passphrase: string;
+
+  /**
+   * Recovery phrase (see ExportMnemonic) to restore the account from when
+   * data_dir has no account yet; a new device key is generated. If data_dir
+   * already holds an account, it must be the same one. Never stored.
+   *
+   * @generated from field: string mnemonic = 9;
+   */
+  mnemonic: string;
+
+  /**
+   * Display name of this device in the device registry, e.g. "Pixel 8"
+   *
+   * @generated from field: string device_name = 10;
+   */
+  deviceName: string;
 };
 
 /**
@@ -122,6 +179,13 @@ export type InitResponse = Message<"syncspace.v1.InitResponse"> & {
    * @generated from field: bool success = 1;
    */
   success: boolean;
+
+  /**
+   * The account was restored from InitRequest.mnemonic
+   *
+   * @generated from field: bool restored = 2;
+   */
+  restored: boolean;
 };
 
 /**
@@ -135,7 +199,15 @@ export const InitResponseSchema: GenMessage<InitResponse> =
 /**
  * @generated from message syncspace.v1.ShutdownRequest
  */
-export type ShutdownRequest = Message<"syncspace.v1.ShutdownRequest"> & {};
+export type ShutdownRequest = Message<"syncspace.v1.ShutdownRequest"> & {
+  /**
+   * How long to wait for in-flight commands and streams before cancelling
+   * them; 0 uses the default (10s), negative waits without a limit
+   *
+   * @generated from field: int64 timeout_ms = 1;
+   */
+  timeoutMs: bigint;
+};
 
 /**
  * Describes the message syncspace.v1.ShutdownRequest.
@@ -153,6 +225,13 @@ export type ShutdownResponse = Message<"syncspace.v1.ShutdownResponse"> & {
    * @generated from field: bool success = 1;
    */
   success: boolean;
+
+  /**
+   * In-flight work did not finish in time and was cancelled
+   *
+   * @generated from field: bool forced = 2;
+   */
+  forced: boolean;
 };
 
 /**
@@ -168,7 +247,7 @@ export const ShutdownResponseSchema: GenMessage<ShutdownResponse> =
  */
 export type CreateSpaceRequest = Message<"syncspace.v1.CreateSpaceRequest"> & {
   /**
-   * Unique space identifier
+   * Client-chosen alias, unique and accepted wherever a space ID is; creating with it again returns the same space
    *
    * @generated from field: string space_id = 1;
    */
@@ -202,6 +281,8 @@ export const CreateSpaceRequestSchema: GenMessage<CreateSpaceRequest> =
  */
 export type CreateSpaceResponse = Message<"syncspace.v1.CreateSpaceResponse"> & {
   /**
+   * Any-Sync space ID
+   *
    * @generated from field: string space_id = 1;
    */
   spaceId: string;
@@ -216,188 +297,271 @@ export const CreateSpaceResponseSchema: GenMessage<CreateSpaceResponse> =
   messageDesc(file_syncspace_v1_syncspace, 7);
 
 /**
- * @generated from message syncspace.v1.JoinSpaceRequest
+ * CreateInviteRequest invites other accounts to a space. The response token
+ * carries everything JoinSpace needs; anyone holding it may join.
+ *
+ * @generated from message syncspace.v1.CreateInviteRequest
  */
-export type JoinSpaceRequest = Message<"syncspace.v1.JoinSpaceRequest"> & {
+export type CreateInviteRequest = Message<"syncspace.v1.CreateInviteRequest"> & {
   /**
-   * Space identifier to join
+   * Space ID or alias
    *
    * @generated from field: string space_id = 1;
    */
   spaceId: string;
 
   /**
-   * Invitation token (if required)
+   * Reader or writer; unspecified invites readers. Ignored with require_approval
    *
-   * @generated from field: string invite_token = 2;
+   * @generated from field: syncspace.v1.SpacePermission permission = 2;
    */
-  inviteToken: string;
+  permission: SpacePermission;
+
+  /**
+   * Seconds until the invite expires (0 = never)
+   *
+   * @generated from field: int64 expires_in_sec = 3;
+   */
+  expiresInSec: bigint;
+
+  /**
+   * Joins wait for ApproveJoinRequest, which sets the permission
+   *
+   * @generated from field: bool require_approval = 4;
+   */
+  requireApproval: boolean;
 };
 
 /**
- * Describes the message syncspace.v1.JoinSpaceRequest.
- * Use `create(JoinSpaceRequestSchema)` to create a new message.
+ * Describes the message syncspace.v1.CreateInviteRequest.
+ * Use `create(CreateInviteRequestSchema)` to create a new message.
  */
-export const JoinSpaceRequestSchema: GenMessage<JoinSpaceRequest> =
+export const CreateInviteRequestSchema: GenMessage<CreateInviteRequest> =
   /*@__PURE__*/
   messageDesc(file_syncspace_v1_syncspace, 8);
 
 /**
- * @generated from message syncspace.v1.JoinSpaceResponse
+ * @generated from message syncspace.v1.CreateInviteResponse
  */
-export type JoinSpaceResponse = Message<"syncspace.v1.JoinSpaceResponse"> & {
+export type CreateInviteResponse = Message<"syncspace.v1.CreateInviteResponse"> & {
   /**
-   * @generated from field: bool success = 1;
+   * Self-contained token to hand to the invitee
+   *
+   * @generated from field: string invite_token = 1;
    */
-  success: boolean;
+  inviteToken: string;
+
+  /**
+   * ID of the invite in the space ACL
+   *
+   * @generated from field: string invite_id = 2;
+   */
+  inviteId: string;
+
+  /**
+   * Unix timestamp, 0 if the invite does not expire
+   *
+   * @generated from field: int64 expires_at = 3;
+   */
+  expiresAt: bigint;
 };
 
 /**
- * Describes the message syncspace.v1.JoinSpaceResponse.
- * Use `create(JoinSpaceResponseSchema)` to create a new message.
+ * Describes the message syncspace.v1.CreateInviteResponse.
+ * Use `create(CreateInviteResponseSchema)` to create a new message.
  */
-export const JoinSpaceResponseSchema: GenMessage<JoinSpaceResponse> =
+export const CreateInviteResponseSchema: GenMessage<CreateInviteResponse> =
   /*@__PURE__*/
   messageDesc(file_syncspace_v1_syncspace, 9);
 
 /**
- * @generated from message syncspace.v1.LeaveSpaceRequest
+ * JoinSpaceRequest joins the space of another account with an invite token
+ * from CreateInvite. The inviting device must be reachable.
+ *
+ * @generated from message syncspace.v1.JoinSpaceRequest
  */
-export type LeaveSpaceRequest = Message<"syncspace.v1.LeaveSpaceRequest"> & {
+export type JoinSpaceRequest = Message<"syncspace.v1.JoinSpaceRequest"> & {
   /**
-   * Space identifier to leave
+   * Optional client-chosen alias for the joined space, as with CreateSpace
    *
    * @generated from field: string space_id = 1;
    */
   spaceId: string;
+
+  /**
+   * Invite token from CreateInvite
+   *
+   * @generated from field: string invite_token = 2;
+   */
+  inviteToken: string;
 };
 
 /**
- * Describes the message syncspace.v1.LeaveSpaceRequest.
- * Use `create(LeaveSpaceRequestSchema)` to create a new message.
+ * Describes the message syncspace.v1.JoinSpaceRequest.
+ * Use `create(JoinSpaceRequestSchema)` to create a new message.
  */
-export const LeaveSpaceRequestSchema: GenMessage<LeaveSpaceRequest> =
+export const JoinSpaceRequestSchema: GenMessage<JoinSpaceRequest> =
   /*@__PURE__*/
   messageDesc(file_syncspace_v1_syncspace, 10);
 
 /**
- * @generated from message syncspace.v1.LeaveSpaceResponse
+ * @generated from message syncspace.v1.JoinSpaceResponse
  */
-export type LeaveSpaceResponse = Message<"syncspace.v1.LeaveSpaceResponse"> & {
+export type JoinSpaceResponse = Message<"syncspace.v1.JoinSpaceResponse"> & {
   /**
    * @generated from field: bool success = 1;
    */
   success: boolean;
+
+  /**
+   * Any-Sync space ID of the joined space
+   *
+   * @generated from field: string space_id = 2;
+   */
+  spaceId: string;
+
+  /**
+   * The join request waits for approval; call JoinSpace again once approved
+   *
+   * @generated from field: bool pending = 3;
+   */
+  pending: boolean;
 };
 
 /**
- * Describes the message syncspace.v1.LeaveSpaceResponse.
- * Use `create(LeaveSpaceResponseSchema)` to create a new message.
+ * Describes the message syncspace.v1.JoinSpaceResponse.
+ * Use `create(JoinSpaceResponseSchema)` to create a new message.
  */
-export const LeaveSpaceResponseSchema: GenMessage<LeaveSpaceResponse> =
+export const JoinSpaceResponseSchema: GenMessage<JoinSpaceResponse> =
   /*@__PURE__*/
   messageDesc(file_syncspace_v1_syncspace, 11);
 
 /**
- * @generated from message syncspace.v1.ListSpacesRequest
+ * @generated from message syncspace.v1.ListMembersRequest
  */
-export type ListSpacesRequest = Message<"syncspace.v1.ListSpacesRequest"> & {};
+export type ListMembersRequest = Message<"syncspace.v1.ListMembersRequest"> & {
+  /**
+   * Space ID or alias
+   *
+   * @generated from field: string space_id = 1;
+   */
+  spaceId: string;
+};
 
 /**
- * Describes the message syncspace.v1.ListSpacesRequest.
- * Use `create(ListSpacesRequestSchema)` to create a new message.
+ * Describes the message syncspace.v1.ListMembersRequest.
+ * Use `create(ListMembersRequestSchema)` to create a new message.
  */
-export const ListSpacesRequestSchema: GenMessage<ListSpacesRequest> =
+export const ListMembersRequestSchema: GenMessage<ListMembersRequest> =
   /*@__PURE__*/
   messageDesc(file_syncspace_v1_syncspace, 12);
 
 /**
- * @generated from message syncspace.v1.ListSpacesResponse
+ * @generated from message syncspace.v1.ListMembersResponse
  */
-export type ListSpacesResponse = Message<"syncspace.v1.ListSpacesResponse"> & {
+export type ListMembersResponse = Message<"syncspace.v1.ListMembersResponse"> & {
   /**
-   * @generated from field: repeated syncspace.v1.SpaceInfo spaces = 1;
+   * Members and pending join requests, by joined_at
+   *
+   * @generated from field: repeated syncspace.v1.SpaceMember members = 1;
    */
-  spaces: SpaceInfo[];
+  members: SpaceMember[];
 };
 
 /**
- * Describes the message syncspace.v1.ListSpacesResponse.
- * Use `create(ListSpacesResponseSchema)` to create a new message.
+ * Describes the message syncspace.v1.ListMembersResponse.
+ * Use `create(ListMembersResponseSchema)` to create a new message.
  */
-export const ListSpacesResponseSchema: GenMessage<ListSpacesResponse> =
+export const ListMembersResponseSchema: GenMessage<ListMembersResponse> =
   /*@__PURE__*/
   messageDesc(file_syncspace_v1_syncspace, 13);
 
 /**
- * @generated from message syncspace.v1.SpaceInfo
+ * @generated from message syncspace.v1.ApproveJoinRequestRequest
  */
-export type SpaceInfo = Message<"syncspace.v1.SpaceInfo"> & {
+export type ApproveJoinRequestRequest = Message<"syncspace.v1.ApproveJoinRequestRequest"> & {
   /**
+   * Space ID or alias
+   *
    * @generated from field: string space_id = 1;
    */
   spaceId: string;
 
   /**
-   * @generated from field: string name = 2;
-   */
-  name: string;
-
-  /**
-   * @generated from field: map<string, string> metadata = 3;
-   */
-  metadata: { [key: string]: string };
-
-  /**
-   * Unix timestamp
+   * Account address of the requesting account
    *
-   * @generated from field: int64 created_at = 4;
+   * @generated from field: string identity = 2;
    */
-  createdAt: bigint;
+  identity: string;
 
   /**
-   * Unix timestamp
+   * Reader, writer or admin; unspecified approves a reader
    *
-   * @generated from field: int64 updated_at = 5;
+   * @generated from field: syncspace.v1.SpacePermission permission = 3;
    */
-  updatedAt: bigint;
+  permission: SpacePermission;
+};
+
+/**
+ * Describes the message syncspace.v1.ApproveJoinRequestRequest.
+ * Use `create(ApproveJoinRequestRequestSchema)` to create a new message.
+ */
+export const ApproveJoinRequestRequestSchema: GenMessage<ApproveJoinRequestRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 14);
 
+/**
+ * @generated from message syncspace.v1.ApproveJoinRequestResponse
+ */
+export type ApproveJoinRequestResponse = Message<"syncspace.v1.ApproveJoinRequestResponse"> & {
   /**
-   * @generated from field: syncspace.v1.SyncStatus sync_status = 6;
+   * @generated from field: syncspace.v1.SpaceMember member = 1;
    */
-  syncStatus: SyncStatus;
+  member?: SpaceMember;
 };
 
 /**
- * Describes the message syncspace.v1.SpaceInfo.
- * Use `create(SpaceInfoSchema)` to create a new message.
+ * Describes the message syncspace.v1.ApproveJoinRequestResponse.
+ * Use `create(ApproveJoinRequestResponseSchema)` to create a new message.
  */
-export const SpaceInfoSchema: GenMessage<SpaceInfo> =
+export const ApproveJoinRequestResponseSchema: GenMessage<ApproveJoinRequestResponse> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 14);
+  messageDesc(file_syncspace_v1_syncspace, 15);
 
 /**
- * @generated from message syncspace.v1.DeleteSpaceRequest
+ * RemoveMemberRequest removes a member from a space and changes its read
+ * key, or declines a pending join request.
+ *
+ * @generated from message syncspace.v1.RemoveMemberRequest
  */
-export type DeleteSpaceRequest = Message<"syncspace.v1.DeleteSpaceRequest"> & {
+export type RemoveMemberRequest = Message<"syncspace.v1.RemoveMemberRequest"> & {
   /**
+   * Space ID or alias
+   *
    * @generated from field: string space_id = 1;
    */
   spaceId: string;
+
+  /**
+   * Account address of the member
+   *
+   * @generated from field: string identity = 2;
+   */
+  identity: string;
 };
 
 /**
- * Describes the message syncspace.v1.DeleteSpaceRequest.
- * Use `create(DeleteSpaceRequestSchema)` to create a new message.
+ * Describes the message syncspace.v1.RemoveMemberRequest.
+ * Use `create(RemoveMemberRequestSchema)` to create a new message.
  */
-export const DeleteSpaceRequestSchema: GenMessage<DeleteSpaceRequest> =
+export const RemoveMemberRequestSchema: GenMessage<RemoveMemberRequest> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 15);
+  messageDesc(file_syncspace_v1_syncspace, 16);
 
 /**
- * @generated from message syncspace.v1.DeleteSpaceResponse
+ * @generated from message syncspace.v1.RemoveMemberResponse
  */
-export type DeleteSpaceResponse = Message<"syncspace.v1.DeleteSpaceResponse"> & {
+export type RemoveMemberResponse = Message<"syncspace.v1.RemoveMemberResponse"> & {
   /**
    * @generated from field: bool success = 1;
    */
@@ -405,512 +569,2217 @@ export type DeleteSpaceResponse = Message<"syncspace.v1.DeleteSpaceResponse"> &
 };
 
 /**
- * Describes the message syncspace.v1.DeleteSpaceResponse.
- * Use `create(DeleteSpaceResponseSchema)` to create a new message.
+ * Describes the message syncspace.v1.RemoveMemberResponse.
+ * Use `create(RemoveMemberResponseSchema)` to create a new message.
  */
-export const DeleteSpaceResponseSchema: GenMessage<DeleteSpaceResponse> =
+export const RemoveMemberResponseSchema: GenMessage<RemoveMemberResponse> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 16);
+  messageDesc(file_syncspace_v1_syncspace, 17);
 
 /**
- * @generated from message syncspace.v1.CreateDocumentRequest
+ * @generated from message syncspace.v1.ChangeMemberPermissionRequest
  */
-export type CreateDocumentRequest = Message<"syncspace.v1.CreateDocumentRequest"> & {
+export type ChangeMemberPermissionRequest = Message<"syncspace.v1.ChangeMemberPermissionRequest"> & {
   /**
+   * Space ID or alias
+   *
    * @generated from field: string space_id = 1;
    */
   spaceId: string;
 
   /**
-   * Unique document identifier within space
-   *
-   * @generated from field: string document_id = 2;
-   */
-  documentId: string;
-
-  /**
-   * Logical collection name (e.g., "notes", "tasks")
-   *
-   * @generated from field: string collection = 3;
-   */
-  collection: string;
-
-  /**
-   * Opaque document payload (application-defined format)
+   * Account address of the member
    *
-   * @generated from field: bytes data = 4;
+   * @generated from field: string identity = 2;
    */
-  data: Uint8Array;
+  identity: string;
 
   /**
-   * Indexable metadata fields
+   * Reader, writer or admin
    *
-   * @generated from field: map<string, string> metadata = 5;
+   * @generated from field: syncspace.v1.SpacePermission permission = 3;
    */
-  metadata: { [key: string]: string };
+  permission: SpacePermission;
 };
 
 /**
- * Describes the message syncspace.v1.CreateDocumentRequest.
- * Use `create(CreateDocumentRequestSchema)` to create a new message.
+ * Describes the message syncspace.v1.ChangeMemberPermissionRequest.
+ * Use `create(ChangeMemberPermissionRequestSchema)` to create a new message.
  */
-export const CreateDocumentRequestSchema: GenMessage<CreateDocumentRequest> =
+export const ChangeMemberPermissionRequestSchema: GenMessage<ChangeMemberPermissionRequest> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 17);
+  messageDesc(file_syncspace_v1_syncspace, 18);
 
 /**
- * @generated from message syncspace.v1.CreateDocumentResponse
+ * @generated from message syncspace.v1.ChangeMemberPermissionResponse
  */
-export type CreateDocumentResponse = Message<"syncspace.v1.CreateDocumentResponse"> & {
-  /**
-   * @generated from field: string document_id = 1;
-   */
-  documentId: string;
-
+export type ChangeMemberPermissionResponse = Message<"syncspace.v1.ChangeMemberPermissionResponse"> & {
   /**
-   * Document version for optimistic locking
-   *
-   * @generated from field: int64 version = 2;
+   * @generated from field: syncspace.v1.SpaceMember member = 1;
    */
-  version: bigint;
+  member?: SpaceMember;
 };
 
 /**
- * Describes the message syncspace.v1.CreateDocumentResponse.
- * Use `create(CreateDocumentResponseSchema)` to create a new message.
+ * Describes the message syncspace.v1.ChangeMemberPermissionResponse.
+ * Use `create(ChangeMemberPermissionResponseSchema)` to create a new message.
  */
-export const CreateDocumentResponseSchema: GenMessage<CreateDocumentResponse> =
+export const ChangeMemberPermissionResponseSchema: GenMessage<ChangeMemberPermissionResponse> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 18);
+  messageDesc(file_syncspace_v1_syncspace, 19);
 
 /**
- * @generated from message syncspace.v1.GetDocumentRequest
+ * LeaveSpaceRequest stops the account from taking part in a space. For a
+ * joined space, the leave is also recorded in the space ACL through the
+ * inviting device, when it can be reached.
+ *
+ * @generated from message syncspace.v1.LeaveSpaceRequest
  */
-export type GetDocumentRequest = Message<"syncspace.v1.GetDocumentRequest"> & {
+export type LeaveSpaceRequest = Message<"syncspace.v1.LeaveSpaceRequest"> & {
   /**
+   * Space ID or alias
+   *
    * @generated from field: string space_id = 1;
    */
   spaceId: string;
 
   /**
-   * @generated from field: string document_id = 2;
+   * Delete the local copy, with its documents, instead of keeping it read-only
+   *
+   * @generated from field: bool delete_local = 2;
    */
-  documentId: string;
+  deleteLocal: boolean;
 };
 
 /**
- * Describes the message syncspace.v1.GetDocumentRequest.
- * Use `create(GetDocumentRequestSchema)` to create a new message.
+ * Describes the message syncspace.v1.LeaveSpaceRequest.
+ * Use `create(LeaveSpaceRequestSchema)` to create a new message.
  */
-export const GetDocumentRequestSchema: GenMessage<GetDocumentRequest> =
+export const LeaveSpaceRequestSchema: GenMessage<LeaveSpaceRequest> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 19);
+  messageDesc(file_syncspace_v1_syncspace, 20);
 
 /**
- * @generated from message syncspace.v1.GetDocumentResponse
+ * @generated from message syncspace.v1.LeaveSpaceResponse
  */
-export type GetDocumentResponse = Message<"syncspace.v1.GetDocumentResponse"> & {
+export type LeaveSpaceResponse = Message<"syncspace.v1.LeaveSpaceResponse"> & {
   /**
-   * @generated from field: syncspace.v1.Document document = 1;
+   * @generated from field: bool success = 1;
    */
-  document?: Document;
+  success: boolean;
 
   /**
-   * @generated from field: bool found = 2;
+   * Why the leave was not recorded in the ACL; empty if it was or there was nothing to record
+   *
+   * @generated from field: string acl_error = 2;
    */
-  found: boolean;
+  aclError: string;
 };
 
 /**
- * Describes the message syncspace.v1.GetDocumentResponse.
- * Use `create(GetDocumentResponseSchema)` to create a new message.
+ * Describes the message syncspace.v1.LeaveSpaceResponse.
+ * Use `create(LeaveSpaceResponseSchema)` to create a new message.
  */
-export const GetDocumentResponseSchema: GenMessage<GetDocumentResponse> =
+export const LeaveSpaceResponseSchema: GenMessage<LeaveSpaceResponse> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 20);
+  messageDesc(file_syncspace_v1_syncspace, 21);
 
 /**
- * @generated from message syncspace.v1.Document
+ * @generated from message syncspace.v1.ListSpacesRequest
  */
-export type Document = Message<"syncspace.v1.Document"> & {
+export type ListSpacesRequest = Message<"syncspace.v1.ListSpacesRequest"> & {};
+
+/**
+ * Describes the message syncspace.v1.ListSpacesRequest.
+ * Use `create(ListSpacesRequestSchema)` to create a new message.
+ */
+export const ListSpacesRequestSchema: GenMessage<ListSpacesRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 22);
+
+/**
+ * @generated from message syncspace.v1.ListSpacesResponse
+ */
+export type ListSpacesResponse = Message<"syncspace.v1.ListSpacesResponse"> & {
   /**
-   * @generated from field: string document_id = 1;
+   * @generated from field: repeated syncspace.v1.SpaceInfo spaces = 1;
    */
-  documentId: string;
+  spaces: SpaceInfo[];
+};
+
+/**
+ * Describes the message syncspace.v1.ListSpacesResponse.
+ * Use `create(ListSpacesResponseSchema)` to create a new message.
+ */
+export const ListSpacesResponseSchema: GenMessage<ListSpacesResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 23);
 
+/**
+ * @generated from message syncspace.v1.SpaceInfo
+ */
+export type SpaceInfo = Message<"syncspace.v1.SpaceInfo"> & {
   /**
-   * @generated from field: string space_id = 2;
+   * @generated from field: string space_id = 1;
    */
   spaceId: string;
 
   /**
-   * @generated from field: string collection = 3;
+   * @generated from field: string name = 2;
    */
-  collection: string;
+  name: string;
 
   /**
-   * Opaque payload
+   * @generated from field: map<string, string> metadata = 3;
+   */
+  metadata: { [key: string]: string };
+
+  /**
+   * Unix timestamp
    *
-   * @generated from field: bytes data = 4;
+   * @generated from field: int64 created_at = 4;
    */
-  data: Uint8Array;
+  createdAt: bigint;
 
   /**
-   * @generated from field: map<string, string> metadata = 5;
+   * Unix timestamp
+   *
+   * @generated from field: int64 updated_at = 5;
    */
-  metadata: { [key: string]: string };
+  updatedAt: bigint;
 
   /**
-   * @generated from field: int64 version = 6;
+   * @generated from field: syncspace.v1.SyncStatus sync_status = 6;
    */
-  version: bigint;
+  syncStatus: SyncStatus;
 
   /**
-   * @generated from field: int64 created_at = 7;
+   * Client-chosen alias from CreateSpace or JoinSpace, if any
+   *
+   * @generated from field: string alias = 7;
    */
-  createdAt: bigint;
+  alias: string;
 
   /**
-   * @generated from field: int64 updated_at = 8;
+   * Unix timestamp the account left the space, 0 while it takes part; a left space is read-only
+   *
+   * @generated from field: int64 left_at = 8;
+   */
+  leftAt: bigint;
+};
+
+/**
+ * Describes the message syncspace.v1.SpaceInfo.
+ * Use `create(SpaceInfoSchema)` to create a new message.
+ */
+export const SpaceInfoSchema: GenMessage<SpaceInfo> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 24);
+
+/**
+ * SpaceMember is an account in the ACL of a space.
+ *
+ * @generated from message syncspace.v1.SpaceMember
+ */
+export type SpaceMember = Message<"syncspace.v1.SpaceMember"> & {
+  /**
+   * Account address
+   *
+   * @generated from field: string identity = 1;
+   */
+  identity: string;
+
+  /**
+   * Unspecified while the join request waits
+   *
+   * @generated from field: syncspace.v1.SpacePermission permission = 2;
+   */
+  permission: SpacePermission;
+
+  /**
+   * @generated from field: syncspace.v1.MemberStatus status = 3;
+   */
+  status: MemberStatus;
+
+  /**
+   * Unix timestamp the member joined, or requested to
+   *
+   * @generated from field: int64 joined_at = 4;
+   */
+  joinedAt: bigint;
+
+  /**
+   * Unix timestamp of the last change of the membership
+   *
+   * @generated from field: int64 updated_at = 5;
    */
   updatedAt: bigint;
 };
 
 /**
- * Describes the message syncspace.v1.Document.
- * Use `create(DocumentSchema)` to create a new message.
+ * Describes the message syncspace.v1.SpaceMember.
+ * Use `create(SpaceMemberSchema)` to create a new message.
  */
-export const DocumentSchema: GenMessage<Document> =
+export const SpaceMemberSchema: GenMessage<SpaceMember> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 21);
+  messageDesc(file_syncspace_v1_syncspace, 25);
 
 /**
- * @generated from message syncspace.v1.UpdateDocumentRequest
+ * UpdateSpaceRequest changes the name and metadata of a space. Unset fields
+ * keep their current value.
+ *
+ * @generated from message syncspace.v1.UpdateSpaceRequest
  */
-export type UpdateDocumentRequest = Message<"syncspace.v1.UpdateDocumentRequest"> & {
+export type UpdateSpaceRequest = Message<"syncspace.v1.UpdateSpaceRequest"> & {
   /**
+   * Space ID or alias
+   *
    * @generated from field: string space_id = 1;
    */
   spaceId: string;
 
   /**
-   * @generated from field: string document_id = 2;
+   * New name
+   *
+   * @generated from field: optional string name = 2;
    */
-  documentId: string;
+  name?: string;
 
   /**
-   * @generated from field: bytes data = 3;
+   * Entries to merge, an empty value removes its key; or the new metadata with replace_metadata
+   *
+   * @generated from field: map<string, string> metadata = 3;
    */
-  data: Uint8Array;
+  metadata: { [key: string]: string };
 
   /**
-   * @generated from field: map<string, string> metadata = 4;
+   * Replace the metadata instead of merging into it
+   *
+   * @generated from field: bool replace_metadata = 4;
    */
-  metadata: { [key: string]: string };
+  replaceMetadata: boolean;
 
   /**
-   * For optimistic locking (0 = skip check)
+   * For optimistic locking, the updated_at the client last saw (0 = skip check)
    *
-   * @generated from field: int64 expected_version = 5;
+   * @generated from field: int64 expected_updated_at = 5;
    */
-  expectedVersion: bigint;
+  expectedUpdatedAt: bigint;
 };
 
 /**
- * Describes the message syncspace.v1.UpdateDocumentRequest.
- * Use `create(UpdateDocumentRequestSchema)` to create a new message.
+ * Describes the message syncspace.v1.UpdateSpaceRequest.
+ * Use `create(UpdateSpaceRequestSchema)` to create a new message.
  */
-export const UpdateDocumentRequestSchema: GenMessage<UpdateDocumentRequest> =
+export const UpdateSpaceRequestSchema: GenMessage<UpdateSpaceRequest> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 22);
+  messageDesc(file_syncspace_v1_syncspace, 26);
 
 /**
- * @generated from message syncspace.v1.UpdateDocumentResponse
+ * @generated from message syncspace.v1.UpdateSpaceResponse
  */
-export type UpdateDocumentResponse = Message<"syncspace.v1.UpdateDocumentResponse"> & {
+export type UpdateSpaceResponse = Message<"syncspace.v1.UpdateSpaceResponse"> & {
   /**
-   * New version after update
+   * The space after the update
    *
-   * @generated from field: int64 version = 1;
+   * @generated from field: syncspace.v1.SpaceInfo space = 1;
    */
-  version: bigint;
+  space?: SpaceInfo;
 };
 
 /**
- * Describes the message syncspace.v1.UpdateDocumentResponse.
- * Use `create(UpdateDocumentResponseSchema)` to create a new message.
+ * Describes the message syncspace.v1.UpdateSpaceResponse.
+ * Use `create(UpdateSpaceResponseSchema)` to create a new message.
  */
-export const UpdateDocumentResponseSchema: GenMessage<UpdateDocumentResponse> =
+export const UpdateSpaceResponseSchema: GenMessage<UpdateSpaceResponse> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 23);
+  messageDesc(file_syncspace_v1_syncspace, 27);
 
 /**
- * @generated from message syncspace.v1.DeleteDocumentRequest
+ * @generated from message syncspace.v1.DeleteSpaceRequest
  */
-export type DeleteDocumentRequest = Message<"syncspace.v1.DeleteDocumentRequest"> & {
+export type DeleteSpaceRequest = Message<"syncspace.v1.DeleteSpaceRequest"> & {
   /**
    * @generated from field: string space_id = 1;
    */
   spaceId: string;
-
-  /**
-   * @generated from field: string document_id = 2;
-   */
-  documentId: string;
 };
 
 /**
- * Describes the message syncspace.v1.DeleteDocumentRequest.
- * Use `create(DeleteDocumentRequestSchema)` to create a new message.
+ * Describes the message syncspace.v1.DeleteSpaceRequest.
+ * Use `create(DeleteSpaceRequestSchema)` to create a new message.
  */
-export const DeleteDocumentRequestSchema: GenMessage<DeleteDocumentRequest> =
+export const DeleteSpaceRequestSchema: GenMessage<DeleteSpaceRequest> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 24);
+  messageDesc(file_syncspace_v1_syncspace, 28);
 
 /**
- * @generated from message syncspace.v1.DeleteDocumentResponse
+ * @generated from message syncspace.v1.DeleteSpaceResponse
  */
-export type DeleteDocumentResponse = Message<"syncspace.v1.DeleteDocumentResponse"> & {
+export type DeleteSpaceResponse = Message<"syncspace.v1.DeleteSpaceResponse"> & {
   /**
-   * @generated from field: bool existed = 1;
+   * @generated from field: bool success = 1;
    */
-  existed: boolean;
+  success: boolean;
 };
 
 /**
- * Describes the message syncspace.v1.DeleteDocumentResponse.
- * Use `create(DeleteDocumentResponseSchema)` to create a new message.
+ * Describes the message syncspace.v1.DeleteSpaceResponse.
+ * Use `create(DeleteSpaceResponseSchema)` to create a new message.
  */
-export const DeleteDocumentResponseSchema: GenMessage<DeleteDocumentResponse> =
+export const DeleteSpaceResponseSchema: GenMessage<DeleteSpaceResponse> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 25);
+  messageDesc(file_syncspace_v1_syncspace, 29);
 
 /**
- * @generated from message syncspace.v1.ListDocumentsRequest
+ * @generated from message syncspace.v1.CreateDocumentRequest
  */
-export type ListDocumentsRequest = Message<"syncspace.v1.ListDocumentsRequest"> & {
+export type CreateDocumentRequest = Message<"syncspace.v1.CreateDocumentRequest"> & {
   /**
    * @generated from field: string space_id = 1;
    */
   spaceId: string;
 
   /**
-   * Filter by collection (empty = all)
+   * Unique document identifier within space
    *
-   * @generated from field: string collection = 2;
+   * @generated from field: string document_id = 2;
+   */
+  documentId: string;
+
+  /**
+   * Logical collection name (e.g., "notes", "tasks")
+   *
+   * @generated from field: string collection = 3;
    */
   collection: string;
 
   /**
-   * Max results (0 = no limit)
+   * Opaque document payload (application-defined format)
    *
-   * @generated from field: int32 limit = 3;
+   * @generated from field: bytes data = 4;
    */
-  limit: number;
+  data: Uint8Array;
 
   /**
-   * Pagination cursor (empty = first page)
+   * Indexable metadata fields
    *
-   * @generated from field: string cursor = 4;
+   * @generated from field: map<string, string> metadata = 5;
    */
-  cursor: string;
+  metadata: { [key: string]: string };
 };
 
 /**
- * Describes the message syncspace.v1.ListDocumentsRequest.
- * Use `create(ListDocumentsRequestSchema)` to create a new message.
+ * Describes the message syncspace.v1.CreateDocumentRequest.
+ * Use `create(CreateDocumentRequestSchema)` to create a new message.
  */
-export const ListDocumentsRequestSchema: GenMessage<ListDocumentsRequest> =
+export const CreateDocumentRequestSchema: GenMessage<CreateDocumentRequest> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 26);
+  messageDesc(file_syncspace_v1_syncspace, 30);
 
 /**
- * @generated from message syncspace.v1.ListDocumentsResponse
+ * @generated from message syncspace.v1.CreateDocumentResponse
  */
-export type ListDocumentsResponse = Message<"syncspace.v1.ListDocumentsResponse"> & {
+export type CreateDocumentResponse = Message<"syncspace.v1.CreateDocumentResponse"> & {
   /**
-   * @generated from field: repeated syncspace.v1.DocumentInfo documents = 1;
+   * @generated from field: string document_id = 1;
    */
-  documents: DocumentInfo[];
+  documentId: string;
 
   /**
-   * Cursor for next page (empty = no more pages)
+   * Document version for optimistic locking
    *
-   * @generated from field: string next_cursor = 2;
+   * @generated from field: int64 version = 2;
    */
-  nextCursor: string;
+  version: bigint;
 };
 
 /**
- * Describes the message syncspace.v1.ListDocumentsResponse.
- * Use `create(ListDocumentsResponseSchema)` to create a new message.
+ * Describes the message syncspace.v1.CreateDocumentResponse.
+ * Use `create(CreateDocumentResponseSchema)` to create a new message.
  */
-export const ListDocumentsResponseSchema: GenMessage<ListDocumentsResponse> =
+export const CreateDocumentResponseSchema: GenMessage<CreateDocumentResponse> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 27);
+  messageDesc(file_syncspace_v1_syncspace, 31);
 
 /**
- * @generated from message syncspace.v1.DocumentInfo
+ * @generated from message syncspace.v1.GetDocumentRequest
  */
-export type DocumentInfo = Message<"syncspace.v1.DocumentInfo"> & {
-  /**
-   * @generated from field: string document_id = 1;
-   */
-  documentId: string;
-
+export type GetDocumentRequest = Message<"syncspace.v1.GetDocumentRequest"> & {
   /**
-   * @generated from field: string collection = 2;
+   * @generated from field: string space_id = 1;
    */
-  collection: string;
+  spaceId: string;
 
   /**
-   * @generated from field: map<string, string> metadata = 3;
+   * @generated from field: string document_id = 2;
    */
-  metadata: { [key: string]: string };
+  documentId: string;
+};
 
-  /**
-   * @generated from field: int64 version = 4;
-   */
-  version: bigint;
+/**
+ * Describes the message syncspace.v1.GetDocumentRequest.
+ * Use `create(GetDocumentRequestSchema)` to create a new message.
+ */
+export const GetDocumentRequestSchema: GenMessage<GetDocumentRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 32);
 
+/**
+ * @generated from message syncspace.v1.GetDocumentResponse
+ */
+export type GetDocumentResponse = Message<"syncspace.v1.GetDocumentResponse"> & {
   /**
-   * @generated from field: int64 created_at = 5;
+   * @generated from field: syncspace.v1.Document document = 1;
+   */
+  document?: Document;
+
+  /**
+   * @generated from field: bool found = 2;
+   */
+  found: boolean;
+};
+
+/**
+ * Describes the message syncspace.v1.GetDocumentResponse.
+ * Use `create(GetDocumentResponseSchema)` to create a new message.
+ */
+export const GetDocumentResponseSchema: GenMessage<GetDocumentResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 33);
+
+/**
+ * @generated from message syncspace.v1.Document
+ */
+export type Document = Message<"syncspace.v1.Document"> & {
+  /**
+   * @generated from field: string document_id = 1;
+   */
+  documentId: string;
+
+  /**
+   * @generated from field: string space_id = 2;
+   */
+  spaceId: string;
+
+  /**
+   * @generated from field: string collection = 3;
+   */
+  collection: string;
+
+  /**
+   * Opaque payload
+   *
+   * @generated from field: bytes data = 4;
+   */
+  data: Uint8Array;
+
+  /**
+   * @generated from field: map<string, string> metadata = 5;
+   */
+  metadata: { [key: string]: string };
+
+  /**
+   * @generated from field: int64 version = 6;
+   */
+  version: bigint;
+
+  /**
+   * @generated from field: int64 created_at = 7;
    */
   createdAt: bigint;
 
   /**
-   * @generated from field: int64 updated_at = 6;
+   * @generated from field: int64 updated_at = 8;
    */
   updatedAt: bigint;
 };
 
 /**
- * Describes the message syncspace.v1.DocumentInfo.
- * Use `create(DocumentInfoSchema)` to create a new message.
+ * Describes the message syncspace.v1.Document.
+ * Use `create(DocumentSchema)` to create a new message.
  */
-export const DocumentInfoSchema: GenMessage<DocumentInfo> =
+export const DocumentSchema: GenMessage<Document> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 28);
+  messageDesc(file_syncspace_v1_syncspace, 34);
 
 /**
- * @generated from message syncspace.v1.QueryDocumentsRequest
+ * @generated from message syncspace.v1.UpdateDocumentRequest
  */
-export type QueryDocumentsRequest = Message<"syncspace.v1.QueryDocumentsRequest"> & {
+export type UpdateDocumentRequest = Message<"syncspace.v1.UpdateDocumentRequest"> & {
+  /**
+   * @generated from field: string space_id = 1;
+   */
+  spaceId: string;
+
+  /**
+   * @generated from field: string document_id = 2;
+   */
+  documentId: string;
+
+  /**
+   * @generated from field: bytes data = 3;
+   */
+  data: Uint8Array;
+
+  /**
+   * @generated from field: map<string, string> metadata = 4;
+   */
+  metadata: { [key: string]: string };
+
+  /**
+   * For optimistic locking (0 = skip check)
+   *
+   * @generated from field: int64 expected_version = 5;
+   */
+  expectedVersion: bigint;
+};
+
+/**
+ * Describes the message syncspace.v1.UpdateDocumentRequest.
+ * Use `create(UpdateDocumentRequestSchema)` to create a new message.
+ */
+export const UpdateDocumentRequestSchema: GenMessage<UpdateDocumentRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 35);
+
+/**
+ * @generated from message syncspace.v1.UpdateDocumentResponse
+ */
+export type UpdateDocumentResponse = Message<"syncspace.v1.UpdateDocumentResponse"> & {
+  /**
+   * New version after update
+   *
+   * @generated from field: int64 version = 1;
+   */
+  version: bigint;
+};
+
+/**
+ * Describes the message syncspace.v1.UpdateDocumentResponse.
+ * Use `create(UpdateDocumentResponseSchema)` to create a new message.
+ */
+export const UpdateDocumentResponseSchema: GenMessage<UpdateDocumentResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 36);
+
+/**
+ * @generated from message syncspace.v1.DeleteDocumentRequest
+ */
+export type DeleteDocumentRequest = Message<"syncspace.v1.DeleteDocumentRequest"> & {
+  /**
+   * @generated from field: string space_id = 1;
+   */
+  spaceId: string;
+
+  /**
+   * @generated from field: string document_id = 2;
+   */
+  documentId: string;
+};
+
+/**
+ * Describes the message syncspace.v1.DeleteDocumentRequest.
+ * Use `create(DeleteDocumentRequestSchema)` to create a new message.
+ */
+export const DeleteDocumentRequestSchema: GenMessage<DeleteDocumentRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 37);
+
+/**
+ * @generated from message syncspace.v1.DeleteDocumentResponse
+ */
+export type DeleteDocumentResponse = Message<"syncspace.v1.DeleteDocumentResponse"> & {
+  /**
+   * @generated from field: bool existed = 1;
+   */
+  existed: boolean;
+};
+
+/**
+ * Describes the message syncspace.v1.DeleteDocumentResponse.
+ * Use `create(DeleteDocumentResponseSchema)` to create a new message.
+ */
+export const DeleteDocumentResponseSchema: GenMessage<DeleteDocumentResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 38);
+
+/**
+ * @generated from message syncspace.v1.ListDocumentsRequest
+ */
+export type ListDocumentsRequest = Message<"syncspace.v1.ListDocumentsRequest"> & {
   /**
    * @generated from field: string space_id = 1;
    */
   spaceId: string;
 
   /**
+   * Filter by collection (empty = all)
+   *
    * @generated from field: string collection = 2;
    */
   collection: string;
 
   /**
-   * @generated from field: repeated syncspace.v1.QueryFilter filters = 3;
+   * Max results (0 = no limit)
+   *
+   * @generated from field: int32 limit = 3;
    */
-  filters: QueryFilter[];
+  limit: number;
+
+  /**
+   * Pagination cursor (empty = first page)
+   *
+   * @generated from field: string cursor = 4;
+   */
+  cursor: string;
+};
+
+/**
+ * Describes the message syncspace.v1.ListDocumentsRequest.
+ * Use `create(ListDocumentsRequestSchema)` to create a new message.
+ */
+export const ListDocumentsRequestSchema: GenMessage<ListDocumentsRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 39);
+
+/**
+ * @generated from message syncspace.v1.ListDocumentsResponse
+ */
+export type ListDocumentsResponse = Message<"syncspace.v1.ListDocumentsResponse"> & {
+  /**
+   * @generated from field: repeated syncspace.v1.DocumentInfo documents = 1;
+   */
+  documents: DocumentInfo[];
+
+  /**
+   * Cursor for next page (empty = no more pages)
+   *
+   * @generated from field: string next_cursor = 2;
+   */
+  nextCursor: string;
+};
+
+/**
+ * Describes the message syncspace.v1.ListDocumentsResponse.
+ * Use `create(ListDocumentsResponseSchema)` to create a new message.
+ */
+export const ListDocumentsResponseSchema: GenMessage<ListDocumentsResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 40);
+
+/**
+ * @generated from message syncspace.v1.DocumentInfo
+ */
+export type DocumentInfo = Message<"syncspace.v1.DocumentInfo"> & {
+  /**
+   * @generated from field: string document_id = 1;
+   */
+  documentId: string;
+
+  /**
+   * @generated from field: string collection = 2;
+   */
+  collection: string;
+
+  /**
+   * @generated from field: map<string, string> metadata = 3;
+   */
+  metadata: { [key: string]: string };
+
+  /**
+   * @generated from field: int64 version = 4;
+   */
+  version: bigint;
+
+  /**
+   * @generated from field: int64 created_at = 5;
+   */
+  createdAt: bigint;
+
+  /**
+   * @generated from field: int64 updated_at = 6;
+   */
+  updatedAt: bigint;
+};
+
+/**
+ * Describes the message syncspace.v1.DocumentInfo.
+ * Use `create(DocumentInfoSchema)` to create a new message.
+ */
+export const DocumentInfoSchema: GenMessage<DocumentInfo> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 41);
+
+/**
+ * @generated from message syncspace.v1.QueryDocumentsRequest
+ */
+export type QueryDocumentsRequest = Message<"syncspace.v1.QueryDocumentsRequest"> & {
+  /**
+   * @generated from field: string space_id = 1;
+   */
+  spaceId: string;
+
+  /**
+   * @generated from field: string collection = 2;
+   */
+  collection: string;
+
+  /**
+   * @generated from field: repeated syncspace.v1.QueryFilter filters = 3;
+   */
+  filters: QueryFilter[];
+
+  /**
+   * @generated from field: int32 limit = 4;
+   */
+  limit: number;
+
+  /**
+   * @generated from field: string cursor = 5;
+   */
+  cursor: string;
+};
+
+/**
+ * Describes the message syncspace.v1.QueryDocumentsRequest.
+ * Use `create(QueryDocumentsRequestSchema)` to create a new message.
+ */
+export const QueryDocumentsRequestSchema: GenMessage<QueryDocumentsRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 42);
+
+/**
+ * @generated from message syncspace.v1.QueryFilter
+ */
+export type QueryFilter = Message<"syncspace.v1.QueryFilter"> & {
+  /**
+   * Metadata field name
+   *
+   * @generated from field: string field = 1;
+   */
+  field: string;
+
+  /**
+   * "eq", "ne", "gt", "gte", "lt", "lte", "contains"
+   *
+   * @generated from field: string operator = 2;
+   */
+  operator: string;
+
+  /**
+   * Filter value
+   *
+   * @generated from field: string value = 3;
+   */
+  value: string;
+};
+
+/**
+ * Describes the message syncspace.v1.QueryFilter.
+ * Use `create(QueryFilterSchema)` to create a new message.
+ */
+export const QueryFilterSchema: GenMessage<QueryFilter> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 43);
+
+/**
+ * @generated from message syncspace.v1.QueryDocumentsResponse
+ */
+export type QueryDocumentsResponse = Message<"syncspace.v1.QueryDocumentsResponse"> & {
+  /**
+   * @generated from field: repeated syncspace.v1.DocumentInfo documents = 1;
+   */
+  documents: DocumentInfo[];
+
+  /**
+   * @generated from field: string next_cursor = 2;
+   */
+  nextCursor: string;
+};
+
+/**
+ * Describes the message syncspace.v1.QueryDocumentsResponse.
+ * Use `create(QueryDocumentsResponseSchema)` to create a new message.
+ */
+export const QueryDocumentsResponseSchema: GenMessage<QueryDocumentsResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 44);
+
+/**
+ * @generated from message syncspace.v1.StartSyncRequest
+ */
+export type StartSyncRequest = Message<"syncspace.v1.StartSyncRequest"> & {
+  /**
+   * Space to sync (empty = all spaces)
+   *
+   * @generated from field: string space_id = 1;
+   */
+  spaceId: string;
+};
+
+/**
+ * Describes the message syncspace.v1.StartSyncRequest.
+ * Use `create(StartSyncRequestSchema)` to create a new message.
+ */
+export const StartSyncRequestSchema: GenMessage<StartSyncRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 45);
+
+/**
+ * @generated from message syncspace.v1.StartSyncResponse
+ */
+export type StartSyncResponse = Message<"syncspace.v1.StartSyncResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message syncspace.v1.StartSyncResponse.
+ * Use `create(StartSyncResponseSchema)` to create a new message.
+ */
+export const StartSyncResponseSchema: GenMessage<StartSyncResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 46);
+
+/**
+ * @generated from message syncspace.v1.PauseSyncRequest
+ */
+export type PauseSyncRequest = Message<"syncspace.v1.PauseSyncRequest"> & {
+  /**
+   * Space to pause (empty = all spaces)
+   *
+   * @generated from field: string space_id = 1;
+   */
+  spaceId: string;
+};
+
+/**
+ * Describes the message syncspace.v1.PauseSyncRequest.
+ * Use `create(PauseSyncRequestSchema)` to create a new message.
+ */
+export const PauseSyncRequestSchema: GenMessage<PauseSyncRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 47);
+
+/**
+ * @generated from message syncspace.v1.PauseSyncResponse
+ */
+export type PauseSyncResponse = Message<"syncspace.v1.PauseSyncResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message syncspace.v1.PauseSyncResponse.
+ * Use `create(PauseSyncResponseSchema)` to create a new message.
+ */
+export const PauseSyncResponseSchema: GenMessage<PauseSyncResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 48);
+
+/**
+ * @generated from message syncspace.v1.GetSyncStatusRequest
+ */
+export type GetSyncStatusRequest = Message<"syncspace.v1.GetSyncStatusRequest"> & {
+  /**
+   * Space to query (empty = all spaces)
+   *
+   * @generated from field: string space_id = 1;
+   */
+  spaceId: string;
+};
+
+/**
+ * Describes the message syncspace.v1.GetSyncStatusRequest.
+ * Use `create(GetSyncStatusRequestSchema)` to create a new message.
+ */
+export const GetSyncStatusRequestSchema: GenMessage<GetSyncStatusRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 49);
+
+/**
+ * @generated from message syncspace.v1.GetSyncStatusResponse
+ */
+export type GetSyncStatusResponse = Message<"syncspace.v1.GetSyncStatusResponse"> & {
+  /**
+   * @generated from field: repeated syncspace.v1.SpaceSyncStatus statuses = 1;
+   */
+  statuses: SpaceSyncStatus[];
+};
+
+/**
+ * Describes the message syncspace.v1.GetSyncStatusResponse.
+ * Use `create(GetSyncStatusResponseSchema)` to create a new message.
+ */
+export const GetSyncStatusResponseSchema: GenMessage<GetSyncStatusResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 50);
+
+/**
+ * @generated from message syncspace.v1.SpaceSyncStatus
+ */
+export type SpaceSyncStatus = Message<"syncspace.v1.SpaceSyncStatus"> & {
+  /**
+   * @generated from field: string space_id = 1;
+   */
+  spaceId: string;
+
+  /**
+   * @generated from field: syncspace.v1.SyncStatus status = 2;
+   */
+  status: SyncStatus;
+
+  /**
+   * Unix timestamp
+   *
+   * @generated from field: int64 last_sync_at = 3;
+   */
+  lastSyncAt: bigint;
+
+  /**
+   * Number of local changes not yet synced
+   *
+   * @generated from field: int32 pending_changes = 4;
+   */
+  pendingChanges: number;
+
+  /**
+   * Last sync error (empty if no error)
+   *
+   * @generated from field: string error = 5;
+   */
+  error: string;
+};
+
+/**
+ * Describes the message syncspace.v1.SpaceSyncStatus.
+ * Use `create(SpaceSyncStatusSchema)` to create a new message.
+ */
+export const SpaceSyncStatusSchema: GenMessage<SpaceSyncStatus> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 51);
+
+/**
+ * @generated from message syncspace.v1.SubscribeRequest
+ */
+export type SubscribeRequest = Message<"syncspace.v1.SubscribeRequest"> & {
+  /**
+   * Event types to subscribe to (empty = all)
+   *
+   * @generated from field: repeated string event_types = 1;
+   */
+  eventTypes: string[];
+
+  /**
+   * Space IDs to filter by (empty = all)
+   *
+   * @generated from field: repeated string space_ids = 2;
+   */
+  spaceIds: string[];
+};
+
+/**
+ * Describes the message syncspace.v1.SubscribeRequest.
+ * Use `create(SubscribeRequestSchema)` to create a new message.
+ */
+export const SubscribeRequestSchema: GenMessage<SubscribeRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 52);
+
+/**
+ * @generated from message syncspace.v1.SubscribeResponse
+ */
+export type SubscribeResponse = Message<"syncspace.v1.SubscribeResponse"> & {
+  /**
+   * @generated from field: string event_id = 1;
+   */
+  eventId: string;
+
+  /**
+   * "document.created", "document.updated", "sync.status_changed", etc.
+   *
+   * @generated from field: string event_type = 2;
+   */
+  eventType: string;
+
+  /**
+   * @generated from field: string space_id = 3;
+   */
+  spaceId: string;
+
+  /**
+   * Unix timestamp
+   *
+   * @generated from field: int64 timestamp = 4;
+   */
+  timestamp: bigint;
+
+  /**
+   * Event-specific data
+   *
+   * @generated from field: bytes payload = 5;
+   */
+  payload: Uint8Array;
+};
+
+/**
+ * Describes the message syncspace.v1.SubscribeResponse.
+ * Use `create(SubscribeResponseSchema)` to create a new message.
+ */
+export const SubscribeResponseSchema: GenMessage<SubscribeResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 53);
+
+/**
+ * @generated from message syncspace.v1.DocumentCreatedEvent
+ */
+export type DocumentCreatedEvent = Message<"syncspace.v1.DocumentCreatedEvent"> & {
+  /**
+   * @generated from field: string document_id = 1;
+   */
+  documentId: string;
+
+  /**
+   * @generated from field: string collection = 2;
+   */
+  collection: string;
+};
+
+/**
+ * Describes the message syncspace.v1.DocumentCreatedEvent.
+ * Use `create(DocumentCreatedEventSchema)` to create a new message.
+ */
+export const DocumentCreatedEventSchema: GenMessage<DocumentCreatedEvent> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 54);
+
+/**
+ * @generated from message syncspace.v1.DocumentUpdatedEvent
+ */
+export type DocumentUpdatedEvent = Message<"syncspace.v1.DocumentUpdatedEvent"> & {
+  /**
+   * @generated from field: string document_id = 1;
+   */
+  documentId: string;
+
+  /**
+   * @generated from field: int64 old_version = 2;
+   */
+  oldVersion: bigint;
+
+  /**
+   * @generated from field: int64 new_version = 3;
+   */
+  newVersion: bigint;
+};
+
+/**
+ * Describes the message syncspace.v1.DocumentUpdatedEvent.
+ * Use `create(DocumentUpdatedEventSchema)` to create a new message.
+ */
+export const DocumentUpdatedEventSchema: GenMessage<DocumentUpdatedEvent> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 55);
+
+/**
+ * @generated from message syncspace.v1.DocumentDeletedEvent
+ */
+export type DocumentDeletedEvent = Message<"syncspace.v1.DocumentDeletedEvent"> & {
+  /**
+   * @generated from field: string document_id = 1;
+   */
+  documentId: string;
+};
+
+/**
+ * Describes the message syncspace.v1.DocumentDeletedEvent.
+ * Use `create(DocumentDeletedEventSchema)` to create a new message.
+ */
+export const DocumentDeletedEventSchema: GenMessage<DocumentDeletedEvent> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 56);
+
+/**
+ * @generated from message syncspace.v1.SpaceUpdatedEvent
+ */
+export type SpaceUpdatedEvent = Message<"syncspace.v1.SpaceUpdatedEvent"> & {
+  /**
+   * @generated from field: string old_name = 1;
+   */
+  oldName: string;
+
+  /**
+   * @generated from field: string new_name = 2;
+   */
+  newName: string;
+
+  /**
+   * @generated from field: map<string, string> old_metadata = 3;
+   */
+  oldMetadata: { [key: string]: string };
+
+  /**
+   * @generated from field: map<string, string> new_metadata = 4;
+   */
+  newMetadata: { [key: string]: string };
+
+  /**
+   * @generated from field: int64 old_updated_at = 5;
+   */
+  oldUpdatedAt: bigint;
+
+  /**
+   * @generated from field: int64 new_updated_at = 6;
+   */
+  newUpdatedAt: bigint;
+};
+
+/**
+ * Describes the message syncspace.v1.SpaceUpdatedEvent.
+ * Use `create(SpaceUpdatedEventSchema)` to create a new message.
+ */
+export const SpaceUpdatedEventSchema: GenMessage<SpaceUpdatedEvent> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 57);
+
+/**
+ * SpaceMemberEvent is the payload of the space.member_joined,
+ * space.member_join_requested, space.member_removed and
+ * space.member_permission_changed events.
+ *
+ * @generated from message syncspace.v1.SpaceMemberEvent
+ */
+export type SpaceMemberEvent = Message<"syncspace.v1.SpaceMemberEvent"> & {
+  /**
+   * Account address of the member
+   *
+   * @generated from field: string identity = 1;
+   */
+  identity: string;
+
+  /**
+   * reader, writer or admin; before the removal for space.member_removed
+   *
+   * @generated from field: string permission = 2;
+   */
+  permission: string;
+
+  /**
+   * active, join_requested or leaving
+   *
+   * @generated from field: string status = 3;
+   */
+  status: string;
+
+  /**
+   * Only for space.member_permission_changed
+   *
+   * @generated from field: string old_permission = 4;
+   */
+  oldPermission: string;
+};
+
+/**
+ * Describes the message syncspace.v1.SpaceMemberEvent.
+ * Use `create(SpaceMemberEventSchema)` to create a new message.
+ */
+export const SpaceMemberEventSchema: GenMessage<SpaceMemberEvent> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 58);
+
+/**
+ * @generated from message syncspace.v1.SyncStatusChangedEvent
+ */
+export type SyncStatusChangedEvent = Message<"syncspace.v1.SyncStatusChangedEvent"> & {
+  /**
+   * @generated from field: syncspace.v1.SyncStatus old_status = 1;
+   */
+  oldStatus: SyncStatus;
+
+  /**
+   * @generated from field: syncspace.v1.SyncStatus new_status = 2;
+   */
+  newStatus: SyncStatus;
+
+  /**
+   * @generated from field: string error = 3;
+   */
+  error: string;
+};
+
+/**
+ * Describes the message syncspace.v1.SyncStatusChangedEvent.
+ * Use `create(SyncStatusChangedEventSchema)` to create a new message.
+ */
+export const SyncStatusChangedEventSchema: GenMessage<SyncStatusChangedEvent> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 59);
+
+/**
+ * BatchRequest executes several commands in one call, in order.
+ * With atomic set, only document commands are allowed: if any command fails,
+ * document changes made by earlier commands are rolled back and their events
+ * are never emitted. Without it, every command runs and reports its own result.
+ *
+ * @generated from message syncspace.v1.BatchRequest
+ */
+export type BatchRequest = Message<"syncspace.v1.BatchRequest"> & {
+  /**
+   * Commands to execute, in order
+   *
+   * @generated from field: repeated syncspace.v1.Command commands = 1;
+   */
+  commands: Command[];
+
+  /**
+   * All-or-nothing execution
+   *
+   * @generated from field: bool atomic = 2;
+   */
+  atomic: boolean;
+};
+
+/**
+ * Describes the message syncspace.v1.BatchRequest.
+ * Use `create(BatchRequestSchema)` to create a new message.
+ */
+export const BatchRequestSchema: GenMessage<BatchRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 60);
+
+/**
+ * @generated from message syncspace.v1.BatchResponse
+ */
+export type BatchResponse = Message<"syncspace.v1.BatchResponse"> & {
+  /**
+   * One result per command, in request order
+   *
+   * @generated from field: repeated syncspace.v1.CommandResponse results = 1;
+   */
+  results: CommandResponse[];
+};
+
+/**
+ * Describes the message syncspace.v1.BatchResponse.
+ * Use `create(BatchResponseSchema)` to create a new message.
+ */
+export const BatchResponseSchema: GenMessage<BatchResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 61);
+
+/**
+ * DescribeCommandsRequest lists the commands registered in the backend.
+ * It can be called before Init.
+ *
+ * @generated from message syncspace.v1.DescribeCommandsRequest
+ */
+export type DescribeCommandsRequest = Message<"syncspace.v1.DescribeCommandsRequest"> & {};
+
+/**
+ * Describes the message syncspace.v1.DescribeCommandsRequest.
+ * Use `create(DescribeCommandsRequestSchema)` to create a new message.
+ */
+export const DescribeCommandsRequestSchema: GenMessage<DescribeCommandsRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 62);
+
+/**
+ * @generated from message syncspace.v1.DescribeCommandsResponse
+ */
+export type DescribeCommandsResponse = Message<"syncspace.v1.DescribeCommandsResponse"> & {
+  /**
+   * Registered commands, sorted by name
+   *
+   * @generated from field: repeated syncspace.v1.CommandInfo commands = 1;
+   */
+  commands: CommandInfo[];
+
+  /**
+   * Serialized google.protobuf.FileDescriptorSet of all request/response messages
+   *
+   * @generated from field: bytes file_descriptor_set = 2;
+   */
+  fileDescriptorSet: Uint8Array;
+
+  /**
+   * Hex SHA-256 of file_descriptor_set, for quick version skew checks
+   *
+   * @generated from field: string schema_digest = 3;
+   */
+  schemaDigest: string;
+};
+
+/**
+ * Describes the message syncspace.v1.DescribeCommandsResponse.
+ * Use `create(DescribeCommandsResponseSchema)` to create a new message.
+ */
+export const DescribeCommandsResponseSchema: GenMessage<DescribeCommandsResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 63);
+
+/**
+ * @generated from message syncspace.v1.CommandInfo
+ */
+export type CommandInfo = Message<"syncspace.v1.CommandInfo"> & {
+  /**
+   * Command name (e.g., "CreateDocument")
+   *
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * Fully-qualified request message name (e.g., "syncspace.v1.CreateDocumentRequest")
+   *
+   * @generated from field: string request_type = 2;
+   */
+  requestType: string;
+
+  /**
+   * Fully-qualified response message name (for streams, the streamed message)
+   *
+   * @generated from field: string response_type = 3;
+   */
+  responseType: string;
+
+  /**
+   * Server-streaming command; see StreamMessage
+   *
+   * @generated from field: bool streaming = 4;
+   */
+  streaming: boolean;
+};
+
+/**
+ * Describes the message syncspace.v1.CommandInfo.
+ * Use `create(CommandInfoSchema)` to create a new message.
+ */
+export const CommandInfoSchema: GenMessage<CommandInfo> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 64);
+
+/**
+ * CommandError is the structured error returned across the dispatch boundary.
+ * Desktop attaches it to the gRPC status details; mobile returns it as the
+ * JSON-encoded message of the thrown error.
+ *
+ * @generated from message syncspace.v1.CommandError
+ */
+export type CommandError = Message<"syncspace.v1.CommandError"> & {
+  /**
+   * Machine-readable error category
+   *
+   * @generated from field: syncspace.v1.ErrorCode code = 1;
+   */
+  code: ErrorCode;
+
+  /**
+   * Human-readable description
+   *
+   * @generated from field: string message = 2;
+   */
+  message: string;
+
+  /**
+   * Additional context (e.g. "space_id", "document_id")
+   *
+   * @generated from field: map<string, string> details = 3;
+   */
+  details: { [key: string]: string };
+};
+
+/**
+ * Describes the message syncspace.v1.CommandError.
+ * Use `create(CommandErrorSchema)` to create a new message.
+ */
+export const CommandErrorSchema: GenMessage<CommandError> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 65);
+
+/**
+ * StreamMessage carries one message of a streaming command (e.g. Subscribe)
+ * to hosts that receive all streams through a single callback, such as the
+ * mobile event handler. Desktop streams over gRPC and does not use it.
+ *
+ * @generated from message syncspace.v1.StreamMessage
+ */
+export type StreamMessage = Message<"syncspace.v1.StreamMessage"> & {
+  /**
+   * Stream ID returned when the stream was started
+   *
+   * @generated from field: string stream_id = 1;
+   */
+  streamId: string;
+
+  /**
+   * Streaming command name
+   *
+   * @generated from field: string command = 2;
+   */
+  command: string;
+
+  /**
+   * Serialized streamed message; empty when done is set
+   *
+   * @generated from field: bytes payload = 3;
+   */
+  payload: Uint8Array;
+
+  /**
+   * Last message of the stream; no more messages follow
+   *
+   * @generated from field: bool done = 4;
+   */
+  done: boolean;
+
+  /**
+   * Why the stream ended, if it ended with an error
+   *
+   * @generated from field: syncspace.v1.CommandError error = 5;
+   */
+  error?: CommandError;
+};
+
+/**
+ * Describes the message syncspace.v1.StreamMessage.
+ * Use `create(StreamMessageSchema)` to create a new message.
+ */
+export const StreamMessageSchema: GenMessage<StreamMessage> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 66);
+
+/**
+ * @generated from message syncspace.v1.ProfileInfo
+ */
+export type ProfileInfo = Message<"syncspace.v1.ProfileInfo"> & {
+  /**
+   * Directory-safe identifier: letters, digits, "-" and "_"
+   *
+   * @generated from field: string profile_id = 1;
+   */
+  profileId: string;
+
+  /**
+   * Display name
+   *
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * Unix timestamp
+   *
+   * @generated from field: int64 created_at = 3;
+   */
+  createdAt: bigint;
+
+  /**
+   * Profile is loaded; its keys are in memory
+   *
+   * @generated from field: bool open = 4;
+   */
+  open: boolean;
+
+  /**
+   * Commands currently run on this profile
+   *
+   * @generated from field: bool active = 5;
+   */
+  active: boolean;
+};
+
+/**
+ * Describes the message syncspace.v1.ProfileInfo.
+ * Use `create(ProfileInfoSchema)` to create a new message.
+ */
+export const ProfileInfoSchema: GenMessage<ProfileInfo> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 67);
+
+/**
+ * @generated from message syncspace.v1.CreateProfileRequest
+ */
+export type CreateProfileRequest = Message<"syncspace.v1.CreateProfileRequest"> & {
+  /**
+   * @generated from field: string profile_id = 1;
+   */
+  profileId: string;
+
+  /**
+   * Defaults to profile_id
+   *
+   * @generated from field: string name = 2;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message syncspace.v1.CreateProfileRequest.
+ * Use `create(CreateProfileRequestSchema)` to create a new message.
+ */
+export const CreateProfileRequestSchema: GenMessage<CreateProfileRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 68);
+
+/**
+ * @generated from message syncspace.v1.CreateProfileResponse
+ */
+export type CreateProfileResponse = Message<"syncspace.v1.CreateProfileResponse"> & {
+  /**
+   * @generated from field: syncspace.v1.ProfileInfo profile = 1;
+   */
+  profile?: ProfileInfo;
+};
+
+/**
+ * Describes the message syncspace.v1.CreateProfileResponse.
+ * Use `create(CreateProfileResponseSchema)` to create a new message.
+ */
+export const CreateProfileResponseSchema: GenMessage<CreateProfileResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 69);
+
+/**
+ * @generated from message syncspace.v1.ListProfilesRequest
+ */
+export type ListProfilesRequest = Message<"syncspace.v1.ListProfilesRequest"> & {};
+
+/**
+ * Describes the message syncspace.v1.ListProfilesRequest.
+ * Use `create(ListProfilesRequestSchema)` to create a new message.
+ */
+export const ListProfilesRequestSchema: GenMessage<ListProfilesRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 70);
+
+/**
+ * @generated from message syncspace.v1.ListProfilesResponse
+ */
+export type ListProfilesResponse = Message<"syncspace.v1.ListProfilesResponse"> & {
+  /**
+   * "default" first, then sorted by profile_id
+   *
+   * @generated from field: repeated syncspace.v1.ProfileInfo profiles = 1;
+   */
+  profiles: ProfileInfo[];
+};
+
+/**
+ * Describes the message syncspace.v1.ListProfilesResponse.
+ * Use `create(ListProfilesResponseSchema)` to create a new message.
+ */
+export const ListProfilesResponseSchema: GenMessage<ListProfilesResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 71);
+
+/**
+ * OpenProfileRequest loads a profile if needed and makes it the active one.
+ *
+ * @generated from message syncspace.v1.OpenProfileRequest
+ */
+export type OpenProfileRequest = Message<"syncspace.v1.OpenProfileRequest"> & {
+  /**
+   * @generated from field: string profile_id = 1;
+   */
+  profileId: string;
+
+  /**
+   * For a passphrase-protected profile, see InitRequest.passphrase
+   *
+   * @generated from field: string passphrase = 2;
+   */
+  passphrase: string;
+
+  /**
+   * Restores the account of a new profile, see InitRequest.mnemonic
+   *
+   * @generated from field: string mnemonic = 3;
+   */
+  mnemonic: string;
+};
+
+/**
+ * Describes the message syncspace.v1.OpenProfileRequest.
+ * Use `create(OpenProfileRequestSchema)` to create a new message.
+ */
+export const OpenProfileRequestSchema: GenMessage<OpenProfileRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 72);
+
+/**
+ * @generated from message syncspace.v1.OpenProfileResponse
+ */
+export type OpenProfileResponse = Message<"syncspace.v1.OpenProfileResponse"> & {
+  /**
+   * @generated from field: syncspace.v1.ProfileInfo profile = 1;
+   */
+  profile?: ProfileInfo;
+};
+
+/**
+ * Describes the message syncspace.v1.OpenProfileResponse.
+ * Use `create(OpenProfileResponseSchema)` to create a new message.
+ */
+export const OpenProfileResponseSchema: GenMessage<OpenProfileResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 73);
+
+/**
+ * CloseProfileRequest unloads a profile. Closing the active profile leaves no
+ * profile active until the next OpenProfile.
+ *
+ * @generated from message syncspace.v1.CloseProfileRequest
+ */
+export type CloseProfileRequest = Message<"syncspace.v1.CloseProfileRequest"> & {
+  /**
+   * @generated from field: string profile_id = 1;
+   */
+  profileId: string;
+};
+
+/**
+ * Describes the message syncspace.v1.CloseProfileRequest.
+ * Use `create(CloseProfileRequestSchema)` to create a new message.
+ */
+export const CloseProfileRequestSchema: GenMessage<CloseProfileRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 74);
+
+/**
+ * @generated from message syncspace.v1.CloseProfileResponse
+ */
+export type CloseProfileResponse = Message<"syncspace.v1.CloseProfileResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message syncspace.v1.CloseProfileResponse.
+ * Use `create(CloseProfileResponseSchema)` to create a new message.
+ */
+export const CloseProfileResponseSchema: GenMessage<CloseProfileResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 75);
+
+/**
+ * DeleteProfileRequest closes a profile and removes all of its data.
+ * The default profile cannot be deleted.
+ *
+ * @generated from message syncspace.v1.DeleteProfileRequest
+ */
+export type DeleteProfileRequest = Message<"syncspace.v1.DeleteProfileRequest"> & {
+  /**
+   * @generated from field: string profile_id = 1;
+   */
+  profileId: string;
+};
+
+/**
+ * Describes the message syncspace.v1.DeleteProfileRequest.
+ * Use `create(DeleteProfileRequestSchema)` to create a new message.
+ */
+export const DeleteProfileRequestSchema: GenMessage<DeleteProfileRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 76);
+
+/**
+ * @generated from message syncspace.v1.DeleteProfileResponse
+ */
+export type DeleteProfileResponse = Message<"syncspace.v1.DeleteProfileResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message syncspace.v1.DeleteProfileResponse.
+ * Use `create(DeleteProfileResponseSchema)` to create a new message.
+ */
+export const DeleteProfileResponseSchema: GenMessage<DeleteProfileResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 77);
+
+/**
+ * GetConfigRequest returns the configuration the backend runs with, after
+ * defaults are applied.
+ *
+ * @generated from message syncspace.v1.GetConfigRequest
+ */
+export type GetConfigRequest = Message<"syncspace.v1.GetConfigRequest"> & {};
+
+/**
+ * Describes the message syncspace.v1.GetConfigRequest.
+ * Use `create(GetConfigRequestSchema)` to create a new message.
+ */
+export const GetConfigRequestSchema: GenMessage<GetConfigRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 78);
+
+/**
+ * @generated from message syncspace.v1.GetConfigResponse
+ */
+export type GetConfigResponse = Message<"syncspace.v1.GetConfigResponse"> & {
+  /**
+   * @generated from field: syncspace.v1.BackendConfig config = 1;
+   */
+  config?: BackendConfig;
+};
+
+/**
+ * Describes the message syncspace.v1.GetConfigResponse.
+ * Use `create(GetConfigResponseSchema)` to create a new message.
+ */
+export const GetConfigResponseSchema: GenMessage<GetConfigResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 79);
+
+/**
+ * BackendConfig is the validated configuration parsed from InitRequest.
+ * InitRequest.config and config_json accept every field except data_dir,
+ * network_id, device_id and extra, under the same names.
+ *
+ * @generated from message syncspace.v1.BackendConfig
+ */
+export type BackendConfig = Message<"syncspace.v1.BackendConfig"> & {
+  /**
+   * @generated from field: string data_dir = 1;
+   */
+  dataDir: string;
+
+  /**
+   * @generated from field: string network_id = 2;
+   */
+  networkId: string;
+
+  /**
+   * @generated from field: string device_id = 3;
+   */
+  deviceId: string;
+
+  /**
+   * "local" (default); network sync is not available yet
+   *
+   * @generated from field: string network_mode = 4;
+   */
+  networkMode: string;
+
+  /**
+   * "debug", "info" (default), "warn" or "error"
+   *
+   * @generated from field: string log_level = 5;
+   */
+  logLevel: string;
+
+  /**
+   * Default command deadline; negative means none
+   *
+   * @generated from field: int64 command_timeout_ms = 6;
+   */
+  commandTimeoutMs: bigint;
+
+  /**
+   * Per-command overrides
+   *
+   * @generated from field: map<string, int64> command_timeouts_ms = 7;
+   */
+  commandTimeoutsMs: { [key: string]: bigint };
+
+  /**
+   * any-sync head sync period (default 5)
+   *
+   * @generated from field: int32 sync_period_sec = 8;
+   */
+  syncPeriodSec: number;
+
+  /**
+   * any-sync tree garbage collection TTL (default 60)
+   *
+   * @generated from field: int32 gc_ttl_sec = 9;
+   */
+  gcTtlSec: number;
+
+  /**
+   * any-sync tree cache (default true)
+   *
+   * @generated from field: bool keep_tree_data_in_memory = 10;
+   */
+  keepTreeDataInMemory: boolean;
+
+  /**
+   * Idle time before the session locks itself; 0 (default) never
+   *
+   * @generated from field: int32 auto_lock_sec = 12;
+   */
+  autoLockSec: number;
+
+  /**
+   * Space objects kept open at once; 0 (default) no limit
+   *
+   * @generated from field: int32 space_cache_size = 13;
+   */
+  spaceCacheSize: number;
+};
+
+/**
+ * Describes the message syncspace.v1.BackendConfig.
+ * Use `create(BackendConfigSchema)` to create a new message.
+ */
+export const BackendConfigSchema: GenMessage<BackendConfig> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 80);
+
+/**
+ * GetStatusRequest reports the state of the backend, for health checks and
+ * bug reports. It succeeds before Init, reporting initialized = false.
+ *
+ * @generated from message syncspace.v1.GetStatusRequest
+ */
+export type GetStatusRequest = Message<"syncspace.v1.GetStatusRequest"> & {};
+
+/**
+ * Describes the message syncspace.v1.GetStatusRequest.
+ * Use `create(GetStatusRequestSchema)` to create a new message.
+ */
+export const GetStatusRequestSchema: GenMessage<GetStatusRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 81);
+
+/**
+ * @generated from message syncspace.v1.GetStatusResponse
+ */
+export type GetStatusResponse = Message<"syncspace.v1.GetStatusResponse"> & {
+  /**
+   * @generated from field: bool initialized = 1;
+   */
+  initialized: boolean;
+
+  /**
+   * @generated from field: string data_dir = 2;
+   */
+  dataDir: string;
+
+  /**
+   * Unix timestamp (seconds) of Init; 0 before Init
+   *
+   * @generated from field: int64 started_at = 3;
+   */
+  startedAt: bigint;
+
+  /**
+   * Time since Init
+   *
+   * @generated from field: int64 uptime_ms = 4;
+   */
+  uptimeMs: bigint;
+
+  /**
+   * Any-Sync space objects loaded in memory
+   *
+   * @generated from field: int32 open_space_count = 5;
+   */
+  openSpaceCount: number;
+
+  /**
+   * Active event subscriptions
+   *
+   * @generated from field: int32 subscriber_count = 6;
+   */
+  subscriberCount: number;
+
+  /**
+   * Total on-disk size of all spaces
+   *
+   * @generated from field: int64 storage_bytes = 7;
+   */
+  storageBytes: bigint;
+
+  /**
+   * Sorted by space_id
+   *
+   * @generated from field: repeated syncspace.v1.SpaceDiagnostics spaces = 8;
+   */
+  spaces: SpaceDiagnostics[];
+
+  /**
+   * Go runtime of the backend
+   *
+   * @generated from field: string go_version = 9;
+   */
+  goVersion: string;
+
+  /**
+   * GOOS/GOARCH, e.g. "darwin/arm64"
+   *
+   * @generated from field: string platform = 10;
+   */
+  platform: string;
+
+  /**
+   * The account key is sealed with a passphrase
+   *
+   * @generated from field: bool passphrase_protected = 11;
+   */
+  passphraseProtected: boolean;
+
+  /**
+   * The session is locked, see Lock
+   *
+   * @generated from field: bool locked = 12;
+   */
+  locked: boolean;
+};
+
+/**
+ * Describes the message syncspace.v1.GetStatusResponse.
+ * Use `create(GetStatusResponseSchema)` to create a new message.
+ */
+export const GetStatusResponseSchema: GenMessage<GetStatusResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 82);
+
+/**
+ * SpaceDiagnostics describes the local state of one space.
+ *
+ * @generated from message syncspace.v1.SpaceDiagnostics
+ */
+export type SpaceDiagnostics = Message<"syncspace.v1.SpaceDiagnostics"> & {
+  /**
+   * @generated from field: string space_id = 1;
+   */
+  spaceId: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * The space object is loaded in memory
+   *
+   * @generated from field: bool open = 3;
+   */
+  open: boolean;
+
+  /**
+   * @generated from field: int32 document_count = 4;
+   */
+  documentCount: number;
+
+  /**
+   * On-disk size of the space storage
+   *
+   * @generated from field: int64 storage_bytes = 5;
+   */
+  storageBytes: bigint;
+
+  /**
+   * Why the space last failed to load; empty if it did not
+   *
+   * @generated from field: string load_error = 6;
+   */
+  loadError: string;
+};
+
+/**
+ * Describes the message syncspace.v1.SpaceDiagnostics.
+ * Use `create(SpaceDiagnosticsSchema)` to create a new message.
+ */
+export const SpaceDiagnosticsSchema: GenMessage<SpaceDiagnostics> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 83);
+
+/**
+ * SetPassphraseRequest protects an unprotected account key.
+ *
+ * @generated from message syncspace.v1.SetPassphraseRequest
+ */
+export type SetPassphraseRequest = Message<"syncspace.v1.SetPassphraseRequest"> & {
+  /**
+   * @generated from field: string passphrase = 1;
+   */
+  passphrase: string;
+};
+
+/**
+ * Describes the message syncspace.v1.SetPassphraseRequest.
+ * Use `create(SetPassphraseRequestSchema)` to create a new message.
+ */
+export const SetPassphraseRequestSchema: GenMessage<SetPassphraseRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 84);
+
+/**
+ * @generated from message syncspace.v1.SetPassphraseResponse
+ */
+export type SetPassphraseResponse = Message<"syncspace.v1.SetPassphraseResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message syncspace.v1.SetPassphraseResponse.
+ * Use `create(SetPassphraseResponseSchema)` to create a new message.
+ */
+export const SetPassphraseResponseSchema: GenMessage<SetPassphraseResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 85);
 
+/**
+ * ChangePassphraseRequest re-seals the account key with a new passphrase.
+ *
+ * @generated from message syncspace.v1.ChangePassphraseRequest
+ */
+export type ChangePassphraseRequest = Message<"syncspace.v1.ChangePassphraseRequest"> & {
   /**
-   * @generated from field: int32 limit = 4;
+   * @generated from field: string current_passphrase = 1;
    */
-  limit: number;
+  currentPassphrase: string;
 
   /**
-   * @generated from field: string cursor = 5;
+   * @generated from field: string new_passphrase = 2;
    */
-  cursor: string;
+  newPassphrase: string;
 };
 
 /**
- * Describes the message syncspace.v1.QueryDocumentsRequest.
- * Use `create(QueryDocumentsRequestSchema)` to create a new message.
+ * Describes the message syncspace.v1.ChangePassphraseRequest.
+ * Use `create(ChangePassphraseRequestSchema)` to create a new message.
  */
-export const QueryDocumentsRequestSchema: GenMessage<QueryDocumentsRequest> =
+export const ChangePassphraseRequestSchema: GenMessage<ChangePassphraseRequest> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 29);
+  messageDesc(file_syncspace_v1_syncspace, 86);
 
 /**
- * @generated from message syncspace.v1.QueryFilter
+ * @generated from message syncspace.v1.ChangePassphraseResponse
  */
-export type QueryFilter = Message<"syncspace.v1.QueryFilter"> & {
+export type ChangePassphraseResponse = Message<"syncspace.v1.ChangePassphraseResponse"> & {
   /**
-   * Metadata field name
-   *
-   * @generated from field: string field = 1;
+   * @generated from field: bool success = 1;
    */
-  field: string;
+  success: boolean;
+};
 
-  /**
-   * "eq", "ne", "gt", "gte", "lt", "lte", "contains"
-   *
-   * @generated from field: string operator = 2;
-   */
-  operator: string;
+/**
+ * Describes the message syncspace.v1.ChangePassphraseResponse.
+ * Use `create(ChangePassphraseResponseSchema)` to create a new message.
+ */
+export const ChangePassphraseResponseSchema: GenMessage<ChangePassphraseResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 87);
 
+/**
+ * RemovePassphraseRequest stores the account key without a passphrase again.
+ *
+ * @generated from message syncspace.v1.RemovePassphraseRequest
+ */
+export type RemovePassphraseRequest = Message<"syncspace.v1.RemovePassphraseRequest"> & {
   /**
-   * Filter value
-   *
-   * @generated from field: string value = 3;
+   * @generated from field: string current_passphrase = 1;
    */
-  value: string;
+  currentPassphrase: string;
 };
 
 /**
- * Describes the message syncspace.v1.QueryFilter.
- * Use `create(QueryFilterSchema)` to create a new message.
+ * Describes the message syncspace.v1.RemovePassphraseRequest.
+ * Use `create(RemovePassphraseRequestSchema)` to create a new message.
  */
-export const QueryFilterSchema: GenMessage<QueryFilter> =
+export const RemovePassphraseRequestSchema: GenMessage<RemovePassphraseRequest> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 30);
+  messageDesc(file_syncspace_v1_syncspace, 88);
 
 /**
- * @generated from message syncspace.v1.QueryDocumentsResponse
+ * @generated from message syncspace.v1.RemovePassphraseResponse
  */
-export type QueryDocumentsResponse = Message<"syncspace.v1.QueryDocumentsResponse"> & {
+export type RemovePassphraseResponse = Message<"syncspace.v1.RemovePassphraseResponse"> & {
   /**
-   * @generated from field: repeated syncspace.v1.DocumentInfo documents = 1;
+   * @generated from field: bool success = 1;
    */
-  documents: DocumentInfo[];
+  success: boolean;
+};
 
+/**
+ * Describes the message syncspace.v1.RemovePassphraseResponse.
+ * Use `create(RemovePassphraseResponseSchema)` to create a new message.
+ */
+export const RemovePassphraseResponseSchema: GenMessage<RemovePassphraseResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 89);
+
+/**
+ * ExportMnemonicRequest asks for the recovery phrase of the account: 24 BIP39
+ * words encoding the account key. Init restores the account from it on a new
+ * device (see InitRequest.mnemonic), so it must be kept secret.
+ *
+ * @generated from message syncspace.v1.ExportMnemonicRequest
+ */
+export type ExportMnemonicRequest = Message<"syncspace.v1.ExportMnemonicRequest"> & {
   /**
-   * @generated from field: string next_cursor = 2;
+   * Required if the account key is passphrase-protected
+   *
+   * @generated from field: string passphrase = 1;
    */
-  nextCursor: string;
+  passphrase: string;
 };
 
 /**
- * Describes the message syncspace.v1.QueryDocumentsResponse.
- * Use `create(QueryDocumentsResponseSchema)` to create a new message.
+ * Describes the message syncspace.v1.ExportMnemonicRequest.
+ * Use `create(ExportMnemonicRequestSchema)` to create a new message.
  */
-export const QueryDocumentsResponseSchema: GenMessage<QueryDocumentsResponse> =
+export const ExportMnemonicRequestSchema: GenMessage<ExportMnemonicRequest> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 31);
+  messageDesc(file_syncspace_v1_syncspace, 90);
 
 /**
- * @generated from message syncspace.v1.StartSyncRequest
+ * @generated from message syncspace.v1.ExportMnemonicResponse
  */
-export type StartSyncRequest = Message<"syncspace.v1.StartSyncRequest"> & {
+export type ExportMnemonicResponse = Message<"syncspace.v1.ExportMnemonicResponse"> & {
   /**
-   * Space to sync (empty = all spaces)
-   *
-   * @generated from field: string space_id = 1;
+   * @generated from field: string mnemonic = 1;
    */
-  spaceId: string;
+  mnemonic: string;
 };
 
 /**
- * Describes the message syncspace.v1.StartSyncRequest.
- * Use `create(StartSyncRequestSchema)` to create a new message.
+ * Describes the message syncspace.v1.ExportMnemonicResponse.
+ * Use `create(ExportMnemonicResponseSchema)` to create a new message.
  */
-export const StartSyncRequestSchema: GenMessage<StartSyncRequest> =
+export const ExportMnemonicResponseSchema: GenMessage<ExportMnemonicResponse> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 32);
+  messageDesc(file_syncspace_v1_syncspace, 91);
 
 /**
- * @generated from message syncspace.v1.StartSyncResponse
+ * LockRequest locks the session: the account keys are cleared from memory
+ * and the spaces are closed, after the running commands finish. Until
+ * Unlock, other data commands fail with ERROR_CODE_LOCKED. Event
+ * subscriptions stay open and receive account.locked. The session also locks
+ * itself after BackendConfig.auto_lock_sec without commands.
+ *
+ * @generated from message syncspace.v1.LockRequest
  */
-export type StartSyncResponse = Message<"syncspace.v1.StartSyncResponse"> & {
+export type LockRequest = Message<"syncspace.v1.LockRequest"> & {};
+
+/**
+ * Describes the message syncspace.v1.LockRequest.
+ * Use `create(LockRequestSchema)` to create a new message.
+ */
+export const LockRequestSchema: GenMessage<LockRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 92);
+
+/**
+ * @generated from message syncspace.v1.LockResponse
+ */
+export type LockResponse = Message<"syncspace.v1.LockResponse"> & {
   /**
    * @generated from field: bool success = 1;
    */
@@ -918,37 +2787,39 @@ export type StartSyncResponse = Message<"syncspace.v1.StartSyncResponse"> & {
 };
 
 /**
- * Describes the message syncspace.v1.StartSyncResponse.
- * Use `create(StartSyncResponseSchema)` to create a new message.
+ * Describes the message syncspace.v1.LockResponse.
+ * Use `create(LockResponseSchema)` to create a new message.
  */
-export const StartSyncResponseSchema: GenMessage<StartSyncResponse> =
+export const LockResponseSchema: GenMessage<LockResponse> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 33);
+  messageDesc(file_syncspace_v1_syncspace, 93);
 
 /**
- * @generated from message syncspace.v1.PauseSyncRequest
+ * UnlockRequest opens the account keys again and emits account.unlocked.
+ *
+ * @generated from message syncspace.v1.UnlockRequest
  */
-export type PauseSyncRequest = Message<"syncspace.v1.PauseSyncRequest"> & {
+export type UnlockRequest = Message<"syncspace.v1.UnlockRequest"> & {
   /**
-   * Space to pause (empty = all spaces)
+   * Required if the account key is passphrase-protected
    *
-   * @generated from field: string space_id = 1;
+   * @generated from field: string passphrase = 1;
    */
-  spaceId: string;
+  passphrase: string;
 };
 
 /**
- * Describes the message syncspace.v1.PauseSyncRequest.
- * Use `create(PauseSyncRequestSchema)` to create a new message.
+ * Describes the message syncspace.v1.UnlockRequest.
+ * Use `create(UnlockRequestSchema)` to create a new message.
  */
-export const PauseSyncRequestSchema: GenMessage<PauseSyncRequest> =
+export const UnlockRequestSchema: GenMessage<UnlockRequest> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 34);
+  messageDesc(file_syncspace_v1_syncspace, 94);
 
 /**
- * @generated from message syncspace.v1.PauseSyncResponse
+ * @generated from message syncspace.v1.UnlockResponse
  */
-export type PauseSyncResponse = Message<"syncspace.v1.PauseSyncResponse"> & {
+export type UnlockResponse = Message<"syncspace.v1.UnlockResponse"> & {
   /**
    * @generated from field: bool success = 1;
    */
@@ -956,262 +2827,459 @@ export type PauseSyncResponse = Message<"syncspace.v1.PauseSyncResponse"> & {
 };
 
 /**
- * Describes the message syncspace.v1.PauseSyncResponse.
- * Use `create(PauseSyncResponseSchema)` to create a new message.
+ * Describes the message syncspace.v1.UnlockResponse.
+ * Use `create(UnlockResponseSchema)` to create a new message.
  */
-export const PauseSyncResponseSchema: GenMessage<PauseSyncResponse> =
+export const UnlockResponseSchema: GenMessage<UnlockResponse> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 35);
+  messageDesc(file_syncspace_v1_syncspace, 95);
 
 /**
- * @generated from message syncspace.v1.GetSyncStatusRequest
+ * DeleteAccountRequest removes the account from this device. The backend
+ * shuts down first, like Shutdown. Then the keys are deleted from the key
+ * store, and the space storages, the document and space metadata and the
+ * device registry are removed from the data directory. Files are overwritten
+ * before removal, on a best-effort basis. Init afterwards creates a new
+ * account. With profiles, the active profile is wiped but not deleted.
+ *
+ * @generated from message syncspace.v1.DeleteAccountRequest
  */
-export type GetSyncStatusRequest = Message<"syncspace.v1.GetSyncStatusRequest"> & {
+export type DeleteAccountRequest = Message<"syncspace.v1.DeleteAccountRequest"> & {
   /**
-   * Space to query (empty = all spaces)
+   * Required if the account key is passphrase-protected
    *
-   * @generated from field: string space_id = 1;
+   * @generated from field: string passphrase = 1;
    */
-  spaceId: string;
+  passphrase: string;
 };
 
 /**
- * Describes the message syncspace.v1.GetSyncStatusRequest.
- * Use `create(GetSyncStatusRequestSchema)` to create a new message.
+ * Describes the message syncspace.v1.DeleteAccountRequest.
+ * Use `create(DeleteAccountRequestSchema)` to create a new message.
  */
-export const GetSyncStatusRequestSchema: GenMessage<GetSyncStatusRequest> =
+export const DeleteAccountRequestSchema: GenMessage<DeleteAccountRequest> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 36);
+  messageDesc(file_syncspace_v1_syncspace, 96);
 
 /**
- * @generated from message syncspace.v1.GetSyncStatusResponse
+ * @generated from message syncspace.v1.DeleteAccountResponse
  */
-export type GetSyncStatusResponse = Message<"syncspace.v1.GetSyncStatusResponse"> & {
+export type DeleteAccountResponse = Message<"syncspace.v1.DeleteAccountResponse"> & {
   /**
-   * @generated from field: repeated syncspace.v1.SpaceSyncStatus statuses = 1;
+   * Relative to the data directory, sorted
+   *
+   * @generated from field: repeated string removed_files = 1;
    */
-  statuses: SpaceSyncStatus[];
+  removedFiles: string[];
+
+  /**
+   * Names removed from the key store, e.g. "account.key"
+   *
+   * @generated from field: repeated string removed_keys = 2;
+   */
+  removedKeys: string[];
+
+  /**
+   * Total size of the removed files
+   *
+   * @generated from field: int64 removed_bytes = 3;
+   */
+  removedBytes: bigint;
 };
 
 /**
- * Describes the message syncspace.v1.GetSyncStatusResponse.
- * Use `create(GetSyncStatusResponseSchema)` to create a new message.
+ * Describes the message syncspace.v1.DeleteAccountResponse.
+ * Use `create(DeleteAccountResponseSchema)` to create a new message.
  */
-export const GetSyncStatusResponseSchema: GenMessage<GetSyncStatusResponse> =
+export const DeleteAccountResponseSchema: GenMessage<DeleteAccountResponse> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 37);
+  messageDesc(file_syncspace_v1_syncspace, 97);
 
 /**
- * @generated from message syncspace.v1.SpaceSyncStatus
+ * @generated from message syncspace.v1.DeviceInfo
  */
-export type SpaceSyncStatus = Message<"syncspace.v1.SpaceSyncStatus"> & {
+export type DeviceInfo = Message<"syncspace.v1.DeviceInfo"> & {
   /**
-   * @generated from field: string space_id = 1;
+   * @generated from field: string device_id = 1;
    */
-  spaceId: string;
+  deviceId: string;
 
   /**
-   * @generated from field: syncspace.v1.SyncStatus status = 2;
+   * @generated from field: string name = 2;
    */
-  status: SyncStatus;
+  name: string;
+
+  /**
+   * Peer ID of the current device key
+   *
+   * @generated from field: string peer_id = 3;
+   */
+  peerId: string;
+
+  /**
+   * Marshalled public device key
+   *
+   * @generated from field: bytes peer_key = 4;
+   */
+  peerKey: Uint8Array;
 
   /**
    * Unix timestamp
    *
-   * @generated from field: int64 last_sync_at = 3;
+   * @generated from field: int64 added_at = 5;
    */
-  lastSyncAt: bigint;
+  addedAt: bigint;
 
   /**
-   * Number of local changes not yet synced
+   * Unix timestamp of the last Init on the device
    *
-   * @generated from field: int32 pending_changes = 4;
+   * @generated from field: int64 last_seen_at = 6;
    */
-  pendingChanges: number;
+  lastSeenAt: bigint;
 
   /**
-   * Last sync error (empty if no error)
+   * Unix timestamp; 0 while the device is active
    *
-   * @generated from field: string error = 5;
+   * @generated from field: int64 revoked_at = 7;
    */
-  error: string;
+  revokedAt: bigint;
+
+  /**
+   * This is the device the backend runs on
+   *
+   * @generated from field: bool current = 8;
+   */
+  current: boolean;
 };
 
 /**
- * Describes the message syncspace.v1.SpaceSyncStatus.
- * Use `create(SpaceSyncStatusSchema)` to create a new message.
+ * Describes the message syncspace.v1.DeviceInfo.
+ * Use `create(DeviceInfoSchema)` to create a new message.
  */
-export const SpaceSyncStatusSchema: GenMessage<SpaceSyncStatus> =
+export const DeviceInfoSchema: GenMessage<DeviceInfo> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 38);
+  messageDesc(file_syncspace_v1_syncspace, 98);
 
 /**
- * @generated from message syncspace.v1.SubscribeRequest
+ * @generated from message syncspace.v1.ListDevicesRequest
  */
-export type SubscribeRequest = Message<"syncspace.v1.SubscribeRequest"> & {
+export type ListDevicesRequest = Message<"syncspace.v1.ListDevicesRequest"> & {};
+
+/**
+ * Describes the message syncspace.v1.ListDevicesRequest.
+ * Use `create(ListDevicesRequestSchema)` to create a new message.
+ */
+export const ListDevicesRequestSchema: GenMessage<ListDevicesRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 99);
+
+/**
+ * @generated from message syncspace.v1.ListDevicesResponse
+ */
+export type ListDevicesResponse = Message<"syncspace.v1.ListDevicesResponse"> & {
   /**
-   * Event types to subscribe to (empty = all)
+   * By date added
    *
-   * @generated from field: repeated string event_types = 1;
+   * @generated from field: repeated syncspace.v1.DeviceInfo devices = 1;
    */
-  eventTypes: string[];
+  devices: DeviceInfo[];
+};
+
+/**
+ * Describes the message syncspace.v1.ListDevicesResponse.
+ * Use `create(ListDevicesResponseSchema)` to create a new message.
+ */
+export const ListDevicesResponseSchema: GenMessage<ListDevicesResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 100);
+
+/**
+ * RotateDeviceKeyRequest replaces the key of this device with a new one.
+ *
+ * @generated from message syncspace.v1.RotateDeviceKeyRequest
+ */
+export type RotateDeviceKeyRequest = Message<"syncspace.v1.RotateDeviceKeyRequest"> & {};
+
+/**
+ * Describes the message syncspace.v1.RotateDeviceKeyRequest.
+ * Use `create(RotateDeviceKeyRequestSchema)` to create a new message.
+ */
+export const RotateDeviceKeyRequestSchema: GenMessage<RotateDeviceKeyRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 101);
 
+/**
+ * @generated from message syncspace.v1.RotateDeviceKeyResponse
+ */
+export type RotateDeviceKeyResponse = Message<"syncspace.v1.RotateDeviceKeyResponse"> & {
   /**
-   * Space IDs to filter by (empty = all)
-   *
-   * @generated from field: repeated string space_ids = 2;
+   * @generated from field: syncspace.v1.DeviceInfo device = 1;
    */
-  spaceIds: string[];
+  device?: DeviceInfo;
 };
 
 /**
- * Describes the message syncspace.v1.SubscribeRequest.
- * Use `create(SubscribeRequestSchema)` to create a new message.
+ * Describes the message syncspace.v1.RotateDeviceKeyResponse.
+ * Use `create(RotateDeviceKeyResponseSchema)` to create a new message.
  */
-export const SubscribeRequestSchema: GenMessage<SubscribeRequest> =
+export const RotateDeviceKeyResponseSchema: GenMessage<RotateDeviceKeyResponse> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 39);
+  messageDesc(file_syncspace_v1_syncspace, 102);
 
 /**
- * @generated from message syncspace.v1.SubscribeResponse
+ * RevokeDeviceRequest revokes another device: Init fails on it with
+ * ERROR_CODE_PERMISSION_DENIED. This device cannot be revoked.
+ *
+ * @generated from message syncspace.v1.RevokeDeviceRequest
  */
-export type SubscribeResponse = Message<"syncspace.v1.SubscribeResponse"> & {
+export type RevokeDeviceRequest = Message<"syncspace.v1.RevokeDeviceRequest"> & {
   /**
-   * @generated from field: string event_id = 1;
+   * @generated from field: string device_id = 1;
    */
-  eventId: string;
+  deviceId: string;
+};
+
+/**
+ * Describes the message syncspace.v1.RevokeDeviceRequest.
+ * Use `create(RevokeDeviceRequestSchema)` to create a new message.
+ */
+export const RevokeDeviceRequestSchema: GenMessage<RevokeDeviceRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 103);
 
+/**
+ * @generated from message syncspace.v1.RevokeDeviceResponse
+ */
+export type RevokeDeviceResponse = Message<"syncspace.v1.RevokeDeviceResponse"> & {
   /**
-   * "document.created", "document.updated", "sync.status_changed", etc.
-   *
-   * @generated from field: string event_type = 2;
+   * @generated from field: syncspace.v1.DeviceInfo device = 1;
    */
-  eventType: string;
+  device?: DeviceInfo;
+};
+
+/**
+ * Describes the message syncspace.v1.RevokeDeviceResponse.
+ * Use `create(RevokeDeviceResponseSchema)` to create a new message.
+ */
+export const RevokeDeviceResponseSchema: GenMessage<RevokeDeviceResponse> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 104);
 
+/**
+ * ExportAccountRequest asks for the account bundle.
+ *
+ * @generated from message syncspace.v1.ExportAccountRequest
+ */
+export type ExportAccountRequest = Message<"syncspace.v1.ExportAccountRequest"> & {
   /**
-   * @generated from field: string space_id = 3;
+   * Encrypts the bundle; required
+   *
+   * @generated from field: string passphrase = 1;
    */
-  spaceId: string;
+  passphrase: string;
 
   /**
-   * Unix timestamp
+   * Required if the account key is passphrase-protected
    *
-   * @generated from field: int64 timestamp = 4;
+   * @generated from field: string account_passphrase = 2;
    */
-  timestamp: bigint;
+  accountPassphrase: string;
+};
+
+/**
+ * Describes the message syncspace.v1.ExportAccountRequest.
+ * Use `create(ExportAccountRequestSchema)` to create a new message.
+ */
+export const ExportAccountRequestSchema: GenMessage<ExportAccountRequest> =
+  /*@__PURE__*/
+  messageDesc(file_syncspace_v1_syncspace, 105);
 
+/**
+ * @generated from message syncspace.v1.ExportAccountResponse
+ */
+export type ExportAccountResponse = Message<"syncspace.v1.ExportAccountResponse"> & {
   /**
-   * Event-specific data
+   * Versioned and encrypted; keep it secret
    *
-   * @generated from field: bytes payload = 5;
+   * @generated from field: bytes bundle = 1;
    */
-  payload: Uint8Array;
+  bundle: Uint8Array;
 };
 
 /**
- * Describes the message syncspace.v1.SubscribeResponse.
- * Use `create(SubscribeResponseSchema)` to create a new message.
+ * Describes the message syncspace.v1.ExportAccountResponse.
+ * Use `create(ExportAccountResponseSchema)` to create a new message.
  */
-export const SubscribeResponseSchema: GenMessage<SubscribeResponse> =
+export const ExportAccountResponseSchema: GenMessage<ExportAccountResponse> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 40);
+  messageDesc(file_syncspace_v1_syncspace, 106);
 
 /**
- * @generated from message syncspace.v1.DocumentCreatedEvent
+ * ImportAccountRequest prepares a data directory from an account bundle. It
+ * runs before Init, which then opens the account with a new device key. The
+ * data directory must not hold an account yet.
+ *
+ * @generated from message syncspace.v1.ImportAccountRequest
  */
-export type DocumentCreatedEvent = Message<"syncspace.v1.DocumentCreatedEvent"> & {
+export type ImportAccountRequest = Message<"syncspace.v1.ImportAccountRequest"> & {
+  /**
+   * @generated from field: string data_dir = 1;
+   */
+  dataDir: string;
+
+  /**
+   * @generated from field: bytes bundle = 2;
+   */
+  bundle: Uint8Array;
+
+  /**
+   * The bundle passphrase; ERROR_CODE_LOCKED if wrong
+   *
+   * @generated from field: string passphrase = 3;
+   */
+  passphrase: string;
+
   /**
-   * @generated from field: string document_id = 1;
+   * Protects the imported account key, like InitRequest.passphrase
+   *
+   * @generated from field: string account_passphrase = 4;
    */
-  documentId: string;
+  accountPassphrase: string;
 
   /**
-   * @generated from field: string collection = 2;
+   * With profiles, imports into this closed profile instead of data_dir
+   *
+   * @generated from field: string profile_id = 5;
    */
-  collection: string;
+  profileId: string;
 };
 
 /**
- * Describes the message syncspace.v1.DocumentCreatedEvent.
- * Use `create(DocumentCreatedEventSchema)` to create a new message.
+ * Describes the message syncspace.v1.ImportAccountRequest.
+ * Use `create(ImportAccountRequestSchema)` to create a new message.
  */
-export const DocumentCreatedEventSchema: GenMessage<DocumentCreatedEvent> =
+export const ImportAccountRequestSchema: GenMessage<ImportAccountRequest> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 41);
+  messageDesc(file_syncspace_v1_syncspace, 107);
 
 /**
- * @generated from message syncspace.v1.DocumentUpdatedEvent
+ * @generated from message syncspace.v1.ImportAccountResponse
  */
-export type DocumentUpdatedEvent = Message<"syncspace.v1.DocumentUpdatedEvent"> & {
+export type ImportAccountResponse = Message<"syncspace.v1.ImportAccountResponse"> & {
   /**
-   * @generated from field: string document_id = 1;
+   * Spaces imported
+   *
+   * @generated from field: int32 space_count = 1;
    */
-  documentId: string;
+  spaceCount: number;
 
   /**
-   * @generated from field: int64 old_version = 2;
+   * Devices in the imported registry
+   *
+   * @generated from field: int32 device_count = 2;
    */
-  oldVersion: bigint;
+  deviceCount: number;
 
   /**
-   * @generated from field: int64 new_version = 3;
+   * Unix timestamp the bundle was exported at
+   *
+   * @generated from field: int64 created_at = 3;
    */
-  newVersion: bigint;
+  createdAt: bigint;
 };
 
 /**
- * Describes the message syncspace.v1.DocumentUpdatedEvent.
- * Use `create(DocumentUpdatedEventSchema)` to create a new message.
+ * Describes the message syncspace.v1.ImportAccountResponse.
+ * Use `create(ImportAccountResponseSchema)` to create a new message.
  */
-export const DocumentUpdatedEventSchema: GenMessage<DocumentUpdatedEvent> =
+export const ImportAccountResponseSchema: GenMessage<ImportAccountResponse> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 42);
+  messageDesc(file_syncspace_v1_syncspace, 108);
 
 /**
- * @generated from message syncspace.v1.DocumentDeletedEvent
+ * SpacePermission is the access of an account to a space.
+ *
+ * @generated from enum syncspace.v1.SpacePermission
  */
-export type DocumentDeletedEvent = Message<"syncspace.v1.DocumentDeletedEvent"> & {
+export enum SpacePermission {
   /**
-   * @generated from field: string document_id = 1;
+   * @generated from enum value: SPACE_PERMISSION_UNSPECIFIED = 0;
    */
-  documentId: string;
-};
+  UNSPECIFIED = 0,
+
+  /**
+   * Reads documents
+   *
+   * @generated from enum value: SPACE_PERMISSION_READER = 1;
+   */
+  READER = 1,
+
+  /**
+   * Reads and writes documents
+   *
+   * @generated from enum value: SPACE_PERMISSION_WRITER = 2;
+   */
+  WRITER = 2,
+
+  /**
+   * Also manages members and invites
+   *
+   * @generated from enum value: SPACE_PERMISSION_ADMIN = 3;
+   */
+  ADMIN = 3,
+
+  /**
+   * The creator of the space
+   *
+   * @generated from enum value: SPACE_PERMISSION_OWNER = 4;
+   */
+  OWNER = 4,
+}
 
 /**
- * Describes the message syncspace.v1.DocumentDeletedEvent.
- * Use `create(DocumentDeletedEventSchema)` to create a new message.
+ * Describes the enum syncspace.v1.SpacePermission.
  */
-export const DocumentDeletedEventSchema: GenMessage<DocumentDeletedEvent> =
+export const SpacePermissionSchema: GenEnum<SpacePermission> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 43);
+  enumDesc(file_syncspace_v1_syncspace, 0);
 
 /**
- * @generated from message syncspace.v1.SyncStatusChangedEvent
+ * @generated from enum syncspace.v1.MemberStatus
  */
-export type SyncStatusChangedEvent = Message<"syncspace.v1.SyncStatusChangedEvent"> & {
+export enum MemberStatus {
   /**
-   * @generated from field: syncspace.v1.SyncStatus old_status = 1;
+   * @generated from enum value: MEMBER_STATUS_UNSPECIFIED = 0;
    */
-  oldStatus: SyncStatus;
+  UNSPECIFIED = 0,
 
   /**
-   * @generated from field: syncspace.v1.SyncStatus new_status = 2;
+   * Has access to the space
+   *
+   * @generated from enum value: MEMBER_STATUS_ACTIVE = 1;
    */
-  newStatus: SyncStatus;
+  ACTIVE = 1,
 
   /**
-   * @generated from field: string error = 3;
+   * Waits for ApproveJoinRequest
+   *
+   * @generated from enum value: MEMBER_STATUS_JOIN_REQUESTED = 2;
    */
-  error: string;
-};
+  JOIN_REQUESTED = 2,
+
+  /**
+   * Asked to be removed from the space
+   *
+   * @generated from enum value: MEMBER_STATUS_LEAVING = 3;
+   */
+  LEAVING = 3,
+}
 
 /**
- * Describes the message syncspace.v1.SyncStatusChangedEvent.
- * Use `create(SyncStatusChangedEventSchema)` to create a new message.
+ * Describes the enum syncspace.v1.MemberStatus.
  */
-export const SyncStatusChangedEventSchema: GenMessage<SyncStatusChangedEvent> =
+export const MemberStatusSchema: GenEnum<MemberStatus> =
   /*@__PURE__*/
-  messageDesc(file_syncspace_v1_syncspace, 44);
+  enumDesc(file_syncspace_v1_syncspace, 1);
 
 /**
  * @generated from enum syncspace.v1.SyncStatus
@@ -1248,7 +3316,117 @@ export enum SyncStatus {
  */
 export const SyncStatusSchema: GenEnum<SyncStatus> =
   /*@__PURE__*/
-  enumDesc(file_syncspace_v1_syncspace, 0);
+  enumDesc(file_syncspace_v1_syncspace, 2);
+
+/**
+ * ErrorCode lets clients branch on failures without parsing messages
+ *
+ * @generated from enum syncspace.v1.ErrorCode
+ */
+export enum ErrorCode {
+  /**
+   * @generated from enum value: ERROR_CODE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Unexpected backend failure
+   *
+   * @generated from enum value: ERROR_CODE_INTERNAL = 1;
+   */
+  INTERNAL = 1,
+
+  /**
+   * Request is malformed or has invalid fields
+   *
+   * @generated from enum value: ERROR_CODE_INVALID_ARGUMENT = 2;
+   */
+  INVALID_ARGUMENT = 2,
+
+  /**
+   * Space or document does not exist
+   *
+   * @generated from enum value: ERROR_CODE_NOT_FOUND = 3;
+   */
+  NOT_FOUND = 3,
+
+  /**
+   * Object with the same identity already exists
+   *
+   * @generated from enum value: ERROR_CODE_ALREADY_EXISTS = 4;
+   */
+  ALREADY_EXISTS = 4,
+
+  /**
+   * Init has not been called
+   *
+   * @generated from enum value: ERROR_CODE_NOT_INITIALIZED = 5;
+   */
+  NOT_INITIALIZED = 5,
+
+  /**
+   * Init was called twice without Shutdown
+   *
+   * @generated from enum value: ERROR_CODE_ALREADY_INITIALIZED = 6;
+   */
+  ALREADY_INITIALIZED = 6,
+
+  /**
+   * expected_version or expected_updated_at did not match
+   *
+   * @generated from enum value: ERROR_CODE_VERSION_CONFLICT = 7;
+   */
+  VERSION_CONFLICT = 7,
+
+  /**
+   * Command is unknown or not implemented yet
+   *
+   * @generated from enum value: ERROR_CODE_UNIMPLEMENTED = 8;
+   */
+  UNIMPLEMENTED = 8,
+
+  /**
+   * Operation timed out
+   *
+   * @generated from enum value: ERROR_CODE_DEADLINE_EXCEEDED = 9;
+   */
+  DEADLINE_EXCEEDED = 9,
+
+  /**
+   * Operation was cancelled by the caller
+   *
+   * @generated from enum value: ERROR_CODE_CANCELLED = 10;
+   */
+  CANCELLED = 10,
+
+  /**
+   * Backend is shutting down and accepts no new commands, or a peer cannot be reached
+   *
+   * @generated from enum value: ERROR_CODE_UNAVAILABLE = 11;
+   */
+  UNAVAILABLE = 11,
+
+  /**
+   * Account passphrase is missing or wrong; details["reason"] says which
+   *
+   * @generated from enum value: ERROR_CODE_LOCKED = 12;
+   */
+  LOCKED = 12,
+
+  /**
+   * The account or device may not do this, e.g. a revoked device
+   *
+   * @generated from enum value: ERROR_CODE_PERMISSION_DENIED = 13;
+   */
+  PERMISSION_DENIED = 13,
+}
+
+/**
+ * Describes the enum syncspace.v1.ErrorCode.
+ */
+export const ErrorCodeSchema: GenEnum<ErrorCode> =
+  /*@__PURE__*/
+  enumDesc(file_syncspace_v1_syncspace, 3);
 
 /**
  * SyncSpaceService provides the complete SyncSpace API for spaces, documents, and synchronization
@@ -1286,6 +3464,14 @@ export const SyncSpaceService: GenService<{
     input: typeof CreateSpaceRequestSchema;
     output: typeof CreateSpaceResponseSchema;
   };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.CreateInvite
+   */
+  createInvite: {
+    methodKind: "unary";
+    input: typeof CreateInviteRequestSchema;
+    output: typeof CreateInviteResponseSchema;
+  };
   /**
    * @generated from rpc syncspace.v1.SyncSpaceService.JoinSpace
    */
@@ -1294,6 +3480,38 @@ export const SyncSpaceService: GenService<{
     input: typeof JoinSpaceRequestSchema;
     output: typeof JoinSpaceResponseSchema;
   };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.ListMembers
+   */
+  listMembers: {
+    methodKind: "unary";
+    input: typeof ListMembersRequestSchema;
+    output: typeof ListMembersResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.ApproveJoinRequest
+   */
+  approveJoinRequest: {
+    methodKind: "unary";
+    input: typeof ApproveJoinRequestRequestSchema;
+    output: typeof ApproveJoinRequestResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.RemoveMember
+   */
+  removeMember: {
+    methodKind: "unary";
+    input: typeof RemoveMemberRequestSchema;
+    output: typeof RemoveMemberResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.ChangeMemberPermission
+   */
+  changeMemberPermission: {
+    methodKind: "unary";
+    input: typeof ChangeMemberPermissionRequestSchema;
+    output: typeof ChangeMemberPermissionResponseSchema;
+  };
   /**
    * @generated from rpc syncspace.v1.SyncSpaceService.LeaveSpace
    */
@@ -1310,6 +3528,14 @@ export const SyncSpaceService: GenService<{
     input: typeof ListSpacesRequestSchema;
     output: typeof ListSpacesResponseSchema;
   };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.UpdateSpace
+   */
+  updateSpace: {
+    methodKind: "unary";
+    input: typeof UpdateSpaceRequestSchema;
+    output: typeof UpdateSpaceResponseSchema;
+  };
   /**
    * @generated from rpc syncspace.v1.SyncSpaceService.DeleteSpace
    */
@@ -1394,6 +3620,144 @@ export const SyncSpaceService: GenService<{
     input: typeof GetSyncStatusRequestSchema;
     output: typeof GetSyncStatusResponseSchema;
   };
+  /**
+   * Batch operations
+   *
+   * @generated from rpc syncspace.v1.SyncSpaceService.Batch
+   */
+  batch: {
+    methodKind: "unary";
+    input: typeof BatchRequestSchema;
+    output: typeof BatchResponseSchema;
+  };
+  /**
+   * Introspection
+   *
+   * @generated from rpc syncspace.v1.SyncSpaceService.DescribeCommands
+   */
+  describeCommands: {
+    methodKind: "unary";
+    input: typeof DescribeCommandsRequestSchema;
+    output: typeof DescribeCommandsResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.GetConfig
+   */
+  getConfig: {
+    methodKind: "unary";
+    input: typeof GetConfigRequestSchema;
+    output: typeof GetConfigResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.GetStatus
+   */
+  getStatus: {
+    methodKind: "unary";
+    input: typeof GetStatusRequestSchema;
+    output: typeof GetStatusResponseSchema;
+  };
+  /**
+   * Account security
+   *
+   * @generated from rpc syncspace.v1.SyncSpaceService.SetPassphrase
+   */
+  setPassphrase: {
+    methodKind: "unary";
+    input: typeof SetPassphraseRequestSchema;
+    output: typeof SetPassphraseResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.ChangePassphrase
+   */
+  changePassphrase: {
+    methodKind: "unary";
+    input: typeof ChangePassphraseRequestSchema;
+    output: typeof ChangePassphraseResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.RemovePassphrase
+   */
+  removePassphrase: {
+    methodKind: "unary";
+    input: typeof RemovePassphraseRequestSchema;
+    output: typeof RemovePassphraseResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.ExportMnemonic
+   */
+  exportMnemonic: {
+    methodKind: "unary";
+    input: typeof ExportMnemonicRequestSchema;
+    output: typeof ExportMnemonicResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.Lock
+   */
+  lock: {
+    methodKind: "unary";
+    input: typeof LockRequestSchema;
+    output: typeof LockResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.Unlock
+   */
+  unlock: {
+    methodKind: "unary";
+    input: typeof UnlockRequestSchema;
+    output: typeof UnlockResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.DeleteAccount
+   */
+  deleteAccount: {
+    methodKind: "unary";
+    input: typeof DeleteAccountRequestSchema;
+    output: typeof DeleteAccountResponseSchema;
+  };
+  /**
+   * Devices
+   *
+   * @generated from rpc syncspace.v1.SyncSpaceService.ListDevices
+   */
+  listDevices: {
+    methodKind: "unary";
+    input: typeof ListDevicesRequestSchema;
+    output: typeof ListDevicesResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.RotateDeviceKey
+   */
+  rotateDeviceKey: {
+    methodKind: "unary";
+    input: typeof RotateDeviceKeyRequestSchema;
+    output: typeof RotateDeviceKeyResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.RevokeDevice
+   */
+  revokeDevice: {
+    methodKind: "unary";
+    input: typeof RevokeDeviceRequestSchema;
+    output: typeof RevokeDeviceResponseSchema;
+  };
+  /**
+   * Account transfer
+   *
+   * @generated from rpc syncspace.v1.SyncSpaceService.ExportAccount
+   */
+  exportAccount: {
+    methodKind: "unary";
+    input: typeof ExportAccountRequestSchema;
+    output: typeof ExportAccountResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.ImportAccount
+   */
+  importAccount: {
+    methodKind: "unary";
+    input: typeof ImportAccountRequestSchema;
+    output: typeof ImportAccountResponseSchema;
+  };
   /**
    * Event streaming
    *
@@ -1404,4 +3768,46 @@ export const SyncSpaceService: GenService<{
     input: typeof SubscribeRequestSchema;
     output: typeof SubscribeResponseSchema;
   };
+  /**
+   * Profile operations
+   *
+   * @generated from rpc syncspace.v1.SyncSpaceService.CreateProfile
+   */
+  createProfile: {
+    methodKind: "unary";
+    input: typeof CreateProfileRequestSchema;
+    output: typeof CreateProfileResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.ListProfiles
+   */
+  listProfiles: {
+    methodKind: "unary";
+    input: typeof ListProfilesRequestSchema;
+    output: typeof ListProfilesResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.OpenProfile
+   */
+  openProfile: {
+    methodKind: "unary";
+    input: typeof OpenProfileRequestSchema;
+    output: typeof OpenProfileResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.CloseProfile
+   */
+  closeProfile: {
+    methodKind: "unary";
+    input: typeof CloseProfileRequestSchema;
+    output: typeof CloseProfileResponseSchema;
+  };
+  /**
+   * @generated from rpc syncspace.v1.SyncSpaceService.DeleteProfile
+   */
+  deleteProfile: {
+    methodKind: "unary";
+    input: typeof DeleteProfileRequestSchema;
+    output: typeof DeleteProfileResponseSchema;
+  };
 }> = /*@__PURE__*/ serviceDesc(file_syncspace_v1_syncspace, 0);
@@ -15,6 +15,7 @@ import org.json.JSONArray
 class CommandArgs {
     var cmd: String = ""
     var data: ByteArray = ByteArray(0)
+    var timeoutMs: Long = 0 // 0 uses the timeout configured at Init
 }
 
 @TauriPlugin
@@ -58,7 +59,7 @@ class AnySyncPlugin(private val activity: Activity): Plugin(activity) {
 
             // Call Go via gomobile FFI
             val response = try {
-                Mobile.command(args.cmd, args.data)
+                Mobile.command(args.cmd, args.data, args.timeoutMs)
             } catch (e: Exception) {
                 Log.e(TAG, "Go command threw exception: ${e.message}", e)
                 invoke.reject(e.message ?: "Go command failed")
@@ -8,6 +8,7 @@ import WebKit
 class CommandArgs: Decodable {
   let cmd: String
   let data: [UInt8]
+  let timeoutMs: Int64? // nil or 0 uses the timeout configured at Init
 }
 
 class AnySyncPlugin: Plugin {
@@ -45,7 +46,7 @@ class AnySyncPlugin: Plugin {
 
       // Call Go via gomobile FFI
       var error: NSError?
-      let response = MobileCommand(args.cmd, data, &error)
+      let response = MobileCommand(args.cmd, data, args.timeoutMs ?? 0, &error)
 
       // Check if an error occurred
       if let error = error {